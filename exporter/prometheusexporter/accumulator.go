@@ -16,6 +16,7 @@ package prometheusexporter // import "github.com/open-telemetry/opentelemetry-co
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -34,6 +35,9 @@ type accumulatedValue struct {
 	// resourceAttrs contain the resource attributes. They are used to output instance and job labels.
 	resourceAttrs pcommon.Map
 
+	// resourceKey identifies the resource that last updated the metric.
+	resourceKey string
+
 	// updated indicates when metric was last changed.
 	updated time.Time
 
@@ -58,13 +62,22 @@ type lastValueAccumulator struct {
 	// metricExpiration contains duration for which metric
 	// should be served after it was updated
 	metricExpiration time.Duration
+
+	// resourceLastSeen keeps the last time metrics were received for each
+	// resource, it is only used if resourceExpiration is set.
+	resourceLastSeen sync.Map
+
+	// resourceExpiration contains duration for which the metrics of a
+	// resource should be served after any metric of the resource was received
+	resourceExpiration time.Duration
 }
 
 // NewAccumulator returns LastValueAccumulator
-func newAccumulator(logger *zap.Logger, metricExpiration time.Duration) accumulator {
+func newAccumulator(logger *zap.Logger, metricExpiration time.Duration, resourceExpiration time.Duration) accumulator {
 	return &lastValueAccumulator{
-		logger:           logger,
-		metricExpiration: metricExpiration,
+		logger:             logger,
+		metricExpiration:   metricExpiration,
+		resourceExpiration: resourceExpiration,
 	}
 }
 
@@ -74,30 +87,36 @@ func (a *lastValueAccumulator) Accumulate(rm pmetric.ResourceMetrics) (n int) {
 	ilms := rm.ScopeMetrics()
 	resourceAttrs := rm.Resource().Attributes()
 
+	var rKey string
+	if a.resourceExpiration > 0 {
+		rKey = resourceKey(resourceAttrs)
+		a.resourceLastSeen.Store(rKey, now)
+	}
+
 	for i := 0; i < ilms.Len(); i++ {
 		ilm := ilms.At(i)
 
 		metrics := ilm.Metrics()
 		for j := 0; j < metrics.Len(); j++ {
-			n += a.addMetric(metrics.At(j), ilm.Scope(), resourceAttrs, now)
+			n += a.addMetric(metrics.At(j), ilm.Scope(), resourceAttrs, rKey, now)
 		}
 	}
 
 	return
 }
 
-func (a *lastValueAccumulator) addMetric(metric pmetric.Metric, il pcommon.InstrumentationScope, resourceAttrs pcommon.Map, now time.Time) int {
+func (a *lastValueAccumulator) addMetric(metric pmetric.Metric, il pcommon.InstrumentationScope, resourceAttrs pcommon.Map, rKey string, now time.Time) int {
 	a.logger.Debug(fmt.Sprintf("accumulating metric: %s", metric.Name()))
 
 	switch metric.Type() {
 	case pmetric.MetricTypeGauge:
-		return a.accumulateGauge(metric, il, resourceAttrs, now)
+		return a.accumulateGauge(metric, il, resourceAttrs, rKey, now)
 	case pmetric.MetricTypeSum:
-		return a.accumulateSum(metric, il, resourceAttrs, now)
+		return a.accumulateSum(metric, il, resourceAttrs, rKey, now)
 	case pmetric.MetricTypeHistogram:
-		return a.accumulateDoubleHistogram(metric, il, resourceAttrs, now)
+		return a.accumulateDoubleHistogram(metric, il, resourceAttrs, rKey, now)
 	case pmetric.MetricTypeSummary:
-		return a.accumulateSummary(metric, il, resourceAttrs, now)
+		return a.accumulateSummary(metric, il, resourceAttrs, rKey, now)
 	default:
 		a.logger.With(
 			zap.String("data_type", string(metric.Type())),
@@ -108,7 +127,7 @@ func (a *lastValueAccumulator) addMetric(metric pmetric.Metric, il pcommon.Instr
 	return 0
 }
 
-func (a *lastValueAccumulator) accumulateSummary(metric pmetric.Metric, il pcommon.InstrumentationScope, resourceAttrs pcommon.Map, now time.Time) (n int) {
+func (a *lastValueAccumulator) accumulateSummary(metric pmetric.Metric, il pcommon.InstrumentationScope, resourceAttrs pcommon.Map, rKey string, now time.Time) (n int) {
 	dps := metric.Summary().DataPoints()
 	for i := 0; i < dps.Len(); i++ {
 		ip := dps.At(i)
@@ -130,14 +149,14 @@ func (a *lastValueAccumulator) accumulateSummary(metric pmetric.Metric, il pcomm
 
 		m := copyMetricMetadata(metric)
 		ip.CopyTo(m.SetEmptySummary().DataPoints().AppendEmpty())
-		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, resourceAttrs: resourceAttrs, scope: il, updated: now})
+		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, resourceAttrs: resourceAttrs, resourceKey: rKey, scope: il, updated: now})
 		n++
 	}
 
 	return n
 }
 
-func (a *lastValueAccumulator) accumulateGauge(metric pmetric.Metric, il pcommon.InstrumentationScope, resourceAttrs pcommon.Map, now time.Time) (n int) {
+func (a *lastValueAccumulator) accumulateGauge(metric pmetric.Metric, il pcommon.InstrumentationScope, resourceAttrs pcommon.Map, rKey string, now time.Time) (n int) {
 	dps := metric.Gauge().DataPoints()
 	for i := 0; i < dps.Len(); i++ {
 		ip := dps.At(i)
@@ -152,7 +171,7 @@ func (a *lastValueAccumulator) accumulateGauge(metric pmetric.Metric, il pcommon
 		if !ok {
 			m := copyMetricMetadata(metric)
 			ip.CopyTo(m.SetEmptyGauge().DataPoints().AppendEmpty())
-			a.registeredMetrics.Store(signature, &accumulatedValue{value: m, resourceAttrs: resourceAttrs, scope: il, updated: now})
+			a.registeredMetrics.Store(signature, &accumulatedValue{value: m, resourceAttrs: resourceAttrs, resourceKey: rKey, scope: il, updated: now})
 			n++
 			continue
 		}
@@ -165,13 +184,13 @@ func (a *lastValueAccumulator) accumulateGauge(metric pmetric.Metric, il pcommon
 
 		m := copyMetricMetadata(metric)
 		ip.CopyTo(m.SetEmptyGauge().DataPoints().AppendEmpty())
-		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, resourceAttrs: resourceAttrs, scope: il, updated: now})
+		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, resourceAttrs: resourceAttrs, resourceKey: rKey, scope: il, updated: now})
 		n++
 	}
 	return
 }
 
-func (a *lastValueAccumulator) accumulateSum(metric pmetric.Metric, il pcommon.InstrumentationScope, resourceAttrs pcommon.Map, now time.Time) (n int) {
+func (a *lastValueAccumulator) accumulateSum(metric pmetric.Metric, il pcommon.InstrumentationScope, resourceAttrs pcommon.Map, rKey string, now time.Time) (n int) {
 	doubleSum := metric.Sum()
 
 	// Drop metrics with unspecified aggregations
@@ -200,7 +219,7 @@ func (a *lastValueAccumulator) accumulateSum(metric pmetric.Metric, il pcommon.I
 			m.SetEmptySum().SetIsMonotonic(metric.Sum().IsMonotonic())
 			m.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
 			ip.CopyTo(m.Sum().DataPoints().AppendEmpty())
-			a.registeredMetrics.Store(signature, &accumulatedValue{value: m, resourceAttrs: resourceAttrs, scope: il, updated: now})
+			a.registeredMetrics.Store(signature, &accumulatedValue{value: m, resourceAttrs: resourceAttrs, resourceKey: rKey, scope: il, updated: now})
 			n++
 			continue
 		}
@@ -226,13 +245,13 @@ func (a *lastValueAccumulator) accumulateSum(metric pmetric.Metric, il pcommon.I
 		m.SetEmptySum().SetIsMonotonic(metric.Sum().IsMonotonic())
 		m.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
 		ip.CopyTo(m.Sum().DataPoints().AppendEmpty())
-		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, resourceAttrs: resourceAttrs, scope: il, updated: now})
+		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, resourceAttrs: resourceAttrs, resourceKey: rKey, scope: il, updated: now})
 		n++
 	}
 	return
 }
 
-func (a *lastValueAccumulator) accumulateDoubleHistogram(metric pmetric.Metric, il pcommon.InstrumentationScope, resourceAttrs pcommon.Map, now time.Time) (n int) {
+func (a *lastValueAccumulator) accumulateDoubleHistogram(metric pmetric.Metric, il pcommon.InstrumentationScope, resourceAttrs pcommon.Map, rKey string, now time.Time) (n int) {
 	doubleHistogram := metric.Histogram()
 
 	// Drop metrics with non-cumulative aggregations
@@ -254,7 +273,7 @@ func (a *lastValueAccumulator) accumulateDoubleHistogram(metric pmetric.Metric,
 		if !ok {
 			m := copyMetricMetadata(metric)
 			ip.CopyTo(m.SetEmptyHistogram().DataPoints().AppendEmpty())
-			a.registeredMetrics.Store(signature, &accumulatedValue{value: m, resourceAttrs: resourceAttrs, scope: il, updated: now})
+			a.registeredMetrics.Store(signature, &accumulatedValue{value: m, resourceAttrs: resourceAttrs, resourceKey: rKey, scope: il, updated: now})
 			n++
 			continue
 		}
@@ -268,7 +287,7 @@ func (a *lastValueAccumulator) accumulateDoubleHistogram(metric pmetric.Metric,
 		m := copyMetricMetadata(metric)
 		ip.CopyTo(m.SetEmptyHistogram().DataPoints().AppendEmpty())
 		m.Histogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
-		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, resourceAttrs: resourceAttrs, scope: il, updated: now})
+		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, resourceAttrs: resourceAttrs, resourceKey: rKey, scope: il, updated: now})
 		n++
 	}
 	return
@@ -280,7 +299,9 @@ func (a *lastValueAccumulator) Collect() ([]pmetric.Metric, []pcommon.Map) {
 
 	var metrics []pmetric.Metric
 	var resourceAttrs []pcommon.Map
-	expirationTime := time.Now().Add(-a.metricExpiration)
+	now := time.Now()
+	expirationTime := now.Add(-a.metricExpiration)
+	resourceExpirationTime := now.Add(-a.resourceExpiration)
 
 	a.registeredMetrics.Range(func(key, value interface{}) bool {
 		v := value.(*accumulatedValue)
@@ -290,14 +311,49 @@ func (a *lastValueAccumulator) Collect() ([]pmetric.Metric, []pcommon.Map) {
 			return true
 		}
 
+		if a.resourceExpiration > 0 {
+			lastSeen, ok := a.resourceLastSeen.Load(v.resourceKey)
+			if !ok || resourceExpirationTime.After(lastSeen.(time.Time)) {
+				a.logger.Debug(fmt.Sprintf("metric expired with its resource: %s", v.value.Name()))
+				a.registeredMetrics.Delete(key)
+				return true
+			}
+		}
+
 		metrics = append(metrics, v.value)
 		resourceAttrs = append(resourceAttrs, v.resourceAttrs)
 		return true
 	})
 
+	if a.resourceExpiration > 0 {
+		a.resourceLastSeen.Range(func(key, value interface{}) bool {
+			if resourceExpirationTime.After(value.(time.Time)) {
+				a.resourceLastSeen.Delete(key)
+			}
+			return true
+		})
+	}
+
 	return metrics, resourceAttrs
 }
 
+// resourceKey builds a key that identifies a resource from all its attributes.
+func resourceKey(attributes pcommon.Map) string {
+	keys := make([]string, 0, attributes.Len())
+	attributes.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		v, _ := attributes.Get(k)
+		b.WriteString(k + separatorString + v.AsString() + separatorString)
+	}
+	return b.String()
+}
+
 func timeseriesSignature(ilmName string, metric pmetric.Metric, attributes pcommon.Map, resourceAttrs pcommon.Map) string {
 	var b strings.Builder
 	b.WriteString(metric.Type().String())
@@ -56,7 +56,7 @@ func TestAccumulateDeltaAggregation(t *testing.T) {
 			ilm.Scope().SetName("test")
 			tt.fillMetric(time.Now(), ilm.Metrics().AppendEmpty())
 
-			a := newAccumulator(zap.NewNop(), 1*time.Hour).(*lastValueAccumulator)
+			a := newAccumulator(zap.NewNop(), 1*time.Hour, 0).(*lastValueAccumulator)
 			n := a.Accumulate(resourceMetrics)
 			require.Equal(t, 0, n)
 
@@ -282,7 +282,7 @@ func TestAccumulateMetrics(t *testing.T) {
 			tt.metric(ts2, 21, ilm2.Metrics())
 			tt.metric(ts1, 13, ilm2.Metrics())
 
-			a := newAccumulator(zap.NewNop(), 1*time.Hour).(*lastValueAccumulator)
+			a := newAccumulator(zap.NewNop(), 1*time.Hour, 0).(*lastValueAccumulator)
 
 			// 2 metric arrived
 			n := a.Accumulate(resourceMetrics2)
@@ -388,7 +388,7 @@ func TestAccumulateDeltaToCumulative(t *testing.T) {
 			resourceMetrics := pmetric.NewResourceMetrics()
 			ilm := resourceMetrics.ScopeMetrics().AppendEmpty()
 			ilm.Scope().SetName("test")
-			a := newAccumulator(zap.NewNop(), 1*time.Hour).(*lastValueAccumulator)
+			a := newAccumulator(zap.NewNop(), 1*time.Hour, 0).(*lastValueAccumulator)
 
 			dataPointValue1 := float64(11)
 			dataPointValue2 := float64(32)
@@ -497,7 +497,7 @@ func TestAccumulateDroppedMetrics(t *testing.T) {
 			ilm.Scope().SetName("test")
 			tt.fillMetric(time.Now(), ilm.Metrics().AppendEmpty())
 
-			a := newAccumulator(zap.NewNop(), 1*time.Hour).(*lastValueAccumulator)
+			a := newAccumulator(zap.NewNop(), 1*time.Hour, 0).(*lastValueAccumulator)
 			n := a.Accumulate(resourceMetrics)
 			require.Equal(t, 0, n)
 
@@ -509,6 +509,64 @@ func TestAccumulateDroppedMetrics(t *testing.T) {
 	}
 }
 
+func TestAccumulateResourceExpiration(t *testing.T) {
+	newResourceMetrics := func(instance string) pmetric.ResourceMetrics {
+		resourceMetrics := pmetric.NewResourceMetrics()
+		resourceMetrics.Resource().Attributes().PutStr("service.name", "test")
+		resourceMetrics.Resource().Attributes().PutStr("service.instance.id", instance)
+		ilm := resourceMetrics.ScopeMetrics().AppendEmpty()
+		ilm.Scope().SetName("test")
+		metric := ilm.Metrics().AppendEmpty()
+		metric.SetName("test_metric")
+		dp := metric.SetEmptyGauge().DataPoints().AppendEmpty()
+		dp.SetIntValue(42)
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+		return resourceMetrics
+	}
+
+	a := newAccumulator(zap.NewNop(), 1*time.Hour, 1*time.Minute).(*lastValueAccumulator)
+	expired := newResourceMetrics("expired")
+	require.Equal(t, 1, a.Accumulate(expired))
+	require.Equal(t, 1, a.Accumulate(newResourceMetrics("alive")))
+
+	// The series of the expired resource are still within the metric
+	// expiration but the resource did not send any data for too long.
+	a.resourceLastSeen.Store(resourceKey(expired.Resource().Attributes()), time.Now().Add(-2*time.Minute))
+
+	metrics, resourceAttrs := a.Collect()
+	require.Len(t, metrics, 1)
+	require.Len(t, resourceAttrs, 1)
+	instance, ok := resourceAttrs[0].Get("service.instance.id")
+	require.True(t, ok)
+	require.Equal(t, "alive", instance.AsString())
+
+	var resources int
+	a.resourceLastSeen.Range(func(_, _ interface{}) bool {
+		resources++
+		return true
+	})
+	require.Equal(t, 1, resources)
+
+	// The resource is tracked again once it sends new data.
+	require.Equal(t, 1, a.Accumulate(expired))
+	metrics, _ = a.Collect()
+	require.Len(t, metrics, 2)
+}
+
+func TestResourceKey(t *testing.T) {
+	attrs1 := pcommon.NewMap()
+	attrs1.PutStr("a", "1")
+	attrs1.PutStr("b", "2")
+	attrs2 := pcommon.NewMap()
+	attrs2.PutStr("b", "2")
+	attrs2.PutStr("a", "1")
+	attrs3 := pcommon.NewMap()
+	attrs3.PutStr("a", "12")
+
+	require.Equal(t, resourceKey(attrs1), resourceKey(attrs2))
+	require.NotEqual(t, resourceKey(attrs1), resourceKey(attrs3))
+}
+
 func getMetricProperties(metric pmetric.Metric) (
 	attributes pcommon.Map,
 	ts time.Time,
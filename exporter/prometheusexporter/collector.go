@@ -40,18 +40,20 @@ type collector struct {
 	accumulator accumulator
 	logger      *zap.Logger
 
-	sendTimestamps bool
-	namespace      string
-	constLabels    prometheus.Labels
+	sendTimestamps    bool
+	disableTargetInfo bool
+	namespace         string
+	constLabels       prometheus.Labels
 }
 
 func newCollector(config *Config, logger *zap.Logger) *collector {
 	return &collector{
-		accumulator:    newAccumulator(logger, config.MetricExpiration),
-		logger:         logger,
-		namespace:      prometheustranslator.CleanUpString(config.Namespace),
-		sendTimestamps: config.SendTimestamps,
-		constLabels:    config.ConstLabels,
+		accumulator:       newAccumulator(logger, config.MetricExpiration, config.ResourceExpiration),
+		logger:            logger,
+		namespace:         prometheustranslator.CleanUpString(config.Namespace),
+		sendTimestamps:    config.SendTimestamps,
+		disableTargetInfo: !config.TargetInfo.Enabled,
+		constLabels:       config.ConstLabels,
 	}
 }
 
@@ -159,6 +161,15 @@ func (c *collector) convertSum(metric pmetric.Metric, resourceAttrs pcommon.Map)
 		return nil, err
 	}
 
+	// Exemplars are only supported on counters, and only the last one is
+	// exposed. They are only visible when using the OpenMetrics format.
+	if metricType == prometheus.CounterValue && ip.Exemplars().Len() > 0 {
+		m, err = prometheus.NewMetricWithExemplars(m, convertExemplars(ip.Exemplars())...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if c.sendTimestamps {
 		return prometheus.NewMetricWithTimestamp(ip.Timestamp().AsTime(), m), nil
 	}
@@ -217,34 +228,14 @@ func (c *collector) convertDoubleHistogram(metric pmetric.Metric, resourceAttrs
 		points[bucket] = cumCount
 	}
 
-	arrLen := ip.Exemplars().Len()
-	exemplars := make([]prometheus.Exemplar, arrLen)
-
-	for i := 0; i < arrLen; i++ {
-		e := ip.Exemplars().At(i)
-		exemplarLabels := make(prometheus.Labels, 0)
-
-		if !e.TraceID().IsEmpty() {
-			exemplarLabels["trace_id"] = e.TraceID().HexString()
-		}
-
-		if !e.SpanID().IsEmpty() {
-			exemplarLabels["span_id"] = e.SpanID().HexString()
-		}
-
-		exemplars[i] = prometheus.Exemplar{
-			Value:     e.DoubleValue(),
-			Labels:    exemplarLabels,
-			Timestamp: e.Timestamp().AsTime(),
-		}
-	}
+	exemplars := convertExemplars(ip.Exemplars())
 
 	m, err := prometheus.NewConstHistogram(desc, ip.Count(), ip.Sum(), points, attributes...)
 	if err != nil {
 		return nil, err
 	}
 
-	if arrLen > 0 {
+	if len(exemplars) > 0 {
 		m, err = prometheus.NewMetricWithExemplars(m, exemplars...)
 		if err != nil {
 			return nil, err
@@ -257,6 +248,37 @@ func (c *collector) convertDoubleHistogram(metric pmetric.Metric, resourceAttrs
 	return m, nil
 }
 
+func convertExemplars(exemplars pmetric.ExemplarSlice) []prometheus.Exemplar {
+	result := make([]prometheus.Exemplar, exemplars.Len())
+	for i := 0; i < exemplars.Len(); i++ {
+		e := exemplars.At(i)
+		exemplarLabels := make(prometheus.Labels, 0)
+
+		if !e.TraceID().IsEmpty() {
+			exemplarLabels["trace_id"] = e.TraceID().HexString()
+		}
+
+		if !e.SpanID().IsEmpty() {
+			exemplarLabels["span_id"] = e.SpanID().HexString()
+		}
+
+		var value float64
+		switch e.ValueType() {
+		case pmetric.ExemplarValueTypeInt:
+			value = float64(e.IntValue())
+		case pmetric.ExemplarValueTypeDouble:
+			value = e.DoubleValue()
+		}
+
+		result[i] = prometheus.Exemplar{
+			Value:     value,
+			Labels:    exemplarLabels,
+			Timestamp: e.Timestamp().AsTime(),
+		}
+	}
+	return result
+}
+
 func (c *collector) createTargetInfoMetrics(resourceAttrs []pcommon.Map) ([]prometheus.Metric, error) {
 	var metrics []prometheus.Metric
 	var lastErr error
@@ -353,13 +375,15 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 
 	inMetrics, resourceAttrs := c.accumulator.Collect()
 
-	targetMetrics, err := c.createTargetInfoMetrics(resourceAttrs)
-	if err != nil {
-		c.logger.Error(fmt.Sprintf("failed to convert metric %s: %s", targetMetricName, err.Error()))
-	}
-	for _, m := range targetMetrics {
-		ch <- m
-		c.logger.Debug(fmt.Sprintf("metric served: %s", m.Desc().String()))
+	if !c.disableTargetInfo {
+		targetMetrics, err := c.createTargetInfoMetrics(resourceAttrs)
+		if err != nil {
+			c.logger.Error(fmt.Sprintf("failed to convert metric %s: %s", targetMetricName, err.Error()))
+		}
+		for _, m := range targetMetrics {
+			ch <- m
+			c.logger.Debug(fmt.Sprintf("metric served: %s", m.Desc().String()))
+		}
 	}
 
 	for i := range inMetrics {
@@ -160,6 +160,92 @@ func TestConvertDoubleHistogramExemplar(t *testing.T) {
 	require.Equal(t, "7436d6ac76178623", ml["span_id"])
 }
 
+func TestConvertMonotonicSumExemplar(t *testing.T) {
+	metric := pmetric.NewMetric()
+	metric.SetName("test_metric")
+	metric.SetEmptySum().SetIsMonotonic(true)
+	metric.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := metric.Sum().DataPoints().AppendEmpty()
+	dp.SetIntValue(42)
+
+	first := dp.Exemplars().AppendEmpty()
+	first.SetIntValue(1)
+	first.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+
+	last := dp.Exemplars().AppendEmpty()
+	last.SetIntValue(3)
+	last.SetTraceID([16]byte{0x64, 0x1d, 0x68, 0xe3, 0x14, 0xa5, 0x81, 0x52, 0xcc, 0x25, 0x81, 0xe7, 0x66, 0x34, 0x35, 0xd1})
+	last.SetSpanID([8]byte{0x74, 0x36, 0xd6, 0xac, 0x76, 0x17, 0x86, 0x23})
+	exemplarTs := time.Unix(1668000000, 0)
+	last.SetTimestamp(pcommon.NewTimestampFromTime(exemplarTs))
+
+	c := collector{logger: zap.NewNop()}
+	pbMetric, err := c.convertSum(metric, pcommon.NewMap())
+	require.NoError(t, err)
+	m := io_prometheus_client.Metric{}
+	require.NoError(t, pbMetric.Write(&m))
+
+	require.Equal(t, 42.0, m.GetCounter().GetValue())
+	exemplar := m.GetCounter().GetExemplar()
+	require.NotNil(t, exemplar)
+	require.Equal(t, 3.0, exemplar.GetValue())
+	require.Equal(t, exemplarTs.Unix(), exemplar.GetTimestamp().GetSeconds())
+	ml := make(map[string]string)
+	for _, l := range exemplar.GetLabel() {
+		ml[l.GetName()] = l.GetValue()
+	}
+	require.Equal(t, map[string]string{
+		"trace_id": "641d68e314a58152cc2581e7663435d1",
+		"span_id":  "7436d6ac76178623",
+	}, ml)
+
+	// Exemplars are not added to non-monotonic sums, which are exported as gauges.
+	metric.Sum().SetIsMonotonic(false)
+	pbMetric, err = c.convertSum(metric, pcommon.NewMap())
+	require.NoError(t, err)
+	m = io_prometheus_client.Metric{}
+	require.NoError(t, pbMetric.Write(&m))
+	require.Equal(t, 42.0, m.GetGauge().GetValue())
+}
+
+func TestCollectTargetInfoDisabled(t *testing.T) {
+	metric := pmetric.NewMetric()
+	metric.SetName("test_metric")
+	metric.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(42)
+
+	rAttrs := pcommon.NewMap()
+	rAttrs.PutStr(conventions.AttributeServiceInstanceID, "localhost:9090")
+	rAttrs.PutStr(conventions.AttributeServiceName, "testapp")
+	rAttrs.PutStr("host.name", "localhost")
+
+	for _, disabled := range []bool{false, true} {
+		c := collector{
+			accumulator: &mockAccumulator{
+				[]pmetric.Metric{metric},
+				rAttrs,
+			},
+			disableTargetInfo: disabled,
+			logger:            zap.NewNop(),
+		}
+
+		ch := make(chan prometheus.Metric, 2)
+		c.Collect(ch)
+		close(ch)
+
+		var names []string
+		for m := range ch {
+			names = append(names, m.Desc().String())
+		}
+		if disabled {
+			require.Len(t, names, 1)
+			require.Contains(t, names[0], "fqName: \"test_metric\"")
+		} else {
+			require.Len(t, names, 2)
+			require.Contains(t, names[0], "fqName: \"target_info\"")
+		}
+	}
+}
+
 // errorCheckCore keeps track of logged errors
 type errorCheckCore struct {
 	errorMessages []string
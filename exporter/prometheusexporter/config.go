@@ -15,6 +15,7 @@
 package prometheusexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusexporter"
 
 import (
+	"errors"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -41,6 +42,14 @@ type Config struct {
 	// MetricExpiration defines how long metrics are kept without updates
 	MetricExpiration time.Duration `mapstructure:"metric_expiration"`
 
+	// ResourceExpiration defines how long the metrics of a resource are kept
+	// after the last time any metric was received for that resource. Zero
+	// disables the expiration per resource.
+	ResourceExpiration time.Duration `mapstructure:"resource_expiration"`
+
+	// TargetInfo allows customizing the target_info metric.
+	TargetInfo TargetInfo `mapstructure:"target_info"`
+
 	// ResourceToTelemetrySettings defines configuration for converting resource attributes to metric labels.
 	ResourceToTelemetrySettings resourcetotelemetry.Settings `mapstructure:"resource_to_telemetry_conversion"`
 
@@ -48,9 +57,18 @@ type Config struct {
 	EnableOpenMetrics bool `mapstructure:"enable_open_metrics"`
 }
 
+// TargetInfo configures the target_info metric generated for each resource.
+type TargetInfo struct {
+	// Enabled if false the target_info metric is not generated by the exporter.
+	Enabled bool `mapstructure:"enabled"`
+}
+
 var _ config.Exporter = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
 func (cfg *Config) Validate() error {
+	if cfg.ResourceExpiration < 0 {
+		return errors.New("resource_expiration can't be negative")
+	}
 	return nil
 }
@@ -60,8 +60,12 @@ func TestLoadConfig(t *testing.T) {
 					"label1":        "value1",
 					"another label": "spaced value",
 				},
-				SendTimestamps:   true,
-				MetricExpiration: 60 * time.Minute,
+				SendTimestamps:     true,
+				MetricExpiration:   60 * time.Minute,
+				ResourceExpiration: 10 * time.Minute,
+				TargetInfo: TargetInfo{
+					Enabled: false,
+				},
 			},
 		},
 	}
@@ -80,3 +84,11 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.ResourceExpiration = -time.Second
+	assert.EqualError(t, cfg.Validate(), "resource_expiration can't be negative")
+}
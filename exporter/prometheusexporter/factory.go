@@ -48,6 +48,9 @@ func createDefaultConfig() config.Exporter {
 		SendTimestamps:    false,
 		MetricExpiration:  time.Minute * 5,
 		EnableOpenMetrics: false,
+		TargetInfo: TargetInfo{
+			Enabled: true,
+		},
 	}
 }
 
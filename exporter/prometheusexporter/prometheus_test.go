@@ -491,6 +491,59 @@ func TestPrometheusExporter_endToEndWithResource(t *testing.T) {
 	}
 }
 
+func TestPrometheusExporter_endToEndOpenMetricsExemplars(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = ":7777"
+	cfg.EnableOpenMetrics = true
+
+	factory := NewFactory()
+	set := componenttest.NewNopExporterCreateSettings()
+	exp, err := factory.CreateMetricsExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, exp.Shutdown(context.Background()))
+		// trigger a get so that the server cleans up our keepalive socket
+		_, err = http.Get("http://localhost:7777/metrics")
+		require.NoError(t, err)
+	})
+
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	md := metricBuilder(0, "metric_", "cpu-exporter", "localhost:8080")
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		exemplar := ms.At(i).Sum().DataPoints().At(0).Exemplars().AppendEmpty()
+		exemplar.SetDoubleValue(3)
+		exemplar.SetTraceID([16]byte{0x64, 0x1d, 0x68, 0xe3, 0x14, 0xa5, 0x81, 0x52, 0xcc, 0x25, 0x81, 0xe7, 0x66, 0x34, 0x35, 0xd1})
+		exemplar.SetSpanID([8]byte{0x74, 0x36, 0xd6, 0xac, 0x76, 0x17, 0x86, 0x23})
+		exemplar.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(1543160298, 0)))
+	}
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), md))
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost:7777/metrics", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "application/openmetrics-text; version=0.0.1")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err, "Failed to perform a scrape")
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	blob, _ := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+
+	// The exemplar labels are not sorted, so they are checked separately.
+	want := []string{
+		`metric_this_one_there_where{arch="x86",instance="localhost:8080",job="cpu-exporter",os="linux"} 100.0 # {`,
+		`metric_this_one_there_where{arch="x86",instance="localhost:8080",job="cpu-exporter",os="windows"} 99.0 # {`,
+		`trace_id="641d68e314a58152cc2581e7663435d1"`,
+		`span_id="7436d6ac76178623"`,
+		`} 3.0 1.543160298e+09`,
+		`# EOF`,
+	}
+	for _, w := range want {
+		assert.Contains(t, string(blob), w)
+	}
+}
+
 func metricBuilder(delta int64, prefix, job, instance string) pmetric.Metrics {
 	md := pmetric.NewMetrics()
 	rms := md.ResourceMetrics().AppendEmpty()
@@ -51,6 +51,10 @@ type Config struct {
 
 	// TargetInfo allows customizing the target_info metric
 	TargetInfo *TargetInfo `mapstructure:"target_info,omitempty"`
+
+	// ExponentialHistogramsAsClassic converts exponential histograms to classic histograms
+	// with explicit buckets, for backends which do not support native histograms.
+	ExponentialHistogramsAsClassic bool `mapstructure:"exponential_histograms_as_classic"`
 }
 
 type TargetInfo struct {
@@ -91,6 +95,12 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("remote write consumer number can't be negative")
 	}
 
+	if cfg.WAL != nil {
+		if err := cfg.WAL.validate(); err != nil {
+			return err
+		}
+	}
+
 	if cfg.TargetInfo == nil {
 		cfg.TargetInfo = &TargetInfo{
 			Enabled: true,
@@ -92,6 +92,10 @@ func TestLoadConfig(t *testing.T) {
 			id:           config.NewComponentIDWithName(typeStr, "negative_num_consumers"),
 			errorMessage: "remote write consumer number can't be negative",
 		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "wal_without_location"),
+			errorMessage: "wal requires either a directory or a storage extension",
+		},
 	}
 
 	for _, tt := range tests {
@@ -138,3 +142,38 @@ func TestDisabledTargetInfo(t *testing.T) {
 
 	assert.False(t, cfg.(*Config).TargetInfo.Enabled)
 }
+
+func TestExponentialHistogramsAsClassic(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.False(t, cfg.(*Config).ExponentialHistogramsAsClassic)
+
+	sub, err := cm.Sub(config.NewComponentIDWithName(typeStr, "exponential_histograms_as_classic").String())
+	require.NoError(t, err)
+	require.NoError(t, config.UnmarshalExporter(sub, cfg))
+
+	assert.True(t, cfg.(*Config).ExponentialHistogramsAsClassic)
+}
+
+func TestWALStorage(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(config.NewComponentIDWithName(typeStr, "wal_storage").String())
+	require.NoError(t, err)
+	require.NoError(t, config.UnmarshalExporter(sub, cfg))
+	require.NoError(t, cfg.Validate())
+
+	storageID := config.NewComponentID("file_storage")
+	assert.Equal(t, &WALConfig{
+		StorageID:         &storageID,
+		BufferSize:        100,
+		TruncateFrequency: 45 * time.Second,
+		MaxItems:          1000,
+		MaxSizeBytes:      1048576,
+	}, cfg.(*Config).WAL)
+}
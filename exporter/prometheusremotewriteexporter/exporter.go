@@ -35,6 +35,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/multierr"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/persistentqueue"
 	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
 )
@@ -54,6 +55,8 @@ type prwExporter struct {
 	clientSettings    *confighttp.HTTPClientSettings
 	settings          component.TelemetrySettings
 	disableTargetInfo bool
+	// exponentialHistogramsAsClassic converts exponential histograms to classic histograms
+	exponentialHistogramsAsClassic bool
 
 	wal *prweWAL
 }
@@ -83,12 +86,14 @@ func newPRWExporter(cfg *Config, set component.ExporterCreateSettings) (*prwExpo
 		clientSettings:    &cfg.HTTPClientSettings,
 		settings:          set.TelemetrySettings,
 		disableTargetInfo: !cfg.TargetInfo.Enabled,
+
+		exponentialHistogramsAsClassic: cfg.ExponentialHistogramsAsClassic,
 	}
 	if cfg.WAL == nil {
 		return prwe, nil
 	}
 
-	prwe.wal, err = newWAL(cfg.WAL, prwe.export)
+	prwe.wal, err = newWAL(cfg.WAL, cfg.ID(), prwe.settings, prwe.export)
 	if err != nil {
 		return nil, err
 	}
@@ -101,7 +106,7 @@ func (prwe *prwExporter) Start(ctx context.Context, host component.Host) (err er
 	if err != nil {
 		return err
 	}
-	return prwe.turnOnWALIfEnabled(contextWithLogger(ctx, prwe.settings.Logger.Named("prw.wal")))
+	return prwe.turnOnWALIfEnabled(ctx, host)
 }
 
 func (prwe *prwExporter) shutdownWALIfEnabled() error {
@@ -135,7 +140,12 @@ func (prwe *prwExporter) PushMetrics(ctx context.Context, md pmetric.Metrics) er
 	case <-prwe.closeChan:
 		return errors.New("shutdown has been called")
 	default:
-		tsMap, err := prometheusremotewrite.FromMetrics(md, prometheusremotewrite.Settings{Namespace: prwe.namespace, ExternalLabels: prwe.externalLabels, DisableTargetInfo: prwe.disableTargetInfo})
+		tsMap, err := prometheusremotewrite.FromMetrics(md, prometheusremotewrite.Settings{
+			Namespace:                      prwe.namespace,
+			ExternalLabels:                 prwe.externalLabels,
+			DisableTargetInfo:              prwe.disableTargetInfo,
+			ExponentialHistogramsAsClassic: prwe.exponentialHistogramsAsClassic,
+		})
 		if err != nil {
 			err = consumererror.NewPermanent(err)
 		}
@@ -174,7 +184,11 @@ func (prwe *prwExporter) handleExport(ctx context.Context, tsMap map[string]*pro
 
 	// Otherwise the WAL is enabled, and just persist the requests to the WAL
 	// and they'll be exported in another goroutine to the RemoteWrite endpoint.
-	if err = prwe.wal.persistToWAL(requests); err != nil {
+	if err = prwe.wal.persistToWAL(ctx, requests); err != nil {
+		if errors.Is(err, persistentqueue.ErrQueueFull) {
+			// The WAL may have room again once pending requests are exported.
+			return err
+		}
 		return consumererror.NewPermanent(err)
 	}
 	return nil
@@ -268,14 +282,9 @@ func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 
 func (prwe *prwExporter) walEnabled() bool { return prwe.wal != nil }
 
-func (prwe *prwExporter) turnOnWALIfEnabled(ctx context.Context) error {
+func (prwe *prwExporter) turnOnWALIfEnabled(ctx context.Context, host component.Host) error {
 	if !prwe.walEnabled() {
 		return nil
 	}
-	cancelCtx, cancel := context.WithCancel(ctx)
-	go func() {
-		<-prwe.closeChan
-		cancel()
-	}()
-	return prwe.wal.run(cancelCtx)
+	return prwe.wal.run(ctx, host)
 }
@@ -23,6 +23,7 @@ import (
 	"runtime"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
@@ -37,7 +38,9 @@ import (
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/atomic"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/persistentqueue"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
 )
 
@@ -808,10 +811,16 @@ func TestWALOnExporterRoundTrip(t *testing.T) {
 	}
 
 	// 1. Create a mock Prometheus Remote Write Exporter that'll just
-	// receive the bytes uploaded to it by our exporter.
+	// receive the bytes uploaded to it by our exporter. It rejects the
+	// uploads until accepting is set, so that the requests stay in the WAL.
 	uploadedBytesCh := make(chan []byte, 1)
 	exiting := make(chan bool)
+	accepting := atomic.NewBool(false)
 	prweServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if !accepting.Load() {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
 		uploaded, err2 := io.ReadAll(req.Body)
 		assert.NoError(t, err2, "Error while reading from HTTP upload")
 		select {
@@ -881,29 +890,29 @@ func TestWALOnExporterRoundTrip(t *testing.T) {
 
 	// 3. Let's now read back all of the WAL records and ensure
 	// that all the prompb.WriteRequest values exist as we sent them.
-	wal, _, werr := cfg.WAL.createWAL()
-	assert.NoError(t, werr)
-	assert.NotNil(t, wal)
-	t.Cleanup(func() {
-		assert.NoError(t, wal.Close())
-	})
-
-	// Read all the indices.
-	firstIndex, ierr := wal.FirstIndex()
-	assert.NoError(t, ierr)
-	lastIndex, ierr := wal.LastIndex()
-	assert.NoError(t, ierr)
+	walReader, werr := newWAL(cfg.WAL, cfg.ID(), set.TelemetrySettings, nil)
+	require.NoError(t, werr)
+	client, cerr := walReader.openStorage(ctx, nopHost)
+	require.NoError(t, cerr)
+	queue, qerr := persistentqueue.New(ctx, client, persistentqueue.Settings{})
+	require.NoError(t, qerr)
+	walReader.queue = queue
+	assert.Equal(t, uint64(1), queue.Len())
+
+	readCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	items, rerr := queue.Read(readCtx, 10)
+	require.NoError(t, rerr)
 
 	var reqs []*prompb.WriteRequest
-	for i := firstIndex; i <= lastIndex; i++ {
-		protoBlob, err := wal.Read(i)
-		assert.NoError(t, err)
-		assert.NotNil(t, protoBlob)
+	for _, item := range items {
 		req := new(prompb.WriteRequest)
-		err = proto.Unmarshal(protoBlob, req)
+		err := proto.Unmarshal(item.Value, req)
 		assert.NoError(t, err)
 		reqs = append(reqs, req)
 	}
+	// Close without acknowledging, so that the requests are replayed below.
+	require.NoError(t, walReader.stop())
 	assert.Equal(t, 1, len(reqs))
 	// We MUST have 2 time series as were passed into tsMap.
 	gotFromWAL := reqs[0]
@@ -925,6 +934,7 @@ func TestWALOnExporterRoundTrip(t *testing.T) {
 	// 4. Finally, ensure that the bytes that were uploaded to the
 	// Prometheus Remote Write endpoint are exactly as were saved in the WAL.
 	// Read from that same WAL, export to the RWExporter server.
+	accepting.Store(true)
 	prwe2, err := newPRWExporter(cfg, set)
 	assert.NoError(t, err)
 	require.NoError(t, prwe2.Start(ctx, nopHost))
@@ -18,42 +18,63 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"path/filepath"
+	"os"
 	"sync"
 	"time"
 
-	"github.com/fsnotify/fsnotify"
 	"github.com/gogo/protobuf/proto"
 	"github.com/prometheus/prometheus/prompb"
-	"github.com/tidwall/wal"
-	"go.uber.org/atomic"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/persistentqueue"
 )
 
+// walStorageName is the name of the storage client the WAL is persisted in.
+const walStorageName = "wal"
+
+// prweWAL persists the write requests in a persistent queue, from which they are
+// read back and exported in another goroutine.
 type prweWAL struct {
-	mu        sync.Mutex // mu protects the fields below.
-	wal       *wal.Log
 	walConfig *WALConfig
-	walPath   string
+	id        config.ComponentID
+	settings  component.TelemetrySettings
+	queue     *persistentqueue.Queue
+	// fileStorage is the storage created for the WAL directory, if no storage extension is configured
+	fileStorage component.Extension
 
 	exportSink func(ctx context.Context, reqL []*prompb.WriteRequest) error
 
-	stopOnce  sync.Once
-	stopChan  chan struct{}
-	rWALIndex *atomic.Uint64
-	wWALIndex *atomic.Uint64
+	stopOnce sync.Once
+	stopChan chan struct{}
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
 }
 
 const (
 	defaultWALBufferSize        = 300
 	defaultWALTruncateFrequency = 1 * time.Minute
+
+	minWALRetryInterval = 100 * time.Millisecond
 )
 
 type WALConfig struct {
-	Directory         string        `mapstructure:"directory"`
-	BufferSize        int           `mapstructure:"buffer_size"`
+	// Directory is where the WAL is stored when no storage extension is configured.
+	Directory string `mapstructure:"directory"`
+	// StorageID is the storage extension the WAL is stored in.
+	StorageID *config.ComponentID `mapstructure:"storage"`
+	// BufferSize is the maximum number of requests read from the WAL before they are exported.
+	BufferSize int `mapstructure:"buffer_size"`
+	// TruncateFrequency is how long requests are read from the WAL before they are exported.
 	TruncateFrequency time.Duration `mapstructure:"truncate_frequency"`
+	// MaxItems is the maximum number of requests held by the WAL. Zero means no limit.
+	MaxItems uint64 `mapstructure:"max_items"`
+	// MaxSizeBytes is the maximum size of the requests held by the WAL. Zero means no limit.
+	MaxSizeBytes uint64 `mapstructure:"max_size_bytes"`
 }
 
 func (wc *WALConfig) bufferSize() int {
@@ -70,7 +91,14 @@ func (wc *WALConfig) truncateFrequency() time.Duration {
 	return defaultWALTruncateFrequency
 }
 
-func newWAL(walConfig *WALConfig, exportSink func(context.Context, []*prompb.WriteRequest) error) (*prweWAL, error) {
+func (wc *WALConfig) validate() error {
+	if wc.Directory == "" && wc.StorageID == nil {
+		return errors.New("wal requires either a directory or a storage extension")
+	}
+	return nil
+}
+
+func newWAL(walConfig *WALConfig, id config.ComponentID, settings component.TelemetrySettings, exportSink func(context.Context, []*prompb.WriteRequest) error) (*prweWAL, error) {
 	if walConfig == nil {
 		// There are cases for which the WAL can be disabled.
 		// TODO: Perhaps log that the WAL wasn't enabled.
@@ -80,357 +108,216 @@ func newWAL(walConfig *WALConfig, exportSink func(context.Context, []*prompb.Wri
 	return &prweWAL{
 		exportSink: exportSink,
 		walConfig:  walConfig,
+		id:         id,
+		settings:   settings,
 		stopChan:   make(chan struct{}),
-		rWALIndex:  atomic.NewUint64(0),
-		wWALIndex:  atomic.NewUint64(0),
 	}, nil
 }
 
-func (wc *WALConfig) createWAL() (*wal.Log, string, error) {
-	walPath := filepath.Join(wc.Directory, "prom_remotewrite")
-	log, err := wal.Open(walPath, &wal.Options{
-		SegmentCacheSize: wc.bufferSize(),
-		NoCopy:           true,
-	})
-	if err != nil {
-		return nil, "", fmt.Errorf("prometheusremotewriteexporter: failed to open WAL: %w", err)
-	}
-	return log, walPath, nil
-}
-
 var (
 	errAlreadyClosed = errors.New("already closed")
 	errNilWAL        = errors.New("wal is nil")
 	errNilConfig     = errors.New("expecting a non-nil configuration")
 )
 
-// retrieveWALIndices queries the WriteAheadLog for its current first and last indices.
-func (prwe *prweWAL) retrieveWALIndices() (err error) {
-	prwe.mu.Lock()
-	defer prwe.mu.Unlock()
-
-	err = prwe.closeWAL()
-	if err != nil {
-		return err
-	}
-
-	log, walPath, err := prwe.walConfig.createWAL()
-	if err != nil {
-		return err
-	}
-
-	prwe.wal = log
-	prwe.walPath = walPath
-
-	rIndex, err := prwe.wal.FirstIndex()
-	if err != nil {
-		return fmt.Errorf("prometheusremotewriteexporter: failed to retrieve the first WAL index: %w", err)
-	}
-	prwe.rWALIndex.Store(rIndex)
-
-	wIndex, err := prwe.wal.LastIndex()
-	if err != nil {
-		return fmt.Errorf("prometheusremotewriteexporter: failed to retrieve the last WAL index: %w", err)
-	}
-	prwe.wWALIndex.Store(wIndex)
-	return nil
-}
-
 func (prwe *prweWAL) stop() error {
 	err := errAlreadyClosed
 	prwe.stopOnce.Do(func() {
-		prwe.mu.Lock()
-		defer prwe.mu.Unlock()
-
 		close(prwe.stopChan)
-		err = prwe.closeWAL()
+		if prwe.cancel != nil {
+			prwe.cancel()
+		}
+		prwe.wg.Wait()
+
+		err = nil
+		if prwe.queue != nil {
+			err = prwe.queue.Close(context.Background())
+		}
+		if prwe.fileStorage != nil {
+			err = multierr.Append(err, prwe.fileStorage.Shutdown(context.Background()))
+		}
 	})
 	return err
 }
 
-// run begins reading from the WAL until prwe.stopChan is closed.
-func (prwe *prweWAL) run(ctx context.Context) (err error) {
-	var logger *zap.Logger
-	logger, err = loggerFromContext(ctx)
-	if err != nil {
-		return
+// openStorage returns the client of the configured storage extension, or of a file
+// storage in the configured directory otherwise. That file storage is started here,
+// and shut down when the WAL is stopped.
+func (prwe *prweWAL) openStorage(ctx context.Context, host component.Host) (storage.Client, error) {
+	wc := prwe.walConfig
+	if wc.StorageID != nil {
+		ext, ok := host.GetExtensions()[*wc.StorageID]
+		if !ok {
+			return nil, fmt.Errorf("storage extension '%s' not found", wc.StorageID)
+		}
+		storageExt, ok := ext.(storage.Extension)
+		if !ok {
+			return nil, fmt.Errorf("non-storage extension '%s' found", wc.StorageID)
+		}
+		return storageExt.GetClient(ctx, component.KindExporter, prwe.id, walStorageName)
 	}
 
-	if err = prwe.retrieveWALIndices(); err != nil {
-		logger.Error("unable to start write-ahead log", zap.Error(err))
-		return
+	if err := os.MkdirAll(wc.Directory, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create the WAL directory: %w", err)
 	}
-
-	runCtx, cancel := context.WithCancel(ctx)
-
-	// Start the process of exporting but wait until the exporting has started.
-	waitUntilStartedCh := make(chan bool)
-	go func() {
-		signalStart := func() { close(waitUntilStartedCh) }
-		defer cancel()
-		for {
-			select {
-			case <-runCtx.Done():
-				return
-			case <-prwe.stopChan:
-				return
-			default:
-				err := prwe.continuallyPopWALThenExport(runCtx, signalStart)
-				signalStart = func() {}
-				if err != nil {
-					// log err
-					logger.Error("error processing WAL entries", zap.Error(err))
-					// Restart WAL
-					if errS := prwe.retrieveWALIndices(); errS != nil {
-						logger.Error("unable to re-start write-ahead log after error", zap.Error(errS))
-						return
-					}
-				}
-			}
-		}
-	}()
-	<-waitUntilStartedCh
-	return nil
+	factory := filestorage.NewFactory()
+	storageCfg := factory.CreateDefaultConfig().(*filestorage.Config)
+	storageCfg.Directory = wc.Directory
+	ext, err := factory.CreateExtension(ctx, component.ExtensionCreateSettings{TelemetrySettings: prwe.settings}, storageCfg)
+	if err != nil {
+		return nil, err
+	}
+	if err = ext.Start(ctx, host); err != nil {
+		return nil, fmt.Errorf("failed to start the WAL file storage: %w", err)
+	}
+	client, err := ext.(storage.Extension).GetClient(ctx, component.KindExporter, prwe.id, walStorageName)
+	if err != nil {
+		return nil, multierr.Append(err, ext.Shutdown(ctx))
+	}
+	prwe.fileStorage = ext
+	return client, nil
 }
 
-// continuallyPopWALThenExport reads a prompb.WriteRequest proto encoded blob from the WAL, and moves
-// the WAL's front index forward until either the read buffer period expires or the maximum
-// buffer size is exceeded. When either of the two conditions are matched, it then exports
-// the requests to the Remote-Write endpoint, and then truncates the head of the WAL to where
-// it last read from.
-func (prwe *prweWAL) continuallyPopWALThenExport(ctx context.Context, signalStart func()) (err error) {
-	var reqL []*prompb.WriteRequest
-	defer func() {
-		// Keeping it within a closure to ensure that the later
-		// updated value of reqL is always flushed to disk.
-		if errL := prwe.exportSink(ctx, reqL); errL != nil {
-			err = multierr.Append(err, errL)
+// run opens the WAL, replaying requests that were not exported before the last shutdown,
+// and begins exporting from it until prwe.stopChan is closed.
+func (prwe *prweWAL) run(ctx context.Context, host component.Host) error {
+	client, err := prwe.openStorage(ctx, host)
+	if err != nil {
+		return fmt.Errorf("prometheusremotewriteexporter: failed to open WAL storage: %w", err)
+	}
+	prwe.queue, err = persistentqueue.New(ctx, client, persistentqueue.Settings{
+		Name:         prwe.id.String(),
+		MaxItems:     prwe.walConfig.MaxItems,
+		MaxSizeBytes: prwe.walConfig.MaxSizeBytes,
+		Logger:       prwe.settings.Logger,
+	})
+	if err != nil {
+		_ = client.Close(ctx)
+		if prwe.fileStorage != nil {
+			_ = prwe.fileStorage.Shutdown(ctx)
+			prwe.fileStorage = nil
 		}
-	}()
-
-	freshTimer := func() *time.Timer {
-		return time.NewTimer(prwe.walConfig.truncateFrequency())
+		return fmt.Errorf("prometheusremotewriteexporter: failed to open WAL: %w", err)
 	}
 
-	timer := freshTimer()
-	defer func() {
-		// Added in a closure to ensure we capture the later
-		// updated value of timer when changed in the loop below.
-		timer.Stop()
-	}()
+	// The context of the caller is only valid for the duration of Start.
+	runCtx, cancel := context.WithCancel(context.Background())
+	prwe.cancel = cancel
+	prwe.wg.Add(1)
+	go prwe.continuallyReadWALThenExport(runCtx)
+	return nil
+}
 
-	signalStart()
+// continuallyReadWALThenExport reads batches of requests from the WAL and exports them to the
+// Remote-Write endpoint, and then acknowledges them so that they are removed from the WAL.
+// Requests that fail to be exported are retried with a backoff.
+func (prwe *prweWAL) continuallyReadWALThenExport(ctx context.Context) {
+	defer prwe.wg.Done()
+	logger := prwe.settings.Logger
 
-	maxCountPerUpload := prwe.walConfig.bufferSize()
+	retryInterval := minWALRetryInterval
 	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-prwe.stopChan:
-			return nil
-		default:
-		}
-
-		var req *prompb.WriteRequest
-		req, err = prwe.readPrompbFromWAL(ctx, prwe.rWALIndex.Load())
+		reqL, lastIndex, err := prwe.readBatchFromWAL(ctx)
 		if err != nil {
-			return err
-		}
-		reqL = append(reqL, req)
-
-		shouldExport := false
-		select {
-		case <-timer.C:
-			shouldExport = true
-		default:
-			shouldExport = len(reqL) >= maxCountPerUpload
+			if errors.Is(err, persistentqueue.ErrClosed) || ctx.Err() != nil {
+				return
+			}
+			logger.Error("error reading from WAL", zap.Error(err))
+			if !prwe.wait(ctx, retryInterval) {
+				return
+			}
+			continue
 		}
-
-		if !shouldExport {
+		if lastIndex == nil {
 			continue
 		}
 
-		// Otherwise, it is time to export, flush and then truncate the WAL, but also to kill the timer!
-		timer.Stop()
-		timer = freshTimer()
-
-		if err = prwe.exportThenFrontTruncateWAL(ctx, reqL); err != nil {
-			return err
+		if len(reqL) > 0 {
+			err = prwe.exportSink(ctx, reqL)
+			if ctx.Err() != nil {
+				// The export was interrupted by the shutdown, the requests are replayed on restart.
+				return
+			}
+			if err != nil {
+				logger.Error("failed to export requests from WAL, retrying", zap.Error(err), zap.Duration("interval", retryInterval))
+				prwe.queue.Rewind()
+				if !prwe.wait(ctx, retryInterval) {
+					return
+				}
+				retryInterval *= 2
+				if maxInterval := prwe.walConfig.truncateFrequency(); retryInterval > maxInterval {
+					retryInterval = maxInterval
+				}
+				continue
+			}
 		}
-		// Reset but reuse the write requests slice.
-		reqL = reqL[:0]
-	}
-}
+		retryInterval = minWALRetryInterval
 
-func (prwe *prweWAL) closeWAL() error {
-	if prwe.wal != nil {
-		err := prwe.wal.Close()
-		prwe.wal = nil
-		return err
+		if err = prwe.queue.Ack(ctx, *lastIndex); err != nil {
+			logger.Error("failed to truncate WAL, exported requests may be sent again", zap.Error(err))
+		}
 	}
-	return nil
 }
 
-func (prwe *prweWAL) syncAndTruncateFront() error {
-	prwe.mu.Lock()
-	defer prwe.mu.Unlock()
+// readBatchFromWAL reads requests from the WAL until either the truncate frequency expires or
+// the maximum buffer size is reached. It returns the index of the last request read, if any.
+func (prwe *prweWAL) readBatchFromWAL(ctx context.Context) ([]*prompb.WriteRequest, *uint64, error) {
+	batchCtx, cancel := context.WithTimeout(ctx, prwe.walConfig.truncateFrequency())
+	defer cancel()
 
-	if prwe.wal == nil {
-		return errNilWAL
-	}
+	var reqL []*prompb.WriteRequest
+	var lastIndex *uint64
+	for n, maxCount := 0, prwe.walConfig.bufferSize(); n < maxCount; {
+		items, err := prwe.queue.Read(batchCtx, maxCount-n)
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+				break
+			}
+			return nil, nil, err
+		}
+		for _, item := range items {
+			n++
+			index := item.Index
+			lastIndex = &index
 
-	// Save all the entries that aren't yet committed, to the tail of the WAL.
-	if err := prwe.wal.Sync(); err != nil {
-		return err
-	}
-	// Truncate the WAL from the front for the entries that we already
-	// read from the WAL and had already exported.
-	if err := prwe.wal.TruncateFront(prwe.rWALIndex.Load()); err != nil && !errors.Is(err, wal.ErrOutOfRange) {
-		return err
+			req := new(prompb.WriteRequest)
+			if err = proto.Unmarshal(item.Value, req); err != nil {
+				prwe.settings.Logger.Error("dropping invalid request from WAL", zap.Error(err))
+				continue
+			}
+			reqL = append(reqL, req)
+		}
 	}
-	return nil
+	return reqL, lastIndex, nil
 }
 
-func (prwe *prweWAL) exportThenFrontTruncateWAL(ctx context.Context, reqL []*prompb.WriteRequest) error {
-	if len(reqL) == 0 {
-		return nil
-	}
-	if cErr := ctx.Err(); cErr != nil {
-		return nil
-	}
-
-	if errL := prwe.exportSink(ctx, reqL); errL != nil {
-		return errL
+func (prwe *prweWAL) wait(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
 	}
-	if err := prwe.syncAndTruncateFront(); err != nil {
-		return err
-	}
-	// Reset by retrieving the respective read and write WAL indices.
-	return prwe.retrieveWALIndices()
 }
 
 // persistToWAL is the routine that'll be hooked into the exporter's receiving side and it'll
 // write them to the Write-Ahead-Log so that shutdowns won't lose data, and that the routine that
 // reads from the WAL can then process the previously serialized requests.
-func (prwe *prweWAL) persistToWAL(requests []*prompb.WriteRequest) error {
-	prwe.mu.Lock()
-	defer prwe.mu.Unlock()
+func (prwe *prweWAL) persistToWAL(ctx context.Context, requests []*prompb.WriteRequest) error {
+	if prwe.queue == nil {
+		return errNilWAL
+	}
 
 	// Write all the requests to the WAL in a batch.
-	batch := new(wal.Batch)
+	blobs := make([][]byte, 0, len(requests))
 	for _, req := range requests {
 		protoBlob, err := proto.Marshal(req)
 		if err != nil {
 			return err
 		}
-		wIndex := prwe.wWALIndex.Add(1)
-		batch.Write(wIndex, protoBlob)
-	}
-
-	return prwe.wal.WriteBatch(batch)
-}
-
-func (prwe *prweWAL) readPrompbFromWAL(ctx context.Context, index uint64) (wreq *prompb.WriteRequest, err error) {
-	prwe.mu.Lock()
-	defer prwe.mu.Unlock()
-
-	var protoBlob []byte
-	for i := 0; i < 12; i++ {
-		// Firstly check if we've been terminated, then exit if so.
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-prwe.stopChan:
-			return nil, fmt.Errorf("attempt to read from WAL after stopped")
-		default:
-		}
-
-		if index <= 0 {
-			index = 1
-		}
-
-		if prwe.wal == nil {
-			return nil, fmt.Errorf("attempt to read from closed WAL")
-		}
-
-		protoBlob, err = prwe.wal.Read(index)
-		if err == nil { // The read succeeded.
-			req := new(prompb.WriteRequest)
-			if err = proto.Unmarshal(protoBlob, req); err != nil {
-				return nil, err
-			}
-
-			// Now increment the WAL's read index.
-			prwe.rWALIndex.Add(1)
-
-			return req, nil
-		}
-
-		if !errors.Is(err, wal.ErrNotFound) {
-			return nil, err
-		}
-
-		if index <= 1 {
-			// This could be the very first attempted read, so try again, after a small sleep.
-			time.Sleep(time.Duration(1<<i) * time.Millisecond)
-			continue
-		}
-
-		// Otherwise, we couldn't find the record, let's try watching
-		// the WAL file until perhaps there is a write to it.
-		walWatcher, werr := fsnotify.NewWatcher()
-		if werr != nil {
-			return nil, werr
-		}
-		if werr = walWatcher.Add(prwe.walPath); werr != nil {
-			return nil, werr
-		}
-
-		// Watch until perhaps there is a write to the WAL file.
-		watchCh := make(chan error)
-		wErr := err
-		go func() {
-			defer func() {
-				watchCh <- wErr
-				close(watchCh)
-				// Close the file watcher.
-				walWatcher.Close()
-			}()
-
-			select {
-			case <-ctx.Done(): // If the context was cancelled, bail out ASAP.
-				wErr = ctx.Err()
-				return
-
-			case event, ok := <-walWatcher.Events:
-				if !ok {
-					return
-				}
-				switch event.Op {
-				case fsnotify.Remove:
-					// The file got deleted.
-					// TODO: Add capabilities to search for the updated file.
-				case fsnotify.Rename:
-					// Renamed, we don't have information about the renamed file's new name.
-				case fsnotify.Write:
-					// Finally a write, let's try reading again, but after some watch.
-					wErr = nil
-				}
-
-			case eerr, ok := <-walWatcher.Errors:
-				if ok {
-					wErr = eerr
-				}
-			}
-		}()
-
-		if gerr := <-watchCh; gerr != nil {
-			return nil, gerr
-		}
-
-		// Otherwise a write occurred might have occurred,
-		// and we can sleep for a little bit then try again.
-		time.Sleep(time.Duration(1<<i) * time.Millisecond)
+		blobs = append(blobs, protoBlob)
 	}
-	return nil, err
+	return prwe.queue.Put(ctx, blobs...)
 }
@@ -16,35 +16,57 @@ package prometheusremotewriteexporter
 
 import (
 	"context"
+	"errors"
 	"sort"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/prometheus/prometheus/prompb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/persistentqueue"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 )
 
+var walTestID = config.NewComponentID(typeStr)
+
 func doNothingExportSink(_ context.Context, reqL []*prompb.WriteRequest) error {
 	_ = reqL
 	return nil
 }
 
+func newTestWAL(t *testing.T, config *WALConfig, exportSink func(context.Context, []*prompb.WriteRequest) error) *prweWAL {
+	pwal, err := newWAL(config, walTestID, componenttest.NewNopTelemetrySettings(), exportSink)
+	require.NoError(t, err)
+	return pwal
+}
+
 func TestWALCreation_nilConfig(t *testing.T) {
 	config := (*WALConfig)(nil)
-	pwal, err := newWAL(config, doNothingExportSink)
+	pwal, err := newWAL(config, walTestID, componenttest.NewNopTelemetrySettings(), doNothingExportSink)
 	require.Equal(t, err, errNilConfig)
 	require.Nil(t, pwal)
 }
 
 func TestWALCreation_nonNilConfig(t *testing.T) {
 	config := &WALConfig{Directory: t.TempDir()}
-	pwal, err := newWAL(config, doNothingExportSink)
+	pwal, err := newWAL(config, walTestID, componenttest.NewNopTelemetrySettings(), doNothingExportSink)
 	require.NotNil(t, pwal)
 	assert.Nil(t, err)
 	assert.NoError(t, pwal.stop())
 }
 
+func TestWALConfigValidate(t *testing.T) {
+	assert.Error(t, (&WALConfig{}).validate())
+	assert.NoError(t, (&WALConfig{Directory: t.TempDir()}).validate())
+	storageID := storagetest.NewStorageID("wal")
+	assert.NoError(t, (&WALConfig{StorageID: &storageID}).validate())
+}
+
 func orderByLabelValueForEach(reqL []*prompb.WriteRequest) {
 	for _, req := range reqL {
 		orderByLabelValue(req)
@@ -91,13 +113,12 @@ func TestWALStopManyTimes(t *testing.T) {
 		TruncateFrequency: 60 * time.Microsecond,
 		BufferSize:        1,
 	}
-	pwal, err := newWAL(config, doNothingExportSink)
-	require.Nil(t, err)
-	require.NotNil(t, pwal)
+	pwal := newTestWAL(t, config, doNothingExportSink)
+	require.NoError(t, pwal.run(context.Background(), componenttest.NewNopHost()))
 
 	// Ensure that invoking .stop() multiple times doesn't cause a panic, but actually
 	// First close should NOT return an error.
-	err = pwal.stop()
+	err := pwal.stop()
 	require.Nil(t, err)
 	for i := 0; i < 4; i++ {
 		// Every invocation to .stop() should return an errAlreadyClosed.
@@ -106,15 +127,8 @@ func TestWALStopManyTimes(t *testing.T) {
 	}
 }
 
-func TestWAL_persist(t *testing.T) {
-	// Unit tests that requests written to the WAL persist.
-	config := &WALConfig{Directory: t.TempDir()}
-
-	pwal, err := newWAL(config, doNothingExportSink)
-	require.Nil(t, err)
-
-	// 1. Write out all the entries.
-	reqL := []*prompb.WriteRequest{
+func testWriteRequests() []*prompb.WriteRequest {
+	return []*prompb.WriteRequest{
 		{
 			Timeseries: []prompb.TimeSeries{
 				{
@@ -136,34 +150,130 @@ func TestWAL_persist(t *testing.T) {
 			},
 		},
 	}
+}
+
+func TestWAL_persist(t *testing.T) {
+	// Unit tests that requests written to the WAL persist.
+	config := &WALConfig{
+		Directory:         t.TempDir(),
+		TruncateFrequency: 100 * time.Millisecond,
+	}
+
+	// The sink never succeeds, so that the requests stay in the WAL.
+	pwal := newTestWAL(t, config, func(context.Context, []*prompb.WriteRequest) error {
+		return errors.New("endpoint unavailable")
+	})
+
+	// 1. Write out all the entries.
+	reqL := testWriteRequests()
 
 	ctx := context.Background()
-	err = pwal.retrieveWALIndices()
-	require.Nil(t, err)
+	require.ErrorIs(t, pwal.persistToWAL(ctx, reqL), errNilWAL)
+	require.NoError(t, pwal.run(ctx, componenttest.NewNopHost()))
+	require.NoError(t, pwal.persistToWAL(ctx, reqL))
+	require.NoError(t, pwal.stop())
+
+	// 2. Read all the entries from the WAL itself, and ensure that
+	// they are exactly in order as we'd expect them.
+	pwal = newTestWAL(t, config, doNothingExportSink)
+	client, err := pwal.openStorage(ctx, componenttest.NewNopHost())
+	require.NoError(t, err)
+	queue, err := persistentqueue.New(ctx, client, persistentqueue.Settings{})
+	require.NoError(t, err)
+	pwal.queue = queue
 	t.Cleanup(func() {
 		assert.NoError(t, pwal.stop())
 	})
 
-	err = pwal.persistToWAL(reqL)
-	require.Nil(t, err)
-
-	// 2. Read all the entries from the WAL itself, guided by the indices available,
-	// and ensure that they are exactly in order as we'd expect them.
-	wal := pwal.wal
-	start, err := wal.FirstIndex()
-	require.Nil(t, err)
-	end, err := wal.LastIndex()
-	require.Nil(t, err)
-
-	var reqLFromWAL []*prompb.WriteRequest
-	for i := start; i <= end; i++ {
-		req, err := pwal.readPrompbFromWAL(ctx, i)
-		require.Nil(t, err)
-		reqLFromWAL = append(reqLFromWAL, req)
-	}
+	reqLFromWAL, lastIndex, err := pwal.readBatchFromWAL(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, lastIndex)
+	assert.Equal(t, uint64(1), *lastIndex)
+	require.Len(t, reqLFromWAL, 2)
 
 	orderByLabelValueForEach(reqL)
 	orderByLabelValueForEach(reqLFromWAL)
 	require.Equal(t, reqLFromWAL[0], reqL[0])
 	require.Equal(t, reqLFromWAL[1], reqL[1])
 }
+
+// recordingSink records the exported requests, failing the first failures exports.
+type recordingSink struct {
+	mu       sync.Mutex
+	failures int
+	attempts int
+	reqL     []*prompb.WriteRequest
+}
+
+func (s *recordingSink) export(_ context.Context, reqL []*prompb.WriteRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts++
+	if s.attempts <= s.failures {
+		return errors.New("endpoint unavailable")
+	}
+	s.reqL = append(s.reqL, reqL...)
+	return nil
+}
+
+func (s *recordingSink) exported() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.reqL)
+}
+
+func TestWAL_exportRetriesUntilSuccess(t *testing.T) {
+	storageID := storagetest.NewStorageID("wal")
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("wal")
+	config := &WALConfig{
+		StorageID:         &storageID,
+		BufferSize:        2,
+		TruncateFrequency: 10 * time.Millisecond,
+	}
+	sink := &recordingSink{failures: 2}
+	pwal := newTestWAL(t, config, sink.export)
+
+	ctx := context.Background()
+	require.NoError(t, pwal.run(ctx, host))
+	t.Cleanup(func() {
+		assert.NoError(t, pwal.stop())
+	})
+	require.NoError(t, pwal.persistToWAL(ctx, testWriteRequests()))
+
+	// The requests are only removed from the WAL once they were exported.
+	require.Eventually(t, func() bool {
+		return sink.exported() == 2 && pwal.queue.Len() == 0
+	}, 10*time.Second, 10*time.Millisecond)
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	assert.Equal(t, 3, sink.attempts)
+}
+
+func TestWAL_sizeCaps(t *testing.T) {
+	config := &WALConfig{
+		Directory: t.TempDir(),
+		MaxItems:  1,
+	}
+	pwal := newTestWAL(t, config, func(context.Context, []*prompb.WriteRequest) error {
+		return errors.New("endpoint unavailable")
+	})
+	ctx := context.Background()
+	require.NoError(t, pwal.run(ctx, componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, pwal.stop())
+	})
+	assert.ErrorIs(t, pwal.persistToWAL(ctx, testWriteRequests()), persistentqueue.ErrQueueFull)
+	assert.NoError(t, pwal.persistToWAL(ctx, testWriteRequests()[:1]))
+}
+
+func TestWAL_missingStorageExtension(t *testing.T) {
+	storageID := storagetest.NewStorageID("missing")
+	config := &WALConfig{StorageID: &storageID}
+	pwal := newTestWAL(t, config, doNothingExportSink)
+	assert.Error(t, pwal.run(context.Background(), storagetest.NewStorageHost()))
+
+	nonStorageID := storagetest.NewNonStorageID("wal")
+	config = &WALConfig{StorageID: &nonStorageID}
+	pwal = newTestWAL(t, config, doNothingExportSink)
+	assert.Error(t, pwal.run(context.Background(), storagetest.NewStorageHost().WithNonStorageExtension("wal")))
+}
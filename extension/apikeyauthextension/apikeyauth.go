@@ -0,0 +1,232 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikeyauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/apikeyauthextension"
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/filewatcher"
+)
+
+var (
+	errNoAPIKey            = errors.New("no API key provided")
+	errInvalidSchemePrefix = errors.New("invalid authorization scheme prefix")
+	errInvalidAPIKey       = errors.New("invalid API key")
+	errRevokedAPIKey       = errors.New("API key has been revoked")
+	errEmptyKeysFile       = errors.New("the API keys file has no keys")
+)
+
+// keyFile is the content of the file holding the API keys
+type keyFile struct {
+	Keys []KeyConfig `yaml:"keys"`
+}
+
+type apiKey struct {
+	tenant  string
+	name    string
+	revoked bool
+}
+
+// apiKeyAuth is an implementation of configauth.ServerAuthenticator. It authenticates
+// requests carrying one of the configured API keys, and attributes them to the tenant of the key.
+type apiKeyAuth struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	// keys are indexed by the SHA-256 hash of the API key, so that
+	// looking a key up does not depend on how much of it is correct
+	muKeys sync.RWMutex
+	keys   map[[sha256.Size]byte]apiKey
+	// fileKeys is the number of keys read from the file when the keys were last loaded
+	fileKeys int
+
+	watcher *filewatcher.Watcher
+	// reloaded is called with the result of each reload of the file, for tests
+	reloaded func(error)
+}
+
+var _ configauth.ServerAuthenticator = (*apiKeyAuth)(nil)
+
+func newAPIKeyAuth(cfg *Config, logger *zap.Logger) *apiKeyAuth {
+	return &apiKeyAuth{
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Start loads the API keys, and starts watching the file holding them when one is configured
+func (a *apiKeyAuth) Start(context.Context, component.Host) error {
+	if err := a.loadKeys(); err != nil {
+		return err
+	}
+	if a.cfg.Filename == "" {
+		return nil
+	}
+
+	var err error
+	a.watcher, err = filewatcher.Start(a.cfg.Filename, a.logger, a.reloadKeys)
+	return err
+}
+
+// reloadKeys reloads the API keys once the file changed, keeping the previous keys if that fails
+func (a *apiKeyAuth) reloadKeys() {
+	err := a.loadKeys()
+	if err != nil {
+		a.logger.Error("failed to reload the API keys, the previous keys are still in use", zap.Error(err))
+	}
+	if a.reloaded != nil {
+		a.reloaded(err)
+	}
+}
+
+// loadKeys reads the API keys from the file and the configuration, and replaces the keys in use
+func (a *apiKeyAuth) loadKeys() error {
+	var configs []KeyConfig
+	if a.cfg.Filename != "" {
+		content, err := os.ReadFile(a.cfg.Filename)
+		if err != nil {
+			return fmt.Errorf("failed to read the API keys file: %w", err)
+		}
+		var file keyFile
+		if err = yaml.Unmarshal(content, &file); err != nil {
+			return fmt.Errorf("failed to parse the API keys file: %w", err)
+		}
+		if err = validateKeys(file.Keys); err != nil {
+			return fmt.Errorf("invalid keys in %s: %w", a.cfg.Filename, err)
+		}
+		if len(file.Keys) == 0 && a.fileKeys > 0 && !a.cfg.AllowEmptyFile {
+			return fmt.Errorf("%w, set allow_empty_file to remove all of them: %s", errEmptyKeysFile, a.cfg.Filename)
+		}
+		configs = file.Keys
+	}
+	configs = append(configs, a.cfg.Keys...)
+
+	keys := make(map[[sha256.Size]byte]apiKey, len(configs))
+	revoked := 0
+	for _, kc := range configs {
+		hash := sha256.Sum256([]byte(kc.Key))
+		if existing, ok := keys[hash]; ok && existing.revoked {
+			continue
+		}
+		keys[hash] = apiKey{tenant: kc.Tenant, name: kc.Name, revoked: kc.Revoked}
+		if kc.Revoked {
+			revoked++
+		}
+	}
+
+	a.muKeys.Lock()
+	a.keys = keys
+	a.muKeys.Unlock()
+	a.fileKeys = len(configs) - len(a.cfg.Keys)
+
+	a.logger.Info("loaded API keys", zap.Int("keys", len(keys)-revoked), zap.Int("revoked", revoked))
+	return nil
+}
+
+// Shutdown stops watching the API keys file
+func (a *apiKeyAuth) Shutdown(context.Context) error {
+	if a.watcher != nil {
+		a.watcher.Stop()
+		a.watcher = nil
+	}
+	return nil
+}
+
+// Authenticate checks the API key carried by the headers, and adds the tenant of the key to the client info
+func (a *apiKeyAuth) Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	value := getHeader(headers, a.cfg.Header)
+	if value == "" {
+		return ctx, errNoAPIKey
+	}
+
+	if a.cfg.Scheme != "" {
+		prefix := a.cfg.Scheme + " "
+		if len(value) < len(prefix) || !strings.EqualFold(value[:len(prefix)], prefix) {
+			return ctx, errInvalidSchemePrefix
+		}
+		value = value[len(prefix):]
+	}
+
+	a.muKeys.RLock()
+	key, ok := a.keys[sha256.Sum256([]byte(value))]
+	a.muKeys.RUnlock()
+	if !ok {
+		return ctx, errInvalidAPIKey
+	}
+	if key.revoked {
+		return ctx, errRevokedAPIKey
+	}
+
+	cl := client.FromContext(ctx)
+	cl.Auth = &authData{tenant: key.tenant, keyName: key.name}
+	return client.NewContext(ctx, cl), nil
+}
+
+// getHeader returns the first value of the header, looked up case-insensitively
+// as HTTP headers are canonicalized while gRPC metadata keys are lowercase
+func getHeader(headers map[string][]string, name string) string {
+	values, ok := headers[http.CanonicalHeaderKey(name)]
+	if !ok {
+		values, ok = headers[strings.ToLower(name)]
+	}
+	if !ok {
+		for k, v := range headers {
+			if strings.EqualFold(k, name) {
+				values = v
+				break
+			}
+		}
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+var _ client.AuthData = (*authData)(nil)
+
+type authData struct {
+	tenant  string
+	keyName string
+}
+
+func (a *authData) GetAttribute(name string) interface{} {
+	switch name {
+	case "tenant":
+		return a.tenant
+	case "key_name":
+		return a.keyName
+	default:
+		return nil
+	}
+}
+
+func (*authData) GetAttributeNames() []string {
+	return []string{"tenant", "key_name"}
+}
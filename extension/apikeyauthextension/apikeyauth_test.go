@@ -0,0 +1,257 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikeyauthextension
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+)
+
+func newTestAuth(t *testing.T, cfg *Config) *apiKeyAuth {
+	if cfg.Header == "" {
+		cfg.Header = defaultHeader
+	}
+	auth := newAPIKeyAuth(cfg, zap.NewNop())
+	require.NoError(t, auth.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, auth.Shutdown(context.Background()))
+	})
+	return auth
+}
+
+func authenticate(auth *apiKeyAuth, headers map[string][]string) (client.AuthData, error) {
+	ctx, err := auth.Authenticate(context.Background(), headers)
+	if err != nil {
+		return nil, err
+	}
+	return client.FromContext(ctx).Auth, nil
+}
+
+func TestAuthenticate(t *testing.T) {
+	auth := newTestAuth(t, &Config{
+		Filename: filepath.Join("testdata", "keys.yaml"),
+		Keys: []KeyConfig{
+			{Key: "inline-key", Tenant: "initech"},
+			// a key revoked anywhere stays revoked
+			{Key: "revoked-key", Tenant: "globex"},
+		},
+	})
+
+	tests := []struct {
+		name        string
+		headers     map[string][]string
+		tenant      string
+		keyName     string
+		expectedErr error
+	}{
+		{
+			name:    "http header",
+			headers: map[string][]string{"X-Api-Key": {"acme-key"}},
+			tenant:  "acme",
+			keyName: "acme-prod",
+		},
+		{
+			name:    "grpc metadata",
+			headers: map[string][]string{"x-api-key": {"globex-key"}},
+			tenant:  "globex",
+		},
+		{
+			name:    "inline key",
+			headers: map[string][]string{"X-API-KEY": {"inline-key"}},
+			tenant:  "initech",
+		},
+		{
+			name:        "missing key",
+			headers:     map[string][]string{"Authorization": {"acme-key"}},
+			expectedErr: errNoAPIKey,
+		},
+		{
+			name:        "unknown key",
+			headers:     map[string][]string{"X-Api-Key": {"unknown-key"}},
+			expectedErr: errInvalidAPIKey,
+		},
+		{
+			name:        "revoked key",
+			headers:     map[string][]string{"X-Api-Key": {"revoked-key"}},
+			expectedErr: errRevokedAPIKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authData, err := authenticate(auth, tt.headers)
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.tenant, authData.GetAttribute("tenant"))
+			assert.Equal(t, tt.keyName, authData.GetAttribute("key_name"))
+			assert.Nil(t, authData.GetAttribute("key"))
+			assert.Equal(t, []string{"tenant", "key_name"}, authData.GetAttributeNames())
+		})
+	}
+}
+
+func TestAuthenticateWithScheme(t *testing.T) {
+	auth := newTestAuth(t, &Config{
+		Header: "Authorization",
+		Scheme: "ApiKey",
+		Keys:   []KeyConfig{{Key: "acme-key", Tenant: "acme"}},
+	})
+
+	authData, err := authenticate(auth, map[string][]string{"authorization": {"apikey acme-key"}})
+	require.NoError(t, err)
+	assert.Equal(t, "acme", authData.GetAttribute("tenant"))
+
+	_, err = authenticate(auth, map[string][]string{"Authorization": {"Bearer acme-key"}})
+	assert.Equal(t, errInvalidSchemePrefix, err)
+
+	_, err = authenticate(auth, map[string][]string{"Authorization": {"acme-key"}})
+	assert.Equal(t, errInvalidSchemePrefix, err)
+}
+
+func TestStartFailsWithInvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte("keys:\n  - key: no-tenant\n"), 0600))
+
+	tests := []struct {
+		filename    string
+		expectedErr string
+	}{
+		{
+			filename:    filepath.Join(dir, "missing.yaml"),
+			expectedErr: "failed to read the API keys file",
+		},
+		{
+			filename:    invalid,
+			expectedErr: "invalid keys in " + invalid + ": key 0: missing tenant",
+		},
+	}
+	for _, tt := range tests {
+		auth := newAPIKeyAuth(&Config{Header: defaultHeader, Filename: tt.filename}, zap.NewNop())
+		assert.ErrorContains(t, auth.Start(context.Background(), componenttest.NewNopHost()), tt.expectedErr)
+		assert.NoError(t, auth.Shutdown(context.Background()))
+	}
+}
+
+// newReloadingAuth returns an authenticator reading its keys from the file, and a channel receiving the result
+// of each reload of the file.
+func newReloadingAuth(t *testing.T, cfg *Config) (*apiKeyAuth, <-chan error) {
+	cfg.Header = defaultHeader
+	reloads := make(chan error, 10)
+	auth := newAPIKeyAuth(cfg, zap.NewNop())
+	auth.reloaded = func(err error) { reloads <- err }
+	require.NoError(t, auth.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, auth.Shutdown(context.Background()))
+	})
+	return auth, reloads
+}
+
+// waitReload waits for a reload of the file for which done returns true.
+func waitReload(t *testing.T, reloads <-chan error, done func(error) bool) {
+	for {
+		select {
+		case err := <-reloads:
+			if done(err) {
+				return
+			}
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "the API keys file was not reloaded")
+		}
+	}
+}
+
+func TestKeysFileReload(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "keys.yaml")
+	require.NoError(t, os.WriteFile(filename, []byte("keys:\n  - key: acme-key\n    tenant: acme\n"), 0600))
+	auth, reloads := newReloadingAuth(t, &Config{Filename: filename})
+
+	acme := map[string][]string{"X-Api-Key": {"acme-key"}}
+	globex := map[string][]string{"X-Api-Key": {"globex-key"}}
+	authenticated := func(headers map[string][]string) func(error) bool {
+		return func(error) bool {
+			_, err := authenticate(auth, headers)
+			return err == nil
+		}
+	}
+
+	_, err := authenticate(auth, acme)
+	require.NoError(t, err)
+	_, err = authenticate(auth, globex)
+	require.Equal(t, errInvalidAPIKey, err)
+
+	// adding a key and revoking another one does not need a restart
+	require.NoError(t, os.WriteFile(filename, []byte(`keys:
+  - key: acme-key
+    tenant: acme
+    revoked: true
+  - key: globex-key
+    tenant: globex
+`), 0600))
+	waitReload(t, reloads, authenticated(globex))
+	_, err = authenticate(auth, acme)
+	require.Equal(t, errRevokedAPIKey, err)
+
+	// an invalid file is ignored, the previous keys remain in use
+	require.NoError(t, os.WriteFile(filename, []byte("keys: [invalid"), 0600))
+	waitReload(t, reloads, func(err error) bool { return err != nil })
+	_, err = authenticate(auth, globex)
+	require.NoError(t, err)
+
+	// so is an empty file, as it is likely being written
+	require.NoError(t, os.WriteFile(filename, nil, 0600))
+	waitReload(t, reloads, func(err error) bool { return errors.Is(err, errEmptyKeysFile) })
+	_, err = authenticate(auth, globex)
+	require.NoError(t, err)
+
+	// removing a key from the file revokes it too
+	require.NoError(t, os.WriteFile(filename, []byte("keys:\n  - key: acme-key\n    tenant: acme\n"), 0600))
+	waitReload(t, reloads, authenticated(acme))
+	_, err = authenticate(auth, globex)
+	require.Equal(t, errInvalidAPIKey, err)
+
+	// replacing the file, as done for Kubernetes secrets, is detected as well
+	replacement := filepath.Join(filepath.Dir(filename), "replacement.yaml")
+	require.NoError(t, os.WriteFile(replacement, []byte("keys:\n  - key: globex-key\n    tenant: globex\n"), 0600))
+	require.NoError(t, os.Rename(replacement, filename))
+	waitReload(t, reloads, authenticated(globex))
+}
+
+func TestKeysFileAllowEmpty(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "keys.yaml")
+	require.NoError(t, os.WriteFile(filename, []byte("keys:\n  - key: acme-key\n    tenant: acme\n"), 0600))
+	auth, reloads := newReloadingAuth(t, &Config{Filename: filename, AllowEmptyFile: true, Keys: []KeyConfig{{Key: "inline-key", Tenant: "acme"}}})
+
+	// emptying the file removes all its keys, the inline keys remain
+	require.NoError(t, os.WriteFile(filename, []byte("keys: []\n"), 0600))
+	waitReload(t, reloads, func(error) bool {
+		_, err := authenticate(auth, map[string][]string{"X-Api-Key": {"acme-key"}})
+		return err == errInvalidAPIKey
+	})
+	_, err := authenticate(auth, map[string][]string{"X-Api-Key": {"inline-key"}})
+	require.NoError(t, err)
+}
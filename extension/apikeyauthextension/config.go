@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikeyauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/apikeyauthextension"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+var (
+	errNoKeySource    = errors.New("no API key source provided, 'filename' or 'keys' must be set")
+	errMissingHeader  = errors.New("missing header name")
+	errMissingKey     = errors.New("missing API key")
+	errMissingTenant  = errors.New("missing tenant")
+	errDuplicateNames = errors.New("duplicate key name")
+)
+
+// Config specifies where the API keys are read from and how they are sent by clients.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"`
+
+	// Header is the name of the header carrying the API key. Defaults to "X-API-Key".
+	Header string `mapstructure:"header,omitempty"`
+
+	// Scheme, when set, is the auth-scheme expected before the API key in the header,
+	// e.g. "Bearer" when the key is sent in the "Authorization" header.
+	Scheme string `mapstructure:"scheme,omitempty"`
+
+	// Filename points to a YAML file holding the API keys. The file is reloaded when it changes.
+	Filename string `mapstructure:"filename,omitempty"`
+
+	// AllowEmptyFile allows a reload of the file without any key to replace the keys of the file.
+	// Otherwise such a reload is ignored, as it is more likely a file being written than a revocation of all the keys.
+	AllowEmptyFile bool `mapstructure:"allow_empty_file,omitempty"`
+
+	// Keys are API keys configured inline, in addition to the ones from the file.
+	Keys []KeyConfig `mapstructure:"keys,omitempty"`
+}
+
+// KeyConfig maps an API key to the tenant it belongs to.
+type KeyConfig struct {
+	// Key is the API key sent by clients.
+	Key string `mapstructure:"key" yaml:"key"`
+
+	// Tenant is the tenant the API key belongs to.
+	Tenant string `mapstructure:"tenant" yaml:"tenant"`
+
+	// Name optionally identifies the API key without revealing it.
+	Name string `mapstructure:"name,omitempty" yaml:"name,omitempty"`
+
+	// Revoked rejects the API key. A key which is revoked anywhere is rejected,
+	// even if it is listed again without being revoked.
+	Revoked bool `mapstructure:"revoked,omitempty" yaml:"revoked,omitempty"`
+}
+
+var _ config.Extension = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Header == "" {
+		return errMissingHeader
+	}
+	if cfg.Filename == "" && len(cfg.Keys) == 0 {
+		return errNoKeySource
+	}
+	if err := validateKeys(cfg.Keys); err != nil {
+		return fmt.Errorf("invalid keys: %w", err)
+	}
+	return nil
+}
+
+func validateKeys(keys []KeyConfig) error {
+	names := map[string]struct{}{}
+	for i, key := range keys {
+		if key.Key == "" {
+			return fmt.Errorf("key %d: %w", i, errMissingKey)
+		}
+		if key.Tenant == "" {
+			return fmt.Errorf("key %d: %w", i, errMissingTenant)
+		}
+		if key.Name == "" {
+			continue
+		}
+		if _, ok := names[key.Name]; ok {
+			return fmt.Errorf("key %d: %w %q", i, errDuplicateNames, key.Name)
+		}
+		names[key.Name] = struct{}{}
+	}
+	return nil
+}
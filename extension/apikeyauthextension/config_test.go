@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikeyauthextension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id          config.ComponentID
+		expected    config.Extension
+		expectedErr string
+	}{
+		{
+			id:          config.NewComponentID(typeStr),
+			expectedErr: errNoKeySource.Error(),
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "file"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				Header:            defaultHeader,
+				Filename:          "/etc/otelcol/api-keys.yaml",
+			},
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "all_settings"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				Header:            "Authorization",
+				Scheme:            "ApiKey",
+				Filename:          "/etc/otelcol/api-keys.yaml",
+				AllowEmptyFile:    true,
+				Keys: []KeyConfig{
+					{Key: "inline-key", Tenant: "acme", Name: "acme-ci"},
+					{Key: "compromised-key", Tenant: "acme", Revoked: true},
+				},
+			},
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "missing_tenant"),
+			expectedErr: "invalid keys: key 0: missing tenant",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalExtension(sub, cfg))
+			if tt.expectedErr != "" {
+				assert.EqualError(t, cfg.Validate(), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		expected string
+	}{
+		{
+			name:     "missing header",
+			config:   Config{Filename: "keys.yaml"},
+			expected: errMissingHeader.Error(),
+		},
+		{
+			name:     "missing key",
+			config:   Config{Header: defaultHeader, Keys: []KeyConfig{{Tenant: "acme"}}},
+			expected: "invalid keys: key 0: missing API key",
+		},
+		{
+			name: "duplicate names",
+			config: Config{Header: defaultHeader, Keys: []KeyConfig{
+				{Key: "one", Tenant: "acme", Name: "ci"},
+				{Key: "two", Tenant: "acme", Name: "ci"},
+			}},
+			expected: `invalid keys: key 1: duplicate key name "ci"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.config.Validate(), tt.expected)
+		})
+	}
+}
@@ -23,8 +23,9 @@ import (
 var (
 	errMissingHeader        = fmt.Errorf("missing header name")
 	errMissingHeadersConfig = fmt.Errorf("missing headers configuration")
-	errMissingSource        = fmt.Errorf("missing header source, must be 'from_context' or 'value'")
-	errConflictingSources   = fmt.Errorf("invalid header source, must either 'from_context' or 'value'")
+	errMissingSource        = fmt.Errorf("missing header source, must be 'from_context', 'from_env', 'from_file' or 'value'")
+	errConflictingSources   = fmt.Errorf("invalid header source, must be only one of 'from_context', 'from_env', 'from_file' or 'value'")
+	errUnexpectedDefault    = fmt.Errorf("invalid header source, 'default_value' can only be used with 'from_context' or 'from_env'")
 )
 
 type Config struct {
@@ -36,6 +37,13 @@ type HeaderConfig struct {
 	Key         *string `mapstructure:"key"`
 	Value       *string `mapstructure:"value"`
 	FromContext *string `mapstructure:"from_context"`
+	// FromEnv is the name of an environment variable resolved on every request.
+	FromEnv *string `mapstructure:"from_env"`
+	// FromFile is the path of a file whose content is used as the value. The
+	// file is re-read whenever it changes.
+	FromFile *string `mapstructure:"from_file"`
+	// DefaultValue is used when the context key or the environment variable is not set.
+	DefaultValue *string `mapstructure:"default_value"`
 }
 
 // Validate checks if the extension configuration is valid
@@ -47,12 +55,21 @@ func (cfg *Config) Validate() error {
 		if header.Key == nil || *header.Key == "" {
 			return errMissingHeader
 		}
-		if header.FromContext == nil && header.Value == nil {
+		sources := 0
+		for _, source := range []*string{header.Value, header.FromContext, header.FromEnv, header.FromFile} {
+			if source != nil {
+				sources++
+			}
+		}
+		if sources == 0 {
 			return errMissingSource
 		}
-		if header.FromContext != nil && header.Value != nil {
+		if sources > 1 {
 			return errConflictingSources
 		}
+		if header.DefaultValue != nil && header.FromContext == nil && header.FromEnv == nil {
+			return errUnexpectedDefault
+		}
 	}
 	return nil
 }
@@ -53,6 +53,28 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "2"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				HeadersConfig: []HeaderConfig{
+					{
+						Key:      stringp("Authorization"),
+						FromFile: stringp("/var/run/secrets/tokens/token"),
+					},
+					{
+						Key:          stringp("X-Scope-OrgID"),
+						FromContext:  stringp("tenant_id"),
+						DefaultValue: stringp("anonymous"),
+					},
+					{
+						Key:          stringp("X-Region"),
+						FromEnv:      stringp("REGION"),
+						DefaultValue: stringp("local"),
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -94,6 +116,27 @@ func TestValidateConfig(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"header value from environment with default",
+			[]HeaderConfig{
+				{
+					Key:          stringp("name"),
+					FromEnv:      stringp("VARIABLE"),
+					DefaultValue: stringp("default"),
+				},
+			},
+			nil,
+		},
+		{
+			"header value from file",
+			[]HeaderConfig{
+				{
+					Key:      stringp("name"),
+					FromFile: stringp("/path/to/token"),
+				},
+			},
+			nil,
+		},
 		{
 			"missing header name for from value",
 			[]HeaderConfig{
@@ -119,6 +162,39 @@ func TestValidateConfig(t *testing.T) {
 			},
 			errConflictingSources,
 		},
+		{
+			"header value from file and environment",
+			[]HeaderConfig{
+				{
+					Key:      stringp("name"),
+					FromEnv:  stringp("VARIABLE"),
+					FromFile: stringp("/path/to/token"),
+				},
+			},
+			errConflictingSources,
+		},
+		{
+			"default value with static value",
+			[]HeaderConfig{
+				{
+					Key:          stringp("name"),
+					Value:        stringp("from config"),
+					DefaultValue: stringp("default"),
+				},
+			},
+			errUnexpectedDefault,
+		},
+		{
+			"default value with file",
+			[]HeaderConfig{
+				{
+					Key:          stringp("name"),
+					FromFile:     stringp("/path/to/token"),
+					DefaultValue: stringp("default"),
+				},
+			},
+			errUnexpectedDefault,
+		},
 		{
 			"header value source is missing",
 			[]HeaderConfig{
@@ -20,7 +20,10 @@ import (
 	"fmt"
 	"net/http"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configauth"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
 	"google.golang.org/grpc/credentials"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/headerssetterextension/internal/source"
@@ -31,27 +34,62 @@ type Header struct {
 	source source.Source
 }
 
-func newHeadersSetterExtension(cfg *Config) (configauth.ClientAuthenticator, error) {
+func newHeadersSetterExtension(cfg *Config, logger *zap.Logger) (configauth.ClientAuthenticator, error) {
 	if cfg == nil {
 		return nil, errors.New("extension configuration is not provided")
 	}
 
 	headers := make([]Header, 0, len(cfg.HeadersConfig))
+	var fileSources []*source.FileSource
 	for _, header := range cfg.HeadersConfig {
+		var defaultValue string
+		if header.DefaultValue != nil {
+			defaultValue = *header.DefaultValue
+		}
+
 		var s source.Source
-		if header.Value != nil {
+		switch {
+		case header.Value != nil:
 			s = &source.StaticSource{
 				Value: *header.Value,
 			}
-		} else if header.FromContext != nil {
+		case header.FromContext != nil:
 			s = &source.ContextSource{
-				Key: *header.FromContext,
+				Key:          *header.FromContext,
+				DefaultValue: defaultValue,
+			}
+		case header.FromEnv != nil:
+			s = &source.EnvSource{
+				Name:         *header.FromEnv,
+				DefaultValue: defaultValue,
+			}
+		case header.FromFile != nil:
+			fs := &source.FileSource{
+				Path:   *header.FromFile,
+				Logger: logger,
 			}
+			fileSources = append(fileSources, fs)
+			s = fs
 		}
 		headers = append(headers, Header{key: *header.Key, source: s})
 	}
 
 	return configauth.NewClientAuthenticator(
+		configauth.WithClientStart(func(ctx context.Context, _ component.Host) error {
+			for _, fs := range fileSources {
+				if err := fs.Start(ctx); err != nil {
+					return err
+				}
+			}
+			return nil
+		}),
+		configauth.WithClientShutdown(func(ctx context.Context) error {
+			var errs error
+			for _, fs := range fileSources {
+				errs = multierr.Append(errs, fs.Shutdown(ctx))
+			}
+			return errs
+		}),
 		configauth.WithClientRoundTripper(
 			func(base http.RoundTripper) (http.RoundTripper, error) {
 				return &headersRoundTripper{
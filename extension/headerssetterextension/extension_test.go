@@ -17,10 +17,16 @@ package headerssetterextension
 import (
 	"context"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
 )
 
 type mockRoundTripper struct{}
@@ -36,7 +42,7 @@ func (m *mockRoundTripper) RoundTrip(req *http.Request) (*http.Response, error)
 func TestRoundTripper(t *testing.T) {
 	for _, tt := range tests {
 		t.Run("round_tripper", func(t *testing.T) {
-			ext, err := newHeadersSetterExtension(tt.cfg)
+			ext, err := newHeadersSetterExtension(tt.cfg, zap.NewNop())
 			assert.NoError(t, err)
 			assert.NotNil(t, ext)
 
@@ -71,7 +77,7 @@ func TestRoundTripper(t *testing.T) {
 func TestPerRPCCredentials(t *testing.T) {
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
-			ext, err := newHeadersSetterExtension(tt.cfg)
+			ext, err := newHeadersSetterExtension(tt.cfg, zap.NewNop())
 			assert.NoError(t, err)
 			assert.NotNil(t, ext)
 
@@ -98,6 +104,51 @@ func TestPerRPCCredentials(t *testing.T) {
 	}
 }
 
+func TestDynamicSources(t *testing.T) {
+	t.Setenv("HEADERS_SETTER_TENANT", "acme")
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("first token\n"), 0600))
+
+	cfg := &Config{
+		HeadersConfig: []HeaderConfig{
+			{Key: stringp("Authorization"), FromFile: stringp(path)},
+			{Key: stringp("X-Tenant"), FromEnv: stringp("HEADERS_SETTER_TENANT"), DefaultValue: stringp("default")},
+		},
+	}
+	ext, err := newHeadersSetterExtension(cfg, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, ext.Shutdown(context.Background())) }()
+
+	perRPC, err := ext.PerRPCCredentials()
+	require.NoError(t, err)
+
+	metadata, err := perRPC.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "first token", "X-Tenant": "acme"}, metadata)
+
+	// both sources are resolved again on the next request
+	t.Setenv("HEADERS_SETTER_TENANT", "")
+	require.NoError(t, os.WriteFile(path, []byte("second token"), 0600))
+	assert.Eventually(t, func() bool {
+		metadata, err = perRPC.GetRequestMetadata(context.Background())
+		return err == nil && metadata["Authorization"] == "second token"
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "default", metadata["X-Tenant"])
+}
+
+func TestStartMissingFile(t *testing.T) {
+	cfg := &Config{
+		HeadersConfig: []HeaderConfig{
+			{Key: stringp("Authorization"), FromFile: stringp(filepath.Join(t.TempDir(), "missing"))},
+		},
+	}
+	ext, err := newHeadersSetterExtension(cfg, zap.NewNop())
+	require.NoError(t, err)
+	assert.Error(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, ext.Shutdown(context.Background()))
+}
+
 var (
 	mrt           = &mockRoundTripper{}
 	header        = "header_name"
@@ -220,6 +271,23 @@ var (
 				"header_name": "",
 			},
 		},
+		{
+			cfg: &Config{
+				HeadersConfig: []HeaderConfig{
+					{
+						Key:          &header,
+						FromContext:  stringp("tenant_"),
+						DefaultValue: stringp("default"),
+					},
+				},
+			},
+			metadata: client.NewMetadata(
+				map[string][]string{"tenant": {"acme"}},
+			),
+			expectedHeaders: map[string]string{
+				"header_name": "default",
+			},
+		},
 	}
 )
 
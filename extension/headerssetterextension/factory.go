@@ -43,8 +43,8 @@ func createDefaultConfig() config.Extension {
 
 func createExtension(
 	_ context.Context,
-	_ component.ExtensionCreateSettings,
+	set component.ExtensionCreateSettings,
 	cfg config.Extension,
 ) (component.Extension, error) {
-	return newHeadersSetterExtension(cfg.(*Config))
+	return newHeadersSetterExtension(cfg.(*Config), set.Logger)
 }
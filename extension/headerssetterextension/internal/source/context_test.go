@@ -57,3 +57,15 @@ func TestContextSourceMultipleFound(t *testing.T) {
 	assert.Error(t, err)
 	assert.Empty(t, header)
 }
+
+func TestContextSourceDefaultValue(t *testing.T) {
+	ts := &ContextSource{Key: "X-Scope-OrgID", DefaultValue: "default"}
+	cl := client.FromContext(context.Background())
+	cl.Metadata = client.NewMetadata(map[string][]string{"Not-Scope-OrgID": {"acme"}})
+	ctx := client.NewContext(context.Background(), cl)
+
+	header, err := ts.Get(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "default", header)
+}
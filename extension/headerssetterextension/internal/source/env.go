@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/headerssetterextension/internal/source"
+
+import (
+	"context"
+	"os"
+)
+
+var _ Source = (*EnvSource)(nil)
+
+// EnvSource resolves the value of an environment variable on every request,
+// falling back to DefaultValue when the variable is unset or empty.
+type EnvSource struct {
+	Name         string
+	DefaultValue string
+}
+
+func (es *EnvSource) Get(_ context.Context) (string, error) {
+	if value, ok := os.LookupEnv(es.Name); ok && value != "" {
+		return value, nil
+	}
+	return es.DefaultValue, nil
+}
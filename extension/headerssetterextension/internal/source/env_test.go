@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvSourceSuccess(t *testing.T) {
+	t.Setenv("HEADERS_SETTER_TENANT", "acme")
+	es := &EnvSource{Name: "HEADERS_SETTER_TENANT", DefaultValue: "default"}
+
+	header, err := es.Get(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", header)
+}
+
+func TestEnvSourceResolvedOnEveryRequest(t *testing.T) {
+	t.Setenv("HEADERS_SETTER_TENANT", "acme")
+	es := &EnvSource{Name: "HEADERS_SETTER_TENANT"}
+
+	header, err := es.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", header)
+
+	t.Setenv("HEADERS_SETTER_TENANT", "globex")
+	header, err = es.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "globex", header)
+}
+
+func TestEnvSourceDefaultValue(t *testing.T) {
+	t.Setenv("HEADERS_SETTER_EMPTY", "")
+	for _, name := range []string{"HEADERS_SETTER_EMPTY", "HEADERS_SETTER_UNSET"} {
+		es := &EnvSource{Name: name, DefaultValue: "default"}
+
+		header, err := es.Get(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "default", header)
+	}
+}
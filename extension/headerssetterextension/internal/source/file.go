@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/headerssetterextension/internal/source"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/filewatcher"
+)
+
+var _ Source = (*FileSource)(nil)
+
+// FileSource returns the content of a file, such as a Kubernetes projected
+// service account token. The file is read on start and re-read whenever it
+// changes, so that rotated credentials are picked up without a restart.
+type FileSource struct {
+	Path   string
+	Logger *zap.Logger
+
+	mu    sync.RWMutex
+	value string
+
+	watcher *filewatcher.Watcher
+}
+
+// Start reads the file and starts watching it for changes.
+func (fs *FileSource) Start(_ context.Context) error {
+	if fs.watcher != nil {
+		return errors.New("file source is already running")
+	}
+
+	value, err := fs.read()
+	if err != nil {
+		return err
+	}
+	fs.setValue(value)
+
+	fs.watcher, err = filewatcher.Start(fs.Path, fs.Logger, fs.reload)
+	return err
+}
+
+// Shutdown stops watching the file.
+func (fs *FileSource) Shutdown(_ context.Context) error {
+	if fs.watcher != nil {
+		fs.watcher.Stop()
+		fs.watcher = nil
+	}
+	return nil
+}
+
+func (fs *FileSource) Get(_ context.Context) (string, error) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	return fs.value, nil
+}
+
+// reload re-reads the file, keeping the previous value if that fails
+func (fs *FileSource) reload() {
+	value, err := fs.read()
+	if err != nil {
+		fs.Logger.Error("failed to reload header value, keeping the previous one", zap.String("path", fs.Path), zap.Error(err))
+		return
+	}
+	fs.setValue(value)
+	fs.Logger.Debug("reloaded header value", zap.String("path", fs.Path))
+}
+
+func (fs *FileSource) read() (string, error) {
+	data, err := os.ReadFile(fs.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read header value from %q: %w", fs.Path, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func (fs *FileSource) setValue(value string) {
+	fs.mu.Lock()
+	fs.value = value
+	fs.mu.Unlock()
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFileSourceReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("first\n"), 0600))
+
+	fs := &FileSource{Path: path, Logger: zap.NewNop()}
+	require.NoError(t, fs.Start(context.Background()))
+	defer func() { assert.NoError(t, fs.Shutdown(context.Background())) }()
+
+	header, err := fs.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "first", header)
+
+	require.NoError(t, os.WriteFile(path, []byte("second"), 0600))
+	assert.Eventually(t, func() bool {
+		header, err = fs.Get(context.Background())
+		return err == nil && header == "second"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestFileSourceReplaced(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(path, []byte("first"), 0600))
+
+	fs := &FileSource{Path: path, Logger: zap.NewNop()}
+	require.NoError(t, fs.Start(context.Background()))
+	defer func() { assert.NoError(t, fs.Shutdown(context.Background())) }()
+
+	// replace the file atomically, the way rotated credentials are usually written
+	tmp := filepath.Join(dir, "token.tmp")
+	require.NoError(t, os.WriteFile(tmp, []byte("second"), 0600))
+	require.NoError(t, os.Rename(tmp, path))
+
+	assert.Eventually(t, func() bool {
+		header, err := fs.Get(context.Background())
+		return err == nil && header == "second"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestFileSourceMissingFile(t *testing.T) {
+	fs := &FileSource{Path: filepath.Join(t.TempDir(), "missing"), Logger: zap.NewNop()}
+	assert.Error(t, fs.Start(context.Background()))
+	assert.NoError(t, fs.Shutdown(context.Background()))
+}
+
+func TestFileSourceAlreadyRunning(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("first"), 0600))
+
+	fs := &FileSource{Path: path, Logger: zap.NewNop()}
+	require.NoError(t, fs.Start(context.Background()))
+	assert.Error(t, fs.Start(context.Background()))
+	assert.NoError(t, fs.Shutdown(context.Background()))
+}
@@ -0,0 +1,362 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheckextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/metric/metricproducer"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	kindReceiver  = "receiver"
+	kindProcessor = "processor"
+	kindExporter  = "exporter"
+
+	queueSizeMetric     = "exporter/queue_size"
+	queueCapacityMetric = "exporter/queue_capacity"
+)
+
+// componentView is a collector self-observability view counting the failures of a kind of component in a pipeline.
+type componentView struct {
+	dataType config.DataType
+	kind     string
+}
+
+var componentViews = map[string]componentView{
+	"exporter/send_failed_spans":         {config.TracesDataType, kindExporter},
+	"exporter/send_failed_metric_points": {config.MetricsDataType, kindExporter},
+	"exporter/send_failed_log_records":   {config.LogsDataType, kindExporter},
+	"processor/refused_spans":            {config.TracesDataType, kindProcessor},
+	"processor/refused_metric_points":    {config.MetricsDataType, kindProcessor},
+	"processor/refused_log_records":      {config.LogsDataType, kindProcessor},
+	"receiver/refused_spans":             {config.TracesDataType, kindReceiver},
+	"receiver/refused_metric_points":     {config.MetricsDataType, kindReceiver},
+	"receiver/refused_log_records":       {config.LogsDataType, kindReceiver},
+}
+
+// componentKey identifies a component within a pipeline, such as "exporter/otlp" in the "traces/2" pipeline.
+type componentKey struct {
+	pipeline string
+	name     string
+}
+
+type failure struct {
+	time  time.Time
+	count int64
+}
+
+// startFailure is the last failure of a receiver to start.
+type startFailure struct {
+	time time.Time
+	err  string
+}
+
+// queueState is the state of the sending queue of an exporter.
+type queueState struct {
+	size     int64
+	capacity int64
+}
+
+type healthStatus struct {
+	Ready     bool                       `json:"ready"`
+	Healthy   bool                       `json:"healthy"`
+	Pipelines map[string]*pipelineStatus `json:"pipelines"`
+}
+
+type pipelineStatus struct {
+	Healthy    bool                        `json:"healthy"`
+	Components map[string]*componentStatus `json:"components"`
+}
+
+type componentStatus struct {
+	Healthy       bool   `json:"healthy"`
+	FailedItems   int64  `json:"failed_items,omitempty"`
+	RefusedItems  int64  `json:"refused_items,omitempty"`
+	QueueSize     *int64 `json:"queue_size,omitempty"`
+	QueueCapacity *int64 `json:"queue_capacity,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+// componentHealthExporter is an open census view exporter keeping the failures of each
+// component during the interval, from which the health of the components is computed.
+type componentHealthExporter struct {
+	settings componentHealthSettings
+	now      func() time.Time
+	queues   func() map[string]queueState
+
+	// pipelines holds the IDs of the pipelines each component is part of, by data type and component name
+	pipelines map[config.DataType]map[string][]string
+
+	mu sync.Mutex
+	// cumulative holds the last value of each row of the views, to turn them into deltas
+	cumulative    map[string]float64
+	failures      map[componentKey][]failure
+	startFailures map[componentKey]startFailure
+}
+
+var _ view.Exporter = (*componentHealthExporter)(nil)
+
+func newComponentHealthExporter(settings componentHealthSettings) *componentHealthExporter {
+	e := &componentHealthExporter{
+		settings:      settings,
+		now:           time.Now,
+		queues:        readQueues,
+		pipelines:     map[config.DataType]map[string][]string{},
+		cumulative:    map[string]float64{},
+		failures:      map[componentKey][]failure{},
+		startFailures: map[componentKey]startFailure{},
+	}
+	for pipelineID, pipeline := range settings.Pipelines {
+		if pipeline == nil {
+			continue
+		}
+		for kind, ids := range map[string][]config.ComponentID{
+			kindReceiver:  pipeline.Receivers,
+			kindProcessor: pipeline.Processors,
+			kindExporter:  pipeline.Exporters,
+		} {
+			for _, id := range ids {
+				e.addPipelineComponent(pipelineID, kind+"/"+id.String())
+			}
+		}
+	}
+	return e
+}
+
+func (e *componentHealthExporter) addPipelineComponent(pipelineID config.ComponentID, name string) {
+	members, ok := e.pipelines[pipelineID.Type()]
+	if !ok {
+		members = map[string][]string{}
+		e.pipelines[pipelineID.Type()] = members
+	}
+	members[name] = append(members[name], pipelineID.String())
+	sort.Strings(members[name])
+	e.failures[componentKey{pipeline: pipelineID.String(), name: name}] = nil
+}
+
+// pipelinesOf returns the IDs of the pipelines of the data type the component is part of. When the pipelines
+// aren't configured, or none of them holds the component, the component is reported in the pipeline type.
+func (e *componentHealthExporter) pipelinesOf(dataType config.DataType, name string) []string {
+	if pipelines, ok := e.pipelines[dataType][name]; ok {
+		return pipelines
+	}
+	return []string{string(dataType)}
+}
+
+// addExporters makes the exporters of each pipeline part of the status, even before they report any failure.
+func (e *componentHealthExporter) addExporters(exporters map[config.DataType][]config.ComponentID) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for dataType, ids := range exporters {
+		for _, id := range ids {
+			name := kindExporter + "/" + id.String()
+			for _, pipeline := range e.pipelinesOf(dataType, name) {
+				key := componentKey{pipeline: pipeline, name: name}
+				if _, ok := e.failures[key]; !ok {
+					e.failures[key] = nil
+				}
+			}
+		}
+	}
+}
+
+// receiverStartFailed records that a receiver of the data type failed to start, the receiver is unhealthy
+// for the interval.
+func (e *componentHealthExporter) receiverStartFailed(dataType config.DataType, id config.ComponentID, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	name := kindReceiver + "/" + id.String()
+	for _, pipeline := range e.pipelinesOf(dataType, name) {
+		key := componentKey{pipeline: pipeline, name: name}
+		e.startFailures[key] = startFailure{time: e.now(), err: err.Error()}
+		if _, ok := e.failures[key]; !ok {
+			e.failures[key] = nil
+		}
+	}
+}
+
+// ExportView records the failures counted by the views since their previous export
+func (e *componentHealthExporter) ExportView(vd *view.Data) {
+	cv, ok := componentViews[vd.View.Name]
+	if !ok {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, row := range vd.Rows {
+		var name string
+		tags := make([]string, 0, len(row.Tags))
+		for _, t := range row.Tags {
+			if t.Key.Name() == cv.kind {
+				name = cv.kind + "/" + t.Value
+			}
+			tags = append(tags, t.Key.Name()+"="+t.Value)
+		}
+		if name == "" {
+			continue
+		}
+		sum, ok := row.Data.(*view.SumData)
+		if !ok {
+			continue
+		}
+
+		rowKey := vd.View.Name + "|" + strings.Join(tags, ",")
+		last, seen := e.cumulative[rowKey]
+		e.cumulative[rowKey] = sum.Value
+		var delta float64
+		switch {
+		case !seen:
+			// the first value is the baseline, the failures it counts may be older than the interval
+		case sum.Value < last:
+			// the view was reset
+			delta = sum.Value
+		default:
+			delta = sum.Value - last
+		}
+
+		for _, pipeline := range e.pipelinesOf(cv.dataType, name) {
+			key := componentKey{pipeline: pipeline, name: name}
+			if delta > 0 {
+				e.failures[key] = append(e.failures[key], failure{time: vd.End, count: int64(delta)})
+			} else if _, ok := e.failures[key]; !ok {
+				e.failures[key] = nil
+			}
+		}
+	}
+}
+
+// status returns the health of every known component, given whether the pipelines are ready.
+func (e *componentHealthExporter) status(ready bool) *healthStatus {
+	queues := e.queues()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	st := &healthStatus{
+		Ready:     ready,
+		Healthy:   true,
+		Pipelines: map[string]*pipelineStatus{},
+	}
+	oldest := e.now().Add(-e.settings.Interval)
+	for key, failures := range e.failures {
+		// rotate the failures that expired the interval
+		for len(failures) > 0 && failures[0].time.Before(oldest) {
+			failures = failures[1:]
+		}
+		e.failures[key] = failures
+
+		var count int64
+		for _, f := range failures {
+			count += f.count
+		}
+		sf, ok := e.startFailures[key]
+		if ok && sf.time.Before(oldest) {
+			delete(e.startFailures, key)
+			sf = startFailure{}
+		}
+
+		cs := e.componentStatus(key, count, sf.err, queues)
+		ps, ok := st.Pipelines[key.pipeline]
+		if !ok {
+			ps = &pipelineStatus{Healthy: true, Components: map[string]*componentStatus{}}
+			st.Pipelines[key.pipeline] = ps
+		}
+		ps.Components[key.name] = cs
+		ps.Healthy = ps.Healthy && cs.Healthy
+		st.Healthy = st.Healthy && cs.Healthy
+	}
+	return st
+}
+
+func (e *componentHealthExporter) componentStatus(key componentKey, count int64, startErr string, queues map[string]queueState) *componentStatus {
+	cs := &componentStatus{Healthy: true}
+	var reasons []string
+
+	kind, id, _ := strings.Cut(key.name, "/")
+	switch kind {
+	case kindExporter:
+		cs.FailedItems = count
+		if count > e.settings.ExporterFailureThreshold {
+			reasons = append(reasons, fmt.Sprintf("failed to send %d items within %v", count, e.settings.Interval))
+		}
+		if q, ok := queues[id]; ok {
+			cs.QueueSize, cs.QueueCapacity = &q.size, &q.capacity
+			if q.capacity > 0 && float64(q.size) >= e.settings.QueueSaturationThreshold*float64(q.capacity) {
+				reasons = append(reasons, fmt.Sprintf("sending queue is %d%% full", q.size*100/q.capacity))
+			}
+		}
+	case kindProcessor:
+		cs.RefusedItems = count
+		if count > e.settings.ProcessorRefusalThreshold {
+			reasons = append(reasons, fmt.Sprintf("refused %d items within %v", count, e.settings.Interval))
+		}
+	case kindReceiver:
+		cs.RefusedItems = count
+		if count > e.settings.ReceiverRefusalThreshold {
+			reasons = append(reasons, fmt.Sprintf("refused %d items within %v", count, e.settings.Interval))
+		}
+		if startErr != "" {
+			reasons = append(reasons, "failed to start: "+startErr)
+		}
+	}
+
+	if len(reasons) > 0 {
+		sort.Strings(reasons)
+		cs.Healthy = false
+		cs.Reason = strings.Join(reasons, ", ")
+	}
+	return cs
+}
+
+// readQueues reads the state of the sending queue of each exporter from the collector self-observability metrics.
+func readQueues() map[string]queueState {
+	queues := map[string]queueState{}
+	for _, producer := range metricproducer.GlobalManager().GetAll() {
+		for _, m := range producer.Read() {
+			if m.Descriptor.Name != queueSizeMetric && m.Descriptor.Name != queueCapacityMetric {
+				continue
+			}
+			for _, ts := range m.TimeSeries {
+				if len(ts.LabelValues) == 0 || len(ts.Points) == 0 {
+					continue
+				}
+				value, ok := ts.Points[len(ts.Points)-1].Value.(int64)
+				if !ok {
+					continue
+				}
+				exporter := ts.LabelValues[0].Value
+				q := queues[exporter]
+				if m.Descriptor.Name == queueSizeMetric {
+					q.size = value
+				} else {
+					q.capacity = value
+				}
+				queues[exporter] = q
+			}
+		}
+	}
+	return queues
+}
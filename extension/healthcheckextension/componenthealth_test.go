@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheckextension
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/config"
+)
+
+func newTestComponentHealthExporter(now *time.Time, queues map[string]queueState) *componentHealthExporter {
+	settings := defaultComponentHealthSettings()
+	settings.ExporterFailureThreshold = 5
+	e := newComponentHealthExporter(settings)
+	e.now = func() time.Time { return *now }
+	e.queues = func() map[string]queueState { return queues }
+	return e
+}
+
+func sumViewData(name string, end time.Time, rows map[string]float64, tagKey string) *view.Data {
+	vd := &view.Data{View: &view.View{Name: name}, End: end}
+	key := tag.MustNewKey(tagKey)
+	for value, sum := range rows {
+		vd.Rows = append(vd.Rows, &view.Row{
+			Tags: []tag.Tag{{Key: key, Value: value}},
+			Data: &view.SumData{Value: sum},
+		})
+	}
+	return vd
+}
+
+func TestComponentHealthExporter_ExporterFailures(t *testing.T) {
+	now := time.Now()
+	e := newTestComponentHealthExporter(&now, nil)
+	e.addExporters(map[config.DataType][]config.ComponentID{
+		config.TracesDataType: {config.NewComponentID("otlp"), config.NewComponentID("logging")},
+	})
+
+	st := e.status(true)
+	assert.True(t, st.Healthy)
+	assert.Len(t, st.Pipelines["traces"].Components, 2)
+
+	// the views are cumulative, the first value is the baseline and only the failures since the previous export
+	// are counted
+	e.ExportView(sumViewData("exporter/send_failed_spans", now, map[string]float64{"otlp": 4}, "exporter"))
+	st = e.status(true)
+	assert.True(t, st.Healthy)
+	assert.Zero(t, st.Pipelines["traces"].Components["exporter/otlp"].FailedItems)
+
+	e.ExportView(sumViewData("exporter/send_failed_spans", now, map[string]float64{"otlp": 10}, "exporter"))
+	st = e.status(true)
+	assert.False(t, st.Healthy)
+	assert.False(t, st.Pipelines["traces"].Healthy)
+	assert.Equal(t, &componentStatus{
+		Healthy:     false,
+		FailedItems: 6,
+		Reason:      "failed to send 6 items within 1m0s",
+	}, st.Pipelines["traces"].Components["exporter/otlp"])
+	assert.True(t, st.Pipelines["traces"].Components["exporter/logging"].Healthy)
+
+	// the failures expire after the interval
+	now = now.Add(2 * time.Minute)
+	e.ExportView(sumViewData("exporter/send_failed_spans", now, map[string]float64{"otlp": 12}, "exporter"))
+	st = e.status(true)
+	assert.True(t, st.Healthy)
+	assert.Equal(t, int64(2), st.Pipelines["traces"].Components["exporter/otlp"].FailedItems)
+}
+
+func TestComponentHealthExporter_QueueSaturation(t *testing.T) {
+	now := time.Now()
+	e := newTestComponentHealthExporter(&now, map[string]queueState{
+		"otlp":   {size: 95, capacity: 100},
+		"otlp/2": {size: 10, capacity: 100},
+	})
+	e.addExporters(map[config.DataType][]config.ComponentID{
+		config.MetricsDataType: {config.NewComponentID("otlp")},
+		config.LogsDataType:    {config.NewComponentID("otlp"), config.NewComponentIDWithName("otlp", "2")},
+	})
+
+	st := e.status(true)
+	assert.False(t, st.Healthy)
+	assert.False(t, st.Pipelines["metrics"].Healthy)
+	otlp := st.Pipelines["logs"].Components["exporter/otlp"]
+	assert.False(t, otlp.Healthy)
+	assert.Equal(t, "sending queue is 95% full", otlp.Reason)
+	assert.Equal(t, int64(95), *otlp.QueueSize)
+	assert.Equal(t, int64(100), *otlp.QueueCapacity)
+	assert.True(t, st.Pipelines["logs"].Components["exporter/otlp/2"].Healthy)
+}
+
+func TestComponentHealthExporter_Refusals(t *testing.T) {
+	now := time.Now()
+	e := newTestComponentHealthExporter(&now, nil)
+
+	e.ExportView(sumViewData("processor/refused_metric_points", now, map[string]float64{"memory_limiter": 0}, "processor"))
+	e.ExportView(sumViewData("processor/refused_metric_points", now, map[string]float64{"memory_limiter": 100}, "processor"))
+	e.ExportView(sumViewData("receiver/refused_log_records", now, map[string]float64{"otlp": 0}, "receiver"))
+	e.ExportView(sumViewData("receiver/accepted_log_records", now, map[string]float64{"otlp": 10}, "receiver"))
+
+	st := e.status(true)
+	assert.False(t, st.Healthy)
+	require.Contains(t, st.Pipelines, "metrics")
+	assert.Equal(t, &componentStatus{
+		Healthy:      false,
+		RefusedItems: 100,
+		Reason:       "refused 100 items within 1m0s",
+	}, st.Pipelines["metrics"].Components["processor/memory_limiter"])
+	assert.Equal(t, &componentStatus{Healthy: true}, st.Pipelines["logs"].Components["receiver/otlp"])
+}
+
+func TestComponentHealthExporter_Pipelines(t *testing.T) {
+	now := time.Now()
+	settings := defaultComponentHealthSettings()
+	settings.Pipelines = map[config.ComponentID]*pipelineSettings{
+		config.NewComponentID("metrics"): {
+			Receivers: []config.ComponentID{config.NewComponentID("otlp")},
+			Exporters: []config.ComponentID{config.NewComponentID("otlp")},
+		},
+		config.NewComponentIDWithName("metrics", "2"): {
+			Receivers:  []config.ComponentID{config.NewComponentID("prometheus")},
+			Processors: []config.ComponentID{config.NewComponentID("memory_limiter")},
+			Exporters:  []config.ComponentID{config.NewComponentID("otlp")},
+		},
+	}
+	e := newComponentHealthExporter(settings)
+	e.now = func() time.Time { return now }
+	e.queues = func() map[string]queueState { return nil }
+
+	// the components of the pipelines are reported before they report any failure
+	st := e.status(true)
+	assert.True(t, st.Healthy)
+	require.Len(t, st.Pipelines, 2)
+	assert.Len(t, st.Pipelines["metrics"].Components, 2)
+	assert.Len(t, st.Pipelines["metrics/2"].Components, 3)
+
+	// the memory_limiter only fails the pipeline it is part of
+	e.ExportView(sumViewData("processor/refused_metric_points", now, map[string]float64{"memory_limiter": 0}, "processor"))
+	e.ExportView(sumViewData("processor/refused_metric_points", now, map[string]float64{"memory_limiter": 10}, "processor"))
+	st = e.status(true)
+	assert.False(t, st.Healthy)
+	assert.True(t, st.Pipelines["metrics"].Healthy)
+	assert.False(t, st.Pipelines["metrics/2"].Healthy)
+	assert.Equal(t, int64(10), st.Pipelines["metrics/2"].Components["processor/memory_limiter"].RefusedItems)
+
+	// the exporter shared by the pipelines fails both of them
+	e.ExportView(sumViewData("exporter/send_failed_metric_points", now, map[string]float64{"otlp": 0}, "exporter"))
+	e.ExportView(sumViewData("exporter/send_failed_metric_points", now, map[string]float64{"otlp": 1}, "exporter"))
+	st = e.status(true)
+	assert.False(t, st.Pipelines["metrics"].Healthy)
+	assert.False(t, st.Pipelines["metrics/2"].Components["exporter/otlp"].Healthy)
+
+	// the components outside of the pipelines are reported in their pipeline type
+	e.ExportView(sumViewData("exporter/send_failed_log_records", now, map[string]float64{"logging": 0}, "exporter"))
+	assert.Contains(t, e.status(true).Pipelines["logs"].Components, "exporter/logging")
+}
+
+func TestComponentHealthExporter_ReceiverStartFailure(t *testing.T) {
+	now := time.Now()
+	e := newTestComponentHealthExporter(&now, nil)
+
+	e.receiverStartFailed(config.MetricsDataType, config.NewComponentID("receiver_creator"), errors.New("redis/discovery: connection refused"))
+	st := e.status(true)
+	assert.False(t, st.Healthy)
+	assert.Equal(t, &componentStatus{
+		Healthy: false,
+		Reason:  "failed to start: redis/discovery: connection refused",
+	}, st.Pipelines["metrics"].Components["receiver/receiver_creator"])
+
+	// the start failures expire after the interval
+	now = now.Add(2 * time.Minute)
+	st = e.status(true)
+	assert.True(t, st.Healthy)
+	assert.Equal(t, &componentStatus{Healthy: true}, st.Pipelines["metrics"].Components["receiver/receiver_creator"])
+}
+
+func TestReadQueues(t *testing.T) {
+	// no exporter with a sending queue is registered in the tests
+	assert.Empty(t, readQueues())
+}
@@ -35,6 +35,10 @@ type Config struct {
 
 	// CheckCollectorPipeline contains the list of settings of collector pipeline health check
 	CheckCollectorPipeline checkCollectorPipelineSettings `mapstructure:"check_collector_pipeline"`
+
+	// ComponentHealth contains the settings of the per component health status, and of the liveness
+	// and readiness endpoints
+	ComponentHealth componentHealthSettings `mapstructure:"component_health"`
 }
 
 var _ config.Extension = (*Config)(nil)
@@ -42,6 +46,12 @@ var (
 	errNoEndpointProvided                      = errors.New("bad config: endpoint must be specified")
 	errInvalidExporterFailureThresholdProvided = errors.New("bad config: exporter_failure_threshold expects a positive number")
 	errInvalidPath                             = errors.New("bad config: path must start with /")
+	errInvalidComponentHealthPath              = errors.New("bad config: component_health paths must start with /")
+	errDuplicatePath                           = errors.New("bad config: path and the component_health paths must be distinct")
+	errInvalidComponentHealthInterval          = errors.New("bad config: component_health interval must be positive")
+	errInvalidComponentHealthThreshold         = errors.New("bad config: component_health thresholds must not be negative")
+	errInvalidQueueSaturationThreshold         = errors.New("bad config: component_health queue_saturation_threshold must be within (0, 1]")
+	errInvalidComponentHealthPipeline          = errors.New("bad config: component_health pipelines must be of type traces, metrics or logs")
 )
 
 // Validate checks if the extension configuration is valid
@@ -59,6 +69,9 @@ func (cfg *Config) Validate() error {
 	if !strings.HasPrefix(cfg.Path, "/") {
 		return errInvalidPath
 	}
+	if cfg.ComponentHealth.Enabled {
+		return cfg.ComponentHealth.validate(cfg.Path)
+	}
 	return nil
 }
 
@@ -70,3 +83,66 @@ type checkCollectorPipelineSettings struct {
 	// ExporterFailureThreshold is the threshold of exporter failure numbers during the Interval
 	ExporterFailureThreshold int `mapstructure:"exporter_failure_threshold"`
 }
+
+type componentHealthSettings struct {
+	// Enabled indicates whether to serve the component health status, liveness and readiness endpoints.
+	Enabled bool `mapstructure:"enabled"`
+	// StatusPath is the path of the JSON health status of each pipeline and component
+	StatusPath string `mapstructure:"status_path"`
+	// LivenessPath is the path reporting whether the collector is running
+	LivenessPath string `mapstructure:"liveness_path"`
+	// ReadinessPath is the path reporting whether the pipelines are ready and all their components are healthy
+	ReadinessPath string `mapstructure:"readiness_path"`
+	// Interval is the time range the failures and refusals are counted over
+	Interval time.Duration `mapstructure:"interval"`
+	// ExporterFailureThreshold is the number of items an exporter can fail to send during the Interval
+	// before it is unhealthy
+	ExporterFailureThreshold int64 `mapstructure:"exporter_failure_threshold"`
+	// QueueSaturationThreshold is the ratio of the sending queue capacity from which an exporter is unhealthy
+	QueueSaturationThreshold float64 `mapstructure:"queue_saturation_threshold"`
+	// ProcessorRefusalThreshold is the number of items a processor, such as the memory_limiter, can refuse
+	// during the Interval before it is unhealthy
+	ProcessorRefusalThreshold int64 `mapstructure:"processor_refusal_threshold"`
+	// ReceiverRefusalThreshold is the number of items a receiver can refuse during the Interval before it is unhealthy
+	ReceiverRefusalThreshold int64 `mapstructure:"receiver_refusal_threshold"`
+	// Pipelines are the pipelines of the service the health is reported for, by pipeline ID. When not set, the
+	// health of the components is reported by pipeline type.
+	Pipelines map[config.ComponentID]*pipelineSettings `mapstructure:"pipelines"`
+}
+
+// pipelineSettings lists the components of a pipeline, as in the service::pipelines configuration.
+type pipelineSettings struct {
+	Receivers  []config.ComponentID `mapstructure:"receivers"`
+	Processors []config.ComponentID `mapstructure:"processors"`
+	Exporters  []config.ComponentID `mapstructure:"exporters"`
+}
+
+func (s *componentHealthSettings) validate(path string) error {
+	paths := map[string]bool{path: true}
+	for _, p := range []string{s.StatusPath, s.LivenessPath, s.ReadinessPath} {
+		if !strings.HasPrefix(p, "/") {
+			return errInvalidComponentHealthPath
+		}
+		if paths[p] {
+			return errDuplicatePath
+		}
+		paths[p] = true
+	}
+	if s.Interval <= 0 {
+		return errInvalidComponentHealthInterval
+	}
+	if s.ExporterFailureThreshold < 0 || s.ProcessorRefusalThreshold < 0 || s.ReceiverRefusalThreshold < 0 {
+		return errInvalidComponentHealthThreshold
+	}
+	if s.QueueSaturationThreshold <= 0 || s.QueueSaturationThreshold > 1 {
+		return errInvalidQueueSaturationThreshold
+	}
+	for id := range s.Pipelines {
+		switch id.Type() {
+		case config.TracesDataType, config.MetricsDataType, config.LogsDataType:
+		default:
+			return errInvalidComponentHealthPipeline
+		}
+	}
+	return nil
+}
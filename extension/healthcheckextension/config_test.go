@@ -17,6 +17,7 @@ package healthcheckextension
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -53,9 +54,37 @@ func TestLoadConfig(t *testing.T) {
 					},
 				},
 				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+				ComponentHealth:        defaultComponentHealthSettings(),
 				Path:                   "/",
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "componenthealth"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: "localhost:13",
+				},
+				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+				ComponentHealth: componentHealthSettings{
+					Enabled:                  true,
+					StatusPath:               "/status",
+					LivenessPath:             "/livez",
+					ReadinessPath:            "/readyz",
+					Interval:                 5 * time.Minute,
+					ExporterFailureThreshold: 10,
+					QueueSaturationThreshold: 0.8,
+					Pipelines: map[config.ComponentID]*pipelineSettings{
+						config.NewComponentIDWithName("metrics", "2"): {
+							Receivers:  []config.ComponentID{config.NewComponentID("prometheus")},
+							Processors: []config.ComponentID{config.NewComponentID("memory_limiter")},
+							Exporters:  []config.ComponentID{config.NewComponentID("otlp")},
+						},
+					},
+				},
+				Path: "/",
+			},
+		},
 		{
 			id:          config.NewComponentIDWithName(typeStr, "missingendpoint"),
 			expectedErr: errNoEndpointProvided,
@@ -68,6 +97,18 @@ func TestLoadConfig(t *testing.T) {
 			id:          config.NewComponentIDWithName(typeStr, "invalidpath"),
 			expectedErr: errInvalidPath,
 		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "duplicatepath"),
+			expectedErr: errDuplicatePath,
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "invalidsaturation"),
+			expectedErr: errInvalidQueueSaturationThreshold,
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "invalidpipeline"),
+			expectedErr: errInvalidComponentHealthPipeline,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -87,3 +128,43 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestComponentHealthValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*componentHealthSettings)
+		expectedErr error
+	}{
+		{
+			name:   "default",
+			modify: func(*componentHealthSettings) {},
+		},
+		{
+			name:        "invalid path",
+			modify:      func(s *componentHealthSettings) { s.ReadinessPath = "readyz" },
+			expectedErr: errInvalidComponentHealthPath,
+		},
+		{
+			name:        "duplicate path",
+			modify:      func(s *componentHealthSettings) { s.LivenessPath = s.ReadinessPath },
+			expectedErr: errDuplicatePath,
+		},
+		{
+			name:        "invalid interval",
+			modify:      func(s *componentHealthSettings) { s.Interval = 0 },
+			expectedErr: errInvalidComponentHealthInterval,
+		},
+		{
+			name:        "negative threshold",
+			modify:      func(s *componentHealthSettings) { s.ProcessorRefusalThreshold = -1 },
+			expectedErr: errInvalidComponentHealthThreshold,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := defaultComponentHealthSettings()
+			tt.modify(&settings)
+			assert.Equal(t, tt.expectedErr, settings.validate("/"))
+		})
+	}
+}
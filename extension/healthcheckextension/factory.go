@@ -16,6 +16,7 @@ package healthcheckextension // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -48,6 +49,7 @@ func createDefaultConfig() config.Extension {
 			Endpoint: defaultEndpoint,
 		},
 		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+		ComponentHealth:        defaultComponentHealthSettings(),
 		Path:                   "/",
 	}
 }
@@ -66,3 +68,18 @@ func defaultCheckCollectorPipelineSettings() checkCollectorPipelineSettings {
 		ExporterFailureThreshold: 5,
 	}
 }
+
+// defaultComponentHealthSettings returns the default settings for ComponentHealth.
+func defaultComponentHealthSettings() componentHealthSettings {
+	return componentHealthSettings{
+		Enabled:                   false,
+		StatusPath:                "/status",
+		LivenessPath:              "/livez",
+		ReadinessPath:             "/readyz",
+		Interval:                  time.Minute,
+		ExporterFailureThreshold:  0,
+		QueueSaturationThreshold:  0.9,
+		ProcessorRefusalThreshold: 0,
+		ReceiverRefusalThreshold:  0,
+	}
+}
@@ -16,6 +16,7 @@ package healthcheckextension // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -24,6 +25,7 @@ import (
 	"github.com/jaegertracing/jaeger/pkg/healthcheck"
 	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
 	"go.uber.org/zap"
 )
 
@@ -34,7 +36,9 @@ type healthCheckExtension struct {
 	server   *http.Server
 	stopCh   chan struct{}
 	exporter *healthCheckExporter
-	settings component.TelemetrySettings
+	// componentExporter keeps the health of each component when component_health is enabled
+	componentExporter *componentHealthExporter
+	settings          component.TelemetrySettings
 }
 
 var _ component.PipelineWatcher = (*healthCheckExtension)(nil)
@@ -52,9 +56,13 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 		return err
 	}
 
+	mux := http.NewServeMux()
+	if hc.config.ComponentHealth.Enabled {
+		hc.startComponentHealth(host, mux)
+	}
+
 	if !hc.config.CheckCollectorPipeline.Enabled {
 		// Mount HC handler
-		mux := http.NewServeMux()
 		mux.Handle(hc.config.Path, hc.state.Handler())
 		hc.server.Handler = mux
 		hc.stopCh = make(chan struct{})
@@ -79,7 +87,6 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 		// ticker used by collector pipeline health check for rotation
 		ticker := time.NewTicker(time.Second)
 
-		mux := http.NewServeMux()
 		mux.Handle(hc.config.Path, hc.handler())
 		hc.server.Handler = mux
 		hc.stopCh = make(chan struct{})
@@ -108,6 +115,64 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 	return nil
 }
 
+// startComponentHealth mounts the component health status, liveness and readiness handlers.
+func (hc *healthCheckExtension) startComponentHealth(host component.Host, mux *http.ServeMux) {
+	hc.componentExporter = newComponentHealthExporter(hc.config.ComponentHealth)
+
+	exporters := map[config.DataType][]config.ComponentID{}
+	for dataType, exps := range host.GetExporters() {
+		for id := range exps {
+			exporters[dataType] = append(exporters[dataType], id)
+		}
+	}
+	hc.componentExporter.addExporters(exporters)
+	view.RegisterExporter(hc.componentExporter)
+
+	mux.Handle(hc.config.ComponentHealth.StatusPath, hc.statusHandler())
+	mux.Handle(hc.config.ComponentHealth.LivenessPath, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// the collector is alive as long as it serves this endpoint, failing components are reported by the readiness
+		w.WriteHeader(http.StatusOK)
+	}))
+	mux.Handle(hc.config.ComponentHealth.ReadinessPath, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if st := hc.componentStatus(); st.Ready && st.Healthy {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+}
+
+func (hc *healthCheckExtension) statusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		st := hc.componentStatus()
+		body, err := json.Marshal(st)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if st.Ready && st.Healthy {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_, _ = w.Write(body)
+	})
+}
+
+// ReportReceiverStartFailure records that a receiver failed to start, such as a receiver started at runtime by
+// the receiver_creator, so that it is reported as unhealthy by the component health status.
+func (hc *healthCheckExtension) ReportReceiverStartFailure(dataType config.DataType, id config.ComponentID, err error) {
+	if hc.componentExporter == nil {
+		return
+	}
+	hc.componentExporter.receiverStartFailed(dataType, id, err)
+}
+
+func (hc *healthCheckExtension) componentStatus() *healthStatus {
+	return hc.componentExporter.status(hc.state.Get() == healthcheck.Ready)
+}
+
 // new handler function used for check collector pipeline
 func (hc *healthCheckExtension) handler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
@@ -131,6 +196,9 @@ func (hc *healthCheckExtension) Shutdown(context.Context) error {
 	if hc.stopCh != nil {
 		<-hc.stopCh
 	}
+	if hc.componentExporter != nil {
+		view.UnregisterExporter(hc.componentExporter)
+	}
 	return err
 }
 
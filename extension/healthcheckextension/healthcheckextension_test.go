@@ -16,6 +16,8 @@ package healthcheckextension
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net"
 	"net/http"
 	"runtime"
@@ -27,6 +29,7 @@ import (
 	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
@@ -246,6 +249,68 @@ func TestHealthCheckExtensionUsageWithCustomPathWithCheckCollectorPipeline(t *te
 	require.NoError(t, resp3.Body.Close(), "Must be able to close the response")
 }
 
+func TestHealthCheckExtensionUsageWithComponentHealth(t *testing.T) {
+	settings := defaultComponentHealthSettings()
+	settings.Enabled = true
+	cfg := Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+		ComponentHealth:        settings,
+		Path:                   "/",
+	}
+
+	hcExt := newServer(cfg, componenttest.NewNopTelemetrySettings())
+	require.NotNil(t, hcExt)
+
+	require.NoError(t, hcExt.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, hcExt.Shutdown(context.Background())) })
+	require.Eventuallyf(t, ensureServerRunning(cfg.Endpoint), 30*time.Second, 1*time.Second, "Failed to start the testing server.")
+
+	client := &http.Client{}
+	get := func(path string) (int, string) {
+		resp, err := client.Get("http://" + cfg.Endpoint + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	code, _ := get("/livez")
+	require.Equal(t, http.StatusOK, code)
+	code, _ = get("/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+
+	require.NoError(t, hcExt.Ready())
+	code, _ = get("/readyz")
+	require.Equal(t, http.StatusOK, code)
+	code, body := get("/status")
+	require.Equal(t, http.StatusOK, code)
+	require.JSONEq(t, `{"ready":true,"healthy":true,"pipelines":{}}`, body)
+
+	hcExt.componentExporter.ExportView(sumViewData("exporter/send_failed_spans", time.Now(), map[string]float64{"otlp": 0}, "exporter"))
+	hcExt.componentExporter.ExportView(sumViewData("exporter/send_failed_spans", time.Now(), map[string]float64{"otlp": 1}, "exporter"))
+	code, _ = get("/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	code, body = get("/status")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.JSONEq(t, `{"ready":true,"healthy":false,"pipelines":{"traces":{"healthy":false,"components":{
+		"exporter/otlp":{"healthy":false,"failed_items":1,"reason":"failed to send 1 items within 1m0s"}}}}}`, body)
+
+	hcExt.ReportReceiverStartFailure(config.TracesDataType, config.NewComponentID("receiver_creator"), errors.New("failed"))
+	code, body = get("/status")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Contains(t, body, `"receiver/receiver_creator":{"healthy":false,"reason":"failed to start: failed"}`)
+
+	// the liveness isn't affected by the failing components
+	code, _ = get("/livez")
+	require.Equal(t, http.StatusOK, code)
+	code, _ = get("/")
+	require.Equal(t, http.StatusOK, code)
+}
+
 func TestHealthCheckExtensionPortAlreadyInUse(t *testing.T) {
 	endpoint := testutil.GetAvailableLocalAddress(t)
 
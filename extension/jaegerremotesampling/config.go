@@ -18,15 +18,18 @@ import (
 	"errors"
 	"time"
 
+	"github.com/jaegertracing/jaeger/plugin/sampling/strategystore/adaptive"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 )
 
 var (
-	errTooManySources     = errors.New("too many sources specified, has to be either 'file' or 'remote'")
-	errNoSources          = errors.New("no sources specified, has to be either 'file' or 'remote'")
+	errTooManySources     = errors.New("too many sources specified, has to be one of 'file', 'remote' or 'adaptive'")
+	errNoSources          = errors.New("no sources specified, has to be one of 'file', 'remote' or 'adaptive'")
 	errAtLeastOneProtocol = errors.New("no protocols selected to serve the strategies, use 'grpc', 'http', or both")
+	errNegativeAdaptive   = errors.New("the 'adaptive' source settings must not be negative")
+	errInvalidProbability = errors.New("the 'adaptive' source sampling probabilities must be between 0 and 1")
 )
 
 // Config has the configuration for the extension enabling the health check
@@ -36,7 +39,7 @@ type Config struct {
 	*confighttp.HTTPServerSettings `mapstructure:"http"`
 	*configgrpc.GRPCServerSettings `mapstructure:"grpc"`
 
-	// Source configures the source for the strategies file. One of `remote` or `file` has to be specified.
+	// Source configures the source for the strategies. One of `remote`, `file` or `adaptive` has to be specified.
 	Source Source `mapstructure:"source"`
 }
 
@@ -49,6 +52,52 @@ type Source struct {
 
 	// ReloadInterval determines the periodicity to refresh the strategies
 	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+
+	// Adaptive computes the strategies from the observed span throughput
+	Adaptive *AdaptiveSettings `mapstructure:"adaptive"`
+}
+
+// AdaptiveSettings configures the adaptive strategies source. Settings left
+// at their zero value fall back to the Jaeger defaults.
+type AdaptiveSettings struct {
+	// StorageID is the storage extension keeping the throughput and the calculated
+	// probabilities. Collectors sharing the same storage converge on the same strategies.
+	// When not set, the state is kept in memory and is local to this collector.
+	StorageID *config.ComponentID `mapstructure:"storage"`
+
+	// TargetSamplesPerSecond is the number of traces per second each operation should be sampled at.
+	TargetSamplesPerSecond float64 `mapstructure:"target_samples_per_second"`
+
+	// DeltaTolerance is the acceptable relative deviation from the target before the probability is changed.
+	DeltaTolerance float64 `mapstructure:"delta_tolerance"`
+
+	// CalculationInterval determines how often the probabilities are calculated.
+	CalculationInterval time.Duration `mapstructure:"calculation_interval"`
+
+	// AggregationBuckets is the number of throughput buckets kept in memory.
+	AggregationBuckets int `mapstructure:"aggregation_buckets"`
+
+	// BucketsForCalculation is the number of buckets used to calculate the probabilities.
+	BucketsForCalculation int `mapstructure:"buckets_for_calculation"`
+
+	// Delay is how far back in time the throughput is read, giving all collectors
+	// the time to store their throughput for an interval.
+	Delay time.Duration `mapstructure:"delay"`
+
+	// InitialSamplingProbability is the probability given to operations without any throughput yet.
+	InitialSamplingProbability float64 `mapstructure:"initial_sampling_probability"`
+
+	// MinSamplingProbability is the lowest probability any operation is sampled at.
+	MinSamplingProbability float64 `mapstructure:"min_sampling_probability"`
+
+	// MinSamplesPerSecond is the lowest number of traces per second sampled for each operation.
+	MinSamplesPerSecond float64 `mapstructure:"min_samples_per_second"`
+
+	// LeaderLeaseRefreshInterval is how often the leader renews its lease to calculate the probabilities.
+	LeaderLeaseRefreshInterval time.Duration `mapstructure:"leader_lease_refresh_interval"`
+
+	// FollowerLeaseRefreshInterval is how often followers try to become the leader.
+	FollowerLeaseRefreshInterval time.Duration `mapstructure:"follower_lease_refresh_interval"`
 }
 
 var _ config.Extension = (*Config)(nil)
@@ -59,13 +108,96 @@ func (cfg *Config) Validate() error {
 		return errAtLeastOneProtocol
 	}
 
-	if cfg.Source.File != "" && cfg.Source.Remote != nil {
+	sources := 0
+	if cfg.Source.File != "" {
+		sources++
+	}
+	if cfg.Source.Remote != nil {
+		sources++
+	}
+	if cfg.Source.Adaptive != nil {
+		sources++
+	}
+
+	if sources > 1 {
 		return errTooManySources
 	}
 
-	if cfg.Source.File == "" && cfg.Source.Remote == nil {
+	if sources == 0 {
 		return errNoSources
 	}
 
+	if cfg.Source.Adaptive != nil {
+		return cfg.Source.Adaptive.validate()
+	}
+
 	return nil
 }
+
+func (s *AdaptiveSettings) validate() error {
+	if s.TargetSamplesPerSecond < 0 || s.DeltaTolerance < 0 || s.CalculationInterval < 0 ||
+		s.AggregationBuckets < 0 || s.BucketsForCalculation < 0 || s.Delay < 0 || s.MinSamplesPerSecond < 0 ||
+		s.LeaderLeaseRefreshInterval < 0 || s.FollowerLeaseRefreshInterval < 0 {
+		return errNegativeAdaptive
+	}
+
+	for _, p := range []float64{s.InitialSamplingProbability, s.MinSamplingProbability} {
+		if p < 0 || p > 1 {
+			return errInvalidProbability
+		}
+	}
+
+	return nil
+}
+
+// options converts the settings to the Jaeger adaptive options, using the Jaeger
+// defaults for the settings that weren't set.
+func (s *AdaptiveSettings) options() adaptive.Options {
+	opts := adaptive.Options{
+		TargetSamplesPerSecond:       s.TargetSamplesPerSecond,
+		DeltaTolerance:               s.DeltaTolerance,
+		CalculationInterval:          s.CalculationInterval,
+		AggregationBuckets:           s.AggregationBuckets,
+		BucketsForCalculation:        s.BucketsForCalculation,
+		Delay:                        s.Delay,
+		InitialSamplingProbability:   s.InitialSamplingProbability,
+		MinSamplingProbability:       s.MinSamplingProbability,
+		MinSamplesPerSecond:          s.MinSamplesPerSecond,
+		LeaderLeaseRefreshInterval:   s.LeaderLeaseRefreshInterval,
+		FollowerLeaseRefreshInterval: s.FollowerLeaseRefreshInterval,
+	}
+	if opts.TargetSamplesPerSecond == 0 {
+		opts.TargetSamplesPerSecond = 1
+	}
+	if opts.DeltaTolerance == 0 {
+		opts.DeltaTolerance = 0.3
+	}
+	if opts.CalculationInterval == 0 {
+		opts.CalculationInterval = time.Minute
+	}
+	if opts.AggregationBuckets == 0 {
+		opts.AggregationBuckets = 10
+	}
+	if opts.BucketsForCalculation == 0 {
+		opts.BucketsForCalculation = 1
+	}
+	if opts.Delay == 0 {
+		opts.Delay = 2 * time.Minute
+	}
+	if opts.InitialSamplingProbability == 0 {
+		opts.InitialSamplingProbability = 0.001
+	}
+	if opts.MinSamplingProbability == 0 {
+		opts.MinSamplingProbability = 1e-5
+	}
+	if opts.MinSamplesPerSecond == 0 {
+		opts.MinSamplesPerSecond = 1.0 / 60
+	}
+	if opts.LeaderLeaseRefreshInterval == 0 {
+		opts.LeaderLeaseRefreshInterval = 5 * time.Second
+	}
+	if opts.FollowerLeaseRefreshInterval == 0 {
+		opts.FollowerLeaseRefreshInterval = time.Minute
+	}
+	return opts
+}
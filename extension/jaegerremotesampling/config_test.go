@@ -31,6 +31,7 @@ import (
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
+	storageID := config.NewComponentID("file_storage")
 	tests := []struct {
 		id       config.ComponentID
 		expected config.Extension
@@ -66,6 +67,24 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "2"),
+			expected: &Config{
+				ExtensionSettings:  config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				HTTPServerSettings: &confighttp.HTTPServerSettings{Endpoint: ":5778"},
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{NetAddr: confignet.NetAddr{
+					Endpoint:  ":14250",
+					Transport: "tcp",
+				}},
+				Source: Source{
+					Adaptive: &AdaptiveSettings{
+						StorageID:              &storageID,
+						TargetSamplesPerSecond: 10,
+						CalculationInterval:    30 * time.Second,
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -112,6 +131,46 @@ func TestValidate(t *testing.T) {
 			},
 			expected: errTooManySources,
 		},
+		{
+			desc: "adaptive with another source",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					File:     "/tmp/some-file",
+					Adaptive: &AdaptiveSettings{},
+				},
+			},
+			expected: errTooManySources,
+		},
+		{
+			desc: "adaptive with defaults",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					Adaptive: &AdaptiveSettings{},
+				},
+			},
+		},
+		{
+			desc: "adaptive with negative interval",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					Adaptive: &AdaptiveSettings{CalculationInterval: -time.Second},
+				},
+			},
+			expected: errNegativeAdaptive,
+		},
+		{
+			desc: "adaptive with invalid probability",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					Adaptive: &AdaptiveSettings{MinSamplingProbability: 1.5},
+				},
+			},
+			expected: errInvalidProbability,
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -120,3 +179,13 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestAdaptiveOptions(t *testing.T) {
+	opts := (&AdaptiveSettings{TargetSamplesPerSecond: 10}).options()
+	assert.Equal(t, 10.0, opts.TargetSamplesPerSecond)
+	assert.Equal(t, time.Minute, opts.CalculationInterval)
+	assert.Equal(t, 10, opts.AggregationBuckets)
+	assert.Equal(t, 1, opts.BucketsForCalculation)
+	assert.Equal(t, 2*time.Minute, opts.Delay)
+	assert.Equal(t, 0.001, opts.InitialSamplingProbability)
+}
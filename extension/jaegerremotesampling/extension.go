@@ -17,11 +17,16 @@ package jaegerremotesampling // import "github.com/open-telemetry/opentelemetry-
 import (
 	"context"
 	"fmt"
+	"time"
 
 	grpcStore "github.com/jaegertracing/jaeger/cmd/agent/app/configmanager/grpc"
 	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
+	"github.com/jaegertracing/jaeger/pkg/hostname"
+	"github.com/jaegertracing/jaeger/pkg/metrics"
+	"github.com/jaegertracing/jaeger/plugin/sampling/strategystore/adaptive"
 	"github.com/jaegertracing/jaeger/plugin/sampling/strategystore/static"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 
@@ -37,6 +42,7 @@ type jrsExtension struct {
 	httpServer    component.Component
 	grpcServer    component.Component
 	samplingStore strategystore.StrategyStore
+	aggregator    strategystore.Aggregator
 
 	closers []func() error
 }
@@ -54,6 +60,7 @@ func (jrse *jrsExtension) Start(ctx context.Context, host component.Host) error
 	// source of the sampling config:
 	// - remote (gRPC)
 	// - local file
+	// - adaptive
 	// we can then use a simplified logic here to assign the appropriate store
 	if jrse.cfg.Source.File != "" {
 		opts := static.Options{
@@ -86,6 +93,12 @@ func (jrse *jrsExtension) Start(ctx context.Context, host component.Host) error
 		})
 	}
 
+	if jrse.cfg.Source.Adaptive != nil {
+		if err := jrse.startAdaptive(ctx, host); err != nil {
+			return err
+		}
+	}
+
 	if jrse.cfg.HTTPServerSettings != nil {
 		httpServer, err := internal.NewHTTP(jrse.telemetry, *jrse.cfg.HTTPServerSettings, jrse.samplingStore)
 		if err != nil {
@@ -113,6 +126,66 @@ func (jrse *jrsExtension) Start(ctx context.Context, host component.Host) error
 	return nil
 }
 
+func (jrse *jrsExtension) startAdaptive(ctx context.Context, host component.Host) error {
+	settings := jrse.cfg.Source.Adaptive
+	opts := settings.options()
+
+	client := internal.NewMemoryClient()
+	if settings.StorageID != nil {
+		ext, found := host.GetExtensions()[*settings.StorageID]
+		if !found {
+			return fmt.Errorf("storage extension %q for the adaptive source not found", settings.StorageID)
+		}
+		storageExt, ok := ext.(storage.Extension)
+		if !ok {
+			return fmt.Errorf("extension %q is not a storage extension", settings.StorageID)
+		}
+		var err error
+		client, err = storageExt.GetClient(ctx, component.KindExtension, jrse.cfg.ID(), "")
+		if err != nil {
+			return fmt.Errorf("failed to get a storage client for the adaptive source: %w", err)
+		}
+	}
+	jrse.closers = append(jrse.closers, func() error {
+		return client.Close(context.Background())
+	})
+
+	id, err := hostname.AsIdentifier()
+	if err != nil {
+		return fmt.Errorf("failed to identify this collector for the adaptive source: %w", err)
+	}
+
+	// throughput is kept for as long as the processor may read it back: all the aggregation buckets, read with a delay
+	retention := opts.CalculationInterval*time.Duration(opts.AggregationBuckets+1) + opts.Delay
+	store := internal.NewSamplingStore(client, id, opts.CalculationInterval, retention)
+
+	processor, err := adaptive.NewStrategyStore(opts, metrics.NullFactory, jrse.telemetry.Logger, store, store)
+	if err != nil {
+		return fmt.Errorf("failed to create the adaptive strategy store: %w", err)
+	}
+	if err := processor.Start(); err != nil {
+		return fmt.Errorf("failed to start the adaptive strategy store: %w", err)
+	}
+	jrse.closers = append(jrse.closers, processor.Close)
+	jrse.samplingStore = processor
+
+	aggregator := adaptive.NewAggregator(metrics.NullFactory, opts.CalculationInterval, store)
+	aggregator.Start()
+	jrse.closers = append(jrse.closers, aggregator.Close)
+	jrse.aggregator = aggregator
+
+	return nil
+}
+
+// RecordThroughput records a root span of the operation, sampled by the given sampler, for the
+// adaptive source. It is a no-op for the other sources. Components feeding the throughput find
+// this extension by this method.
+func (jrse *jrsExtension) RecordThroughput(service, operation, samplerType string, probability float64) {
+	if jrse.aggregator != nil {
+		jrse.aggregator.RecordThroughput(service, operation, samplerType, probability)
+	}
+}
+
 func (jrse *jrsExtension) Shutdown(ctx context.Context) error {
 	// we probably don't want to break whenever an error occurs, we want to continue and close the other resources
 	if jrse.httpServer != nil {
@@ -127,8 +200,9 @@ func (jrse *jrsExtension) Shutdown(ctx context.Context) error {
 		}
 	}
 
-	for _, closer := range jrse.closers {
-		if err := closer(); err != nil {
+	// the resources are closed in the reverse order of their creation, as the latest ones may depend on the earliest
+	for i := len(jrse.closers) - 1; i >= 0; i-- {
+		if err := jrse.closers[i](); err != nil {
 			jrse.telemetry.Logger.Error("error while shutting down the sampling store", zap.Error(err))
 		}
 	}
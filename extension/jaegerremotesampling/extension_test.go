@@ -24,9 +24,14 @@ import (
 	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"google.golang.org/grpc"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal"
 )
 
 func TestNewExtension(t *testing.T) {
@@ -84,6 +89,93 @@ func TestStartAndShutdownRemote(t *testing.T) {
 	assert.NoError(t, e.Shutdown(context.Background()))
 }
 
+func TestStartAndShutdownAdaptive(t *testing.T) {
+	// prepare
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPServerSettings.Endpoint = "localhost:0"
+	cfg.GRPCServerSettings.NetAddr.Endpoint = "localhost:0"
+	cfg.Source.Adaptive = &AdaptiveSettings{InitialSamplingProbability: 0.25}
+
+	e := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+	require.NotNil(t, e)
+	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+
+	// test
+	e.RecordThroughput("svc", "op", "probabilistic", 0.25)
+	resp, err := e.samplingStore.GetSamplingStrategy(context.Background(), "svc")
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, 0.25, resp.OperationSampling.DefaultSamplingProbability)
+	assert.NoError(t, e.Shutdown(context.Background()))
+}
+
+func TestStartAdaptiveWithStorage(t *testing.T) {
+	storageID := config.NewComponentID("file_storage")
+	testCases := []struct {
+		desc       string
+		extensions map[config.ComponentID]component.Extension
+		err        string
+	}{
+		{
+			desc: "storage extension",
+			extensions: map[config.ComponentID]component.Extension{
+				storageID: &mockStorageExtension{},
+			},
+		},
+		{
+			desc: "missing extension",
+			err:  `storage extension "file_storage" for the adaptive source not found`,
+		},
+		{
+			desc: "not a storage extension",
+			extensions: map[config.ComponentID]component.Extension{
+				storageID: &nopExtension{},
+			},
+			err: `extension "file_storage" is not a storage extension`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.HTTPServerSettings = nil
+			cfg.GRPCServerSettings.NetAddr.Endpoint = "localhost:0"
+			cfg.Source.Adaptive = &AdaptiveSettings{StorageID: &storageID}
+
+			e := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+			err := e.Start(context.Background(), &mockHost{extensions: tC.extensions})
+			if tC.err != "" {
+				assert.EqualError(t, err, tC.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, e.Shutdown(context.Background()))
+		})
+	}
+}
+
+type mockHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *mockHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+type nopExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+type mockStorageExtension struct {
+	nopExtension
+}
+
+func (m *mockStorageExtension) GetClient(context.Context, component.Kind, config.ComponentID, string) (storage.Client, error) {
+	return internal.NewMemoryClient(), nil
+}
+
 type samplingServer struct {
 	api_v2.UnimplementedSamplingManagerServer
 }
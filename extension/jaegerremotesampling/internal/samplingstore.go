@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/model"
+	"github.com/jaegertracing/jaeger/pkg/distributedlock"
+	"github.com/jaegertracing/jaeger/storage/samplingstore"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+const (
+	participantsKey  = "participants"
+	probabilitiesKey = "probabilities"
+	lockKeyPrefix    = "lock/"
+)
+
+var (
+	_ samplingstore.Store  = (*SamplingStore)(nil)
+	_ distributedlock.Lock = (*SamplingStore)(nil)
+)
+
+// SamplingStore keeps the adaptive sampling state in a storage client. It implements
+// both the store for the throughput and probabilities, and the lock used to elect the
+// collector calculating the probabilities. As the storage client can't list its keys,
+// every collector registers itself as a participant so that its throughput can be found.
+type SamplingStore struct {
+	client    storage.Client
+	hostname  string
+	interval  time.Duration
+	retention time.Duration
+	now       func() time.Time
+
+	mu      sync.Mutex
+	buckets []int64
+}
+
+type probabilitiesAndQPS struct {
+	Hostname      string                              `json:"hostname"`
+	Probabilities model.ServiceOperationProbabilities `json:"probabilities"`
+	QPS           model.ServiceOperationQPS           `json:"qps"`
+}
+
+// NewSamplingStore returns a store for the given collector hostname. The throughput is stored
+// in buckets of the given interval, which are removed once they are older than the retention.
+func NewSamplingStore(client storage.Client, hostname string, interval, retention time.Duration) *SamplingStore {
+	return &SamplingStore{
+		client:    client,
+		hostname:  hostname,
+		interval:  interval,
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+// InsertThroughput stores the throughput of this collector in the bucket of the current time.
+func (s *SamplingStore) InsertThroughput(throughput []*model.Throughput) error {
+	ctx := context.Background()
+	now := s.now()
+	bucket := now.Truncate(s.interval).Unix()
+	key := throughputKey(bucket, s.hostname)
+
+	var existing []*model.Throughput
+	if err := s.get(ctx, key, &existing); err != nil {
+		return err
+	}
+	if err := s.set(ctx, key, append(existing, throughput...)); err != nil {
+		return err
+	}
+
+	if err := s.register(ctx, now); err != nil {
+		return err
+	}
+	return s.expire(ctx, bucket, now)
+}
+
+// GetThroughput returns the throughput of all the participants for the buckets starting within [start, end).
+func (s *SamplingStore) GetThroughput(start, end time.Time) ([]*model.Throughput, error) {
+	ctx := context.Background()
+	participants := map[string]int64{}
+	if err := s.get(ctx, participantsKey, &participants); err != nil {
+		return nil, err
+	}
+
+	var ops []storage.Operation
+	for b := start.Truncate(s.interval); b.Before(end); b = b.Add(s.interval) {
+		if b.Before(start) {
+			continue
+		}
+		for hostname := range participants {
+			ops = append(ops, storage.GetOperation(throughputKey(b.Unix(), hostname)))
+		}
+	}
+	if len(ops) == 0 {
+		return nil, nil
+	}
+	if err := s.client.Batch(ctx, ops...); err != nil {
+		return nil, err
+	}
+
+	var result []*model.Throughput
+	for _, op := range ops {
+		if op.Value == nil {
+			continue
+		}
+		var throughput []*model.Throughput
+		if err := json.Unmarshal(op.Value, &throughput); err != nil {
+			return nil, fmt.Errorf("failed to decode %q: %w", op.Key, err)
+		}
+		result = append(result, throughput...)
+	}
+	return result, nil
+}
+
+// InsertProbabilitiesAndQPS stores the latest probabilities calculated by the given collector.
+func (s *SamplingStore) InsertProbabilitiesAndQPS(hostname string, probabilities model.ServiceOperationProbabilities, qps model.ServiceOperationQPS) error {
+	return s.set(context.Background(), probabilitiesKey, probabilitiesAndQPS{
+		Hostname:      hostname,
+		Probabilities: probabilities,
+		QPS:           qps,
+	})
+}
+
+// GetLatestProbabilities returns the latest probabilities, which are empty when none were calculated yet.
+func (s *SamplingStore) GetLatestProbabilities() (model.ServiceOperationProbabilities, error) {
+	var latest probabilitiesAndQPS
+	if err := s.get(context.Background(), probabilitiesKey, &latest); err != nil {
+		return nil, err
+	}
+	if latest.Probabilities == nil {
+		return model.ServiceOperationProbabilities{}, nil
+	}
+	return latest.Probabilities, nil
+}
+
+// Acquire renews the candidacy of this collector for the resource, and reports whether it is the leader. The
+// storage clients have no compare-and-swap operation, so instead of a single lease, every collector keeps its own
+// candidacy key alive and the leader is chosen deterministically: it is the registered participant with the lowest
+// hostname among the ones whose candidacy hasn't expired. A collector which isn't registered yet registers itself
+// and only becomes a candidate on its next attempt. The collectors may still briefly disagree on the leader
+// while the participants change, until each of them renews its candidacy.
+func (s *SamplingStore) Acquire(resource string, ttl time.Duration) (bool, error) {
+	ctx := context.Background()
+	now := s.now()
+
+	participants := map[string]int64{}
+	if err := s.get(ctx, participantsKey, &participants); err != nil {
+		return false, err
+	}
+	if _, ok := participants[s.hostname]; !ok {
+		return false, s.register(ctx, now)
+	}
+
+	// the candidacy outlives a missed refresh, as the followers refresh it every ttl
+	if err := s.set(ctx, candidateKey(resource, s.hostname), now.Add(2*ttl)); err != nil {
+		return false, err
+	}
+	var ops []storage.Operation
+	for hostname := range participants {
+		if hostname < s.hostname {
+			ops = append(ops, storage.GetOperation(candidateKey(resource, hostname)))
+		}
+	}
+	if len(ops) == 0 {
+		return true, nil
+	}
+	if err := s.client.Batch(ctx, ops...); err != nil {
+		return false, err
+	}
+	for _, op := range ops {
+		if op.Value == nil {
+			continue
+		}
+		var expiry time.Time
+		if err := json.Unmarshal(op.Value, &expiry); err != nil {
+			return false, fmt.Errorf("failed to decode %q: %w", op.Key, err)
+		}
+		if now.Before(expiry) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Forfeit withdraws the candidacy of this collector for the resource, and reports whether it was a candidate.
+func (s *SamplingStore) Forfeit(resource string) (bool, error) {
+	ctx := context.Background()
+	key := candidateKey(resource, s.hostname)
+
+	data, err := s.client.Get(ctx, key)
+	if err != nil || data == nil {
+		return false, err
+	}
+	if err := s.client.Delete(ctx, key); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// register records this collector as a participant, dropping the ones which haven't
+// stored any throughput within the retention. Without compare-and-swap, concurrent
+// registrations may overwrite each other: a participant missing from the list is added
+// back by its next registration, until then its throughput is ignored and it can't be elected.
+func (s *SamplingStore) register(ctx context.Context, now time.Time) error {
+	participants := map[string]int64{}
+	if err := s.get(ctx, participantsKey, &participants); err != nil {
+		return err
+	}
+	participants[s.hostname] = now.Unix()
+	oldest := now.Add(-s.retention).Unix()
+	for hostname, seen := range participants {
+		if seen < oldest {
+			delete(participants, hostname)
+		}
+	}
+	return s.set(ctx, participantsKey, participants)
+}
+
+// expire deletes the buckets written by this collector which are older than the retention.
+func (s *SamplingStore) expire(ctx context.Context, bucket int64, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.buckets) == 0 || s.buckets[len(s.buckets)-1] != bucket {
+		s.buckets = append(s.buckets, bucket)
+	}
+
+	oldest := now.Add(-s.retention).Unix()
+	var ops []storage.Operation
+	for len(s.buckets) > 0 && s.buckets[0] < oldest {
+		ops = append(ops, storage.DeleteOperation(throughputKey(s.buckets[0], s.hostname)))
+		s.buckets = s.buckets[1:]
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	return s.client.Batch(ctx, ops...)
+}
+
+func (s *SamplingStore) get(ctx context.Context, key string, v interface{}) error {
+	data, err := s.client.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode %q: %w", key, err)
+	}
+	return nil
+}
+
+func (s *SamplingStore) set(ctx context.Context, key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, key, data)
+}
+
+func candidateKey(resource, hostname string) string {
+	return lockKeyPrefix + resource + "/" + hostname
+}
+
+func throughputKey(bucket int64, hostname string) string {
+	return fmt.Sprintf("throughput/%d/%s", bucket, hostname)
+}
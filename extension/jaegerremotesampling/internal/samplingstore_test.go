@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(client *memoryClient, hostname string, now *time.Time) *SamplingStore {
+	s := NewSamplingStore(client, hostname, time.Minute, 5*time.Minute)
+	s.now = func() time.Time { return *now }
+	return s
+}
+
+func TestThroughputAcrossParticipants(t *testing.T) {
+	client := NewMemoryClient().(*memoryClient)
+	now := time.Unix(6000, 0)
+	first := newTestStore(client, "first", &now)
+	second := newTestStore(client, "second", &now)
+
+	require.NoError(t, first.InsertThroughput([]*model.Throughput{{Service: "svc", Operation: "op", Count: 2}}))
+	require.NoError(t, second.InsertThroughput([]*model.Throughput{{Service: "svc", Operation: "op", Count: 3}}))
+	// inserting twice within a bucket keeps both
+	require.NoError(t, first.InsertThroughput([]*model.Throughput{{Service: "svc", Operation: "other", Count: 1}}))
+
+	throughput, err := second.GetThroughput(now, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*model.Throughput{
+		{Service: "svc", Operation: "op", Count: 2},
+		{Service: "svc", Operation: "op", Count: 3},
+		{Service: "svc", Operation: "other", Count: 1},
+	}, throughput)
+
+	// the bucket doesn't start within the range
+	throughput, err = second.GetThroughput(now.Add(time.Second), now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, throughput)
+}
+
+func TestThroughputExpiry(t *testing.T) {
+	client := NewMemoryClient().(*memoryClient)
+	now := time.Unix(6000, 0)
+	first := newTestStore(client, "first", &now)
+	second := newTestStore(client, "second", &now)
+	start := now
+
+	require.NoError(t, first.InsertThroughput([]*model.Throughput{{Service: "svc", Operation: "op", Count: 1}}))
+	require.NoError(t, second.InsertThroughput([]*model.Throughput{{Service: "svc", Operation: "op", Count: 1}}))
+
+	now = now.Add(10 * time.Minute)
+	require.NoError(t, first.InsertThroughput([]*model.Throughput{{Service: "svc", Operation: "op", Count: 1}}))
+
+	// the first bucket of the first store was expired, and the second store isn't a participant anymore
+	throughput, err := first.GetThroughput(start, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Len(t, throughput, 1)
+	assert.NotContains(t, client.data, throughputKey(start.Unix(), "first"))
+	assert.Contains(t, client.data, throughputKey(start.Unix(), "second"))
+}
+
+func TestProbabilities(t *testing.T) {
+	now := time.Unix(6000, 0)
+	s := newTestStore(NewMemoryClient().(*memoryClient), "first", &now)
+
+	probabilities, err := s.GetLatestProbabilities()
+	require.NoError(t, err)
+	assert.Empty(t, probabilities)
+
+	expected := model.ServiceOperationProbabilities{"svc": {"op": 0.5}}
+	require.NoError(t, s.InsertProbabilitiesAndQPS("first", expected, model.ServiceOperationQPS{"svc": {"op": 2}}))
+	probabilities, err = s.GetLatestProbabilities()
+	require.NoError(t, err)
+	assert.Equal(t, expected, probabilities)
+}
+
+func TestLock(t *testing.T) {
+	client := NewMemoryClient().(*memoryClient)
+	now := time.Unix(6000, 0)
+	first := newTestStore(client, "first", &now)
+	second := newTestStore(client, "second", &now)
+
+	acquire := func(s *SamplingStore) bool {
+		acquired, err := s.Acquire("leader", time.Minute)
+		require.NoError(t, err)
+		return acquired
+	}
+
+	// the collectors register on their first attempt
+	assert.False(t, acquire(second))
+	assert.False(t, acquire(first))
+
+	// the candidate with the lowest hostname is the leader
+	assert.True(t, acquire(second))
+	assert.True(t, acquire(first))
+	assert.False(t, acquire(second))
+	assert.True(t, acquire(first))
+
+	forfeited, err := second.Forfeit("leader")
+	require.NoError(t, err)
+	assert.True(t, forfeited)
+	forfeited, err = second.Forfeit("leader")
+	require.NoError(t, err)
+	assert.False(t, forfeited)
+
+	// the candidacy of the first collector expired
+	now = now.Add(3 * time.Minute)
+	assert.True(t, acquire(second))
+	assert.True(t, acquire(first))
+	assert.False(t, acquire(second))
+
+	forfeited, err = first.Forfeit("leader")
+	require.NoError(t, err)
+	assert.True(t, forfeited)
+	assert.True(t, acquire(second))
+}
+
+func TestMemoryClient(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryClient()
+
+	v, err := c.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, v)
+
+	require.NoError(t, c.Set(ctx, "key", []byte("value")))
+	v, err = c.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), v)
+
+	require.NoError(t, c.Delete(ctx, "key"))
+	v, err = c.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, v)
+	assert.NoError(t, c.Close(ctx))
+}
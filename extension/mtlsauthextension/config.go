@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtlsauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension"
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+var (
+	errEmptyIdentity      = errors.New("allowed identity must not be empty")
+	errInvalidSPIFFEID    = errors.New("SPIFFE ID must be a URI with the 'spiffe' scheme and a trust domain")
+	errInvalidTrustDomain = errors.New("trust domain must be a host name, without scheme or path")
+)
+
+// Config restricts which client certificates are accepted. When no identity is allowed explicitly,
+// any client certificate verified by the receiver's TLS configuration is accepted.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"`
+
+	// AllowedCommonNames are the accepted common names of the certificate subject.
+	AllowedCommonNames []string `mapstructure:"allowed_common_names,omitempty"`
+
+	// AllowedDNSNames are the accepted DNS subject alternative names.
+	AllowedDNSNames []string `mapstructure:"allowed_dns_names,omitempty"`
+
+	// AllowedSPIFFEIDs are the accepted SPIFFE IDs, e.g. "spiffe://example.org/ns/prod/sa/agent".
+	AllowedSPIFFEIDs []string `mapstructure:"allowed_spiffe_ids,omitempty"`
+
+	// AllowedTrustDomains accepts any SPIFFE ID belonging to one of the trust domains, e.g. "example.org".
+	AllowedTrustDomains []string `mapstructure:"allowed_trust_domains,omitempty"`
+}
+
+var _ config.Extension = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	for _, list := range [][]string{cfg.AllowedCommonNames, cfg.AllowedDNSNames} {
+		for _, identity := range list {
+			if identity == "" {
+				return errEmptyIdentity
+			}
+		}
+	}
+	for _, id := range cfg.AllowedSPIFFEIDs {
+		if _, err := parseSPIFFEID(id); err != nil {
+			return fmt.Errorf("invalid allowed SPIFFE ID %q: %w", id, err)
+		}
+	}
+	for _, td := range cfg.AllowedTrustDomains {
+		if td == "" || strings.ContainsAny(td, ":/") {
+			return fmt.Errorf("invalid allowed trust domain %q: %w", td, errInvalidTrustDomain)
+		}
+	}
+	return nil
+}
+
+// allowsAny reports whether no allowlist is configured
+func (cfg *Config) allowsAny() bool {
+	return len(cfg.AllowedCommonNames) == 0 && len(cfg.AllowedDNSNames) == 0 &&
+		len(cfg.AllowedSPIFFEIDs) == 0 && len(cfg.AllowedTrustDomains) == 0
+}
+
+// parseSPIFFEID parses the URI and checks that it is a SPIFFE ID
+func parseSPIFFEID(id string) (*url.URL, error) {
+	u, err := url.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	if !isSPIFFEID(u) {
+		return nil, errInvalidSPIFFEID
+	}
+	return u, nil
+}
+
+func isSPIFFEID(u *url.URL) bool {
+	return strings.EqualFold(u.Scheme, "spiffe") && u.Host != "" && u.User == nil &&
+		u.RawQuery == "" && u.Fragment == ""
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtlsauthextension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id          config.ComponentID
+		expected    config.Extension
+		expectedErr string
+	}{
+		{
+			id:       config.NewComponentID(typeStr),
+			expected: NewFactory().CreateDefaultConfig(),
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "allowlist"),
+			expected: &Config{
+				ExtensionSettings:   config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				AllowedCommonNames:  []string{"agent"},
+				AllowedDNSNames:     []string{"agent.example.org"},
+				AllowedSPIFFEIDs:    []string{"spiffe://example.org/ns/prod/sa/agent"},
+				AllowedTrustDomains: []string{"staging.example.org"},
+			},
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "invalid_spiffe_id"),
+			expectedErr: `invalid allowed SPIFFE ID "https://example.org/agent": ` + errInvalidSPIFFEID.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalExtension(sub, cfg))
+			if tt.expectedErr != "" {
+				assert.EqualError(t, cfg.Validate(), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		expected string
+	}{
+		{
+			name:     "empty common name",
+			config:   Config{AllowedCommonNames: []string{""}},
+			expected: errEmptyIdentity.Error(),
+		},
+		{
+			name:     "empty DNS name",
+			config:   Config{AllowedDNSNames: []string{"agent.example.org", ""}},
+			expected: errEmptyIdentity.Error(),
+		},
+		{
+			name:     "SPIFFE ID without trust domain",
+			config:   Config{AllowedSPIFFEIDs: []string{"spiffe:///agent"}},
+			expected: `invalid allowed SPIFFE ID "spiffe:///agent": ` + errInvalidSPIFFEID.Error(),
+		},
+		{
+			name:     "trust domain with scheme",
+			config:   Config{AllowedTrustDomains: []string{"spiffe://example.org"}},
+			expected: `invalid allowed trust domain "spiffe://example.org": ` + errInvalidTrustDomain.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.config.Validate(), tt.expected)
+		})
+	}
+}
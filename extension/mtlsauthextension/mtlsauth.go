@@ -0,0 +1,230 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtlsauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension"
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/connctx"
+)
+
+var (
+	errNoTLSConnection     = errors.New("the request was not received over a TLS connection")
+	errNoClientCertificate = errors.New("no verified client certificate")
+	errIdentityNotAllowed  = errors.New("client certificate identity is not allowed")
+)
+
+// mtlsAuth is an implementation of configauth.ServerAuthenticator. It authenticates requests
+// by the client certificate verified during the TLS handshake, and exposes its identity.
+type mtlsAuth struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	commonNames  map[string]struct{}
+	dnsNames     map[string]struct{}
+	spiffeIDs    map[string]struct{}
+	trustDomains map[string]struct{}
+}
+
+var _ configauth.ServerAuthenticator = (*mtlsAuth)(nil)
+
+func newMTLSAuth(cfg *Config, logger *zap.Logger) *mtlsAuth {
+	a := &mtlsAuth{
+		cfg:          cfg,
+		logger:       logger,
+		commonNames:  toSet(cfg.AllowedCommonNames, false),
+		dnsNames:     toSet(cfg.AllowedDNSNames, true),
+		spiffeIDs:    map[string]struct{}{},
+		trustDomains: toSet(cfg.AllowedTrustDomains, true),
+	}
+	for _, id := range cfg.AllowedSPIFFEIDs {
+		// the configuration has been validated, so the ID can be parsed
+		if u, err := parseSPIFFEID(id); err == nil {
+			a.spiffeIDs[normalizeSPIFFEID(u)] = struct{}{}
+		}
+	}
+	return a
+}
+
+func toSet(values []string, caseInsensitive bool) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		if caseInsensitive {
+			v = strings.ToLower(v)
+		}
+		set[v] = struct{}{}
+	}
+	return set
+}
+
+// normalizeSPIFFEID lowercases the scheme and trust domain, which are case-insensitive,
+// while the path is compared as is
+func normalizeSPIFFEID(u *url.URL) string {
+	return "spiffe://" + strings.ToLower(u.Host) + u.EscapedPath()
+}
+
+// Start does nothing besides reporting when any verified client certificate is accepted
+func (a *mtlsAuth) Start(context.Context, component.Host) error {
+	if a.cfg.allowsAny() {
+		a.logger.Info("no allowed identities are configured, any verified client certificate is accepted")
+	}
+	return nil
+}
+
+// Shutdown does nothing
+func (a *mtlsAuth) Shutdown(context.Context) error {
+	return nil
+}
+
+// ConnContext adds the connection to the context of the HTTP requests it carries, so that their
+// client certificate can be authenticated. The HTTP receivers of this repository set it as the ConnContext
+// of their http.Server, other HTTP servers must set it too.
+func ConnContext(ctx context.Context, c net.Conn) context.Context {
+	return connctx.ConnContext(ctx, c)
+}
+
+// connectionState returns the state of the TLS connection a gRPC or an HTTP request was received from.
+func connectionState(ctx context.Context) (tls.ConnectionState, bool) {
+	if p, ok := peer.FromContext(ctx); ok {
+		tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+		return tlsInfo.State, ok
+	}
+	return connctx.TLSConnectionState(ctx)
+}
+
+// Authenticate checks the identity of the verified client certificate of the connection,
+// and adds it to the client info. The headers are not used.
+func (a *mtlsAuth) Authenticate(ctx context.Context, _ map[string][]string) (context.Context, error) {
+	state, ok := connectionState(ctx)
+	if !ok {
+		return ctx, errNoTLSConnection
+	}
+	// only certificates which were verified against the client CA are trusted,
+	// certificates which were merely presented by the client are not
+	if len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return ctx, errNoClientCertificate
+	}
+
+	data := newAuthData(state.VerifiedChains[0][0])
+	if !a.allowed(data) {
+		return ctx, errIdentityNotAllowed
+	}
+
+	cl := client.FromContext(ctx)
+	cl.Auth = data
+	return client.NewContext(ctx, cl), nil
+}
+
+// allowed checks whether any identity of the certificate is allowed
+func (a *mtlsAuth) allowed(data *authData) bool {
+	if a.cfg.allowsAny() {
+		return true
+	}
+	if _, ok := a.commonNames[data.commonName]; ok {
+		return true
+	}
+	for _, name := range data.dnsNames {
+		if _, ok := a.dnsNames[strings.ToLower(name)]; ok {
+			return true
+		}
+	}
+	for _, u := range data.spiffeURIs {
+		if _, ok := a.spiffeIDs[normalizeSPIFFEID(u)]; ok {
+			return true
+		}
+		if _, ok := a.trustDomains[strings.ToLower(u.Host)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+var _ client.AuthData = (*authData)(nil)
+
+// authData exposes the identity of a client certificate
+type authData struct {
+	subject        string
+	commonName     string
+	dnsNames       []string
+	emailAddresses []string
+	ipAddresses    []string
+	uris           []string
+	spiffeIDs      []string
+	spiffeURIs     []*url.URL
+}
+
+func newAuthData(cert *x509.Certificate) *authData {
+	data := &authData{
+		subject:        cert.Subject.String(),
+		commonName:     cert.Subject.CommonName,
+		dnsNames:       cert.DNSNames,
+		emailAddresses: cert.EmailAddresses,
+	}
+	for _, ip := range cert.IPAddresses {
+		data.ipAddresses = append(data.ipAddresses, ip.String())
+	}
+	for _, u := range cert.URIs {
+		data.uris = append(data.uris, u.String())
+		if isSPIFFEID(u) {
+			data.spiffeIDs = append(data.spiffeIDs, u.String())
+			data.spiffeURIs = append(data.spiffeURIs, u)
+		}
+	}
+	return data
+}
+
+func (a *authData) GetAttribute(name string) interface{} {
+	switch name {
+	case "subject":
+		return a.subject
+	case "common_name":
+		return a.commonName
+	case "dns_names":
+		return a.dnsNames
+	case "email_addresses":
+		return a.emailAddresses
+	case "ip_addresses":
+		return a.ipAddresses
+	case "uris":
+		return a.uris
+	case "spiffe_id":
+		// an X.509 SVID carries exactly one SPIFFE ID
+		if len(a.spiffeIDs) == 0 {
+			return nil
+		}
+		return a.spiffeIDs[0]
+	case "spiffe_ids":
+		return a.spiffeIDs
+	default:
+		return nil
+	}
+}
+
+func (*authData) GetAttributeNames() []string {
+	return []string{"subject", "common_name", "dns_names", "email_addresses", "ip_addresses", "uris", "spiffe_id", "spiffe_ids"}
+}
@@ -0,0 +1,389 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtlsauthextension
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+)
+
+func newCertificate(t *testing.T, commonName string, dnsNames []string, uris ...string) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{"Example"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     dnsNames,
+		IPAddresses:  []net.IP{net.ParseIP("10.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	for _, uri := range uris {
+		u, err := url.Parse(uri)
+		require.NoError(t, err)
+		template.URIs = append(template.URIs, u)
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func contextWithCertificate(cert *x509.Certificate) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{cert},
+				VerifiedChains:   [][]*x509.Certificate{{cert}},
+			},
+		},
+	})
+}
+
+func TestAuthenticateExposesIdentity(t *testing.T) {
+	auth := newMTLSAuth(&Config{}, zap.NewNop())
+	require.NoError(t, auth.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, auth.Shutdown(context.Background())) }()
+
+	cert := newCertificate(t, "agent", []string{"agent.example.org"},
+		"spiffe://example.org/ns/prod/sa/agent", "https://example.org/agent")
+	ctx, err := auth.Authenticate(contextWithCertificate(cert), nil)
+	require.NoError(t, err)
+
+	data := client.FromContext(ctx).Auth
+	require.NotNil(t, data)
+	assert.Equal(t, "CN=agent,O=Example", data.GetAttribute("subject"))
+	assert.Equal(t, "agent", data.GetAttribute("common_name"))
+	assert.Equal(t, []string{"agent.example.org"}, data.GetAttribute("dns_names"))
+	assert.Equal(t, []string{"10.0.0.1"}, data.GetAttribute("ip_addresses"))
+	assert.Equal(t, []string{"spiffe://example.org/ns/prod/sa/agent", "https://example.org/agent"}, data.GetAttribute("uris"))
+	assert.Equal(t, "spiffe://example.org/ns/prod/sa/agent", data.GetAttribute("spiffe_id"))
+	assert.Equal(t, []string{"spiffe://example.org/ns/prod/sa/agent"}, data.GetAttribute("spiffe_ids"))
+	assert.Nil(t, data.GetAttribute("unknown"))
+	assert.Contains(t, data.GetAttributeNames(), "spiffe_id")
+}
+
+func TestAuthenticateWithoutSPIFFEID(t *testing.T) {
+	auth := newMTLSAuth(&Config{}, zap.NewNop())
+
+	ctx, err := auth.Authenticate(contextWithCertificate(newCertificate(t, "agent", nil)), nil)
+	require.NoError(t, err)
+
+	data := client.FromContext(ctx).Auth
+	assert.Nil(t, data.GetAttribute("spiffe_id"))
+	assert.Empty(t, data.GetAttribute("spiffe_ids"))
+}
+
+func TestAuthenticateAllowlist(t *testing.T) {
+	cfg := &Config{
+		AllowedCommonNames:  []string{"collector"},
+		AllowedDNSNames:     []string{"agent.example.org"},
+		AllowedSPIFFEIDs:    []string{"spiffe://Example.org/ns/prod/sa/agent"},
+		AllowedTrustDomains: []string{"staging.example.org"},
+	}
+	require.NoError(t, cfg.Validate())
+	auth := newMTLSAuth(cfg, zap.NewNop())
+
+	tests := []struct {
+		name    string
+		cert    *x509.Certificate
+		allowed bool
+	}{
+		{
+			name:    "common name",
+			cert:    newCertificate(t, "collector", nil),
+			allowed: true,
+		},
+		{
+			name:    "DNS name",
+			cert:    newCertificate(t, "other", []string{"other.example.org", "AGENT.example.org"}),
+			allowed: true,
+		},
+		{
+			name:    "SPIFFE ID",
+			cert:    newCertificate(t, "other", nil, "spiffe://example.org/ns/prod/sa/agent"),
+			allowed: true,
+		},
+		{
+			name:    "trust domain",
+			cert:    newCertificate(t, "other", nil, "spiffe://staging.example.org/ns/test/sa/any"),
+			allowed: true,
+		},
+		{
+			name: "SPIFFE ID with another path",
+			cert: newCertificate(t, "other", nil, "spiffe://example.org/ns/prod/sa/other"),
+		},
+		{
+			name: "trust domain as a non SPIFFE URI",
+			cert: newCertificate(t, "other", nil, "https://staging.example.org/agent"),
+		},
+		{
+			name: "common name differing in case",
+			cert: newCertificate(t, "Collector", nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := auth.Authenticate(contextWithCertificate(tt.cert), nil)
+			if !tt.allowed {
+				assert.ErrorIs(t, err, errIdentityNotAllowed)
+				assert.Nil(t, client.FromContext(ctx).Auth)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, client.FromContext(ctx).Auth)
+		})
+	}
+}
+
+func TestAuthenticateWithoutVerifiedCertificate(t *testing.T) {
+	auth := newMTLSAuth(&Config{}, zap.NewNop())
+	cert := newCertificate(t, "agent", nil)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected error
+	}{
+		{
+			name:     "no peer",
+			ctx:      context.Background(),
+			expected: errNoTLSConnection,
+		},
+		{
+			name:     "plaintext connection",
+			ctx:      peer.NewContext(context.Background(), &peer.Peer{}),
+			expected: errNoTLSConnection,
+		},
+		{
+			name:     "plaintext HTTP connection",
+			ctx:      ConnContext(context.Background(), &net.TCPConn{}),
+			expected: errNoTLSConnection,
+		},
+		{
+			name:     "no client certificate",
+			ctx:      peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{}}),
+			expected: errNoClientCertificate,
+		},
+		{
+			name: "unverified client certificate",
+			ctx: peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{
+				State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}},
+			}}),
+			expected: errNoClientCertificate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := auth.Authenticate(tt.ctx, map[string][]string{"x-client-cert": {"forged"}})
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+// testPKI is a CA issuing the certificates of a server and of its clients.
+type testPKI struct {
+	t    *testing.T
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pool *x509.CertPool
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Example CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &testPKI{t: t, cert: cert, key: key, pool: pool}
+}
+
+func (p *testPKI) issue(commonName string, usage x509.ExtKeyUsage) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(p.t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{commonName},
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, p.cert, &key.PublicKey, p.key)
+	require.NoError(p.t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// serverConfig requests a client certificate, and verifies it when one is sent.
+func (p *testPKI) serverConfig() *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{p.issue("localhost", x509.ExtKeyUsageServerAuth)},
+		ClientCAs:    p.pool,
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+		MinVersion:   tls.VersionTLS12,
+	}
+}
+
+// clientConfig presents a client certificate with the common name, or none when it is empty.
+func (p *testPKI) clientConfig(commonName string) *tls.Config {
+	cfg := &tls.Config{
+		RootCAs:    p.pool,
+		ServerName: "localhost",
+		MinVersion: tls.VersionTLS12,
+	}
+	if commonName != "" {
+		cfg.Certificates = []tls.Certificate{p.issue(commonName, x509.ExtKeyUsageClientAuth)}
+	}
+	return cfg
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *extensionsHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+func newAuthHost(t *testing.T) (component.Host, *configauth.Authentication) {
+	auth := newMTLSAuth(&Config{AllowedCommonNames: []string{"agent"}}, zap.NewNop())
+	require.NoError(t, auth.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, auth.Shutdown(context.Background())) })
+
+	id := config.NewComponentID(typeStr)
+	host := &extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{id: auth},
+	}
+	return host, &configauth.Authentication{AuthenticatorID: id}
+}
+
+func TestAuthenticateGRPC(t *testing.T) {
+	pki := newTestPKI(t)
+	host, authentication := newAuthHost(t)
+
+	settings := configgrpc.GRPCServerSettings{Auth: authentication}
+	opts, err := settings.ToServerOption(host, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	commonNames := make(chan interface{}, 1)
+	opts = append(opts,
+		grpc.Creds(credentials.NewTLS(pki.serverConfig())),
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			commonNames <- client.FromContext(ctx).Auth.GetAttribute("common_name")
+			return handler(ctx, req)
+		}),
+	)
+	srv := grpc.NewServer(opts...)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(srv.Stop)
+
+	check := func(commonName string) error {
+		conn, err := grpc.Dial(ln.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(pki.clientConfig(commonName))))
+		require.NoError(t, err)
+		defer conn.Close()
+		_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+		return err
+	}
+
+	require.NoError(t, check("agent"))
+	assert.Equal(t, "agent", <-commonNames)
+	assert.ErrorContains(t, check("intruder"), errIdentityNotAllowed.Error())
+	assert.ErrorContains(t, check(""), errNoClientCertificate.Error())
+}
+
+func TestAuthenticateHTTP(t *testing.T) {
+	pki := newTestPKI(t)
+	host, authentication := newAuthHost(t)
+
+	settings := confighttp.HTTPServerSettings{Auth: authentication}
+	srv, err := settings.ToServer(host, componenttest.NewNopTelemetrySettings(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, client.FromContext(r.Context()).Auth.GetAttribute("common_name"))
+	}))
+	require.NoError(t, err)
+	srv.ConnContext = ConnContext
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	go func() { _ = srv.Serve(tls.NewListener(ln, pki.serverConfig())) }()
+	t.Cleanup(func() { assert.NoError(t, srv.Close()) })
+
+	get := func(commonName string) (int, string) {
+		transport := &http.Transport{TLSClientConfig: pki.clientConfig(commonName)}
+		defer transport.CloseIdleConnections()
+		resp, err := (&http.Client{Transport: transport}).Get("https://" + ln.Addr().String())
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	status, body := get("agent")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "agent", body)
+	status, _ = get("intruder")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	status, _ = get("")
+	assert.Equal(t, http.StatusUnauthorized, status)
+}
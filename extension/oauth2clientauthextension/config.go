@@ -16,6 +16,7 @@ package oauth2clientauthextension // import "github.com/open-telemetry/opentelem
 
 import (
 	"errors"
+	"fmt"
 	"net/url"
 	"time"
 
@@ -23,16 +24,33 @@ import (
 	"go.opentelemetry.io/collector/config/configtls"
 )
 
+const (
+	grantTypeClientCredentials = "client_credentials"
+	grantTypeJWTBearer         = "jwt_bearer"
+	grantTypeTokenExchange     = "token_exchange"
+)
+
 var (
-	errNoClientIDProvided     = errors.New("no ClientID provided in the OAuth2 exporter configuration")
-	errNoTokenURLProvided     = errors.New("no TokenURL provided in OAuth Client Credentials configuration")
-	errNoClientSecretProvided = errors.New("no ClientSecret provided in OAuth Client Credentials configuration")
+	errNoClientIDProvided          = errors.New("no ClientID provided in the OAuth2 exporter configuration")
+	errNoTokenURLProvided          = errors.New("no TokenURL provided in OAuth Client Credentials configuration")
+	errNoClientSecretProvided      = errors.New("no ClientSecret provided in OAuth Client Credentials configuration")
+	errConflictingClientSecret     = errors.New("only one of ClientSecret and ClientSecretFile can be provided")
+	errUnsupportedGrantType        = errors.New("unsupported GrantType, must be one of 'client_credentials', 'jwt_bearer' or 'token_exchange'")
+	errNoPrivateKeyProvided        = errors.New("no PrivateKeyFile provided in OAuth JWT Bearer configuration")
+	errNoIssuerProvided            = errors.New("no Issuer or ClientID provided in OAuth JWT Bearer configuration")
+	errUnsupportedSigningAlgorithm = errors.New("unsupported SigningAlgorithm in OAuth JWT Bearer configuration")
+	errNoSubjectTokenProvided      = errors.New("no SubjectTokenFile provided in OAuth Token Exchange configuration")
+	errNoActorTokenTypeProvided    = errors.New("no ActorTokenType provided for the ActorTokenFile in OAuth Token Exchange configuration")
 )
 
 // Config stores the configuration for OAuth2 Client Credentials (2-legged OAuth2 flow) setup.
 type Config struct {
 	config.ExtensionSettings `mapstructure:",squash"`
 
+	// GrantType is the grant used to obtain tokens: "client_credentials" (default),
+	// "jwt_bearer" or "token_exchange".
+	GrantType string `mapstructure:"grant_type,omitempty"`
+
 	// ClientID is the application's ID.
 	// See https://datatracker.ietf.org/doc/html/rfc6749#section-2.2
 	ClientID string `mapstructure:"client_id"`
@@ -41,6 +59,10 @@ type Config struct {
 	// See https://datatracker.ietf.org/doc/html/rfc6749#section-2.3.1
 	ClientSecret string `mapstructure:"client_secret"`
 
+	// ClientSecretFile is the path of a file holding the application's secret.
+	// The file is reloaded when it changes.
+	ClientSecretFile string `mapstructure:"client_secret_file,omitempty"`
+
 	// EndpointParams specifies additional parameters for requests to the token endpoint.
 	EndpointParams url.Values `mapstructure:"endpoint_params"`
 
@@ -59,18 +81,109 @@ type Config struct {
 	// Timeout parameter configures `http.Client.Timeout` for the underneath client to authorization
 	// server while fetching and refreshing tokens.
 	Timeout time.Duration `mapstructure:"timeout,omitempty"`
+
+	// JWTBearer configures the JWT bearer grant.
+	// See https://datatracker.ietf.org/doc/html/rfc7523#section-2.1
+	JWTBearer JWTBearerConfig `mapstructure:"jwt_bearer,omitempty"`
+
+	// TokenExchange configures the token exchange grant.
+	// See https://datatracker.ietf.org/doc/html/rfc8693
+	TokenExchange TokenExchangeConfig `mapstructure:"token_exchange,omitempty"`
+}
+
+// JWTBearerConfig configures the JWT assertion sent as authorization grant.
+type JWTBearerConfig struct {
+	// PrivateKeyFile is the path of a PEM encoded RSA, ECDSA or Ed25519 private key signing
+	// the assertion. The file is reloaded when it changes.
+	PrivateKeyFile string `mapstructure:"private_key_file"`
+
+	// SigningAlgorithm is the JWS algorithm signing the assertion, e.g. "RS256" or "ES256".
+	// Defaults to the algorithm matching the private key.
+	SigningAlgorithm string `mapstructure:"signing_algorithm,omitempty"`
+
+	// KeyID is set as the "kid" header of the assertion.
+	KeyID string `mapstructure:"key_id,omitempty"`
+
+	// Issuer is the "iss" claim of the assertion. Defaults to the ClientID.
+	Issuer string `mapstructure:"issuer,omitempty"`
+
+	// Subject is the "sub" claim of the assertion. Defaults to the Issuer.
+	Subject string `mapstructure:"subject,omitempty"`
+
+	// Audience is the "aud" claim of the assertion. Defaults to the TokenURL.
+	Audience string `mapstructure:"audience,omitempty"`
+
+	// Expiration is the lifetime of the assertion. Defaults to 5 minutes.
+	Expiration time.Duration `mapstructure:"expiration,omitempty"`
+
+	// Claims are additional claims of the assertion.
+	Claims map[string]interface{} `mapstructure:"claims,omitempty"`
+}
+
+// TokenExchangeConfig configures the token exchanged for an access token.
+type TokenExchangeConfig struct {
+	// SubjectTokenFile is the path of a file holding the token to exchange, e.g. a
+	// Kubernetes service account token. The file is reloaded when it changes.
+	SubjectTokenFile string `mapstructure:"subject_token_file"`
+
+	// SubjectTokenType is the type of the subject token.
+	// Defaults to "urn:ietf:params:oauth:token-type:jwt".
+	SubjectTokenType string `mapstructure:"subject_token_type,omitempty"`
+
+	// ActorTokenFile is the path of a file holding a token representing the acting party.
+	// The file is reloaded when it changes.
+	ActorTokenFile string `mapstructure:"actor_token_file,omitempty"`
+
+	// ActorTokenType is the type of the actor token, required with ActorTokenFile.
+	ActorTokenType string `mapstructure:"actor_token_type,omitempty"`
+
+	// RequestedTokenType is the type of the requested token.
+	RequestedTokenType string `mapstructure:"requested_token_type,omitempty"`
+
+	// Audience are the logical names of the services the token is requested for.
+	Audience []string `mapstructure:"audience,omitempty"`
+
+	// Resource are the URIs of the services the token is requested for.
+	Resource []string `mapstructure:"resource,omitempty"`
 }
 
 var _ config.Extension = (*Config)(nil)
 
 // Validate checks if the extension configuration is valid
 func (cfg *Config) Validate() error {
-	if cfg.ClientID == "" {
-		return errNoClientIDProvided
+	if cfg.ClientSecret != "" && cfg.ClientSecretFile != "" {
+		return errConflictingClientSecret
 	}
-	if cfg.ClientSecret == "" {
-		return errNoClientSecretProvided
+
+	switch cfg.GrantType {
+	case "", grantTypeClientCredentials:
+		if cfg.ClientID == "" {
+			return errNoClientIDProvided
+		}
+		if cfg.ClientSecret == "" && cfg.ClientSecretFile == "" {
+			return errNoClientSecretProvided
+		}
+	case grantTypeJWTBearer:
+		if cfg.JWTBearer.PrivateKeyFile == "" {
+			return errNoPrivateKeyProvided
+		}
+		if cfg.JWTBearer.Issuer == "" && cfg.ClientID == "" {
+			return errNoIssuerProvided
+		}
+		if alg := cfg.JWTBearer.SigningAlgorithm; alg != "" && !isSupportedSigningAlgorithm(alg) {
+			return fmt.Errorf("%w: %q", errUnsupportedSigningAlgorithm, alg)
+		}
+	case grantTypeTokenExchange:
+		if cfg.TokenExchange.SubjectTokenFile == "" {
+			return errNoSubjectTokenProvided
+		}
+		if cfg.TokenExchange.ActorTokenFile != "" && cfg.TokenExchange.ActorTokenType == "" {
+			return errNoActorTokenTypeProvided
+		}
+	default:
+		return fmt.Errorf("%w: %q", errUnsupportedGrantType, cfg.GrantType)
 	}
+
 	if cfg.TokenURL == "" {
 		return errNoTokenURLProvided
 	}
@@ -68,6 +68,63 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "secretfile"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				ClientID:          "someclientid",
+				ClientSecretFile:  "/var/run/secrets/oauth2/client-secret",
+				TokenURL:          "https://example.com/oauth2/default/v1/token",
+			},
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "jwtbearer"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				GrantType:         grantTypeJWTBearer,
+				ClientID:          "someclientid",
+				TokenURL:          "https://example.com/oauth2/default/v1/token",
+				Scopes:            []string{"api.metrics"},
+				JWTBearer: JWTBearerConfig{
+					PrivateKeyFile:   "/var/run/secrets/oauth2/private-key.pem",
+					SigningAlgorithm: "ES256",
+					KeyID:            "somekeyid",
+					Subject:          "someserviceaccount",
+					Audience:         "https://example.com",
+					Expiration:       time.Minute,
+					Claims:           map[string]interface{}{"tenant": "sometenant"},
+				},
+			},
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "tokenexchange"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				GrantType:         grantTypeTokenExchange,
+				TokenURL:          "https://example.com/oauth2/default/v1/token",
+				TokenExchange: TokenExchangeConfig{
+					SubjectTokenFile:   "/var/run/secrets/tokens/token",
+					RequestedTokenType: "urn:ietf:params:oauth:token-type:access_token",
+					Audience:           []string{"someaudience"},
+				},
+			},
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "unsupportedgrant"),
+			expectedErr: errUnsupportedGrantType,
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "conflictingsecret"),
+			expectedErr: errConflictingClientSecret,
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "missingprivatekey"),
+			expectedErr: errNoPrivateKeyProvided,
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "missingsubjecttoken"),
+			expectedErr: errNoSubjectTokenProvided,
+		},
 		{
 			id:          config.NewComponentIDWithName(typeStr, "missingurl"),
 			expectedErr: errNoTokenURLProvided,
@@ -99,3 +156,69 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *Config
+		expectedErr error
+	}{
+		{
+			name: "jwt_bearer_without_issuer",
+			config: &Config{
+				GrantType: grantTypeJWTBearer,
+				TokenURL:  "https://example.com/v1/token",
+				JWTBearer: JWTBearerConfig{PrivateKeyFile: "key.pem"},
+			},
+			expectedErr: errNoIssuerProvided,
+		},
+		{
+			name: "jwt_bearer_with_issuer",
+			config: &Config{
+				GrantType: grantTypeJWTBearer,
+				TokenURL:  "https://example.com/v1/token",
+				JWTBearer: JWTBearerConfig{PrivateKeyFile: "key.pem", Issuer: "someissuer"},
+			},
+		},
+		{
+			name: "jwt_bearer_unsupported_algorithm",
+			config: &Config{
+				GrantType: grantTypeJWTBearer,
+				ClientID:  "someclientid",
+				TokenURL:  "https://example.com/v1/token",
+				JWTBearer: JWTBearerConfig{PrivateKeyFile: "key.pem", SigningAlgorithm: "HS256"},
+			},
+			expectedErr: errUnsupportedSigningAlgorithm,
+		},
+		{
+			name: "token_exchange_without_actor_token_type",
+			config: &Config{
+				GrantType: grantTypeTokenExchange,
+				TokenURL:  "https://example.com/v1/token",
+				TokenExchange: TokenExchangeConfig{
+					SubjectTokenFile: "token",
+					ActorTokenFile:   "actor-token",
+				},
+			},
+			expectedErr: errNoActorTokenTypeProvided,
+		},
+		{
+			name: "token_exchange_without_token_url",
+			config: &Config{
+				GrantType:     grantTypeTokenExchange,
+				TokenExchange: TokenExchangeConfig{SubjectTokenFile: "token"},
+			},
+			expectedErr: errNoTokenURLProvided,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
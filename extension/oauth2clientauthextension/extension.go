@@ -18,7 +18,11 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"golang.org/x/oauth2"
@@ -27,12 +31,19 @@ import (
 	grpcOAuth "google.golang.org/grpc/credentials/oauth"
 )
 
-// clientAuthenticator provides implementation for providing client authentication using OAuth2 client credentials
-// workflow for both gRPC and HTTP clients.
+// clientAuthenticator provides implementation for providing client authentication using OAuth2 client credentials,
+// JWT bearer or token exchange workflows for both gRPC and HTTP clients.
 type clientAuthenticator struct {
+	cfg               *Config
 	clientCredentials *clientcredentials.Config
 	logger            *zap.Logger
 	client            *http.Client
+
+	// files with credentials, which are reloaded when they change
+	clientSecretFile *watchedFile
+	privateKeyFile   *watchedFile
+	subjectTokenFile *watchedFile
+	actorTokenFile   *watchedFile
 }
 
 type errorWrappingTokenSource struct {
@@ -47,14 +58,8 @@ var _ oauth2.TokenSource = (*errorWrappingTokenSource)(nil)
 var errFailedToGetSecurityToken = fmt.Errorf("failed to get security token from token endpoint")
 
 func newClientAuthenticator(cfg *Config, logger *zap.Logger) (*clientAuthenticator, error) {
-	if cfg.ClientID == "" {
-		return nil, errNoClientIDProvided
-	}
-	if cfg.ClientSecret == "" {
-		return nil, errNoClientSecretProvided
-	}
-	if cfg.TokenURL == "" {
-		return nil, errNoTokenURLProvided
+	if err := cfg.Validate(); err != nil {
+		return nil, err
 	}
 
 	transport := http.DefaultTransport.(*http.Transport).Clone()
@@ -65,7 +70,8 @@ func newClientAuthenticator(cfg *Config, logger *zap.Logger) (*clientAuthenticat
 	}
 	transport.TLSClientConfig = tlsCfg
 
-	return &clientAuthenticator{
+	ca := &clientAuthenticator{
+		cfg: cfg,
 		clientCredentials: &clientcredentials.Config{
 			ClientID:       cfg.ClientID,
 			ClientSecret:   cfg.ClientSecret,
@@ -78,7 +84,150 @@ func newClientAuthenticator(cfg *Config, logger *zap.Logger) (*clientAuthenticat
 			Transport: transport,
 			Timeout:   cfg.Timeout,
 		},
-	}, nil
+	}
+
+	if cfg.ClientSecretFile != "" {
+		if ca.clientSecretFile, err = newWatchedFile(cfg.ClientSecretFile, parseString, logger); err != nil {
+			return nil, fmt.Errorf("failed to load client secret: %w", err)
+		}
+	}
+	switch cfg.GrantType {
+	case grantTypeJWTBearer:
+		parser := signingKeyParser(cfg.JWTBearer.SigningAlgorithm)
+		if ca.privateKeyFile, err = newWatchedFile(cfg.JWTBearer.PrivateKeyFile, parser, logger); err != nil {
+			return nil, fmt.Errorf("failed to load private key: %w", err)
+		}
+	case grantTypeTokenExchange:
+		if ca.subjectTokenFile, err = newWatchedFile(cfg.TokenExchange.SubjectTokenFile, parseString, logger); err != nil {
+			return nil, fmt.Errorf("failed to load subject token: %w", err)
+		}
+		if cfg.TokenExchange.ActorTokenFile != "" {
+			if ca.actorTokenFile, err = newWatchedFile(cfg.TokenExchange.ActorTokenFile, parseString, logger); err != nil {
+				return nil, fmt.Errorf("failed to load actor token: %w", err)
+			}
+		}
+	}
+	return ca, nil
+}
+
+// files returns the configured files with credentials
+func (o *clientAuthenticator) files() []*watchedFile {
+	var files []*watchedFile
+	for _, f := range []*watchedFile{o.clientSecretFile, o.privateKeyFile, o.subjectTokenFile, o.actorTokenFile} {
+		if f != nil {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// start watches the files with credentials for changes
+func (o *clientAuthenticator) start(context.Context, component.Host) error {
+	for _, f := range o.files() {
+		if err := f.start(); err != nil {
+			return fmt.Errorf("failed to watch %q: %w", f.path, err)
+		}
+	}
+	return nil
+}
+
+// shutdown stops watching the files with credentials
+func (o *clientAuthenticator) shutdown(context.Context) error {
+	for _, f := range o.files() {
+		f.shutdown()
+	}
+	return nil
+}
+
+func (o *clientAuthenticator) clientSecret() string {
+	if o.clientSecretFile != nil {
+		return o.clientSecretFile.get().(string)
+	}
+	return o.cfg.ClientSecret
+}
+
+// tokenSource returns a TokenSource obtaining tokens with the configured grant, and reusing them until they expire
+func (o *clientAuthenticator) tokenSource() oauth2.TokenSource {
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, o.client)
+
+	var ts oauth2.TokenSource
+	switch o.cfg.GrantType {
+	case grantTypeJWTBearer:
+		ts = oauth2.ReuseTokenSource(nil, tokenSourceFunc(func() (*oauth2.Token, error) {
+			return o.jwtBearerToken(ctx)
+		}))
+	case grantTypeTokenExchange:
+		ts = oauth2.ReuseTokenSource(nil, tokenSourceFunc(func() (*oauth2.Token, error) {
+			return o.tokenExchangeToken(ctx)
+		}))
+	default:
+		if o.clientSecretFile == nil {
+			ts = o.clientCredentials.TokenSource(ctx)
+			break
+		}
+		// the secret may have changed since the previous token was requested
+		ts = oauth2.ReuseTokenSource(nil, tokenSourceFunc(func() (*oauth2.Token, error) {
+			cc := *o.clientCredentials
+			cc.ClientSecret = o.clientSecret()
+			return cc.Token(ctx)
+		}))
+	}
+
+	return errorWrappingTokenSource{
+		ts:       ts,
+		tokenURL: o.cfg.TokenURL,
+	}
+}
+
+// grantParams returns the parameters common to all grants
+func (o *clientAuthenticator) grantParams(grantType string) url.Values {
+	params := url.Values{"grant_type": {grantType}}
+	for k, v := range o.cfg.EndpointParams {
+		params[k] = append([]string(nil), v...)
+	}
+	if len(o.cfg.Scopes) > 0 {
+		params.Set("scope", strings.Join(o.cfg.Scopes, " "))
+	}
+	return params
+}
+
+// jwtBearerToken requests a token with a signed JWT as authorization grant.
+// See https://datatracker.ietf.org/doc/html/rfc7523#section-2.1
+func (o *clientAuthenticator) jwtBearerToken(ctx context.Context) (*oauth2.Token, error) {
+	assertion, err := newAssertion(o.cfg, o.privateKeyFile.get().(*signingKey), time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign assertion: %w", err)
+	}
+	params := o.grantParams(jwtBearerGrantType)
+	params.Set("assertion", assertion)
+	return retrieveToken(ctx, o.client, o.cfg.TokenURL, o.cfg.ClientID, o.clientSecret(), params)
+}
+
+// tokenExchangeToken requests a token in exchange for the subject token.
+// See https://datatracker.ietf.org/doc/html/rfc8693#section-2.1
+func (o *clientAuthenticator) tokenExchangeToken(ctx context.Context) (*oauth2.Token, error) {
+	cfg := o.cfg.TokenExchange
+	params := o.grantParams(tokenExchangeGrantType)
+	params.Set("subject_token", o.subjectTokenFile.get().(string))
+	subjectTokenType := cfg.SubjectTokenType
+	if subjectTokenType == "" {
+		subjectTokenType = jwtTokenType
+	}
+	params.Set("subject_token_type", subjectTokenType)
+	if o.actorTokenFile != nil {
+		params.Set("actor_token", o.actorTokenFile.get().(string))
+		params.Set("actor_token_type", cfg.ActorTokenType)
+	}
+	if cfg.RequestedTokenType != "" {
+		params.Set("requested_token_type", cfg.RequestedTokenType)
+	}
+	for _, audience := range cfg.Audience {
+		params.Add("audience", audience)
+	}
+	for _, resource := range cfg.Resource {
+		params.Add("resource", resource)
+	}
+	return retrieveToken(ctx, o.client, o.cfg.TokenURL, o.cfg.ClientID, o.clientSecret(), params)
 }
 
 func (ewts errorWrappingTokenSource) Token() (*oauth2.Token, error) {
@@ -91,27 +240,19 @@ func (ewts errorWrappingTokenSource) Token() (*oauth2.Token, error) {
 	return tok, nil
 }
 
-// roundTripper returns oauth2.Transport, an http.RoundTripper that performs "client-credential" OAuth flow and
+// roundTripper returns oauth2.Transport, an http.RoundTripper that performs the configured OAuth flow and
 // also auto refreshes OAuth tokens as needed.
 func (o *clientAuthenticator) roundTripper(base http.RoundTripper) (http.RoundTripper, error) {
-	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, o.client)
 	return &oauth2.Transport{
-		Source: errorWrappingTokenSource{
-			ts:       o.clientCredentials.TokenSource(ctx),
-			tokenURL: o.clientCredentials.TokenURL,
-		},
-		Base: base,
+		Source: o.tokenSource(),
+		Base:   base,
 	}, nil
 }
 
-// perRPCCredentials returns gRPC PerRPCCredentials that supports "client-credential" OAuth flow. The underneath
-// oauth2.clientcredentials.Config instance will manage tokens performing auto refresh as necessary.
+// perRPCCredentials returns gRPC PerRPCCredentials that supports the configured OAuth flow. The underneath
+// token source will manage tokens performing auto refresh as necessary.
 func (o *clientAuthenticator) perRPCCredentials() (credentials.PerRPCCredentials, error) {
-	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, o.client)
 	return grpcOAuth.TokenSource{
-		TokenSource: errorWrappingTokenSource{
-			ts:       o.clientCredentials.TokenSource(ctx),
-			tokenURL: o.clientCredentials.TokenURL,
-		},
+		TokenSource: o.tokenSource(),
 	}, nil
 }
@@ -16,12 +16,21 @@ package oauth2clientauthextension
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtls"
@@ -296,3 +305,227 @@ func TestFailContactingOAuth(t *testing.T) {
 	assert.ErrorIs(t, err, errFailedToGetSecurityToken)
 	assert.Contains(t, err.Error(), serverURL.String())
 }
+
+// newTokenServer returns a token endpoint which checks the token request, and issues
+// tokens expiring immediately so that every request obtains a new token
+func newTokenServer(t *testing.T, check func(r *http.Request) string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		token := check(r)
+		if token == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			assert.NoError(t, json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant", "error_description": "rejected by test"}))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": token,
+			"token_type":   "Bearer",
+			"expires_in":   1,
+		}))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func writeFile(t *testing.T, path string, content []byte) {
+	// replace the file like Kubernetes does when a mounted secret is updated
+	tmp := path + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, content, 0600))
+	require.NoError(t, os.Rename(tmp, path))
+}
+
+// requireToken waits until the authenticator sends the expected token
+func requireToken(t *testing.T, ca *clientAuthenticator, expected string) {
+	ts := ca.tokenSource()
+	assert.Eventually(t, func() bool {
+		token, err := ts.Token()
+		return err == nil && token.AccessToken == expected
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestClientSecretFile(t *testing.T) {
+	server := newTokenServer(t, func(r *http.Request) string {
+		id, secret, _ := r.BasicAuth()
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "someclientid", id)
+		return "token-for-" + secret
+	})
+
+	secretFile := filepath.Join(t.TempDir(), "client-secret")
+	require.NoError(t, os.WriteFile(secretFile, []byte("first-secret\n"), 0600))
+
+	ca, err := newClientAuthenticator(&Config{
+		ClientID:         "someclientid",
+		ClientSecretFile: secretFile,
+		TokenURL:         server.URL,
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, ca.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, ca.shutdown(context.Background())) }()
+
+	requireToken(t, ca, "token-for-first-secret")
+	writeFile(t, secretFile, []byte("second-secret"))
+	requireToken(t, ca, "token-for-second-secret")
+}
+
+func TestJWTBearerGrant(t *testing.T) {
+	firstKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	secondKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	var tokenURL string
+	server := newTokenServer(t, func(r *http.Request) string {
+		assert.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "api.metrics api.logs", r.PostForm.Get("scope"))
+		assert.Equal(t, "someaudience", r.PostForm.Get("audience"))
+		assert.Equal(t, "someclientid", r.PostForm.Get("client_id"))
+		_, _, hasBasicAuth := r.BasicAuth()
+		assert.False(t, hasBasicAuth)
+
+		// the token tells which key signed the assertion
+		for name, key := range map[string]*ecdsa.PrivateKey{"first": firstKey, "second": secondKey} {
+			token, err := jwt.Parse(r.PostForm.Get("assertion"), func(*jwt.Token) (interface{}, error) {
+				return &key.PublicKey, nil
+			}, jwt.WithValidMethods([]string{"ES256"}))
+			if err != nil {
+				continue
+			}
+			claims := token.Claims.(jwt.MapClaims)
+			assert.Equal(t, "someclientid", claims["iss"])
+			assert.True(t, claims.VerifyAudience(tokenURL, true))
+			return "token-signed-by-" + name
+		}
+		return ""
+	})
+	tokenURL = server.URL
+
+	keyFile := filepath.Join(t.TempDir(), "private-key.pem")
+	require.NoError(t, os.WriteFile(keyFile, encodePKCS8(t, firstKey), 0600))
+
+	ca, err := newClientAuthenticator(&Config{
+		GrantType:      grantTypeJWTBearer,
+		ClientID:       "someclientid",
+		TokenURL:       server.URL,
+		Scopes:         []string{"api.metrics", "api.logs"},
+		EndpointParams: url.Values{"audience": []string{"someaudience"}},
+		JWTBearer:      JWTBearerConfig{PrivateKeyFile: keyFile},
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, ca.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, ca.shutdown(context.Background())) }()
+
+	requireToken(t, ca, "token-signed-by-first")
+	writeFile(t, keyFile, encodePKCS8(t, secondKey))
+	requireToken(t, ca, "token-signed-by-second")
+
+	// an invalid key is not used, the previous key remains in use
+	writeFile(t, keyFile, []byte("not a key"))
+	time.Sleep(100 * time.Millisecond)
+	requireToken(t, ca, "token-signed-by-second")
+}
+
+func TestTokenExchangeGrant(t *testing.T) {
+	server := newTokenServer(t, func(r *http.Request) string {
+		id, secret, _ := r.BasicAuth()
+		assert.Equal(t, "someclientid", id)
+		assert.Equal(t, "someclientsecret", secret)
+		assert.Equal(t, "urn:ietf:params:oauth:grant-type:token-exchange", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "urn:ietf:params:oauth:token-type:jwt", r.PostForm.Get("subject_token_type"))
+		assert.Equal(t, "urn:ietf:params:oauth:token-type:access_token", r.PostForm.Get("requested_token_type"))
+		assert.Equal(t, []string{"first", "second"}, r.PostForm["audience"])
+		assert.Equal(t, "actor-token", r.PostForm.Get("actor_token"))
+		assert.Equal(t, "urn:ietf:params:oauth:token-type:id_token", r.PostForm.Get("actor_token_type"))
+		assert.Empty(t, r.PostForm.Get("client_id"))
+		return "exchanged-" + r.PostForm.Get("subject_token")
+	})
+
+	dir := t.TempDir()
+	subjectTokenFile := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(subjectTokenFile, []byte("first-subject-token"), 0600))
+	actorTokenFile := filepath.Join(dir, "actor-token")
+	require.NoError(t, os.WriteFile(actorTokenFile, []byte("actor-token"), 0600))
+
+	ca, err := newClientAuthenticator(&Config{
+		GrantType:    grantTypeTokenExchange,
+		ClientID:     "someclientid",
+		ClientSecret: "someclientsecret",
+		TokenURL:     server.URL,
+		TokenExchange: TokenExchangeConfig{
+			SubjectTokenFile:   subjectTokenFile,
+			ActorTokenFile:     actorTokenFile,
+			ActorTokenType:     "urn:ietf:params:oauth:token-type:id_token",
+			RequestedTokenType: "urn:ietf:params:oauth:token-type:access_token",
+			Audience:           []string{"first", "second"},
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, ca.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, ca.shutdown(context.Background())) }()
+
+	requireToken(t, ca, "exchanged-first-subject-token")
+	writeFile(t, subjectTokenFile, []byte("second-subject-token"))
+	requireToken(t, ca, "exchanged-second-subject-token")
+}
+
+func TestTokenEndpointError(t *testing.T) {
+	server := newTokenServer(t, func(*http.Request) string { return "" })
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("subject-token"), 0600))
+
+	ca, err := newClientAuthenticator(&Config{
+		GrantType:     grantTypeTokenExchange,
+		TokenURL:      server.URL,
+		TokenExchange: TokenExchangeConfig{SubjectTokenFile: tokenFile},
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	_, err = ca.tokenSource().Token()
+	assert.ErrorIs(t, err, errFailedToGetSecurityToken)
+	assert.ErrorContains(t, err, "invalid_grant rejected by test")
+}
+
+func TestMissingCredentialsFiles(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing")
+	tests := []struct {
+		name        string
+		settings    *Config
+		expectedErr string
+	}{
+		{
+			name: "client_secret_file",
+			settings: &Config{
+				ClientID:         "someclientid",
+				ClientSecretFile: missing,
+				TokenURL:         "https://example.com/v1/token",
+			},
+			expectedErr: "failed to load client secret",
+		},
+		{
+			name: "private_key_file",
+			settings: &Config{
+				GrantType: grantTypeJWTBearer,
+				ClientID:  "someclientid",
+				TokenURL:  "https://example.com/v1/token",
+				JWTBearer: JWTBearerConfig{PrivateKeyFile: missing},
+			},
+			expectedErr: "failed to load private key",
+		},
+		{
+			name: "subject_token_file",
+			settings: &Config{
+				GrantType:     grantTypeTokenExchange,
+				TokenURL:      "https://example.com/v1/token",
+				TokenExchange: TokenExchangeConfig{SubjectTokenFile: missing},
+			},
+			expectedErr: "failed to load subject token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newClientAuthenticator(tt.settings, zap.NewNop())
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -50,6 +50,8 @@ func createExtension(_ context.Context, set component.ExtensionCreateSettings, c
 	}
 
 	return configauth.NewClientAuthenticator(
+		configauth.WithClientStart(ca.start),
+		configauth.WithClientShutdown(ca.shutdown),
 		configauth.WithClientRoundTripper(ca.roundTripper),
 		configauth.WithPerRPCCredentials(ca.perRPCCredentials),
 	), nil
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth2clientauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/oauth2clientauthextension"
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/filewatcher"
+)
+
+var errEmptyFile = errors.New("file is empty")
+
+// watchedFile holds the parsed content of a file with credentials, which is reloaded
+// when the file changes so that rotated credentials are used without a restart.
+type watchedFile struct {
+	path   string
+	parse  func([]byte) (interface{}, error)
+	logger *zap.Logger
+
+	mu    sync.RWMutex
+	value interface{}
+
+	watcher *filewatcher.Watcher
+}
+
+// newWatchedFile reads and parses the file, failing when it cannot be loaded
+func newWatchedFile(path string, parse func([]byte) (interface{}, error), logger *zap.Logger) (*watchedFile, error) {
+	f := &watchedFile{
+		path:   path,
+		parse:  parse,
+		logger: logger,
+	}
+	if err := f.load(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// parseString returns the content of the file without surrounding whitespace
+func parseString(data []byte) (interface{}, error) {
+	value := strings.TrimSpace(string(data))
+	if value == "" {
+		return nil, errEmptyFile
+	}
+	return value, nil
+}
+
+func (f *watchedFile) load() error {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return fmt.Errorf("failed to read %q: %w", f.path, err)
+	}
+	value, err := f.parse(data)
+	if err != nil {
+		return fmt.Errorf("failed to load %q: %w", f.path, err)
+	}
+	f.mu.Lock()
+	f.value = value
+	f.mu.Unlock()
+	return nil
+}
+
+func (f *watchedFile) get() interface{} {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.value
+}
+
+// start watches the file and reloads it when it changes
+func (f *watchedFile) start() error {
+	if f.watcher != nil {
+		return fmt.Errorf("%q is already watched", f.path)
+	}
+	var err error
+	f.watcher, err = filewatcher.Start(f.path, f.logger, f.reload)
+	return err
+}
+
+// reload reloads the file, keeping the previous content if that fails
+func (f *watchedFile) reload() {
+	if err := f.load(); err != nil {
+		f.logger.Error("failed to reload credentials file, the previous content is still in use", zap.Error(err))
+		return
+	}
+	f.logger.Info("reloaded credentials file", zap.String("path", f.path))
+}
+
+// shutdown stops watching the file
+func (f *watchedFile) shutdown() {
+	if f.watcher == nil {
+		return
+	}
+	f.watcher.Stop()
+	f.watcher = nil
+}
@@ -3,6 +3,8 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/extension/oauth
 go 1.18
 
 require (
+	github.com/golang-jwt/jwt/v4 v4.4.2
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/common v0.63.0
 	github.com/stretchr/testify v1.8.1
 	go.opentelemetry.io/collector v0.63.0
 	go.uber.org/zap v1.23.0
@@ -45,8 +47,9 @@ require (
 	google.golang.org/appengine v1.6.7 // indirect
 	google.golang.org/genproto v0.0.0-20220915135415-7fd63a7952de // indirect
 	google.golang.org/protobuf v1.28.1 // indirect
-	gopkg.in/check.v1 v1.0.0-20201130134442-10cb98267c6c // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
 require go.uber.org/multierr v1.8.0
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/common => ../../internal/common
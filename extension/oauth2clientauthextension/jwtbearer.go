@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth2clientauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/oauth2clientauthextension"
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const defaultAssertionExpiration = 5 * time.Minute
+
+var supportedSigningAlgorithms = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+}
+
+func isSupportedSigningAlgorithm(alg string) bool {
+	for _, supported := range supportedSigningAlgorithms {
+		if alg == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// signingKey is a private key, together with the method signing assertions with it
+type signingKey struct {
+	key    crypto.Signer
+	method jwt.SigningMethod
+}
+
+// signingKeyParser returns a parser of PEM encoded private keys. When no algorithm is
+// configured, the algorithm is chosen from the type of the key.
+func signingKeyParser(alg string) func([]byte) (interface{}, error) {
+	return func(data []byte) (interface{}, error) {
+		key, err := parsePrivateKey(data)
+		if err != nil {
+			return nil, err
+		}
+		keyAlg := alg
+		if keyAlg == "" {
+			if keyAlg, err = defaultSigningAlgorithm(key); err != nil {
+				return nil, err
+			}
+		}
+		if err = checkKeyType(key, keyAlg); err != nil {
+			return nil, err
+		}
+		return &signingKey{key: key, method: jwt.GetSigningMethod(keyAlg)}, nil
+	}
+}
+
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM encoded private key found")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return signer, nil
+}
+
+func defaultSigningAlgorithm(key crypto.Signer) (string, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return "RS256", nil
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return "ES256", nil
+		case elliptic.P384():
+			return "ES384", nil
+		case elliptic.P521():
+			return "ES512", nil
+		}
+		return "", fmt.Errorf("unsupported elliptic curve %s", k.Curve.Params().Name)
+	case ed25519.PrivateKey:
+		return "EdDSA", nil
+	}
+	return "", fmt.Errorf("unsupported private key type %T", key)
+}
+
+// checkKeyType makes sure that the key can be used with the algorithm, so that a
+// mismatch is reported when the key is loaded rather than when a token is requested
+func checkKeyType(key crypto.Signer, alg string) error {
+	var ok bool
+	switch alg[:2] {
+	case "RS", "PS":
+		_, ok = key.(*rsa.PrivateKey)
+	case "ES":
+		var k *ecdsa.PrivateKey
+		if k, ok = key.(*ecdsa.PrivateKey); ok {
+			expected, _ := defaultSigningAlgorithm(k)
+			ok = expected == alg
+		}
+	case "Ed":
+		_, ok = key.(ed25519.PrivateKey)
+	}
+	if !ok {
+		return fmt.Errorf("private key of type %T cannot be used with signing algorithm %s", key, alg)
+	}
+	return nil
+}
+
+// newAssertion creates a signed JWT to be used as authorization grant.
+// See https://datatracker.ietf.org/doc/html/rfc7523#section-3
+func newAssertion(cfg *Config, key *signingKey, now time.Time) (string, error) {
+	issuer := cfg.JWTBearer.Issuer
+	if issuer == "" {
+		issuer = cfg.ClientID
+	}
+	subject := cfg.JWTBearer.Subject
+	if subject == "" {
+		subject = issuer
+	}
+	audience := cfg.JWTBearer.Audience
+	if audience == "" {
+		audience = cfg.TokenURL
+	}
+	expiration := cfg.JWTBearer.Expiration
+	if expiration <= 0 {
+		expiration = defaultAssertionExpiration
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	claims := jwt.MapClaims{}
+	for k, v := range cfg.JWTBearer.Claims {
+		claims[k] = v
+	}
+	claims["iss"] = issuer
+	claims["sub"] = subject
+	claims["aud"] = audience
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(expiration).Unix()
+	claims["jti"] = hex.EncodeToString(id)
+
+	token := jwt.NewWithClaims(key.method, claims)
+	if cfg.JWTBearer.KeyID != "" {
+		token.Header["kid"] = cfg.JWTBearer.KeyID
+	}
+	return token.SignedString(key.key)
+}
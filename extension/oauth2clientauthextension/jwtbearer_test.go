@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth2clientauthextension
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePKCS8(t *testing.T, key crypto.Signer) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func TestSigningKeyParser(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		pem         []byte
+		alg         string
+		expectedAlg string
+		expectedErr string
+	}{
+		{
+			name:        "rsa_pkcs1",
+			pem:         pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}),
+			expectedAlg: "RS256",
+		},
+		{
+			name:        "rsa_pkcs8_pss",
+			pem:         encodePKCS8(t, rsaKey),
+			alg:         "PS512",
+			expectedAlg: "PS512",
+		},
+		{
+			name:        "ecdsa_sec1",
+			pem:         pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER}),
+			expectedAlg: "ES384",
+		},
+		{
+			name:        "ed25519",
+			pem:         encodePKCS8(t, edKey),
+			expectedAlg: "EdDSA",
+		},
+		{
+			name:        "ecdsa_with_other_curve",
+			pem:         encodePKCS8(t, ecKey),
+			alg:         "ES256",
+			expectedErr: "cannot be used with signing algorithm ES256",
+		},
+		{
+			name:        "rsa_with_ecdsa_algorithm",
+			pem:         encodePKCS8(t, rsaKey),
+			alg:         "ES256",
+			expectedErr: "cannot be used with signing algorithm ES256",
+		},
+		{
+			name:        "not_pem",
+			pem:         []byte("not a key"),
+			expectedErr: "no PEM encoded private key found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := signingKeyParser(tt.alg)(tt.pem)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAlg, value.(*signingKey).method.Alg())
+		})
+	}
+}
+
+func TestNewAssertion(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	now := time.Now().Truncate(time.Second)
+
+	tests := []struct {
+		name     string
+		config   *Config
+		expected jwt.MapClaims
+	}{
+		{
+			name: "defaults",
+			config: &Config{
+				ClientID: "someclientid",
+				TokenURL: "https://example.com/v1/token",
+			},
+			expected: jwt.MapClaims{
+				"iss": "someclientid",
+				"sub": "someclientid",
+				"aud": "https://example.com/v1/token",
+				"exp": float64(now.Add(defaultAssertionExpiration).Unix()),
+			},
+		},
+		{
+			name: "all_settings",
+			config: &Config{
+				ClientID: "someclientid",
+				TokenURL: "https://example.com/v1/token",
+				JWTBearer: JWTBearerConfig{
+					Issuer:     "someissuer",
+					Subject:    "somesubject",
+					Audience:   "someaudience",
+					Expiration: time.Minute,
+					Claims:     map[string]interface{}{"tenant": "sometenant", "iss": "ignored"},
+				},
+			},
+			expected: jwt.MapClaims{
+				"iss":    "someissuer",
+				"sub":    "somesubject",
+				"aud":    "someaudience",
+				"exp":    float64(now.Add(time.Minute).Unix()),
+				"tenant": "sometenant",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.JWTBearer.KeyID = "somekeyid"
+			assertion, err := newAssertion(tt.config, &signingKey{key: key, method: jwt.SigningMethodES256}, now)
+			require.NoError(t, err)
+
+			token, err := jwt.Parse(assertion, func(token *jwt.Token) (interface{}, error) {
+				return &key.PublicKey, nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, "somekeyid", token.Header["kid"])
+
+			claims := token.Claims.(jwt.MapClaims)
+			assert.NotEmpty(t, claims["jti"])
+			assert.Equal(t, float64(now.Unix()), claims["iat"])
+			for k, v := range tt.expected {
+				assert.Equal(t, v, claims[k], k)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth2clientauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/oauth2clientauthextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	jwtBearerGrantType     = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+	tokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+	jwtTokenType           = "urn:ietf:params:oauth:token-type:jwt"
+
+	// maxTokenResponseSize limits how much of the token endpoint response is read
+	maxTokenResponseSize = 1 << 20
+)
+
+// tokenResponse is the response of the token endpoint, which is either a token or an error.
+// See https://datatracker.ietf.org/doc/html/rfc6749#section-5
+type tokenResponse struct {
+	AccessToken  string      `json:"access_token"`
+	TokenType    string      `json:"token_type"`
+	RefreshToken string      `json:"refresh_token"`
+	ExpiresIn    json.Number `json:"expires_in"`
+
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// tokenSourceFunc adapts a function to an oauth2.TokenSource
+type tokenSourceFunc func() (*oauth2.Token, error)
+
+func (f tokenSourceFunc) Token() (*oauth2.Token, error) {
+	return f()
+}
+
+// retrieveToken requests a token with the grant parameters from the token endpoint. The client
+// authenticates with HTTP Basic authentication when it has a secret, and is identified by the
+// client_id parameter otherwise.
+// See https://datatracker.ietf.org/doc/html/rfc6749#section-2.3.1
+func retrieveToken(ctx context.Context, client *http.Client, tokenURL, clientID, clientSecret string, params url.Values) (*oauth2.Token, error) {
+	if clientID != "" && clientSecret == "" {
+		params.Set("client_id", clientID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if clientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(clientSecret))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxTokenResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	var tr tokenResponse
+	jsonErr := json.Unmarshal(body, &tr)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		if jsonErr == nil && tr.Error != "" {
+			return nil, fmt.Errorf("token request failed with status %d: %s %s", resp.StatusCode, tr.Error, tr.ErrorDescription)
+		}
+		return nil, fmt.Errorf("token request failed with status %d", resp.StatusCode)
+	}
+	if jsonErr != nil {
+		return nil, fmt.Errorf("failed to parse token response: %w", jsonErr)
+	}
+	if tr.AccessToken == "" {
+		return nil, errors.New("token response does not contain an access token")
+	}
+
+	token := &oauth2.Token{
+		AccessToken:  tr.AccessToken,
+		TokenType:    tr.TokenType,
+		RefreshToken: tr.RefreshToken,
+	}
+	// a token which is not an access token has the "N_A" type, but is still sent as bearer token
+	// See https://datatracker.ietf.org/doc/html/rfc8693#section-2.2.1
+	if strings.EqualFold(token.TokenType, "N_A") {
+		token.TokenType = "Bearer"
+	}
+	if tr.ExpiresIn != "" {
+		expiresIn, err := strconv.ParseInt(string(tr.ExpiresIn), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expires_in in token response: %w", err)
+		}
+		if expiresIn > 0 {
+			token.Expiry = time.Now().Add(time.Duration(expiresIn) * time.Second)
+		}
+	}
+	return token, nil
+}
@@ -36,6 +36,10 @@ const (
 	PodType EndpointType = "pod"
 	// K8sNodeType is a Kubernetes Node endpoint.
 	K8sNodeType EndpointType = "k8s.node"
+	// K8sServiceType is a Kubernetes Service endpoint.
+	K8sServiceType EndpointType = "k8s.service"
+	// K8sIngressType is a Kubernetes Ingress endpoint.
+	K8sIngressType EndpointType = "k8s.ingress"
 	// HostPortType is a hostport endpoint.
 	HostPortType EndpointType = "hostport"
 	// ContainerType is a container endpoint.
@@ -46,6 +50,8 @@ var (
 	_ EndpointDetails = (*Pod)(nil)
 	_ EndpointDetails = (*Port)(nil)
 	_ EndpointDetails = (*K8sNode)(nil)
+	_ EndpointDetails = (*K8sService)(nil)
+	_ EndpointDetails = (*K8sIngress)(nil)
 	_ EndpointDetails = (*HostPort)(nil)
 	_ EndpointDetails = (*Container)(nil)
 )
@@ -273,3 +279,83 @@ func (n *K8sNode) Env() EndpointEnv {
 func (n *K8sNode) Type() EndpointType {
 	return K8sNodeType
 }
+
+// K8sService represents a Kubernetes Service object.
+type K8sService struct {
+	// Name is the name of the Kubernetes Service.
+	Name string
+	// UID is the unique ID for the service
+	UID string
+	// Namespace is the namespace of the service
+	Namespace string
+	// Annotations is an arbitrary key-value map of non-identifying, user-specified service metadata
+	Annotations map[string]string
+	// Labels is the map of identifying, user-specified service metadata
+	Labels map[string]string
+	// ServiceType is the type of the service: ClusterIP, NodePort, LoadBalancer or ExternalName
+	ServiceType string
+	// ClusterIP is the IP address of the service inside the cluster, empty for headless and ExternalName services
+	ClusterIP string
+	// Port is the port number of the service port of the endpoint, or zero for a service without ports
+	Port uint16
+	// PortName is the name of the service port of the endpoint
+	PortName string
+	// Ports is the map of the port numbers of the service by port name
+	Ports map[string]uint16
+}
+
+func (s *K8sService) Env() EndpointEnv {
+	return map[string]interface{}{
+		"name":         s.Name,
+		"uid":          s.UID,
+		"namespace":    s.Namespace,
+		"annotations":  s.Annotations,
+		"labels":       s.Labels,
+		"service_type": s.ServiceType,
+		"cluster_ip":   s.ClusterIP,
+		"port":         s.Port,
+		"port_name":    s.PortName,
+		"ports":        s.Ports,
+	}
+}
+
+func (s *K8sService) Type() EndpointType {
+	return K8sServiceType
+}
+
+// K8sIngress represents a path of a rule of a Kubernetes Ingress object.
+type K8sIngress struct {
+	// Name is the name of the Kubernetes Ingress.
+	Name string
+	// UID is the unique ID for the ingress
+	UID string
+	// Namespace is the namespace of the ingress
+	Namespace string
+	// Annotations is an arbitrary key-value map of non-identifying, user-specified ingress metadata
+	Annotations map[string]string
+	// Labels is the map of identifying, user-specified ingress metadata
+	Labels map[string]string
+	// Scheme is https if the host of the rule is covered by the TLS configuration of the ingress, http otherwise
+	Scheme string
+	// Host is the host of the rule, or the address of the load balancer if the rule has no host
+	Host string
+	// Path is the path of the rule
+	Path string
+}
+
+func (i *K8sIngress) Env() EndpointEnv {
+	return map[string]interface{}{
+		"name":        i.Name,
+		"uid":         i.UID,
+		"namespace":   i.Namespace,
+		"annotations": i.Annotations,
+		"labels":      i.Labels,
+		"scheme":      i.Scheme,
+		"host":        i.Host,
+		"path":        i.Path,
+	}
+}
+
+func (i *K8sIngress) Type() EndpointType {
+	return K8sIngressType
+}
@@ -211,6 +211,88 @@ func TestEndpointEnv(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Kubernetes Service",
+			endpoint: Endpoint{
+				ID:     EndpointID("k8s_service_endpoint_id"),
+				Target: "redis.default.svc:6379",
+				Details: &K8sService{
+					Name:      "redis",
+					UID:       "redis-uid",
+					Namespace: "default",
+					Annotations: map[string]string{
+						"annotation_key": "annotation_val",
+					},
+					Labels: map[string]string{
+						"label_key": "label_val",
+					},
+					ServiceType: "ClusterIP",
+					ClusterIP:   "10.0.0.1",
+					Port:        6379,
+					PortName:    "redis",
+					Ports:       map[string]uint16{"redis": 6379, "metrics": 9121},
+				},
+			},
+			want: EndpointEnv{
+				"type":         "k8s.service",
+				"id":           "k8s_service_endpoint_id",
+				"endpoint":     "redis.default.svc:6379",
+				"name":         "redis",
+				"uid":          "redis-uid",
+				"namespace":    "default",
+				"service_type": "ClusterIP",
+				"cluster_ip":   "10.0.0.1",
+				"port":         uint16(6379),
+				"port_name":    "redis",
+				"ports":        map[string]uint16{"redis": 6379, "metrics": 9121},
+				"annotations": map[string]string{
+					"annotation_key": "annotation_val",
+				},
+				"labels": map[string]string{
+					"label_key": "label_val",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Kubernetes Ingress",
+			endpoint: Endpoint{
+				ID:     EndpointID("k8s_ingress_endpoint_id"),
+				Target: "https://example.com/api",
+				Details: &K8sIngress{
+					Name:      "api",
+					UID:       "api-uid",
+					Namespace: "default",
+					Annotations: map[string]string{
+						"annotation_key": "annotation_val",
+					},
+					Labels: map[string]string{
+						"label_key": "label_val",
+					},
+					Scheme: "https",
+					Host:   "example.com",
+					Path:   "/api",
+				},
+			},
+			want: EndpointEnv{
+				"type":      "k8s.ingress",
+				"id":        "k8s_ingress_endpoint_id",
+				"endpoint":  "https://example.com/api",
+				"name":      "api",
+				"uid":       "api-uid",
+				"namespace": "default",
+				"scheme":    "https",
+				"host":      "example.com",
+				"path":      "/api",
+				"annotations": map[string]string{
+					"annotation_key": "annotation_val",
+				},
+				"labels": map[string]string{
+					"label_key": "label_val",
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -223,3 +223,91 @@ func TestExtensionObserveNodes(t *testing.T) {
 
 	require.NoError(t, ext.Shutdown(context.Background()))
 }
+
+func TestExtensionObserveServices(t *testing.T) {
+	factory := NewFactory()
+	config := factory.CreateDefaultConfig().(*Config)
+	config.ObservePods = false
+	config.ObserveServices = true
+	mockServiceHost(t, config)
+
+	ext, err := newObserver(config, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+
+	obs := ext.(*k8sObserver)
+	require.NotNil(t, obs.serviceListerWatcher)
+	serviceListerWatcher := framework.NewFakeControllerSource()
+	obs.serviceListerWatcher = serviceListerWatcher
+
+	serviceListerWatcher.Add(service1V1)
+
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	sink := &endpointSink{}
+	obs.ListAndWatch(sink)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.added) == 2
+	})
+
+	assert.ElementsMatch(t, convertServiceToEndpoints("k8s_observer", service1V1), sink.added)
+
+	serviceListerWatcher.Modify(service1V2)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.changed) == 2
+	})
+
+	assert.ElementsMatch(t, convertServiceToEndpoints("k8s_observer", service1V2), sink.changed)
+
+	serviceListerWatcher.Delete(service1V2)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.removed) == 2
+	})
+
+	assert.ElementsMatch(t, convertServiceToEndpoints("k8s_observer", service1V2), sink.removed)
+
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
+
+func TestExtensionObserveIngresses(t *testing.T) {
+	factory := NewFactory()
+	config := factory.CreateDefaultConfig().(*Config)
+	config.ObservePods = false
+	config.ObserveIngresses = true
+	mockServiceHost(t, config)
+
+	ext, err := newObserver(config, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+
+	obs := ext.(*k8sObserver)
+	require.NotNil(t, obs.ingressListerWatcher)
+	ingressListerWatcher := framework.NewFakeControllerSource()
+	obs.ingressListerWatcher = ingressListerWatcher
+
+	ingressListerWatcher.Add(ingress1V1)
+
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	sink := &endpointSink{}
+	obs.ListAndWatch(sink)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.added) == 3
+	})
+
+	assert.ElementsMatch(t, convertIngressToEndpoints("k8s_observer", ingress1V1), sink.added)
+
+	ingressListerWatcher.Modify(ingress1V2)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.removed) == 1
+	})
+
+	assert.Equal(t, "http://example.com/", sink.removed[0].Target)
+
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
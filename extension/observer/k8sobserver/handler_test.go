@@ -203,3 +203,110 @@ func TestNodeEndpointsChanged(t *testing.T) {
 		},
 	}, th.ListEndpoints())
 }
+
+func TestServiceEndpointsAdded(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(service1V1)
+	assert.ElementsMatch(t, []observer.Endpoint{
+		{
+			ID:     "test-1/service1-UID/redis(6379)",
+			Target: "service1.default.svc:6379",
+			Details: &observer.K8sService{
+				UID:         "service1-UID",
+				Annotations: map[string]string{"annotation-key": "annotation-value"},
+				Labels:      map[string]string{"env": "prod"},
+				Name:        "service1",
+				Namespace:   "default",
+				ServiceType: "ClusterIP",
+				ClusterIP:   "10.0.0.1",
+				Port:        6379,
+				PortName:    "redis",
+				Ports:       map[string]uint16{"redis": 6379, "metrics": 9121},
+			},
+		},
+		{
+			ID:     "test-1/service1-UID/metrics(9121)",
+			Target: "service1.default.svc:9121",
+			Details: &observer.K8sService{
+				UID:         "service1-UID",
+				Annotations: map[string]string{"annotation-key": "annotation-value"},
+				Labels:      map[string]string{"env": "prod"},
+				Name:        "service1",
+				Namespace:   "default",
+				ServiceType: "ClusterIP",
+				ClusterIP:   "10.0.0.1",
+				Port:        9121,
+				PortName:    "metrics",
+				Ports:       map[string]uint16{"redis": 6379, "metrics": 9121},
+			},
+		},
+	}, th.ListEndpoints())
+}
+
+func TestServiceEndpointsRemoved(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(service1V1)
+	th.OnDelete(service1V1)
+	assert.Empty(t, th.ListEndpoints())
+}
+
+func TestServiceEndpointsChanged(t *testing.T) {
+	th := newTestHandler()
+	// Nothing changed.
+	th.OnUpdate(service1V1, service1V1)
+	require.Empty(t, th.ListEndpoints())
+
+	// Ports changed.
+	th.OnUpdate(service1V1, service1V2)
+	changedPorts := service1V2.DeepCopy()
+	changedPorts.Spec.Ports = changedPorts.Spec.Ports[1:]
+	th.OnUpdate(service1V2, changedPorts)
+	assert.ElementsMatch(t, []observer.Endpoint{
+		{
+			ID:     "test-1/service1-UID/metrics(9121)",
+			Target: "service1.default.svc:9121",
+			Details: &observer.K8sService{
+				UID:         "service1-UID",
+				Annotations: map[string]string{"annotation-key": "annotation-value"},
+				Labels: map[string]string{
+					"env":             "prod",
+					"service-version": "2",
+				},
+				Name:        "service1",
+				Namespace:   "default",
+				ServiceType: "ClusterIP",
+				ClusterIP:   "10.0.0.1",
+				Port:        9121,
+				PortName:    "metrics",
+				Ports:       map[string]uint16{"metrics": 9121},
+			},
+		},
+	}, th.ListEndpoints())
+}
+
+func TestIngressEndpointsAdded(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(ingress1V1)
+	assert.ElementsMatch(t, convertIngressToEndpoints("test-1", ingress1V1), th.ListEndpoints())
+	assert.Len(t, th.ListEndpoints(), 3)
+}
+
+func TestIngressEndpointsRemoved(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(ingress1V1)
+	th.OnDelete(ingress1V1)
+	assert.Empty(t, th.ListEndpoints())
+}
+
+func TestIngressEndpointsChanged(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(ingress1V1)
+
+	// The rule of example.com was removed.
+	th.OnUpdate(ingress1V1, ingress1V2)
+	endpoints := th.ListEndpoints()
+	require.Len(t, endpoints, 2)
+	for _, e := range endpoints {
+		assert.Equal(t, "secure.example.com", e.Details.(*observer.K8sIngress).Host)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/k8sobserver"
+
+import (
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+// convertIngressToEndpoints converts an ingress instance into a slice of k8s.ingress endpoints, one for
+// each path of each rule. The Target is the URL of the path. Rules without host use the address of the
+// load balancer of the ingress and are skipped until it is known, rules with a wildcard host are skipped.
+func convertIngressToEndpoints(idNamespace string, ingress *networkingv1.Ingress) []observer.Endpoint {
+	var loadBalancer string
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		if lb.Hostname != "" {
+			loadBalancer = lb.Hostname
+		} else {
+			loadBalancer = lb.IP
+		}
+		if loadBalancer != "" {
+			break
+		}
+	}
+
+	tlsHosts := map[string]bool{}
+	for _, tls := range ingress.Spec.TLS {
+		for _, host := range tls.Hosts {
+			tlsHosts[host] = true
+		}
+	}
+
+	rules := ingress.Spec.Rules
+	if len(rules) == 0 && ingress.Spec.DefaultBackend != nil {
+		// the default backend receives all the traffic of an ingress without rules
+		rules = []networkingv1.IngressRule{{}}
+	}
+
+	var endpoints []observer.Endpoint
+	for _, rule := range rules {
+		host := rule.Host
+		if strings.HasPrefix(host, "*") {
+			continue
+		}
+		scheme := "http"
+		if host == "" {
+			host = loadBalancer
+		} else if tlsHosts[host] {
+			scheme = "https"
+		}
+		if host == "" {
+			continue
+		}
+
+		var paths []string
+		if rule.HTTP != nil {
+			for _, path := range rule.HTTP.Paths {
+				if path.Path == "" {
+					paths = append(paths, "/")
+				} else {
+					paths = append(paths, path.Path)
+				}
+			}
+		}
+		if len(paths) == 0 {
+			paths = []string{"/"}
+		}
+
+		for _, path := range paths {
+			endpoints = append(endpoints, observer.Endpoint{
+				ID:     observer.EndpointID(fmt.Sprintf("%s/%s/%s%s", idNamespace, ingress.UID, host, path)),
+				Target: fmt.Sprintf("%s://%s%s", scheme, host, path),
+				Details: &observer.K8sIngress{
+					UID:         string(ingress.UID),
+					Annotations: ingress.Annotations,
+					Labels:      ingress.Labels,
+					Name:        ingress.Name,
+					Namespace:   ingress.Namespace,
+					Scheme:      scheme,
+					Host:        host,
+					Path:        path,
+				},
+			})
+		}
+	}
+	return endpoints
+}
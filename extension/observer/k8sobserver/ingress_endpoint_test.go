@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func ingressDetails(scheme, host, path string) *observer.K8sIngress {
+	return &observer.K8sIngress{
+		UID:       "name-UID",
+		Labels:    map[string]string{"env": "prod"},
+		Name:      "name",
+		Namespace: "default",
+		Scheme:    scheme,
+		Host:      host,
+		Path:      path,
+	}
+}
+
+func TestIngressObjectToK8sIngressEndpoints(t *testing.T) {
+	expectedEndpoints := []observer.Endpoint{
+		{
+			ID:      "namespace/name-UID/secure.example.com/api",
+			Target:  "https://secure.example.com/api",
+			Details: ingressDetails("https", "secure.example.com", "/api"),
+		},
+		{
+			ID:      "namespace/name-UID/secure.example.com/metrics",
+			Target:  "https://secure.example.com/metrics",
+			Details: ingressDetails("https", "secure.example.com", "/metrics"),
+		},
+		{
+			ID:      "namespace/name-UID/example.com/",
+			Target:  "http://example.com/",
+			Details: ingressDetails("http", "example.com", "/"),
+		},
+	}
+
+	endpoints := convertIngressToEndpoints("namespace", NewIngress("name"))
+	assert.Equal(t, expectedEndpoints, endpoints)
+}
+
+func TestIngressWithoutHostToK8sIngressEndpoints(t *testing.T) {
+	ingress := NewIngress("name")
+	ingress.Spec.Rules = []networkingv1.IngressRule{{}, {Host: "*.example.com"}}
+
+	// the load balancer address is not known yet
+	assert.Empty(t, convertIngressToEndpoints("namespace", ingress))
+
+	ingress.Status.LoadBalancer.Ingress = []v1.LoadBalancerIngress{{IP: "1.2.3.4"}}
+	assert.Equal(t, []observer.Endpoint{
+		{
+			ID:      "namespace/name-UID/1.2.3.4/",
+			Target:  "http://1.2.3.4/",
+			Details: ingressDetails("http", "1.2.3.4", "/"),
+		},
+	}, convertIngressToEndpoints("namespace", ingress))
+}
+
+func TestIngressDefaultBackendToK8sIngressEndpoints(t *testing.T) {
+	ingress := NewIngress("name")
+	ingress.Spec.Rules = nil
+	ingress.Spec.DefaultBackend = &networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{Name: "backend"},
+	}
+	ingress.Status.LoadBalancer.Ingress = []v1.LoadBalancerIngress{{Hostname: "lb.example.com"}}
+
+	assert.Equal(t, []observer.Endpoint{
+		{
+			ID:      "namespace/name-UID/lb.example.com/",
+			Target:  "http://lb.example.com/",
+			Details: ingressDetails("http", "lb.example.com", "/"),
+		},
+	}, convertIngressToEndpoints("namespace", ingress))
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/k8sobserver"
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+// convertServiceToEndpoints converts a service instance into a k8s.service observer.Endpoint for each port of the
+// service. The Target is the external name of ExternalName services and the cluster DNS name of the service
+// otherwise, followed by the port. A service without ports is converted into a single endpoint without port.
+func convertServiceToEndpoints(idNamespace string, service *v1.Service) []observer.Endpoint {
+	serviceID := observer.EndpointID(fmt.Sprintf("%s/%s", idNamespace, service.UID))
+
+	host := fmt.Sprintf("%s.%s.svc", service.Name, service.Namespace)
+	if service.Spec.Type == v1.ServiceTypeExternalName {
+		host = service.Spec.ExternalName
+	}
+
+	clusterIP := service.Spec.ClusterIP
+	if clusterIP == v1.ClusterIPNone {
+		clusterIP = ""
+	}
+
+	serviceDetails := observer.K8sService{
+		UID:         string(service.UID),
+		Annotations: service.Annotations,
+		Labels:      service.Labels,
+		Name:        service.Name,
+		Namespace:   service.Namespace,
+		ServiceType: string(service.Spec.Type),
+		ClusterIP:   clusterIP,
+		Ports:       map[string]uint16{},
+	}
+	for _, port := range service.Spec.Ports {
+		serviceDetails.Ports[port.Name] = uint16(port.Port)
+	}
+
+	if len(service.Spec.Ports) == 0 {
+		return []observer.Endpoint{{
+			ID:      serviceID,
+			Target:  host,
+			Details: &serviceDetails,
+		}}
+	}
+
+	endpoints := make([]observer.Endpoint, 0, len(service.Spec.Ports))
+	for _, port := range service.Spec.Ports {
+		portDetails := serviceDetails
+		portDetails.Port = uint16(port.Port)
+		portDetails.PortName = port.Name
+		endpoints = append(endpoints, observer.Endpoint{
+			ID:      observer.EndpointID(fmt.Sprintf("%s/%s(%d)", serviceID, port.Name, port.Port)),
+			Target:  net.JoinHostPort(host, strconv.Itoa(int(port.Port))),
+			Details: &portDetails,
+		})
+	}
+	return endpoints
+}
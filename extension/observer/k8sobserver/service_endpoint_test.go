@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func TestServiceObjectToK8sServiceEndpoints(t *testing.T) {
+	expectedServices := []observer.Endpoint{
+		{
+			ID:     "namespace/name-UID/redis(6379)",
+			Target: "name.default.svc:6379",
+			Details: &observer.K8sService{
+				UID:         "name-UID",
+				Annotations: map[string]string{"annotation-key": "annotation-value"},
+				Labels:      map[string]string{"env": "prod"},
+				Name:        "name",
+				Namespace:   "default",
+				ServiceType: "ClusterIP",
+				ClusterIP:   "10.0.0.1",
+				Port:        6379,
+				PortName:    "redis",
+				Ports:       map[string]uint16{"redis": 6379, "metrics": 9121},
+			},
+		},
+		{
+			ID:     "namespace/name-UID/metrics(9121)",
+			Target: "name.default.svc:9121",
+			Details: &observer.K8sService{
+				UID:         "name-UID",
+				Annotations: map[string]string{"annotation-key": "annotation-value"},
+				Labels:      map[string]string{"env": "prod"},
+				Name:        "name",
+				Namespace:   "default",
+				ServiceType: "ClusterIP",
+				ClusterIP:   "10.0.0.1",
+				Port:        9121,
+				PortName:    "metrics",
+				Ports:       map[string]uint16{"redis": 6379, "metrics": 9121},
+			},
+		},
+	}
+
+	endpoints := convertServiceToEndpoints("namespace", NewService("name"))
+	require.Equal(t, expectedServices, endpoints)
+}
+
+func TestExternalNameServiceObjectToK8sServiceEndpoint(t *testing.T) {
+	service := NewService("name")
+	service.Spec.Type = v1.ServiceTypeExternalName
+	service.Spec.ExternalName = "redis.example.com"
+	service.Spec.ClusterIP = ""
+	service.Spec.Ports = nil
+
+	expectedServices := []observer.Endpoint{{
+		ID:     "namespace/name-UID",
+		Target: "redis.example.com",
+		Details: &observer.K8sService{
+			UID:         "name-UID",
+			Annotations: map[string]string{"annotation-key": "annotation-value"},
+			Labels:      map[string]string{"env": "prod"},
+			Name:        "name",
+			Namespace:   "default",
+			ServiceType: "ExternalName",
+			Ports:       map[string]uint16{},
+		},
+	}}
+
+	endpoints := convertServiceToEndpoints("namespace", service)
+	require.Equal(t, expectedServices, endpoints)
+}
+
+func TestHeadlessServiceObjectToK8sServiceEndpoint(t *testing.T) {
+	service := NewService("name")
+	service.Spec.ClusterIP = v1.ClusterIPNone
+
+	endpoints := convertServiceToEndpoints("namespace", service)
+	require.Len(t, endpoints, 2)
+	require.Equal(t, "name.default.svc:6379", endpoints[0].Target)
+	require.Equal(t, "", endpoints[0].Details.(*observer.K8sService).ClusterIP)
+}
@@ -19,16 +19,24 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"go.etcd.io/bbolt"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.uber.org/zap"
 )
 
-var defaultBucket = []byte(`default`)
+var (
+	defaultBucket = []byte(`default`)
+
+	errSizeLimitExceeded = errors.New("storage size limit exceeded")
+)
 
 const (
 	elapsedKey       = "elapsed"
@@ -39,6 +47,10 @@ const (
 )
 
 type fileStorageClient struct {
+	// totalSize and dataSize are the sizes measured after the last write, accessed atomically
+	totalSize int64
+	dataSize  int64
+
 	logger          *zap.Logger
 	compactionMutex sync.RWMutex
 	db              *bbolt.DB
@@ -46,6 +58,14 @@ type fileStorageClient struct {
 	openTimeout     time.Duration
 	cancel          context.CancelFunc
 	closed          bool
+
+	cipher   *valueCipher
+	mutators []tag.Mutator
+	// maxSize limits dataSize, while maxTotalSize limits the value returned by
+	// storageSize, which accounts for all the clients of the extension
+	maxSize      int64
+	maxTotalSize int64
+	storageSize  func() int64
 }
 
 func bboltOptions(timeout time.Duration) *bbolt.Options {
@@ -57,7 +77,7 @@ func bboltOptions(timeout time.Duration) *bbolt.Options {
 	}
 }
 
-func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig) (*fileStorageClient, error) {
+func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, vc *valueCipher) (*fileStorageClient, error) {
 	options := bboltOptions(timeout)
 	db, err := bbolt.Open(filePath, 0600, options)
 	if err != nil {
@@ -65,15 +85,25 @@ func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compa
 	}
 
 	initBucket := func(tx *bbolt.Tx) error {
-		_, err := tx.CreateBucketIfNotExists(defaultBucket)
-		return err
+		if _, err := tx.CreateBucketIfNotExists(defaultBucket); err != nil {
+			return err
+		}
+		return initEncryption(tx, vc)
 	}
 	if err := db.Update(initBucket); err != nil {
 		_ = db.Close()
 		return nil, err
 	}
 
-	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout}
+	client := &fileStorageClient{
+		logger:        logger,
+		db:            db,
+		compactionCfg: compactionCfg,
+		openTimeout:   timeout,
+		cipher:        vc,
+		mutators:      []tag.Mutator{tag.Upsert(databaseTagKey, filepath.Base(filePath))},
+	}
+	client.updateSize(context.Background())
 	if compactionCfg.OnRebound {
 		client.startCompactionLoop(context.Background())
 	}
@@ -104,18 +134,45 @@ func (c *fileStorageClient) Delete(ctx context.Context, key string) error {
 
 // Batch executes the specified operations in order. Get operation results are updated in place
 func (c *fileStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	// values holds what is written for each Set operation, encrypted when encryption is enabled
+	values := make([][]byte, len(ops))
+	for i, op := range ops {
+		if op.Type != storage.Set {
+			continue
+		}
+		values[i] = op.Value
+		if c.cipher != nil {
+			var err error
+			if values[i], err = c.cipher.encrypt(op.Key, op.Value); err != nil {
+				return err
+			}
+		}
+	}
+
 	batch := func(tx *bbolt.Tx) error {
 		bucket := tx.Bucket(defaultBucket)
 		if bucket == nil {
 			return errors.New("storage not initialized")
 		}
 
+		// only the batches growing the storage are limited, so that the data can always be replaced or deleted
+		if growth := batchGrowth(bucket, ops, values); growth > 0 {
+			if err := c.checkSizeLimits(ctx, growth); err != nil {
+				return err
+			}
+		}
+
 		var err error
-		for _, op := range ops {
+		for i, op := range ops {
 			switch op.Type {
 			case storage.Get:
 				value := bucket.Get([]byte(op.Key))
-				if value != nil {
+				if value != nil && c.cipher != nil {
+					// decryption allocates a new slice, which remains valid after the transaction
+					if op.Value, err = c.cipher.decrypt(op.Key, value); err != nil {
+						return fmt.Errorf("failed to get %q: %w", op.Key, err)
+					}
+				} else if value != nil {
 					// the output of Bucket.Get is only valid within a transaction, so we need to make a copy
 					// to be able to return the value
 					op.Value = make([]byte, len(value))
@@ -124,7 +181,7 @@ func (c *fileStorageClient) Batch(ctx context.Context, ops ...storage.Operation)
 					op.Value = nil
 				}
 			case storage.Set:
-				err = bucket.Put([]byte(op.Key), op.Value)
+				err = bucket.Put([]byte(op.Key), values[i])
 			case storage.Delete:
 				err = bucket.Delete([]byte(op.Key))
 			default:
@@ -141,7 +198,87 @@ func (c *fileStorageClient) Batch(ctx context.Context, ops ...storage.Operation)
 
 	c.compactionMutex.RLock()
 	defer c.compactionMutex.RUnlock()
-	return c.db.Update(batch)
+
+	if err := c.db.Update(batch); err != nil {
+		return err
+	}
+	if hasWrite(ops) {
+		c.updateSize(ctx)
+	}
+	return nil
+}
+
+func hasWrite(ops []storage.Operation) bool {
+	for _, op := range ops {
+		if op.Type == storage.Set || op.Type == storage.Delete {
+			return true
+		}
+	}
+	return false
+}
+
+// batchGrowth returns the number of bytes the operations add to the bucket, which is negative when
+// they replace or delete more than they add. values holds what is written for each Set operation.
+func batchGrowth(bucket *bbolt.Bucket, ops []storage.Operation, values [][]byte) int64 {
+	// sizes holds the size of the keys written by the previous operations of the batch
+	sizes := map[string]int64{}
+	size := func(key string) int64 {
+		if s, ok := sizes[key]; ok {
+			return s
+		}
+		if value := bucket.Get([]byte(key)); value != nil {
+			return int64(len(key) + len(value))
+		}
+		return 0
+	}
+
+	var growth int64
+	for i, op := range ops {
+		switch op.Type {
+		case storage.Set:
+			written := int64(len(op.Key) + len(values[i]))
+			growth += written - size(op.Key)
+			sizes[op.Key] = written
+		case storage.Delete:
+			growth -= size(op.Key)
+			sizes[op.Key] = 0
+		}
+	}
+	return growth
+}
+
+// checkSizeLimits returns an error if growing by the given number of bytes would exceed
+// either the size limit of the client or the size limit of the whole storage.
+// Limits are checked against the sizes measured after the last write, so they are approximate.
+func (c *fileStorageClient) checkSizeLimits(ctx context.Context, growth int64) error {
+	if c.maxSize > 0 {
+		if size := atomic.LoadInt64(&c.dataSize) + growth; size > c.maxSize {
+			_ = stats.RecordWithTags(ctx, append(c.mutators, tag.Upsert(limitTagKey, clientLimit)), mRejectedOperations.M(1))
+			return fmt.Errorf("%w: writing %d more bytes would grow %s to %d bytes, above the client limit of %d bytes",
+				errSizeLimitExceeded, growth, c.db.Path(), size, c.maxSize)
+		}
+	}
+	if c.maxTotalSize > 0 && c.storageSize != nil {
+		if size := c.storageSize() + growth; size > c.maxTotalSize {
+			_ = stats.RecordWithTags(ctx, append(c.mutators, tag.Upsert(limitTagKey, totalLimit)), mRejectedOperations.M(1))
+			return fmt.Errorf("%w: writing %d more bytes would grow the storage to %d bytes, above the total limit of %d bytes",
+				errSizeLimitExceeded, growth, size, c.maxTotalSize)
+		}
+	}
+	return nil
+}
+
+// updateSize measures the database and records its size.
+// It must be called while holding compactionMutex, or before the client is shared.
+func (c *fileStorageClient) updateSize(ctx context.Context) {
+	totalSize, dataSize, err := c.getDbSize()
+	if err != nil {
+		c.logger.Debug("failed to get db size", zap.Error(err))
+		return
+	}
+	atomic.StoreInt64(&c.totalSize, totalSize)
+	atomic.StoreInt64(&c.dataSize, dataSize)
+	_ = stats.RecordWithTags(ctx, c.mutators, mDatabaseSize.M(totalSize), mDatabaseDataSize.M(dataSize))
 }
 
 // Close will close the database
@@ -153,11 +290,32 @@ func (c *fileStorageClient) Close(_ context.Context) error {
 		c.cancel()
 	}
 	c.closed = true
+	// a closed client no longer counts towards the total size of the storage
+	atomic.StoreInt64(&c.totalSize, 0)
+	atomic.StoreInt64(&c.dataSize, 0)
 	return c.db.Close()
 }
 
-// Compact database. Use temporary file as helper as we cannot replace database in-place
+// Compact database and record the outcome of the compaction
 func (c *fileStorageClient) Compact(compactionDirectory string, timeout time.Duration, maxTransactionSize int64) error {
+	sizeBefore := atomic.LoadInt64(&c.totalSize)
+	err := c.compact(compactionDirectory, timeout, maxTransactionSize)
+
+	ctx := context.Background()
+	if err != nil {
+		_ = stats.RecordWithTags(ctx, append(c.mutators, tag.Upsert(resultTagKey, compactionFailure)), mCompactions.M(1))
+		return err
+	}
+	_ = stats.RecordWithTags(ctx, append(c.mutators, tag.Upsert(resultTagKey, compactionSuccess)), mCompactions.M(1))
+	// the size is reset when the client was closed in the meantime
+	if sizeAfter := atomic.LoadInt64(&c.totalSize); sizeAfter > 0 && sizeBefore > sizeAfter {
+		_ = stats.RecordWithTags(ctx, c.mutators, mCompactionReclaim.M(sizeBefore-sizeAfter))
+	}
+	return nil
+}
+
+// compact database. Use temporary file as helper as we cannot replace database in-place
+func (c *fileStorageClient) compact(compactionDirectory string, timeout time.Duration, maxTransactionSize int64) error {
 	var err error
 	var file *os.File
 	var compactedDb *bbolt.DB
@@ -225,6 +383,7 @@ func (c *fileStorageClient) Compact(compactionDirectory string, timeout time.Dur
 	if openErr != nil {
 		return fmt.Errorf("failed to open db after compaction: %w", openErr)
 	}
+	c.updateSize(context.Background())
 	if moveErr != nil {
 		// if we only failed the remove, we're mostly ok and should just log a warning
 		var pathErr *os.PathError
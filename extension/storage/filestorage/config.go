@@ -32,6 +32,32 @@ type Config struct {
 	Timeout   time.Duration `mapstructure:"timeout,omitempty"`
 
 	Compaction *CompactionConfig `mapstructure:"compaction,omitempty"`
+
+	// Encryption enables encryption of the stored values, disabled when not set
+	Encryption *EncryptionConfig `mapstructure:"encryption,omitempty"`
+
+	// MaxClientSizeMiB limits the size of the data stored by each client, zero means no limit
+	MaxClientSizeMiB int64 `mapstructure:"max_client_size_mib,omitempty"`
+	// MaxTotalSizeMiB limits the size of the data stored by all the clients together, zero means no limit
+	MaxTotalSizeMiB int64 `mapstructure:"max_total_size_mib,omitempty"`
+}
+
+// EncryptionConfig defines configuration for the AES-GCM encryption of stored values.
+type EncryptionConfig struct {
+	// Key is the key used to encrypt and decrypt values
+	Key KeySource `mapstructure:"key"`
+	// PreviousKeys are only used to decrypt values. Values encrypted with any of them
+	// are re-encrypted with Key when the database is opened
+	PreviousKeys []KeySource `mapstructure:"previous_keys,omitempty"`
+}
+
+// KeySource defines where a base64 encoded 16, 24 or 32 bytes long key is read from.
+// Exactly one of File or Env must be set.
+type KeySource struct {
+	// File is the path of a file holding the key
+	File string `mapstructure:"file,omitempty"`
+	// Env is the name of an environment variable holding the key
+	Env string `mapstructure:"env,omitempty"`
 }
 
 // CompactionConfig defines configuration for optional file storage compaction.
@@ -91,5 +117,31 @@ func (cfg *Config) Validate() error {
 		return errors.New("compaction check interval must be positive when rebound compaction is set")
 	}
 
+	if cfg.Encryption != nil {
+		if err := cfg.Encryption.Key.validate(); err != nil {
+			return fmt.Errorf("encryption key: %w", err)
+		}
+		for i, key := range cfg.Encryption.PreviousKeys {
+			if err := key.validate(); err != nil {
+				return fmt.Errorf("previous encryption key %d: %w", i, err)
+			}
+		}
+	}
+
+	if cfg.MaxClientSizeMiB < 0 {
+		return errors.New("max client size cannot be less than 0")
+	}
+
+	if cfg.MaxTotalSizeMiB < 0 {
+		return errors.New("max total size cannot be less than 0")
+	}
+
+	return nil
+}
+
+func (ks KeySource) validate() error {
+	if (ks.File == "") == (ks.Env == "") {
+		return errors.New("exactly one of 'file' or 'env' must be set")
+	}
 	return nil
 }
@@ -57,6 +57,12 @@ func TestLoadConfig(t *testing.T) {
 					CheckInterval:              time.Second * 5,
 				},
 				Timeout: 2 * time.Second,
+				Encryption: &EncryptionConfig{
+					Key:          KeySource{Env: "FILE_STORAGE_KEY"},
+					PreviousKeys: []KeySource{{File: "/etc/otelcol/previous.key"}},
+				},
+				MaxClientSizeMiB: 64,
+				MaxTotalSizeMiB:  256,
 			},
 		},
 	}
@@ -103,3 +109,51 @@ func TestHandleProvidingFilePathAsDirWithAnError(t *testing.T) {
 	require.Error(t, err)
 	require.EqualError(t, err, file.Name()+" is not a directory")
 }
+
+func TestValidateEncryptionAndSizeLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		update   func(cfg *Config)
+		expected string
+	}{
+		{
+			name: "missing key",
+			update: func(cfg *Config) {
+				cfg.Encryption = &EncryptionConfig{}
+			},
+			expected: "encryption key: exactly one of 'file' or 'env' must be set",
+		},
+		{
+			name: "ambiguous previous key",
+			update: func(cfg *Config) {
+				cfg.Encryption = &EncryptionConfig{
+					Key:          KeySource{Env: "KEY"},
+					PreviousKeys: []KeySource{{File: "key", Env: "KEY"}},
+				}
+			},
+			expected: "previous encryption key 0: exactly one of 'file' or 'env' must be set",
+		},
+		{
+			name: "negative client size",
+			update: func(cfg *Config) {
+				cfg.MaxClientSizeMiB = -1
+			},
+			expected: "max client size cannot be less than 0",
+		},
+		{
+			name: "negative total size",
+			update: func(cfg *Config) {
+				cfg.MaxTotalSizeMiB = -1
+			},
+			expected: "max total size cannot be less than 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Directory = t.TempDir()
+			tt.update(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.expected)
+		})
+	}
+}
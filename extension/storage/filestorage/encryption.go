@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.etcd.io/bbolt"
+)
+
+const (
+	// keyIDSize is the length of the key identifier prepended to every encrypted value
+	keyIDSize = 4
+
+	encryptionAlgorithm = "aes-gcm"
+)
+
+var (
+	metadataBucket        = []byte(`metadata`)
+	encryptionMetadataKey = []byte(`encryption`)
+
+	errEncryptedWithoutKey = errors.New("database is encrypted, but no encryption key is configured")
+	errUnknownKey          = errors.New("value was encrypted with a key which is not configured")
+	errMalformedValue      = errors.New("encrypted value is malformed")
+)
+
+type keyID [keyIDSize]byte
+
+// valueCipher encrypts values with the current key and decrypts them with
+// either the current or one of the previous keys.
+type valueCipher struct {
+	currentID keyID
+	current   cipher.AEAD
+	keys      map[keyID]cipher.AEAD
+}
+
+func newValueCipher(cfg *EncryptionConfig) (*valueCipher, error) {
+	vc := &valueCipher{keys: map[keyID]cipher.AEAD{}}
+
+	sources := append([]KeySource{cfg.Key}, cfg.PreviousKeys...)
+	for i, source := range sources {
+		key, err := source.load()
+		if err != nil {
+			return nil, err
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key from %s: %w", source, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+
+		id := newKeyID(key)
+		if _, ok := vc.keys[id]; ok {
+			return nil, fmt.Errorf("encryption key from %s is configured more than once", source)
+		}
+		vc.keys[id] = aead
+		if i == 0 {
+			vc.currentID = id
+			vc.current = aead
+		}
+	}
+
+	return vc, nil
+}
+
+func newKeyID(key []byte) keyID {
+	var id keyID
+	sum := sha256.Sum256(key)
+	copy(id[:], sum[:keyIDSize])
+	return id
+}
+
+// load reads the base64 encoded key from the file or environment variable
+func (ks KeySource) load() ([]byte, error) {
+	var encoded string
+	switch {
+	case ks.File != "":
+		data, err := os.ReadFile(ks.File)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read encryption key: %w", err)
+		}
+		encoded = string(data)
+	case ks.Env != "":
+		encoded = os.Getenv(ks.Env)
+	}
+
+	encoded = strings.TrimSpace(encoded)
+	if encoded == "" {
+		return nil, fmt.Errorf("encryption key from %s is empty", ks)
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("encryption key from %s is not valid base64: %w", ks, err)
+	}
+	return key, nil
+}
+
+func (ks KeySource) String() string {
+	if ks.File != "" {
+		return fmt.Sprintf("file %q", ks.File)
+	}
+	return fmt.Sprintf("environment variable %q", ks.Env)
+}
+
+// encrypt seals the value with the current key. The key of the entry is used as
+// additional data, so that an encrypted value cannot be moved to another key.
+// The result has the layout: key id | nonce | ciphertext
+func (vc *valueCipher) encrypt(key string, value []byte) ([]byte, error) {
+	nonceSize := vc.current.NonceSize()
+	out := make([]byte, keyIDSize+nonceSize, keyIDSize+nonceSize+len(value)+vc.current.Overhead())
+	copy(out, vc.currentID[:])
+	if _, err := io.ReadFull(rand.Reader, out[keyIDSize:]); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return vc.current.Seal(out, out[keyIDSize:], value, []byte(key)), nil
+}
+
+// decrypt opens a value sealed by encrypt with any of the configured keys
+func (vc *valueCipher) decrypt(key string, value []byte) ([]byte, error) {
+	if len(value) < keyIDSize {
+		return nil, errMalformedValue
+	}
+	var id keyID
+	copy(id[:], value)
+	aead, ok := vc.keys[id]
+	if !ok {
+		return nil, errUnknownKey
+	}
+
+	nonceSize := aead.NonceSize()
+	if len(value) < keyIDSize+nonceSize+aead.Overhead() {
+		return nil, errMalformedValue
+	}
+	nonce := value[keyIDSize : keyIDSize+nonceSize]
+	// open into a non-nil slice, so that an empty value is not mistaken for a missing one
+	plain, err := aead.Open([]byte{}, nonce, value[keyIDSize+nonceSize:], []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	return plain, nil
+}
+
+// isCurrent checks whether the value was encrypted with the current key
+func (vc *valueCipher) isCurrent(value []byte) bool {
+	return len(value) >= keyIDSize && bytes.Equal(value[:keyIDSize], vc.currentID[:])
+}
+
+// initEncryption makes sure that every value in the database is encrypted with the current key.
+// Values stored before encryption was enabled are encrypted, and values encrypted with one of the
+// previous keys are re-encrypted, which completes a key rotation. A database which was encrypted
+// cannot be opened anymore without a key.
+func initEncryption(tx *bbolt.Tx, vc *valueCipher) error {
+	meta := tx.Bucket(metadataBucket)
+	encrypted := meta != nil && meta.Get(encryptionMetadataKey) != nil
+	if vc == nil {
+		if encrypted {
+			return errEncryptedWithoutKey
+		}
+		return nil
+	}
+
+	bucket := tx.Bucket(defaultBucket)
+	if bucket == nil {
+		return errors.New("storage not initialized")
+	}
+
+	// the bucket must not be modified while iterating over it, so collect the updates first
+	updates := map[string][]byte{}
+	err := bucket.ForEach(func(k, v []byte) error {
+		plain := v
+		if encrypted {
+			if vc.isCurrent(v) {
+				return nil
+			}
+			var err error
+			if plain, err = vc.decrypt(string(k), v); err != nil {
+				return fmt.Errorf("failed to rotate encryption key of %q: %w", k, err)
+			}
+		}
+		value, err := vc.encrypt(string(k), plain)
+		if err != nil {
+			return err
+		}
+		updates[string(k)] = value
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for k, v := range updates {
+		if err = bucket.Put([]byte(k), v); err != nil {
+			return err
+		}
+	}
+
+	if meta == nil {
+		if meta, err = tx.CreateBucket(metadataBucket); err != nil {
+			return err
+		}
+	}
+	return meta.Put(encryptionMetadataKey, []byte(encryptionAlgorithm))
+}
@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorage
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+	"go.uber.org/zap"
+)
+
+func newTestKeyFile(t *testing.T, key string) KeySource {
+	path := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString([]byte(key))+"\n"), 0600))
+	return KeySource{File: path}
+}
+
+func newTestCipher(t *testing.T, current KeySource, previous ...KeySource) *valueCipher {
+	vc, err := newValueCipher(&EncryptionConfig{Key: current, PreviousKeys: previous})
+	require.NoError(t, err)
+	return vc
+}
+
+func rawValue(t *testing.T, client *fileStorageClient, key string) []byte {
+	var value []byte
+	require.NoError(t, client.db.View(func(tx *bbolt.Tx) error {
+		value = append([]byte{}, tx.Bucket(defaultBucket).Get([]byte(key))...)
+		return nil
+	}))
+	return value
+}
+
+func TestKeySourceLoad(t *testing.T) {
+	t.Setenv("FILESTORAGE_TEST_KEY", base64.StdEncoding.EncodeToString([]byte("0123456789abcdef")))
+	t.Setenv("FILESTORAGE_TEST_EMPTY_KEY", "")
+	t.Setenv("FILESTORAGE_TEST_INVALID_KEY", "not base64!")
+
+	key, err := KeySource{Env: "FILESTORAGE_TEST_KEY"}.load()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("0123456789abcdef"), key)
+
+	key, err = newTestKeyFile(t, "0123456789abcdef0123456789abcdef").load()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("0123456789abcdef0123456789abcdef"), key)
+
+	_, err = KeySource{Env: "FILESTORAGE_TEST_EMPTY_KEY"}.load()
+	assert.EqualError(t, err, `encryption key from environment variable "FILESTORAGE_TEST_EMPTY_KEY" is empty`)
+
+	_, err = KeySource{Env: "FILESTORAGE_TEST_INVALID_KEY"}.load()
+	assert.ErrorContains(t, err, "is not valid base64")
+
+	_, err = KeySource{File: filepath.Join(t.TempDir(), "missing")}.load()
+	assert.ErrorContains(t, err, "failed to read encryption key")
+}
+
+func TestNewValueCipherErrors(t *testing.T) {
+	_, err := newValueCipher(&EncryptionConfig{Key: newTestKeyFile(t, "too short")})
+	assert.ErrorContains(t, err, "invalid encryption key")
+
+	key := newTestKeyFile(t, "0123456789abcdef")
+	_, err = newValueCipher(&EncryptionConfig{Key: key, PreviousKeys: []KeySource{newTestKeyFile(t, "0123456789abcdef")}})
+	assert.ErrorContains(t, err, "is configured more than once")
+}
+
+func TestValueCipher(t *testing.T) {
+	vc := newTestCipher(t, newTestKeyFile(t, "0123456789abcdef0123456789abcdef"))
+
+	encrypted, err := vc.encrypt("key", []byte("value"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(encrypted), "value")
+	assert.True(t, vc.isCurrent(encrypted))
+
+	decrypted, err := vc.decrypt("key", encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), decrypted)
+
+	// the value is bound to its key
+	_, err = vc.decrypt("other", encrypted)
+	assert.ErrorContains(t, err, "failed to decrypt value")
+
+	empty, err := vc.encrypt("key", nil)
+	require.NoError(t, err)
+	decrypted, err = vc.decrypt("key", empty)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{}, decrypted)
+
+	_, err = vc.decrypt("key", encrypted[:keyIDSize+1])
+	assert.ErrorIs(t, err, errMalformedValue)
+
+	other := newTestCipher(t, newTestKeyFile(t, "fedcba9876543210fedcba9876543210"))
+	_, err = other.decrypt("key", encrypted)
+	assert.ErrorIs(t, err, errUnknownKey)
+}
+
+func TestClientEncryption(t *testing.T) {
+	ctx := context.Background()
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+	oldKey := newTestKeyFile(t, "0123456789abcdef0123456789abcdef")
+	newKey := newTestKeyFile(t, "fedcba9876543210fedcba9876543210")
+
+	// values stored before encryption was enabled
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "plain", []byte("plain value")))
+	require.NoError(t, client.Close(ctx))
+
+	// enabling encryption encrypts the existing values
+	oldCipher := newTestCipher(t, oldKey)
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, oldCipher)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "encrypted", []byte("encrypted value")))
+	assert.True(t, oldCipher.isCurrent(rawValue(t, client, "plain")))
+	assert.True(t, oldCipher.isCurrent(rawValue(t, client, "encrypted")))
+	value, err := client.Get(ctx, "plain")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("plain value"), value)
+	require.NoError(t, client.Close(ctx))
+
+	// the database cannot be opened anymore without a key
+	_, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
+	assert.ErrorIs(t, err, errEncryptedWithoutKey)
+
+	// nor with an unknown key
+	_, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, newTestCipher(t, newKey))
+	assert.ErrorIs(t, err, errUnknownKey)
+
+	// rotating the key re-encrypts the values with the new key
+	newCipher := newTestCipher(t, newKey, oldKey)
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, newCipher)
+	require.NoError(t, err)
+	assert.False(t, oldCipher.isCurrent(rawValue(t, client, "plain")))
+	assert.True(t, newCipher.isCurrent(rawValue(t, client, "plain")))
+	assert.True(t, newCipher.isCurrent(rawValue(t, client, "encrypted")))
+	require.NoError(t, client.Close(ctx))
+
+	// after the rotation, the previous key is no longer needed
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, newTestCipher(t, newKey))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+	value, err = client.Get(ctx, "plain")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("plain value"), value)
+	value, err = client.Get(ctx, "encrypted")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("encrypted value"), value)
+	value, err = client.Get(ctx, "missing")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
@@ -18,6 +18,8 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"sync"
+	"sync/atomic"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -28,16 +30,28 @@ import (
 type localFileStorage struct {
 	cfg    *Config
 	logger *zap.Logger
+	cipher *valueCipher
+
+	clientsMutex sync.Mutex
+	clients      []*fileStorageClient
 }
 
 // Ensure this storage extension implements the appropriate interface
 var _ storage.Extension = (*localFileStorage)(nil)
 
 func newLocalFileStorage(logger *zap.Logger, config *Config) (component.Extension, error) {
-	return &localFileStorage{
+	lfs := &localFileStorage{
 		cfg:    config,
 		logger: logger,
-	}, nil
+	}
+	if config.Encryption != nil {
+		vc, err := newValueCipher(config.Encryption)
+		if err != nil {
+			return nil, err
+		}
+		lfs.cipher = vc
+	}
+	return lfs, nil
 }
 
 // Start does nothing
@@ -62,7 +76,7 @@ func (lfs *localFileStorage) GetClient(ctx context.Context, kind component.Kind,
 	}
 	// TODO sanitize rawName
 	absoluteName := filepath.Join(lfs.cfg.Directory, rawName)
-	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction)
+	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, lfs.cipher)
 
 	if err != nil {
 		return nil, err
@@ -76,9 +90,30 @@ func (lfs *localFileStorage) GetClient(ctx context.Context, kind component.Kind,
 		}
 	}
 
+	client.maxSize = lfs.cfg.MaxClientSizeMiB * oneMiB
+	if lfs.cfg.MaxTotalSizeMiB > 0 {
+		client.maxTotalSize = lfs.cfg.MaxTotalSizeMiB * oneMiB
+		client.storageSize = lfs.dataSize
+		lfs.clientsMutex.Lock()
+		lfs.clients = append(lfs.clients, client)
+		lfs.clientsMutex.Unlock()
+	}
+
 	return client, nil
 }
 
+// dataSize returns the size of the data stored by all the clients, as measured after their last write
+func (lfs *localFileStorage) dataSize() int64 {
+	lfs.clientsMutex.Lock()
+	defer lfs.clientsMutex.Unlock()
+
+	var size int64
+	for _, client := range lfs.clients {
+		size += atomic.LoadInt64(&client.dataSize)
+	}
+	return size
+}
+
 func kindString(k component.Kind) string {
 	switch k {
 	case component.KindReceiver:
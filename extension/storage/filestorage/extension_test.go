@@ -398,3 +398,46 @@ func TestCompactionRemoveTemp(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 0, len(files))
 }
+
+func TestTotalSizeLimit(t *testing.T) {
+	ctx := context.Background()
+
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	cfg.MaxTotalSizeMiB = 1
+
+	extension, err := f.CreateExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), cfg)
+	require.NoError(t, err)
+	se, ok := extension.(storage.Extension)
+	require.True(t, ok)
+
+	first, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("first"), "")
+	require.NoError(t, err)
+	second, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("second"), "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, second.Close(ctx))
+	})
+
+	require.NoError(t, first.Set(ctx, "key", make([]byte, 768*1024)))
+
+	// the second client is limited by the data stored by the first one
+	err = second.Set(ctx, "key", make([]byte, 512*1024))
+	require.ErrorIs(t, err, errSizeLimitExceeded)
+	require.Contains(t, err.Error(), "above the total limit")
+
+	// a closed client no longer counts towards the limit
+	require.NoError(t, first.Close(ctx))
+	require.NoError(t, second.Set(ctx, "key", make([]byte, 512*1024)))
+}
+
+func TestEncryptionKeyErrors(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	cfg.Encryption = &EncryptionConfig{Key: KeySource{File: filepath.Join(cfg.Directory, "missing")}}
+
+	_, err := f.CreateExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), cfg)
+	require.ErrorContains(t, err, "failed to read encryption key")
+}
@@ -16,8 +16,10 @@ package filestorage // import "github.com/open-telemetry/opentelemetry-collector
 
 import (
 	"context"
+	"sync"
 	"time"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 )
@@ -34,8 +36,14 @@ const (
 	defaultCompactionInterval         = time.Second * 5
 )
 
+var once sync.Once
+
 // NewFactory creates a factory for HostObserver extension.
 func NewFactory() component.ExtensionFactory {
+	once.Do(func() {
+		_ = view.Register(MetricViews()...)
+	})
+
 	return component.NewExtensionFactory(
 		typeStr,
 		createDefaultConfig,
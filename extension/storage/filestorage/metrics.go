@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+const (
+	compactionSuccess = "success"
+	compactionFailure = "failure"
+
+	clientLimit = "client"
+	totalLimit  = "total"
+)
+
+var (
+	databaseTagKey = tag.MustNewKey("database")
+	resultTagKey   = tag.MustNewKey("result")
+	limitTagKey    = tag.MustNewKey("limit")
+
+	mDatabaseSize       = stats.Int64("filestorage/database_size", "Size of the database file, including the free pages", stats.UnitBytes)
+	mDatabaseDataSize   = stats.Int64("filestorage/database_data_size", "Size of the data stored in the database", stats.UnitBytes)
+	mCompactions        = stats.Int64("filestorage/compactions", "Number of compactions, by result", stats.UnitDimensionless)
+	mCompactionReclaim  = stats.Int64("filestorage/compaction_reclaimed_size", "Size reclaimed by compactions", stats.UnitBytes)
+	mRejectedOperations = stats.Int64("filestorage/rejected_operations", "Number of batches rejected because a size limit was exceeded", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views related to file storage.
+func MetricViews() []*view.View {
+	tagKeys := []tag.Key{databaseTagKey}
+
+	return []*view.View{
+		{
+			Name:        mDatabaseSize.Name(),
+			Measure:     mDatabaseSize,
+			Description: mDatabaseSize.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.LastValue(),
+		},
+		{
+			Name:        mDatabaseDataSize.Name(),
+			Measure:     mDatabaseDataSize,
+			Description: mDatabaseDataSize.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.LastValue(),
+		},
+		{
+			Name:        mCompactions.Name(),
+			Measure:     mCompactions,
+			Description: mCompactions.Description(),
+			TagKeys:     []tag.Key{databaseTagKey, resultTagKey},
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        mCompactionReclaim.Name(),
+			Measure:     mCompactionReclaim,
+			Description: mCompactionReclaim.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        mRejectedOperations.Name(),
+			Measure:     mRejectedOperations,
+			Description: mRejectedOperations.Description(),
+			TagKeys:     []tag.Key{databaseTagKey, limitTagKey},
+			Aggregation: view.Sum(),
+		},
+	}
+}
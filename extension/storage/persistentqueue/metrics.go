@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistentqueue // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/persistentqueue"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	queueTagKey = tag.MustNewKey("queue")
+
+	mSize           = stats.Int64("persistentqueue/size", "Number of items in the queue", stats.UnitDimensionless)
+	mSizeBytes      = stats.Int64("persistentqueue/size_bytes", "Size of the items in the queue", stats.UnitBytes)
+	mReplayedItems  = stats.Int64("persistentqueue/replayed_items", "Number of items found in the queue when it was opened, which are replayed", stats.UnitDimensionless)
+	mCorruptedItems = stats.Int64("persistentqueue/corrupted_items", "Number of items that were dropped because they were missing or corrupted in storage", stats.UnitDimensionless)
+	mRejectedItems  = stats.Int64("persistentqueue/rejected_items", "Number of items that were rejected because the queue was full", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views related to persistent queues.
+func MetricViews() []*view.View {
+	tagKeys := []tag.Key{queueTagKey}
+
+	return []*view.View{
+		{
+			Name:        mSize.Name(),
+			Measure:     mSize,
+			Description: mSize.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.LastValue(),
+		},
+		{
+			Name:        mSizeBytes.Name(),
+			Measure:     mSizeBytes,
+			Description: mSizeBytes.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.LastValue(),
+		},
+		{
+			Name:        mReplayedItems.Name(),
+			Measure:     mReplayedItems,
+			Description: mReplayedItems.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        mCorruptedItems.Name(),
+			Measure:     mCorruptedItems,
+			Description: mCorruptedItems.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        mRejectedItems.Name(),
+			Measure:     mRejectedItems,
+			Description: mRejectedItems.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		},
+	}
+}
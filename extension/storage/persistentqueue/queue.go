@@ -0,0 +1,407 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistentqueue // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/persistentqueue"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"strconv"
+	"sync"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+const (
+	metadataKey   = "metadata"
+	itemKeyPrefix = "item_"
+
+	// metadataSize is the size of the encoded metadata: the ack index, the write index
+	// and the size in bytes of the queue, followed by a checksum.
+	metadataSize = 3*8 + 4
+	// checksumSize is the size of the checksum prepended to every item.
+	checksumSize = 4
+)
+
+var (
+	// ErrQueueFull is returned by Put when adding the items would exceed the size caps of the queue.
+	ErrQueueFull = errors.New("persistent queue is full")
+	// ErrClosed is returned when the queue is used after Close was called.
+	ErrClosed = errors.New("persistent queue is closed")
+)
+
+// Settings configures a Queue.
+type Settings struct {
+	// Name identifies the queue in logs and metrics.
+	Name string
+	// MaxItems is the maximum number of items held by the queue. Zero means no limit.
+	MaxItems uint64
+	// MaxSizeBytes is the maximum total size of the items held by the queue. Zero means no limit.
+	MaxSizeBytes uint64
+	// Logger is used to report recovered corruption.
+	Logger *zap.Logger
+}
+
+// Item is an entry read from the queue.
+type Item struct {
+	// Index is the position of the item in the queue; it is passed to Ack once the item is processed.
+	Index uint64
+	// Value is the payload that was passed to Put.
+	Value []byte
+}
+
+// metadata is the state of the queue that is persisted next to its items.
+type metadata struct {
+	// ackIndex is the index of the first item that has not been acknowledged yet.
+	ackIndex uint64
+	// writeIndex is the index the next item is written at.
+	writeIndex uint64
+	// sizeBytes is the total size of the items in [ackIndex, writeIndex).
+	sizeBytes uint64
+}
+
+// Queue is a FIFO queue persisted in a storage.Client with at-least-once delivery semantics:
+// items handed out by Read stay in storage until they are acknowledged with Ack, and items
+// that were not acknowledged before a restart are replayed when the queue is opened again.
+//
+// Items that are missing or fail their checksum, for instance after an unclean shutdown, are
+// skipped and counted rather than blocking the queue. A corrupted metadata record resets the
+// queue to empty.
+type Queue struct {
+	client   storage.Client
+	settings Settings
+	mutators []tag.Mutator
+
+	mu   sync.Mutex
+	meta metadata
+	// readIndex is the index of the next item returned by Read. Items in [meta.ackIndex, readIndex)
+	// have been read but not acknowledged yet.
+	readIndex uint64
+	// readSizes holds the size of the items that have been read but not acknowledged yet.
+	readSizes map[uint64]uint64
+
+	notify    chan struct{}
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// New opens the queue persisted in the given client, replaying any items that were not
+// acknowledged when it was last used.
+func New(ctx context.Context, client storage.Client, settings Settings) (*Queue, error) {
+	if settings.Logger == nil {
+		settings.Logger = zap.NewNop()
+	}
+	q := &Queue{
+		client:    client,
+		settings:  settings,
+		mutators:  []tag.Mutator{tag.Upsert(queueTagKey, settings.Name)},
+		readSizes: make(map[uint64]uint64),
+		notify:    make(chan struct{}, 1),
+		closed:    make(chan struct{}),
+	}
+
+	buf, err := client.Get(ctx, metadataKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read queue metadata: %w", err)
+	}
+	if buf != nil {
+		meta, ok := decodeMetadata(buf)
+		if !ok {
+			// Without the metadata the position of the items is unknown, so start afresh.
+			settings.Logger.Error("Queue metadata is corrupted, resetting the queue", zap.String("queue", settings.Name))
+			_ = stats.RecordWithTags(ctx, q.mutators, mCorruptedItems.M(1))
+		} else {
+			q.meta = meta
+		}
+	}
+	q.readIndex = q.meta.ackIndex
+
+	if replayed := q.meta.writeIndex - q.meta.ackIndex; replayed > 0 {
+		settings.Logger.Info("Replaying items from the persistent queue",
+			zap.String("queue", settings.Name), zap.Uint64("items", replayed))
+		_ = stats.RecordWithTags(ctx, q.mutators, mReplayedItems.M(int64(replayed)))
+	}
+	q.recordSize(ctx)
+	return q, nil
+}
+
+// Len returns the number of items in the queue, including the ones that were read but not acknowledged.
+func (q *Queue) Len() uint64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.meta.writeIndex - q.meta.ackIndex
+}
+
+// SizeBytes returns the total size of the items in the queue.
+func (q *Queue) SizeBytes() uint64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.meta.sizeBytes
+}
+
+// Put atomically appends the values to the queue. It returns ErrQueueFull, without adding
+// any value, if they would not fit in the size caps.
+func (q *Queue) Put(ctx context.Context, values ...[]byte) error {
+	if len(values) == 0 {
+		return nil
+	}
+	if q.isClosed() {
+		return ErrClosed
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var size uint64
+	for _, value := range values {
+		size += uint64(len(value))
+	}
+	count := uint64(len(values))
+	if (q.settings.MaxItems > 0 && q.meta.writeIndex-q.meta.ackIndex+count > q.settings.MaxItems) ||
+		(q.settings.MaxSizeBytes > 0 && q.meta.sizeBytes+size > q.settings.MaxSizeBytes) {
+		_ = stats.RecordWithTags(ctx, q.mutators, mRejectedItems.M(int64(count)))
+		return ErrQueueFull
+	}
+
+	meta := q.meta
+	ops := make([]storage.Operation, 0, len(values)+1)
+	for _, value := range values {
+		ops = append(ops, storage.SetOperation(itemKey(meta.writeIndex), encodeItem(value)))
+		meta.writeIndex++
+	}
+	meta.sizeBytes += size
+	ops = append(ops, storage.SetOperation(metadataKey, encodeMetadata(meta)))
+	if err := q.client.Batch(ctx, ops...); err != nil {
+		return fmt.Errorf("failed to write items to the queue: %w", err)
+	}
+	q.meta = meta
+	q.recordSize(ctx)
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Read returns up to max items that have not been read yet, blocking until at least one is
+// available, the context is done or the queue is closed. Items must be acknowledged with Ack
+// once processed, otherwise they are replayed by Rewind or when the queue is opened again.
+func (q *Queue) Read(ctx context.Context, max int) ([]Item, error) {
+	if max <= 0 {
+		return nil, fmt.Errorf("invalid number of items to read: %d", max)
+	}
+	for {
+		items, err := q.tryRead(ctx, max)
+		if err != nil || len(items) > 0 {
+			return items, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-q.closed:
+			return nil, ErrClosed
+		case <-q.notify:
+		}
+	}
+}
+
+func (q *Queue) tryRead(ctx context.Context, max int) ([]Item, error) {
+	if q.isClosed() {
+		return nil, ErrClosed
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	// Loop so that a run of corrupted items does not look like an empty queue.
+	for q.readIndex < q.meta.writeIndex {
+		start := q.readIndex
+		end := start + uint64(max)
+		if end > q.meta.writeIndex {
+			end = q.meta.writeIndex
+		}
+
+		ops := make([]storage.Operation, 0, end-start)
+		for i := start; i < end; i++ {
+			ops = append(ops, storage.GetOperation(itemKey(i)))
+		}
+		if err := q.client.Batch(ctx, ops...); err != nil {
+			return nil, fmt.Errorf("failed to read items from the queue: %w", err)
+		}
+
+		items := make([]Item, 0, len(ops))
+		var corrupted int64
+		for i, op := range ops {
+			index := start + uint64(i)
+			value, ok := decodeItem(op.Value)
+			if !ok {
+				// The item is skipped, and is removed from storage once the items around it are acknowledged.
+				q.settings.Logger.Warn("Dropping missing or corrupted item from the queue",
+					zap.String("queue", q.settings.Name), zap.Uint64("index", index))
+				corrupted++
+				q.readSizes[index] = uint64(len(op.Value))
+				continue
+			}
+			q.readSizes[index] = uint64(len(value))
+			items = append(items, Item{Index: index, Value: value})
+		}
+		q.readIndex = end
+		if corrupted > 0 {
+			_ = stats.RecordWithTags(ctx, q.mutators, mCorruptedItems.M(corrupted))
+		}
+
+		if len(items) > 0 {
+			return items, nil
+		}
+		if start == q.meta.ackIndex {
+			// Nothing is in flight before the corrupted items, so they can be removed right away.
+			if err := q.ackLocked(ctx, end-1); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Ack acknowledges all the items up to and including the given index, removing them from the queue.
+func (q *Queue) Ack(ctx context.Context, index uint64) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if index < q.meta.ackIndex {
+		// Already acknowledged.
+		return nil
+	}
+	if index >= q.readIndex {
+		return fmt.Errorf("cannot acknowledge item %d which has not been read", index)
+	}
+	return q.ackLocked(ctx, index)
+}
+
+func (q *Queue) ackLocked(ctx context.Context, index uint64) error {
+	meta := q.meta
+	ops := make([]storage.Operation, 0, index-meta.ackIndex+2)
+	for i := meta.ackIndex; i <= index; i++ {
+		ops = append(ops, storage.DeleteOperation(itemKey(i)))
+		size := q.readSizes[i]
+		if size > meta.sizeBytes {
+			size = meta.sizeBytes
+		}
+		meta.sizeBytes -= size
+	}
+	meta.ackIndex = index + 1
+	ops = append(ops, storage.SetOperation(metadataKey, encodeMetadata(meta)))
+	if err := q.client.Batch(ctx, ops...); err != nil {
+		return fmt.Errorf("failed to acknowledge items in the queue: %w", err)
+	}
+	for i := q.meta.ackIndex; i <= index; i++ {
+		delete(q.readSizes, i)
+	}
+	q.meta = meta
+	q.recordSize(ctx)
+	return nil
+}
+
+// Rewind makes all the items that were read but not acknowledged available to Read again.
+func (q *Queue) Rewind() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.readIndex = q.meta.ackIndex
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Close unblocks pending reads and closes the storage client. Items that were not
+// acknowledged are replayed when the queue is opened again.
+func (q *Queue) Close(ctx context.Context) error {
+	err := ErrClosed
+	q.closeOnce.Do(func() {
+		close(q.closed)
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		err = q.client.Close(ctx)
+	})
+	return err
+}
+
+func (q *Queue) isClosed() bool {
+	select {
+	case <-q.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (q *Queue) recordSize(ctx context.Context) {
+	_ = stats.RecordWithTags(ctx, q.mutators,
+		mSize.M(int64(q.meta.writeIndex-q.meta.ackIndex)),
+		mSizeBytes.M(int64(q.meta.sizeBytes)))
+}
+
+func itemKey(index uint64) string {
+	return itemKeyPrefix + strconv.FormatUint(index, 10)
+}
+
+func encodeItem(value []byte) []byte {
+	buf := make([]byte, checksumSize+len(value))
+	binary.LittleEndian.PutUint32(buf, crc32.ChecksumIEEE(value))
+	copy(buf[checksumSize:], value)
+	return buf
+}
+
+func decodeItem(buf []byte) ([]byte, bool) {
+	if len(buf) < checksumSize {
+		return nil, false
+	}
+	value := buf[checksumSize:]
+	if binary.LittleEndian.Uint32(buf) != crc32.ChecksumIEEE(value) {
+		return nil, false
+	}
+	return value, true
+}
+
+func encodeMetadata(meta metadata) []byte {
+	buf := make([]byte, metadataSize)
+	binary.LittleEndian.PutUint64(buf[0:], meta.ackIndex)
+	binary.LittleEndian.PutUint64(buf[8:], meta.writeIndex)
+	binary.LittleEndian.PutUint64(buf[16:], meta.sizeBytes)
+	binary.LittleEndian.PutUint32(buf[24:], crc32.ChecksumIEEE(buf[:24]))
+	return buf
+}
+
+func decodeMetadata(buf []byte) (metadata, bool) {
+	if len(buf) != metadataSize || binary.LittleEndian.Uint32(buf[24:]) != crc32.ChecksumIEEE(buf[:24]) {
+		return metadata{}, false
+	}
+	meta := metadata{
+		ackIndex:   binary.LittleEndian.Uint64(buf[0:]),
+		writeIndex: binary.LittleEndian.Uint64(buf[8:]),
+		sizeBytes:  binary.LittleEndian.Uint64(buf[16:]),
+	}
+	if meta.ackIndex > meta.writeIndex {
+		return metadata{}, false
+	}
+	return meta, true
+}
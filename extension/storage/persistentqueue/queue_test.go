@@ -0,0 +1,261 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistentqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func newTestClient(dir string) *storagetest.TestClient {
+	id := config.NewComponentID("test")
+	if dir == "" {
+		return storagetest.NewInMemoryClient(component.KindExporter, id, "queue")
+	}
+	return storagetest.NewFileBackedClient(component.KindExporter, id, "queue", dir)
+}
+
+func newTestQueue(t *testing.T, client *storagetest.TestClient, settings Settings) *Queue {
+	q, err := New(context.Background(), client, settings)
+	require.NoError(t, err)
+	return q
+}
+
+func values(items []Item) []string {
+	var vals []string
+	for _, item := range items {
+		vals = append(vals, string(item.Value))
+	}
+	return vals
+}
+
+func TestPutReadAck(t *testing.T) {
+	ctx := context.Background()
+	q := newTestQueue(t, newTestClient(""), Settings{Name: "test"})
+	defer func() { assert.NoError(t, q.Close(ctx)) }()
+
+	require.NoError(t, q.Put(ctx, []byte("a"), []byte("bb")))
+	require.NoError(t, q.Put(ctx, []byte("ccc")))
+	assert.Equal(t, uint64(3), q.Len())
+	assert.Equal(t, uint64(6), q.SizeBytes())
+
+	items, err := q.Read(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "bb"}, values(items))
+
+	items, err = q.Read(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ccc"}, values(items))
+
+	// Read items stay in the queue until they are acknowledged.
+	assert.Equal(t, uint64(3), q.Len())
+	require.NoError(t, q.Ack(ctx, 1))
+	assert.Equal(t, uint64(1), q.Len())
+	assert.Equal(t, uint64(3), q.SizeBytes())
+	require.NoError(t, q.Ack(ctx, items[0].Index))
+	assert.Equal(t, uint64(0), q.Len())
+	assert.Equal(t, uint64(0), q.SizeBytes())
+
+	// Acknowledging twice is a no-op, acknowledging unread items is an error.
+	assert.NoError(t, q.Ack(ctx, 1))
+	assert.Error(t, q.Ack(ctx, 3))
+}
+
+func TestReadInvalidMax(t *testing.T) {
+	q := newTestQueue(t, newTestClient(""), Settings{})
+	_, err := q.Read(context.Background(), 0)
+	assert.Error(t, err)
+}
+
+func TestReadBlocksUntilPut(t *testing.T) {
+	ctx := context.Background()
+	q := newTestQueue(t, newTestClient(""), Settings{})
+	defer func() { assert.NoError(t, q.Close(ctx)) }()
+
+	done := make(chan []Item)
+	go func() {
+		items, err := q.Read(ctx, 10)
+		assert.NoError(t, err)
+		done <- items
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("read returned before any item was put")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	require.NoError(t, q.Put(ctx, []byte("a")))
+	select {
+	case items := <-done:
+		assert.Equal(t, []string{"a"}, values(items))
+	case <-time.After(5 * time.Second):
+		t.Fatal("read did not return after an item was put")
+	}
+}
+
+func TestReadContextDone(t *testing.T) {
+	q := newTestQueue(t, newTestClient(""), Settings{})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := q.Read(ctx, 1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClose(t *testing.T) {
+	ctx := context.Background()
+	q := newTestQueue(t, newTestClient(""), Settings{})
+
+	errs := make(chan error)
+	go func() {
+		_, err := q.Read(ctx, 1)
+		errs <- err
+	}()
+	require.NoError(t, q.Close(ctx))
+	assert.ErrorIs(t, <-errs, ErrClosed)
+	assert.ErrorIs(t, q.Close(ctx), ErrClosed)
+	assert.ErrorIs(t, q.Put(ctx, []byte("a")), ErrClosed)
+}
+
+func TestRewind(t *testing.T) {
+	ctx := context.Background()
+	q := newTestQueue(t, newTestClient(""), Settings{})
+	defer func() { assert.NoError(t, q.Close(ctx)) }()
+
+	require.NoError(t, q.Put(ctx, []byte("a"), []byte("b"), []byte("c")))
+	items, err := q.Read(ctx, 1)
+	require.NoError(t, err)
+	require.NoError(t, q.Ack(ctx, items[0].Index))
+	items, err = q.Read(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, values(items))
+
+	// Unacknowledged items are delivered again.
+	q.Rewind()
+	items, err = q.Read(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, values(items))
+}
+
+func TestSizeCaps(t *testing.T) {
+	ctx := context.Background()
+
+	q := newTestQueue(t, newTestClient(""), Settings{MaxItems: 2})
+	require.NoError(t, q.Put(ctx, []byte("a")))
+	assert.ErrorIs(t, q.Put(ctx, []byte("b"), []byte("c")), ErrQueueFull)
+	require.NoError(t, q.Put(ctx, []byte("b")))
+	assert.ErrorIs(t, q.Put(ctx, []byte("c")), ErrQueueFull)
+	items, err := q.Read(ctx, 1)
+	require.NoError(t, err)
+	require.NoError(t, q.Ack(ctx, items[0].Index))
+	assert.NoError(t, q.Put(ctx, []byte("c")))
+
+	q = newTestQueue(t, newTestClient(""), Settings{MaxSizeBytes: 4})
+	require.NoError(t, q.Put(ctx, []byte("abc")))
+	assert.ErrorIs(t, q.Put(ctx, []byte("de")), ErrQueueFull)
+	assert.NoError(t, q.Put(ctx, []byte("d")))
+	assert.Equal(t, uint64(2), q.Len())
+}
+
+func TestReplayAfterRestart(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	q := newTestQueue(t, newTestClient(dir), Settings{})
+	require.NoError(t, q.Put(ctx, []byte("a"), []byte("b"), []byte("c")))
+	items, err := q.Read(ctx, 3)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	// Only the first item is processed before the restart.
+	require.NoError(t, q.Ack(ctx, items[0].Index))
+	require.NoError(t, q.Close(ctx))
+
+	q = newTestQueue(t, newTestClient(dir), Settings{})
+	defer func() { assert.NoError(t, q.Close(ctx)) }()
+	assert.Equal(t, uint64(2), q.Len())
+	assert.Equal(t, uint64(2), q.SizeBytes())
+	items, err = q.Read(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, values(items))
+	require.NoError(t, q.Ack(ctx, items[1].Index))
+
+	// New items are appended after the replayed ones.
+	require.NoError(t, q.Put(ctx, []byte("d")))
+	items, err = q.Read(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"d"}, values(items))
+	assert.Equal(t, uint64(3), items[0].Index)
+}
+
+func TestCorruptedItems(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient("")
+	q := newTestQueue(t, client, Settings{})
+	defer func() { assert.NoError(t, q.Close(ctx)) }()
+
+	require.NoError(t, q.Put(ctx, []byte("a"), []byte("b"), []byte("c"), []byte("d")))
+	require.NoError(t, client.Set(ctx, itemKey(1), []byte("garbage")))
+	require.NoError(t, client.Delete(ctx, itemKey(2)))
+
+	items, err := q.Read(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "d"}, values(items))
+	require.NoError(t, q.Ack(ctx, items[1].Index))
+	assert.Equal(t, uint64(0), q.Len())
+	assert.Equal(t, uint64(0), q.SizeBytes())
+
+	// A batch made only of corrupted items is skipped and removed.
+	require.NoError(t, q.Put(ctx, []byte("e"), []byte("f")))
+	require.NoError(t, client.Set(ctx, itemKey(4), []byte("garbage")))
+	items, err = q.Read(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"f"}, values(items))
+	assert.Equal(t, uint64(1), q.Len())
+	value, err := client.Get(ctx, itemKey(4))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestCorruptedMetadata(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient("")
+	q := newTestQueue(t, client, Settings{})
+	require.NoError(t, q.Put(ctx, []byte("a")))
+
+	require.NoError(t, client.Set(ctx, metadataKey, []byte("garbage")))
+	q = newTestQueue(t, client, Settings{})
+	assert.Equal(t, uint64(0), q.Len())
+
+	require.NoError(t, q.Put(ctx, []byte("b")))
+	items, err := q.Read(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, values(items))
+}
+
+func TestMetricViews(t *testing.T) {
+	views := MetricViews()
+	require.Len(t, views, 5)
+	for _, v := range views {
+		assert.Equal(t, []string{"queue"}, []string{v.TagKeys[0].Name()})
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/redisstorage"
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis/v7"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+type redisStorageClient struct {
+	client     *redis.Client
+	prefix     string
+	expiration time.Duration
+}
+
+func newClient(client *redis.Client, prefix string, expiration time.Duration) *redisStorageClient {
+	return &redisStorageClient{
+		client:     client,
+		prefix:     prefix,
+		expiration: expiration,
+	}
+}
+
+// Get will retrieve data from storage that corresponds to the specified key
+func (c *redisStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.client.WithContext(ctx).Get(c.prefix + key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	return value, err
+}
+
+// Set will store data. The data can be retrieved using the same key
+func (c *redisStorageClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.client.WithContext(ctx).Set(c.prefix+key, value, c.expiration).Err()
+}
+
+// Delete will delete data associated with the specified key
+func (c *redisStorageClient) Delete(ctx context.Context, key string) error {
+	return c.client.WithContext(ctx).Del(c.prefix + key).Err()
+}
+
+// Batch executes the specified operations in order, within a single transaction.
+// Get operation results are updated in place
+func (c *redisStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	pipe := c.client.WithContext(ctx).TxPipeline()
+	gets := make([]*redis.StringCmd, len(ops))
+	for i, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			gets[i] = pipe.Get(c.prefix + op.Key)
+		case storage.Set:
+			pipe.Set(c.prefix+op.Key, op.Value, c.expiration)
+		case storage.Delete:
+			pipe.Del(c.prefix + op.Key)
+		default:
+			_ = pipe.Discard()
+			return errors.New("wrong operation type")
+		}
+	}
+
+	// a missing key is reported as a redis.Nil error by its Get command, which is not a failure
+	cmds, err := pipe.ExecContext(ctx)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+	for _, cmd := range cmds {
+		if cmdErr := cmd.Err(); cmdErr != nil && !errors.Is(cmdErr, redis.Nil) {
+			return cmdErr
+		}
+	}
+
+	for i, get := range gets {
+		if get == nil {
+			continue
+		}
+		value, getErr := get.Bytes()
+		if errors.Is(getErr, redis.Nil) {
+			value = nil
+		}
+		ops[i].Value = value
+	}
+	return nil
+}
+
+// Close does nothing, as the connection is shared by all the clients of the extension
+func (c *redisStorageClient) Close(context.Context) error {
+	return nil
+}
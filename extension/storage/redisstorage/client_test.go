@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisstorage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v7"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+func newTestClient(t *testing.T, expiration time.Duration) (*redisStorageClient, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	rc := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		require.NoError(t, rc.Close())
+	})
+	return newClient(rc, "prefix:", expiration), server
+}
+
+func TestClientOperations(t *testing.T) {
+	client, server := newTestClient(t, 0)
+
+	ctx := context.Background()
+	testKey := "testKey"
+	testValue := []byte("testValue")
+
+	// Make sure nothing is there
+	value, err := client.Get(ctx, testKey)
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	// Set it
+	err = client.Set(ctx, testKey, testValue)
+	require.NoError(t, err)
+	stored, err := server.Get("prefix:" + testKey)
+	require.NoError(t, err)
+	require.Equal(t, string(testValue), stored)
+
+	// Get it back out, make sure it's right
+	value, err = client.Get(ctx, testKey)
+	require.NoError(t, err)
+	require.Equal(t, testValue, value)
+
+	// Delete it
+	err = client.Delete(ctx, testKey)
+	require.NoError(t, err)
+
+	// Make sure it's gone
+	value, err = client.Get(ctx, testKey)
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	require.NoError(t, client.Close(ctx))
+}
+
+func TestClientBatchOperations(t *testing.T) {
+	client, _ := newTestClient(t, 0)
+
+	ctx := context.Background()
+	testSetEntries := []storage.Operation{
+		storage.SetOperation("testKey1", []byte("testValue1")),
+		storage.SetOperation("testKey2", []byte("testValue2")),
+	}
+
+	testGetEntries := []storage.Operation{
+		storage.GetOperation("testKey1"),
+		storage.GetOperation("testKey2"),
+		storage.GetOperation("missing"),
+	}
+
+	// Make sure nothing is there
+	err := client.Batch(ctx, testGetEntries...)
+	require.NoError(t, err)
+	for _, op := range testGetEntries {
+		require.Nil(t, op.Value)
+	}
+
+	// Set it
+	err = client.Batch(ctx, testSetEntries...)
+	require.NoError(t, err)
+
+	// Get it back out, make sure it's right
+	err = client.Batch(ctx, testGetEntries...)
+	require.NoError(t, err)
+	require.Equal(t, []byte("testValue1"), testGetEntries[0].Value)
+	require.Equal(t, []byte("testValue2"), testGetEntries[1].Value)
+	require.Nil(t, testGetEntries[2].Value)
+
+	// Operations are applied in order
+	setThenGet := []storage.Operation{
+		storage.DeleteOperation("testKey1"),
+		storage.GetOperation("testKey1"),
+		storage.SetOperation("testKey2", []byte("updated")),
+		storage.GetOperation("testKey2"),
+	}
+	err = client.Batch(ctx, setThenGet...)
+	require.NoError(t, err)
+	require.Nil(t, setThenGet[1].Value)
+	require.Equal(t, []byte("updated"), setThenGet[3].Value)
+
+	wrongOp := storage.GetOperation("testKey2")
+	wrongOp.Type = 10
+	err = client.Batch(ctx, storage.GetOperation("testKey2"), wrongOp)
+	require.EqualError(t, err, "wrong operation type")
+}
+
+func TestClientExpiration(t *testing.T) {
+	client, server := newTestClient(t, time.Minute)
+	ctx := context.Background()
+
+	require.NoError(t, client.Set(ctx, "set", []byte("value")))
+	require.NoError(t, client.Batch(ctx, storage.SetOperation("batch", []byte("value"))))
+	require.Equal(t, time.Minute, server.TTL("prefix:set"))
+	require.Equal(t, time.Minute, server.TTL("prefix:batch"))
+
+	// setting a key again renews its expiration
+	server.FastForward(40 * time.Second)
+	require.NoError(t, client.Set(ctx, "set", []byte("value")))
+	server.FastForward(40 * time.Second)
+
+	value, err := client.Get(ctx, "set")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+	value, err = client.Get(ctx, "batch")
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
+
+func TestClientErrors(t *testing.T) {
+	client, server := newTestClient(t, 0)
+	ctx := context.Background()
+	server.Close()
+
+	_, err := client.Get(ctx, "key")
+	require.Error(t, err)
+	require.Error(t, client.Set(ctx, "key", []byte("value")))
+	require.Error(t, client.Delete(ctx, "key"))
+	require.Error(t, client.Batch(ctx, storage.GetOperation("key")))
+}
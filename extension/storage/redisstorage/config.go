@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/redisstorage"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// Config defines configuration for redisstorage extension.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"`
+
+	// Endpoint is the address of the Redis server, in the host:port format
+	Endpoint string `mapstructure:"endpoint"`
+	// Password must match the password specified in the requirepass server configuration option
+	Password string `mapstructure:"password"`
+	// DB is the Redis database to select after connecting to the server
+	DB int `mapstructure:"db"`
+
+	TLS configtls.TLSClientSetting `mapstructure:"tls,omitempty"`
+
+	// Prefix is prepended to the keys of every component, so that several collectors
+	// can share a Redis server without their keys colliding
+	Prefix string `mapstructure:"prefix"`
+	// Expiration is the time to live of all the keys, renewed each time a key is set.
+	// Keys never expire when it is zero.
+	Expiration time.Duration `mapstructure:"expiration"`
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return fmt.Errorf("missing endpoint for %s", cfg.ID())
+	}
+	if cfg.DB < 0 {
+		return errors.New("db cannot be less than 0")
+	}
+	if cfg.Expiration < 0 {
+		return errors.New("expiration cannot be less than 0")
+	}
+	if cfg.Expiration > 0 && cfg.Expiration < time.Millisecond {
+		return errors.New("expiration must be at least 1ms, the resolution of Redis expiration")
+	}
+
+	return nil
+}
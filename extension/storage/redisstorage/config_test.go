@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisstorage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id       config.ComponentID
+		expected config.Extension
+	}{
+		{
+			id:       config.NewComponentID(typeStr),
+			expected: NewFactory().CreateDefaultConfig(),
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "all_settings"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				Endpoint:          "redis.example.com:6380",
+				Password:          "secret",
+				DB:                2,
+				TLS: configtls.TLSClientSetting{
+					Insecure: true,
+				},
+				Prefix:     "otelcol-gateway",
+				Expiration: time.Hour,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalExtension(sub, cfg))
+
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		update   func(cfg *Config)
+		expected string
+	}{
+		{
+			name:     "missing endpoint",
+			update:   func(cfg *Config) { cfg.Endpoint = "" },
+			expected: "missing endpoint for redis_storage",
+		},
+		{
+			name:     "negative db",
+			update:   func(cfg *Config) { cfg.DB = -1 },
+			expected: "db cannot be less than 0",
+		},
+		{
+			name:     "negative expiration",
+			update:   func(cfg *Config) { cfg.Expiration = -time.Second },
+			expected: "expiration cannot be less than 0",
+		},
+		{
+			name:     "expiration below resolution",
+			update:   func(cfg *Config) { cfg.Expiration = time.Microsecond },
+			expected: "expiration must be at least 1ms, the resolution of Redis expiration",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			tt.update(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.expected)
+		})
+	}
+}
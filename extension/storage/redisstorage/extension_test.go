@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisstorage
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+func TestExtensionIntegrity(t *testing.T) {
+	ctx := context.Background()
+	se, _ := newTestExtension(t)
+
+	type mockComponent struct {
+		kind component.Kind
+		name config.ComponentID
+	}
+
+	components := []mockComponent{
+		{kind: component.KindReceiver, name: newTestEntity("receiver_one")},
+		{kind: component.KindReceiver, name: newTestEntity("receiver_two")},
+		{kind: component.KindProcessor, name: newTestEntity("processor_one")},
+		{kind: component.KindProcessor, name: newTestEntity("processor_two")},
+		{kind: component.KindExporter, name: newTestEntity("exporter_one")},
+		{kind: component.KindExporter, name: newTestEntity("exporter_two")},
+		{kind: component.KindExtension, name: newTestEntity("extension_one")},
+		{kind: component.KindExtension, name: newTestEntity("extension_two")},
+	}
+
+	// Make a client for each component
+	clients := make(map[config.ComponentID]storage.Client)
+	for _, c := range components {
+		client, err := se.GetClient(ctx, c.kind, c.name, "")
+		require.NoError(t, err)
+		clients[c.name] = client
+	}
+
+	thrashClient := func(wg *sync.WaitGroup, n config.ComponentID, c storage.Client) {
+		defer wg.Done()
+		// keys and values
+		keys := []string{"a", "b", "c", "d", "e"}
+		myBytes := []byte(n.Name())
+
+		// Set my values
+		for i := 0; i < len(keys); i++ {
+			assert.NoError(t, c.Set(ctx, keys[i], myBytes))
+		}
+
+		// Repeatedly thrash client
+		for j := 0; j < 20; j++ {
+			// Make sure my values are still mine
+			for i := 0; i < len(keys); i++ {
+				v, err := c.Get(ctx, keys[i])
+				assert.NoError(t, err)
+				assert.Equal(t, myBytes, v)
+			}
+
+			// Delete my values
+			for i := 0; i < len(keys); i++ {
+				assert.NoError(t, c.Delete(ctx, keys[i]))
+			}
+
+			// Reset my values
+			for i := 0; i < len(keys); i++ {
+				assert.NoError(t, c.Set(ctx, keys[i], myBytes))
+			}
+		}
+		assert.NoError(t, c.Close(ctx))
+	}
+
+	// Use clients concurrently
+	var wg sync.WaitGroup
+	for name, client := range clients {
+		wg.Add(1)
+		go thrashClient(&wg, name, client)
+	}
+	wg.Wait()
+}
+
+func TestKeyPrefix(t *testing.T) {
+	ctx := context.Background()
+	se, server := newTestExtension(t)
+
+	client, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("my_component"), "")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+
+	named, err := se.GetClient(ctx, component.KindExporter, newTestEntity("my_component"), "queue")
+	require.NoError(t, err)
+	require.NoError(t, named.Set(ctx, "key", []byte("other")))
+
+	assert.ElementsMatch(t, []string{
+		"otelcol:receiver_nop_my_component:key",
+		"otelcol:exporter_nop_my_component_queue:key",
+	}, server.Keys())
+
+	// components can only see their own keys
+	value, err := named.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("other"), value)
+}
+
+func TestStartFailsWithoutServer(t *testing.T) {
+	server := miniredis.RunT(t)
+	addr := server.Addr()
+	server.Close()
+
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = addr
+
+	extension, err := f.CreateExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), cfg)
+	require.NoError(t, err)
+	err = extension.Start(context.Background(), componenttest.NewNopHost())
+	require.ErrorContains(t, err, "failed to connect to redis at "+addr)
+	require.NoError(t, extension.Shutdown(context.Background()))
+
+	_, err = extension.(storage.Extension).GetClient(context.Background(), component.KindReceiver, newTestEntity("my_component"), "")
+	require.EqualError(t, err, "redis storage is not started")
+}
+
+func newTestExtension(t *testing.T) (storage.Extension, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = server.Addr()
+
+	extension, err := f.CreateExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, extension.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, extension.Shutdown(context.Background()))
+	})
+
+	se, ok := extension.(storage.Extension)
+	require.True(t, ok)
+
+	return se, server
+}
+
+func newTestEntity(name string) config.ComponentID {
+	return config.NewComponentIDWithName("nop", name)
+}
@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package connctx carries the connection of the HTTP requests in their context, so that the server
+// authenticators can read the state of their TLS connection, as they do from the peer of the gRPC requests.
+package connctx // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/connctx"
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+)
+
+type connKey struct{}
+
+// ConnContext adds the connection to the context of the requests it carries. It is meant to be set as the
+// ConnContext of the http.Server of the receivers.
+func ConnContext(ctx context.Context, c net.Conn) context.Context {
+	return context.WithValue(ctx, connKey{}, c)
+}
+
+// TLSConnectionState returns the state of the TLS connection the HTTP request of the context was received
+// from, once its handshake is complete.
+func TLSConnectionState(ctx context.Context) (tls.ConnectionState, bool) {
+	// the HTTP server completes the handshake before reading the requests
+	c, ok := ctx.Value(connKey{}).(*tls.Conn)
+	if !ok {
+		return tls.ConnectionState{}, false
+	}
+	state := c.ConnectionState()
+	return state, state.HandshakeComplete
+}
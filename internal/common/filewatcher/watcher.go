@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package filewatcher notifies the changes of a file, including when it is replaced like the files of
+// Kubernetes config maps and secrets are.
+package filewatcher // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/filewatcher"
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+// Delay is how long a file has to remain unchanged before its change is notified,
+// so that a file being written is only notified once complete.
+const Delay = 100 * time.Millisecond
+
+// Watcher calls a function when a file changes. It watches the parent directory of the file rather than the
+// file itself, as a watch on the file is dropped when the file is removed or replaced.
+type Watcher struct {
+	path     string
+	target   string
+	onChange func()
+	logger   *zap.Logger
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Start watches the file at path, and calls onChange from another goroutine each time the file changed.
+func Start(path string, logger *zap.Logger, onChange func()) (*Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	path = filepath.Clean(path)
+	if err = watcher.Add(filepath.Dir(path)); err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &Watcher{
+		path:     path,
+		target:   resolve(path),
+		onChange: onChange,
+		logger:   logger,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	go w.watch(ctx, watcher)
+	return w, nil
+}
+
+// Stop stops watching the file, and waits for onChange to return if it is being called.
+func (w *Watcher) Stop() {
+	w.cancel()
+	<-w.done
+}
+
+func (w *Watcher) watch(ctx context.Context, watcher *fsnotify.Watcher) {
+	defer close(w.done)
+	defer watcher.Close()
+
+	notify := time.NewTimer(Delay)
+	if !notify.Stop() {
+		<-notify.C
+	}
+	defer notify.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-notify.C:
+			w.onChange()
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if !w.changed(event) {
+				continue
+			}
+			// the notification is postponed until the file stops changing
+			if !notify.Stop() {
+				select {
+				case <-notify.C:
+				default:
+				}
+			}
+			notify.Reset(Delay)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			w.logger.Error("error watching file", zap.String("path", w.path), zap.Error(err))
+		}
+	}
+}
+
+// changed reports whether the event of the directory changed the file: either the file itself changed, or
+// a symbolic link it resolves through was replaced.
+// NOTE: k8s configmaps and secrets replace a symlink to the directory holding the files.
+// SEE: https://martensson.io/go-fsnotify-and-kubernetes-configmaps/
+func (w *Watcher) changed(event fsnotify.Event) bool {
+	target := resolve(w.path)
+	if filepath.Clean(event.Name) == w.path {
+		w.target = target
+		return event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) != 0
+	}
+	if target == w.target {
+		return false
+	}
+	w.target = target
+	return true
+}
+
+// resolve returns the path of the file the symbolic links of path point to, or an empty string when
+// the file doesn't exist.
+func resolve(path string) string {
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return ""
+	}
+	return target
+}
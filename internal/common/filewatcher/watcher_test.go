@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filewatcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func startWatcher(t *testing.T, path string) <-chan struct{} {
+	changes := make(chan struct{}, 10)
+	w, err := Start(path, zap.NewNop(), func() { changes <- struct{}{} })
+	require.NoError(t, err)
+	t.Cleanup(w.Stop)
+	return changes
+}
+
+func waitChange(t *testing.T, changes <-chan struct{}) {
+	select {
+	case <-changes:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the change was not notified")
+	}
+}
+
+func TestWatchFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(path, []byte("first"), 0600))
+	changes := startWatcher(t, path)
+
+	require.NoError(t, os.WriteFile(path, []byte("second"), 0600))
+	waitChange(t, changes)
+
+	// the file is still watched after being removed
+	require.NoError(t, os.Remove(path))
+	waitChange(t, changes)
+	require.NoError(t, os.WriteFile(path, []byte("third"), 0600))
+	waitChange(t, changes)
+
+	// and when it is replaced
+	replacement := filepath.Join(dir, "replacement")
+	require.NoError(t, os.WriteFile(replacement, []byte("fourth"), 0600))
+	require.NoError(t, os.Rename(replacement, path))
+	waitChange(t, changes)
+
+	// the other files of the directory are ignored
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other"), []byte("other"), 0600))
+	select {
+	case <-changes:
+		require.Fail(t, "the change of another file was notified")
+	case <-time.After(2 * Delay):
+	}
+}
+
+func TestWatchKubernetesSecret(t *testing.T) {
+	// the files of a secret are symbolic links to the files of a directory, itself replaced through a symbolic link
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "..v1"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "..v1", "token"), []byte("first"), 0600))
+	require.NoError(t, os.Symlink("..v1", filepath.Join(dir, "..data")))
+	path := filepath.Join(dir, "token")
+	require.NoError(t, os.Symlink(filepath.Join("..data", "token"), path))
+	changes := startWatcher(t, path)
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "..v2"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "..v2", "token"), []byte("second"), 0600))
+	require.NoError(t, os.Symlink("..v2", filepath.Join(dir, "..data_tmp")))
+	require.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "..v1")))
+	waitChange(t, changes)
+}
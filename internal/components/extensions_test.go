@@ -33,6 +33,7 @@ import (
 	"go.opentelemetry.io/collector/extension/ballastextension"
 	"go.opentelemetry.io/collector/extension/zpagesextension"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/apikeyauthextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/asapauthextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/basicauthextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/bearertokenauthextension"
@@ -40,6 +41,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/headerssetterextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/httpforwarder"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/oauth2clientauthextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/ecstaskobserver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/hostobserver"
@@ -123,6 +125,14 @@ func TestDefaultExtensions(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			extension: "apikeyauth",
+			getConfigFn: func() config.Extension {
+				cfg := extFactories["apikeyauth"].CreateDefaultConfig().(*apikeyauthextension.Config)
+				cfg.Keys = []apikeyauthextension.KeyConfig{{Key: "key", Tenant: "tenant"}}
+				return cfg
+			},
+		},
 		{
 			extension: "asapclient",
 			getConfigFn: func() config.Extension {
@@ -170,6 +180,12 @@ func TestDefaultExtensions(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			extension: "mtlsauth",
+			getConfigFn: func() config.Extension {
+				return extFactories["mtlsauth"].CreateDefaultConfig()
+			},
+		},
 		{
 			extension: "oauth2client",
 			getConfigFn: func() config.Extension {
@@ -212,6 +228,10 @@ func TestDefaultExtensions(t *testing.T) {
 			extension:     "k8s_observer",
 			skipLifecycle: true, // Requires a K8s api to interfact with and validate
 		},
+		{
+			extension:     "redis_storage",
+			skipLifecycle: true, // Requires a running Redis server in order to complete life cycle testing
+		},
 		{
 			extension: "headers_setter",
 			getConfigFn: func() config.Extension {
@@ -121,6 +121,10 @@ func TestDefaultProcessors(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			processor:     "sampling_throughput",
+			skipLifecycle: true, // Requires a jaegerremotesampling extension to record the throughput
+		},
 		{
 			processor:     "servicegraph",
 			skipLifecycle: true,
@@ -129,9 +133,15 @@ func TestDefaultProcessors(t *testing.T) {
 			processor:     "spanmetrics",
 			skipLifecycle: true, // Requires a running exporter to convert data to/from
 		},
+		{
+			processor: "cardinalitylimiter",
+		},
 		{
 			processor: "cumulativetodelta",
 		},
+		{
+			processor: "metricstarttime",
+		},
 		{
 			processor: "tail_sampling",
 		},
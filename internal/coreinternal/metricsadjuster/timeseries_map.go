@@ -0,0 +1,350 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metricsadjuster tracks cumulative timeseries across batches in order to
+// set their start timestamps to the first observed point and to detect resets.
+package metricsadjuster // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/metricsadjuster"
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// Notes on garbage collection (gc):
+//
+// Some sources may export timeseries that only exist for a short period of time. In order to keep
+// the TimeseriesMap from leaking memory for entries that no longer exist, the TimeseriesMap needs to
+// remove entries that haven't been accessed for a long period of time.
+//
+// The gc strategy uses a standard mark-and-sweep approach - each time a TimeseriesMap is accessed,
+// it is marked. Similarly, each time a timeseriesInfo is accessed, it is also marked.
+//
+// The gc for the TimeseriesMap is straightforward - the map is locked and, for each timeseriesInfo
+// in the map, if it has not been marked, it is removed otherwise it is unmarked. Owners of several
+// TimeseriesMaps can use IsMarked() to drop whole maps that were not accessed since the last gc.
+
+// timeseriesInfo contains the information necessary to adjust from the initial point and to detect resets.
+type timeseriesInfo struct {
+	mark bool
+
+	number    numberInfo
+	histogram histogramInfo
+	summary   summaryInfo
+}
+
+type numberInfo struct {
+	startTime     pcommon.Timestamp
+	previousValue float64
+}
+
+type histogramInfo struct {
+	startTime     pcommon.Timestamp
+	previousCount uint64
+	previousSum   float64
+}
+
+type summaryInfo struct {
+	startTime     pcommon.Timestamp
+	previousCount uint64
+	previousSum   float64
+}
+
+type timeseriesKey struct {
+	name           string
+	attributes     string
+	aggTemporality pmetric.AggregationTemporality
+}
+
+// TimeseriesMap maps from a timeseries instance (metric * label values) to the timeseries info for
+// the instance.
+type TimeseriesMap struct {
+	sync.RWMutex
+	// The mutex is used to protect access to the member fields. It must be held by the callers of
+	// AdjustMetric() and IsMarked(), and it is acquired by GC().
+
+	mark   bool
+	tsiMap map[timeseriesKey]*timeseriesInfo
+}
+
+// NewTimeseriesMap creates a new (empty) TimeseriesMap.
+func NewTimeseriesMap() *TimeseriesMap {
+	return &TimeseriesMap{mark: true, tsiMap: map[timeseriesKey]*timeseriesInfo{}}
+}
+
+// Get the timeseriesInfo for the timeseries associated with the metric and label values.
+func (tsm *TimeseriesMap) get(metric pmetric.Metric, kv pcommon.Map) (*timeseriesInfo, bool) {
+	// This should only be invoked be functions called (directly or indirectly) by AdjustMetric().
+	// The lock protecting tsm.tsiMap is held by the caller.
+	name := metric.Name()
+	key := timeseriesKey{
+		name:       name,
+		attributes: AttributesSignature(kv),
+	}
+	switch metric.Type() {
+	case pmetric.MetricTypeHistogram:
+		// There are 2 types of Histograms whose aggregation temporality needs distinguishing:
+		// * CumulativeHistogram
+		// * GaugeHistogram
+		key.aggTemporality = metric.Histogram().AggregationTemporality()
+	case pmetric.MetricTypeExponentialHistogram:
+		key.aggTemporality = metric.ExponentialHistogram().AggregationTemporality()
+	}
+
+	tsm.mark = true
+	tsi, ok := tsm.tsiMap[key]
+	if !ok {
+		tsi = &timeseriesInfo{}
+		tsm.tsiMap[key] = tsi
+	}
+	tsi.mark = true
+	return tsi, ok
+}
+
+// signatureEscaper escapes the separators of AttributesSignature in keys and values.
+var signatureEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, `,`, `\,`)
+
+// AttributesSignature returns a signature which identifies a set of attributes, made of
+// their sorted key=value pairs. Attributes with an empty string value are left out, as
+// Prometheus treats empty labels as missing. Otherwise, different attributes always
+// have different signatures.
+func AttributesSignature(attrs pcommon.Map) string {
+	pairs := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		if v.Type() == pcommon.ValueTypeStr && v.Str() == "" {
+			return true
+		}
+		pairs = append(pairs, signatureEscaper.Replace(k)+"="+signatureEscaper.Replace(v.AsString()))
+		return true
+	})
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// IsMarked reports whether the TimeseriesMap was accessed since the last GC().
+func (tsm *TimeseriesMap) IsMarked() bool {
+	return tsm.mark
+}
+
+// GC removes timeseries that have not been accessed since the previous call.
+func (tsm *TimeseriesMap) GC() {
+	tsm.Lock()
+	defer tsm.Unlock()
+	// this shouldn't happen under the current gc() strategy
+	if !tsm.mark {
+		return
+	}
+	for ts, tsi := range tsm.tsiMap {
+		if !tsi.mark {
+			delete(tsm.tsiMap, ts)
+		} else {
+			tsi.mark = false
+		}
+	}
+	tsm.mark = false
+}
+
+// AdjustMetric sets the start time of the cumulative points of the metric to the start time of
+// the first point seen for their timeseries, or of the last point that reset the timeseries.
+// It returns false if the metric type is not supported.
+func (tsm *TimeseriesMap) AdjustMetric(metric pmetric.Metric) bool {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		// gauges don't need to be adjusted so no additional processing is necessary
+
+	case pmetric.MetricTypeHistogram:
+		adjustMetricHistogram(tsm, metric)
+
+	case pmetric.MetricTypeExponentialHistogram:
+		adjustMetricExponentialHistogram(tsm, metric)
+
+	case pmetric.MetricTypeSummary:
+		adjustMetricSummary(tsm, metric)
+
+	case pmetric.MetricTypeSum:
+		adjustMetricSum(tsm, metric)
+
+	default:
+		return false
+	}
+	return true
+}
+
+func adjustMetricHistogram(tsm *TimeseriesMap, current pmetric.Metric) {
+	histogram := current.Histogram()
+	if histogram.AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+		// Only dealing with CumulativeDistributions.
+		return
+	}
+
+	currentPoints := histogram.DataPoints()
+	for i := 0; i < currentPoints.Len(); i++ {
+		currentDist := currentPoints.At(i)
+		tsi, found := tsm.get(current, currentDist.Attributes())
+		if !found {
+			// initialize everything.
+			tsi.histogram.startTime = currentDist.StartTimestamp()
+			tsi.histogram.previousCount = currentDist.Count()
+			tsi.histogram.previousSum = currentDist.Sum()
+			continue
+		}
+
+		if currentDist.Flags().NoRecordedValue() {
+			// TODO: Investigate why this does not reset.
+			currentDist.SetStartTimestamp(tsi.histogram.startTime)
+			continue
+		}
+
+		if currentDist.Count() < tsi.histogram.previousCount || currentDist.Sum() < tsi.histogram.previousSum {
+			// reset re-initialize everything.
+			tsi.histogram.startTime = currentDist.StartTimestamp()
+			tsi.histogram.previousCount = currentDist.Count()
+			tsi.histogram.previousSum = currentDist.Sum()
+			continue
+		}
+
+		// Update only previous values.
+		tsi.histogram.previousCount = currentDist.Count()
+		tsi.histogram.previousSum = currentDist.Sum()
+		currentDist.SetStartTimestamp(tsi.histogram.startTime)
+	}
+}
+
+func adjustMetricExponentialHistogram(tsm *TimeseriesMap, current pmetric.Metric) {
+	histogram := current.ExponentialHistogram()
+	if histogram.AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+		// Only dealing with CumulativeDistributions.
+		return
+	}
+
+	currentPoints := histogram.DataPoints()
+	for i := 0; i < currentPoints.Len(); i++ {
+		currentDist := currentPoints.At(i)
+		tsi, found := tsm.get(current, currentDist.Attributes())
+		if !found {
+			// initialize everything.
+			tsi.histogram.startTime = currentDist.StartTimestamp()
+			tsi.histogram.previousCount = currentDist.Count()
+			tsi.histogram.previousSum = currentDist.Sum()
+			continue
+		}
+
+		if currentDist.Flags().NoRecordedValue() {
+			// TODO: Investigate why this does not reset.
+			currentDist.SetStartTimestamp(tsi.histogram.startTime)
+			continue
+		}
+
+		if currentDist.Count() < tsi.histogram.previousCount || currentDist.Sum() < tsi.histogram.previousSum {
+			// reset re-initialize everything.
+			tsi.histogram.startTime = currentDist.StartTimestamp()
+			tsi.histogram.previousCount = currentDist.Count()
+			tsi.histogram.previousSum = currentDist.Sum()
+			continue
+		}
+
+		// Update only previous values.
+		tsi.histogram.previousCount = currentDist.Count()
+		tsi.histogram.previousSum = currentDist.Sum()
+		currentDist.SetStartTimestamp(tsi.histogram.startTime)
+	}
+}
+
+func adjustMetricSum(tsm *TimeseriesMap, current pmetric.Metric) {
+	sum := current.Sum()
+	if sum.AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+		// Only dealing with cumulative sums.
+		return
+	}
+
+	currentPoints := sum.DataPoints()
+	for i := 0; i < currentPoints.Len(); i++ {
+		currentSum := currentPoints.At(i)
+		currentValue := numberValue(currentSum)
+		tsi, found := tsm.get(current, currentSum.Attributes())
+		if !found {
+			// initialize everything.
+			tsi.number.startTime = currentSum.StartTimestamp()
+			tsi.number.previousValue = currentValue
+			continue
+		}
+
+		if currentSum.Flags().NoRecordedValue() {
+			// TODO: Investigate why this does not reset.
+			currentSum.SetStartTimestamp(tsi.number.startTime)
+			continue
+		}
+
+		if currentValue < tsi.number.previousValue {
+			// reset re-initialize everything.
+			tsi.number.startTime = currentSum.StartTimestamp()
+			tsi.number.previousValue = currentValue
+			continue
+		}
+
+		// Update only previous values.
+		tsi.number.previousValue = currentValue
+		currentSum.SetStartTimestamp(tsi.number.startTime)
+	}
+}
+
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
+
+func adjustMetricSummary(tsm *TimeseriesMap, current pmetric.Metric) {
+	currentPoints := current.Summary().DataPoints()
+
+	for i := 0; i < currentPoints.Len(); i++ {
+		currentSummary := currentPoints.At(i)
+		tsi, found := tsm.get(current, currentSummary.Attributes())
+		if !found {
+			// initialize everything.
+			tsi.summary.startTime = currentSummary.StartTimestamp()
+			tsi.summary.previousCount = currentSummary.Count()
+			tsi.summary.previousSum = currentSummary.Sum()
+			continue
+		}
+
+		if currentSummary.Flags().NoRecordedValue() {
+			// TODO: Investigate why this does not reset.
+			currentSummary.SetStartTimestamp(tsi.summary.startTime)
+			continue
+		}
+
+		if (currentSummary.Count() != 0 &&
+			tsi.summary.previousCount != 0 &&
+			currentSummary.Count() < tsi.summary.previousCount) ||
+			(currentSummary.Sum() != 0 &&
+				tsi.summary.previousSum != 0 &&
+				currentSummary.Sum() < tsi.summary.previousSum) {
+			// reset re-initialize everything.
+			tsi.summary.startTime = currentSummary.StartTimestamp()
+			tsi.summary.previousCount = currentSummary.Count()
+			tsi.summary.previousSum = currentSummary.Sum()
+			continue
+		}
+
+		// Update only previous values.
+		tsi.summary.previousCount = currentSummary.Count()
+		tsi.summary.previousSum = currentSummary.Sum()
+		currentSummary.SetStartTimestamp(tsi.summary.startTime)
+	}
+}
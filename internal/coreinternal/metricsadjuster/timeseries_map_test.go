@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsadjuster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	t1 = pcommon.Timestamp(1_000_000_000)
+	t2 = pcommon.Timestamp(2_000_000_000)
+	t3 = pcommon.Timestamp(3_000_000_000)
+)
+
+func intSum(temporality pmetric.AggregationTemporality, start, ts pcommon.Timestamp, value int64) pmetric.Metric {
+	metric := pmetric.NewMetric()
+	metric.SetName("requests")
+	sum := metric.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(temporality)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("method", "GET")
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(value)
+	return metric
+}
+
+func TestAdjustMetricIntSum(t *testing.T) {
+	tsm := NewTimeseriesMap()
+	tsm.Lock()
+	defer tsm.Unlock()
+
+	first := intSum(pmetric.AggregationTemporalityCumulative, t1, t1, 10)
+	assert.True(t, tsm.AdjustMetric(first))
+	assert.Equal(t, t1, first.Sum().DataPoints().At(0).StartTimestamp())
+
+	second := intSum(pmetric.AggregationTemporalityCumulative, t2, t2, 15)
+	assert.True(t, tsm.AdjustMetric(second))
+	assert.Equal(t, t1, second.Sum().DataPoints().At(0).StartTimestamp())
+
+	reset := intSum(pmetric.AggregationTemporalityCumulative, t3, t3, 3)
+	assert.True(t, tsm.AdjustMetric(reset))
+	assert.Equal(t, t3, reset.Sum().DataPoints().At(0).StartTimestamp())
+}
+
+func TestAdjustMetricDeltaSum(t *testing.T) {
+	tsm := NewTimeseriesMap()
+	tsm.Lock()
+	defer tsm.Unlock()
+
+	assert.True(t, tsm.AdjustMetric(intSum(pmetric.AggregationTemporalityDelta, t1, t2, 10)))
+	delta := intSum(pmetric.AggregationTemporalityDelta, t2, t3, 5)
+	assert.True(t, tsm.AdjustMetric(delta))
+	assert.Equal(t, t2, delta.Sum().DataPoints().At(0).StartTimestamp())
+	assert.Empty(t, tsm.tsiMap)
+}
+
+func TestAdjustMetricUnsupported(t *testing.T) {
+	tsm := NewTimeseriesMap()
+	tsm.Lock()
+	defer tsm.Unlock()
+
+	assert.False(t, tsm.AdjustMetric(pmetric.NewMetric()))
+}
+
+func TestGC(t *testing.T) {
+	tsm := NewTimeseriesMap()
+	adjust := func(m pmetric.Metric) {
+		tsm.Lock()
+		defer tsm.Unlock()
+		tsm.AdjustMetric(m)
+	}
+
+	adjust(intSum(pmetric.AggregationTemporalityCumulative, t1, t1, 10))
+	// unmark all entries
+	tsm.GC()
+	assert.False(t, tsm.IsMarked())
+	assert.Len(t, tsm.tsiMap, 1)
+
+	// only another timeseries is accessed, so the first one is collected
+	other := intSum(pmetric.AggregationTemporalityCumulative, t2, t2, 1)
+	other.SetName("errors")
+	adjust(other)
+	tsm.GC()
+	assert.Len(t, tsm.tsiMap, 1)
+
+	// the timeseries starts over
+	m := intSum(pmetric.AggregationTemporalityCumulative, t2, t2, 20)
+	adjust(m)
+	assert.Equal(t, t2, m.Sum().DataPoints().At(0).StartTimestamp())
+}
+
+func TestAttributesSignature(t *testing.T) {
+	a := pcommon.NewMap()
+	a.PutStr("service.name", "svc")
+	a.PutInt("port", 8080)
+	b := pcommon.NewMap()
+	b.PutInt("port", 8080)
+	b.PutStr("service.name", "svc")
+
+	assert.Equal(t, "port=8080,service.name=svc", AttributesSignature(a))
+	assert.Equal(t, AttributesSignature(a), AttributesSignature(b))
+
+	// Non-string values are part of the signature, empty strings are not
+	c := pcommon.NewMap()
+	c.PutInt("port", 8081)
+	c.PutStr("service.name", "svc")
+	assert.NotEqual(t, AttributesSignature(a), AttributesSignature(c))
+	d := pcommon.NewMap()
+	d.PutStr("service.name", "svc")
+	d.PutStr("host", "")
+	assert.Equal(t, "service.name=svc", AttributesSignature(d))
+
+	// Separators in keys and values are escaped
+	e := pcommon.NewMap()
+	e.PutStr("a", "b,c=d")
+	f := pcommon.NewMap()
+	f.PutStr("a", "b")
+	f.PutStr("c", "d")
+	assert.NotEqual(t, AttributesSignature(e), AttributesSignature(f))
+	assert.Equal(t, `a=b\,c\=d`, AttributesSignature(e))
+}
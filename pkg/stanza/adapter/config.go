@@ -25,9 +25,28 @@ import (
 // BaseConfig is the common configuration of a stanza-based receiver
 type BaseConfig struct {
 	config.ReceiverSettings `mapstructure:",squash"`
-	Operators               []operator.Config   `mapstructure:"operators"`
-	Converter               ConverterConfig     `mapstructure:"converter"`
-	StorageID               *config.ComponentID `mapstructure:"storage"`
+	Operators               []operator.Config    `mapstructure:"operators"`
+	Converter               ConverterConfig      `mapstructure:"converter"`
+	StorageID               *config.ComponentID  `mapstructure:"storage"`
+	RetryOnFailure          RetryOnFailureConfig `mapstructure:"retry_on_failure"`
+}
+
+// RetryOnFailureConfig controls how logs which are refused by the next
+// consumer are retried. While logs are being retried, the receiver stops
+// accepting new log entries, which applies backpressure to its input.
+type RetryOnFailureConfig struct {
+	// Enabled enables retrying logs which are refused with a non-permanent error.
+	Enabled bool `mapstructure:"enabled"`
+	// InitialInterval is the time to wait after the first failure before retrying.
+	// By default: 1s.
+	InitialInterval time.Duration `mapstructure:"initial_interval"`
+	// MaxInterval is the upper bound on the time to wait between retries.
+	// By default: 30s.
+	MaxInterval time.Duration `mapstructure:"max_interval"`
+	// MaxElapsedTime is the maximum amount of time spent retrying a batch of
+	// logs, after which it is dropped. Zero means that logs are retried until
+	// they are accepted or the receiver is shut down.
+	MaxElapsedTime time.Duration `mapstructure:"max_elapsed_time"`
 }
 
 // ConverterConfig controls how the internal entry.Entry to plog.Logs converter
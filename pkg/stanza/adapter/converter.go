@@ -248,7 +248,11 @@ func (c *Converter) aggregationLoop() {
 			}
 
 			for r, pLogs := range resourceIDToLogs {
-				c.flushChan <- pLogs
+				select {
+				case c.flushChan <- pLogs:
+				case <-c.stopChan:
+					return
+				}
 				delete(resourceIDToLogs, r)
 			}
 
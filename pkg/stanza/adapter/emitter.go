@@ -37,6 +37,7 @@ type LogEmitter struct {
 	wg            sync.WaitGroup
 	maxBatchSize  uint
 	flushInterval time.Duration
+	tracker       *tracker
 }
 
 type LogEmitterOption func(*LogEmitter)
@@ -83,6 +84,7 @@ func NewLogEmitter(opts ...LogEmitterOption) *LogEmitter {
 		batch:         make([]*entry.Entry, 0, defaultMaxBatchSize),
 		flushInterval: defaultFlushInterval,
 		cancel:        func() {},
+		tracker:       newTracker(),
 	}
 
 	for _, opt := range opts {
@@ -121,6 +123,7 @@ func (e *LogEmitter) OutChannel() <-chan []*entry.Entry {
 
 // Process will emit an entry to the output channel
 func (e *LogEmitter) Process(ctx context.Context, ent *entry.Entry) error {
+	e.tracker.add(1)
 	if oldBatch := e.appendEntry(ent); len(oldBatch) > 0 {
 		e.flush(ctx, oldBatch)
 	}
@@ -168,6 +171,70 @@ func (e *LogEmitter) flush(ctx context.Context, batch []*entry.Entry) {
 	select {
 	case e.logChan <- batch:
 	case <-ctx.Done():
+		e.tracker.complete(len(batch))
+	}
+}
+
+// Sync flushes the current batch and waits until the entries processed so far
+// have been consumed, or dropped, by the receiver reading the log channel.
+func (e *LogEmitter) Sync(ctx context.Context) error {
+	target := e.tracker.emitted()
+	if batch := e.makeNewBatch(); len(batch) > 0 {
+		e.flush(ctx, batch)
+	}
+	return e.tracker.wait(ctx, target)
+}
+
+// tracker counts the entries processed by the emitter and the ones which have
+// been consumed or dropped since.
+type tracker struct {
+	mu        sync.Mutex
+	processed uint64
+	done      uint64
+	// changed is closed and replaced whenever done changes
+	changed chan struct{}
+}
+
+func newTracker() *tracker {
+	return &tracker{changed: make(chan struct{})}
+}
+
+func (t *tracker) add(n int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.processed += uint64(n)
+}
+
+func (t *tracker) emitted() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.processed
+}
+
+// complete records that n entries have been consumed or dropped
+func (t *tracker) complete(n int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.done += uint64(n)
+	close(t.changed)
+	t.changed = make(chan struct{})
+}
+
+// wait blocks until target entries have been consumed or dropped
+func (t *tracker) wait(ctx context.Context, target uint64) error {
+	for {
+		t.mu.Lock()
+		done, changed := t.done, t.changed
+		t.mu.Unlock()
+		if done >= target {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 	}
 }
 
@@ -50,6 +50,57 @@ func TestLogEmitter(t *testing.T) {
 	}
 }
 
+func TestLogEmitterSync(t *testing.T) {
+	emitter := NewLogEmitter(
+		LogEmitterWithLogger(zaptest.NewLogger(t).Sugar()),
+		LogEmitterWithFlushInterval(time.Hour),
+	)
+
+	require.NoError(t, emitter.Start(nil))
+	defer func() {
+		require.NoError(t, emitter.Stop())
+	}()
+
+	in := entry.New()
+	require.NoError(t, emitter.Process(context.Background(), in))
+
+	synced := make(chan error, 1)
+	go func() {
+		synced <- emitter.Sync(context.Background())
+	}()
+
+	// Sync flushes the current batch, but does not return until it has been consumed
+	var out []*entry.Entry
+	select {
+	case out = <-emitter.logChan:
+		require.Equal(t, []*entry.Entry{in}, out)
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for output")
+	}
+	select {
+	case <-synced:
+		require.FailNow(t, "Sync returned before the entries were consumed")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	emitter.tracker.complete(len(out))
+	select {
+	case err := <-synced:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for sync")
+	}
+
+	// Sync fails when the entries are not consumed before the context is done
+	require.NoError(t, emitter.Process(context.Background(), entry.New()))
+	go func() {
+		<-emitter.logChan
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, emitter.Sync(ctx), context.DeadlineExceeded)
+}
+
 func TestLogEmitterRespectsMaxBatchSize(t *testing.T) {
 	const (
 		numEntries   = 1111
@@ -16,7 +16,9 @@ package adapter // import "github.com/open-telemetry/opentelemetry-collector-con
 
 import (
 	"context"
+	"sync"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
@@ -34,8 +36,15 @@ type LogReceiverType interface {
 	InputConfig(config.Receiver) operator.Config
 }
 
+var once sync.Once
+
 // NewFactory creates a factory for a Stanza-based receiver
 func NewFactory(logReceiverType LogReceiverType, sl component.StabilityLevel) component.ReceiverFactory {
+	once.Do(func() {
+		// TODO: as with other -contrib factories registering metrics, this is causing the error being ignored
+		_ = view.Register(MetricViews()...)
+	})
+
 	return component.NewReceiverFactory(
 		logReceiverType.Type(),
 		logReceiverType.CreateDefaultConfig,
@@ -97,6 +106,7 @@ func createLogsReceiver(logReceiverType LogReceiverType) component.CreateLogsRec
 			converter: converter,
 			obsrecv:   obsrecv,
 			storageID: baseCfg.StorageID,
+			retry:     baseCfg.RetryOnFailure,
 		}, nil
 	}
 }
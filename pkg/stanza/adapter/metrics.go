@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	receiverTagKey = tag.MustNewKey("receiver")
+
+	mConsumeRetries     = stats.Int64("stanza/consume_retries", "Number of times that logs refused by the next consumer were retried", stats.UnitDimensionless)
+	mDroppedLogRecords  = stats.Int64("stanza/dropped_log_records", "Number of log records that were dropped because the next consumer failed to accept them", stats.UnitDimensionless)
+	mBackpressureMillis = stats.Int64("stanza/backpressure_duration", "Time spent waiting to retry logs refused by the next consumer, during which no new logs are accepted", stats.UnitMilliseconds)
+)
+
+// MetricViews returns the metrics views related to passing logs from stanza-based receivers to the next consumer.
+func MetricViews() []*view.View {
+	tagKeys := []tag.Key{receiverTagKey}
+
+	return []*view.View{
+		{
+			Name:        mConsumeRetries.Name(),
+			Measure:     mConsumeRetries,
+			Description: mConsumeRetries.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        mDroppedLogRecords.Name(),
+			Measure:     mDroppedLogRecords,
+			Description: mDroppedLogRecords.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        mBackpressureMillis.Name(),
+			Measure:     mBackpressureMillis,
+			Description: mBackpressureMillis.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		},
+	}
+}
@@ -17,6 +17,7 @@ package adapter
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/config"
@@ -79,6 +80,32 @@ func (m *mockLogsRejecter) ConsumeLogs(ctx context.Context, ld plog.Logs) error
 	return errors.New("no")
 }
 
+// mockLogsRefuser refuses logs with the given error until it has been called refusals times.
+type mockLogsRefuser struct {
+	consumertest.LogsSink
+	mu       sync.Mutex
+	refusals int
+	calls    int
+	err      error
+}
+
+func (m *mockLogsRefuser) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls++
+	if m.calls <= m.refusals {
+		return m.err
+	}
+	return m.LogsSink.ConsumeLogs(ctx, ld)
+}
+
+func (m *mockLogsRefuser) Calls() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls
+}
+
 const testType = "test"
 
 type TestConfig struct {
@@ -18,12 +18,18 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/jpillora/backoff"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
@@ -41,6 +47,7 @@ type receiver struct {
 	converter *Converter
 	logger    *zap.Logger
 	obsrecv   *obsreport.Receiver
+	retry     RetryOnFailureConfig
 
 	storageID     *config.ComponentID
 	storageClient storage.Client
@@ -59,7 +66,7 @@ func (r *receiver) Start(ctx context.Context, host component.Host) error {
 		return fmt.Errorf("storage client: %w", err)
 	}
 
-	if err := r.pipe.Start(r.storageClient); err != nil {
+	if err := r.pipe.Start(&syncPersister{Persister: r.storageClient, emitter: r.emitter}); err != nil {
 		return fmt.Errorf("start stanza: %w", err)
 	}
 
@@ -108,6 +115,7 @@ func (r *receiver) emitterLoop(ctx context.Context) {
 
 			if err := r.converter.Batch(e); err != nil {
 				r.logger.Error("Could not add entry to batch", zap.Error(err))
+				r.emitter.tracker.complete(len(e))
 			}
 		}
 	}
@@ -132,13 +140,77 @@ func (r *receiver) consumerLoop(ctx context.Context) {
 				continue
 			}
 			obsrecvCtx := r.obsrecv.StartLogsOp(ctx)
-			cErr := r.consumer.ConsumeLogs(ctx, pLogs)
-			if cErr != nil {
-				r.logger.Error("ConsumeLogs() failed", zap.Error(cErr))
-			}
+			cErr := r.consume(ctx, pLogs)
 			r.obsrecv.EndLogsOp(obsrecvCtx, "stanza", pLogs.LogRecordCount(), cErr)
+			r.emitter.tracker.complete(pLogs.LogRecordCount())
+		}
+	}
+}
+
+const (
+	defaultRetryInitialInterval = time.Second
+	defaultRetryMaxInterval     = 30 * time.Second
+)
+
+// consume passes logs to the next consumer. When retry_on_failure is enabled,
+// logs which are refused with a non-permanent error, such as by the
+// memory_limiter processor, are retried until they are accepted. Since the
+// consumer loop does not read from the converter while retrying, the
+// converter, the emitter and ultimately the input operators are blocked.
+func (r *receiver) consume(ctx context.Context, pLogs plog.Logs) error {
+	mutators := []tag.Mutator{tag.Upsert(receiverTagKey, r.id.String())}
+
+	err := r.consumer.ConsumeLogs(ctx, pLogs)
+	if err == nil {
+		return nil
+	}
+
+	if r.retry.Enabled && !consumererror.IsPermanent(err) {
+		b := backoff.Backoff{
+			Min:    r.retry.InitialInterval,
+			Max:    r.retry.MaxInterval,
+			Factor: 2,
+			Jitter: true,
+		}
+		if b.Min <= 0 {
+			b.Min = defaultRetryInitialInterval
+		}
+		if b.Max <= 0 {
+			b.Max = defaultRetryMaxInterval
+		}
+
+		start := time.Now()
+		for err != nil && !consumererror.IsPermanent(err) {
+			if r.retry.MaxElapsedTime > 0 && time.Since(start) >= r.retry.MaxElapsedTime {
+				r.logger.Warn("No more retries left", zap.Duration("elapsed", time.Since(start)))
+				break
+			}
+
+			interval := b.Duration()
+			r.logger.Warn("ConsumeLogs() failed, will retry", zap.Error(err), zap.Duration("interval", interval))
+
+			waitStart := time.Now()
+			select {
+			case <-ctx.Done():
+				r.logger.Error("ConsumeLogs() failed, receiver is shutting down", zap.Error(err))
+				_ = stats.RecordWithTags(context.Background(), mutators, mDroppedLogRecords.M(int64(pLogs.LogRecordCount())))
+				return err
+			case <-time.After(interval):
+			}
+			_ = stats.RecordWithTags(ctx, mutators,
+				mConsumeRetries.M(1),
+				mBackpressureMillis.M(time.Since(waitStart).Milliseconds()))
+
+			err = r.consumer.ConsumeLogs(ctx, pLogs)
+		}
+		if err == nil {
+			return nil
 		}
 	}
+
+	r.logger.Error("ConsumeLogs() failed", zap.Error(err))
+	_ = stats.RecordWithTags(ctx, mutators, mDroppedLogRecords.M(int64(pLogs.LogRecordCount())))
+	return err
 }
 
 // Shutdown is invoked during service shutdown
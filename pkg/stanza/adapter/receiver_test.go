@@ -16,7 +16,9 @@ package adapter
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"testing"
@@ -26,6 +28,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.uber.org/zap"
 	"gopkg.in/yaml.v2"
@@ -103,6 +106,116 @@ func TestHandleConsumeError(t *testing.T) {
 	require.NoError(t, logsReceiver.Shutdown(context.Background()))
 }
 
+func TestHandleConsumeRetry(t *testing.T) {
+	testCases := []struct {
+		name          string
+		err           error
+		retry         RetryOnFailureConfig
+		expectedCalls int
+		expectedLogs  int
+	}{
+		{
+			name:          "retry_disabled",
+			err:           errors.New("refused"),
+			retry:         RetryOnFailureConfig{Enabled: false},
+			expectedCalls: 1,
+			expectedLogs:  0,
+		},
+		{
+			name:          "retry_until_accepted",
+			err:           errors.New("refused"),
+			retry:         RetryOnFailureConfig{Enabled: true, InitialInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond},
+			expectedCalls: 4,
+			expectedLogs:  1,
+		},
+		{
+			name:          "permanent_error",
+			err:           consumererror.NewPermanent(errors.New("bad data")),
+			retry:         RetryOnFailureConfig{Enabled: true, InitialInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond},
+			expectedCalls: 1,
+			expectedLogs:  0,
+		},
+		{
+			name:          "max_elapsed_time",
+			err:           errors.New("refused"),
+			retry:         RetryOnFailureConfig{Enabled: true, InitialInterval: 20 * time.Millisecond, MaxInterval: 20 * time.Millisecond, MaxElapsedTime: time.Nanosecond},
+			expectedCalls: 1,
+			expectedLogs:  0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockConsumer := &mockLogsRefuser{refusals: 3, err: tc.err}
+			factory := NewFactory(TestReceiverType{}, component.StabilityLevelInDevelopment)
+
+			cfg := factory.CreateDefaultConfig().(*TestConfig)
+			cfg.RetryOnFailure = tc.retry
+			logsReceiver, err := factory.CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, mockConsumer)
+			require.NoError(t, err, "receiver should successfully build")
+			require.NoError(t, logsReceiver.Start(context.Background(), componenttest.NewNopHost()))
+
+			stanzaReceiver := logsReceiver.(*receiver)
+			stanzaReceiver.emitter.logChan <- []*entry.Entry{entry.New()}
+
+			require.Eventually(t,
+				func() bool {
+					return mockConsumer.Calls() == tc.expectedCalls
+				},
+				10*time.Second, 5*time.Millisecond, "unexpected number of calls to the consumer",
+			)
+			require.Never(t,
+				func() bool {
+					return mockConsumer.Calls() > tc.expectedCalls
+				},
+				100*time.Millisecond, 5*time.Millisecond, "unexpected retries",
+			)
+			require.Equal(t, tc.expectedLogs, mockConsumer.LogRecordCount())
+			require.NoError(t, logsReceiver.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestShutdownWhileRetrying(t *testing.T) {
+	mockConsumer := &mockLogsRefuser{refusals: math.MaxInt, err: errors.New("refused")}
+	factory := NewFactory(TestReceiverType{}, component.StabilityLevelInDevelopment)
+
+	cfg := factory.CreateDefaultConfig().(*TestConfig)
+	cfg.RetryOnFailure = RetryOnFailureConfig{Enabled: true, InitialInterval: time.Hour}
+	logsReceiver, err := factory.CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, mockConsumer)
+	require.NoError(t, err, "receiver should successfully build")
+	require.NoError(t, logsReceiver.Start(context.Background(), componenttest.NewNopHost()))
+
+	stanzaReceiver := logsReceiver.(*receiver)
+	stanzaReceiver.emitter.logChan <- []*entry.Entry{entry.New()}
+
+	require.Eventually(t,
+		func() bool {
+			return mockConsumer.Calls() == 1
+		},
+		10*time.Second, 5*time.Millisecond, "one call to the consumer expected",
+	)
+
+	// While retrying, the receiver does not accept new entries
+	select {
+	case stanzaReceiver.emitter.logChan <- []*entry.Entry{entry.New()}:
+	case <-time.After(100 * time.Millisecond):
+	}
+	require.Eventually(t,
+		func() bool {
+			select {
+			case stanzaReceiver.emitter.logChan <- []*entry.Entry{entry.New()}:
+				return false
+			case <-time.After(50 * time.Millisecond):
+				return true
+			}
+		},
+		10*time.Second, 5*time.Millisecond, "receiver should apply backpressure",
+	)
+
+	require.NoError(t, logsReceiver.Shutdown(context.Background()))
+}
+
 func BenchmarkReadLine(b *testing.B) {
 	filePath := filepath.Join(b.TempDir(), "bench.log")
 
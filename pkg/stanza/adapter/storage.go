@@ -21,6 +21,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 )
 
 func GetStorageClient(ctx context.Context, host component.Host, storageID *config.ComponentID, componentID config.ComponentID) (storage.Client, error) {
@@ -50,3 +52,15 @@ func (r *receiver) setStorageClient(ctx context.Context, host component.Host) er
 	r.storageClient = client
 	return nil
 }
+
+// syncPersister is the persister of the operators of a receiver. Its Sync waits
+// until the entries emitted so far have been consumed by the next consumer, or
+// dropped, so that inputs can checkpoint their position only afterwards.
+type syncPersister struct {
+	operator.Persister
+	emitter *LogEmitter
+}
+
+func (p *syncPersister) Sync(ctx context.Context) error {
+	return p.emitter.Sync(ctx)
+}
@@ -57,9 +57,25 @@ type Config struct {
 	FingerprintSize         helper.ByteSize       `mapstructure:"fingerprint_size,omitempty"`
 	MaxLogSize              helper.ByteSize       `mapstructure:"max_log_size,omitempty"`
 	MaxConcurrentFiles      int                   `mapstructure:"max_concurrent_files,omitempty"`
+	MaxBytesPerPoll         helper.ByteSize       `mapstructure:"max_bytes_per_poll,omitempty"`
+	RateLimit               RateLimitConfig       `mapstructure:"rate_limit,omitempty"`
+	PerFileRateLimit        RateLimitConfig       `mapstructure:"per_file_rate_limit,omitempty"`
+	MetricsPerFile          bool                  `mapstructure:"metrics_per_file,omitempty"`
 	Splitter                helper.SplitterConfig `mapstructure:",squash,omitempty"`
 }
 
+// buildTagger returns the tagger of per file metrics, or nil if they are disabled.
+// At most max_concurrent_files files are tagged, to bound the number of series.
+func (c Config) buildTagger() *fileTagger {
+	if !c.MetricsPerFile {
+		return nil
+	}
+	return &fileTagger{
+		limit: c.MaxConcurrentFiles,
+		paths: make(map[string]struct{}),
+	}
+}
+
 // Build will build a file input operator from the supplied configuration
 func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error) {
 	if emit == nil {
@@ -94,6 +110,18 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error
 		return nil, fmt.Errorf("`max_concurrent_files` must be greater than 1")
 	}
 
+	if c.MaxBytesPerPoll < 0 {
+		return nil, fmt.Errorf("`max_bytes_per_poll` must not be negative")
+	}
+
+	if err := c.RateLimit.validate("rate_limit"); err != nil {
+		return nil, err
+	}
+
+	if err := c.PerFileRateLimit.validate("per_file_rate_limit"); err != nil {
+		return nil, err
+	}
+
 	if c.FingerprintSize == 0 {
 		c.FingerprintSize = DefaultFingerprintSize
 	} else if c.FingerprintSize < MinFingerprintSize {
@@ -125,11 +153,15 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error
 			readerConfig: &readerConfig{
 				fingerprintSize: int(c.FingerprintSize),
 				maxLogSize:      int(c.MaxLogSize),
+				maxBytesPerPoll: int64(c.MaxBytesPerPoll),
 				emit:            emit,
+				limiter:         c.RateLimit.build(int(c.MaxLogSize)),
+				tagger:          c.buildTagger(),
 			},
-			fromBeginning:   startAtBeginning,
-			splitterFactory: factory,
-			encodingConfig:  c.Splitter.EncodingConfig,
+			perFileRateLimit: c.PerFileRateLimit,
+			fromBeginning:    startAtBeginning,
+			splitterFactory:  factory,
+			encodingConfig:   c.Splitter.EncodingConfig,
 		},
 		finder:        c.Finder,
 		roller:        newRoller(),
@@ -304,6 +304,35 @@ func TestUnmarshal(t *testing.T) {
 					return newMockOperatorConfig(cfg)
 				}(),
 			},
+			{
+				Name: "max_bytes_per_poll",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.MaxBytesPerPoll = helper.ByteSize(1048576)
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
+			{
+				Name: "rate_limit",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.RateLimit = RateLimitConfig{
+						BytesPerSecond:   helper.ByteSize(10485760),
+						EntriesPerSecond: 5000,
+					}
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
+			{
+				Name: "per_file_rate_limit",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.PerFileRateLimit = RateLimitConfig{
+						BytesPerSecond: helper.ByteSize(1024),
+					}
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
 			{
 				Name: "max_log_size_mib_lower",
 				Expect: func() *mockOperatorConfig {
@@ -432,6 +461,30 @@ func TestBuild(t *testing.T) {
 			require.NoError,
 			func(t *testing.T, f *Manager) {},
 		},
+		{
+			"NegativeMaxBytesPerPoll",
+			func(f *Config) {
+				f.MaxBytesPerPoll = -1
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"NegativeRateLimit",
+			func(f *Config) {
+				f.RateLimit.EntriesPerSecond = -1
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"NegativePerFileRateLimit",
+			func(f *Config) {
+				f.PerFileRateLimit.BytesPerSecond = -1
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"InvalidEncoding",
 			func(f *Config) {
@@ -23,6 +23,7 @@ import (
 	"sync"
 	"time"
 
+	"go.opencensus.io/stats"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
@@ -45,6 +46,9 @@ type Manager struct {
 
 	knownFiles []*Reader
 	seenPaths  map[string]struct{}
+
+	// pollStart is the index of the matched file that the next poll starts with
+	pollStart int
 }
 
 func (m *Manager) Start(persister operator.Persister) error {
@@ -112,15 +116,38 @@ func (m *Manager) poll(ctx context.Context) {
 	}
 
 	// Get the list of paths on disk
-	matches := m.finder.FindFiles()
+	matches := m.rotate(m.finder.FindFiles())
+	var lag int64
 	for len(matches) > m.maxBatchFiles {
-		m.consume(ctx, matches[:m.maxBatchFiles])
+		lag += m.consume(ctx, matches[:m.maxBatchFiles])
 		matches = matches[m.maxBatchFiles:]
 	}
-	m.consume(ctx, matches)
+	lag += m.consume(ctx, matches)
+
+	if !m.readerFactory.readerConfig.tagger.tagsAll() {
+		stats.Record(ctx, mFileLag.M(lag))
+	}
+}
+
+// rotate reorders the matched paths so that each poll starts with the batch
+// following the first batch of the previous poll. Otherwise, the files at the
+// end of the match order would always be read last, after max_bytes_per_poll
+// and the rate limits have been used up by the files before them.
+func (m *Manager) rotate(matches []string) []string {
+	if len(matches) == 0 {
+		return matches
+	}
+	start := m.pollStart % len(matches)
+	m.pollStart = start + m.maxBatchFiles
+
+	rotated := make([]string, 0, len(matches))
+	rotated = append(rotated, matches[start:]...)
+	return append(rotated, matches[:start]...)
 }
 
-func (m *Manager) consume(ctx context.Context, paths []string) {
+// consume reads the given paths and returns the number of bytes that remain
+// to be read from the ones whose metrics are not reported per file
+func (m *Manager) consume(ctx context.Context, paths []string) int64 {
 	m.Debug("Consuming files")
 	readers := m.makeReaders(paths)
 
@@ -139,12 +166,32 @@ func (m *Manager) consume(ctx context.Context, paths []string) {
 	}
 	wg.Wait()
 
+	// The lag of files which are tagged is recorded per file, the rest is
+	// returned to be recorded as a whole
+	var lag int64
+	for _, reader := range readers {
+		if mutators := reader.tagger.mutators(reader.file.Name()); mutators != nil {
+			_ = stats.RecordWithTags(ctx, mutators, mFileLag.M(reader.lag()))
+			continue
+		}
+		lag += reader.lag()
+	}
+
 	// Any new files that appear should be consumed entirely
 	m.readerFactory.fromBeginning = true
 
 	m.roller.roll(ctx, readers)
 	m.saveCurrent(readers)
+
+	// Only checkpoint the offsets once the entries read up to them have been consumed
+	if syncer, ok := m.persister.(operator.Syncer); ok {
+		if err := syncer.Sync(ctx); err != nil {
+			m.Debugw("Offsets not checkpointed, since the entries read were not consumed", zap.Error(err))
+			return lag
+		}
+	}
 	m.syncLastPollFiles(ctx)
+	return lag
 }
 
 // makeReaders takes a list of paths, then creates readers from each of those paths,
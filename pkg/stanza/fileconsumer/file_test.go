@@ -16,6 +16,7 @@ package fileconsumer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -25,10 +26,12 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
 )
@@ -1180,3 +1183,217 @@ func TestEncodings(t *testing.T) {
 		})
 	}
 }
+
+func TestMaxBytesPerPoll(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.MaxBytesPerPoll = 20
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	chatty := openTemp(t, tempDir)
+	writeString(t, chatty, "chatty01\nchatty02\nchatty03\nchatty04\nchatty05\n")
+	quiet := openTemp(t, tempDir)
+	writeString(t, quiet, "quiet01\n")
+
+	// Each file is read until at least max_bytes_per_poll bytes have been read
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls, [][]byte{
+		[]byte("chatty01"), []byte("chatty02"), []byte("chatty03"),
+		[]byte("quiet01"),
+	})
+
+	// The remainder of the chatty file is read on the following poll
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls, [][]byte{
+		[]byte("chatty04"), []byte("chatty05"),
+	})
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.RateLimit.EntriesPerSecond = 2
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "testlog1\ntestlog2\ntestlog3\n")
+
+	// Only the burst is read before the poll is canceled
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	operator.poll(ctx)
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	waitForToken(t, emitCalls, []byte("testlog2"))
+	expectNoTokens(t, emitCalls)
+
+	// Reading resumes from the first entry that was not emitted
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog3"))
+}
+
+func TestReadMetrics(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.MaxBytesPerPoll = 5
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "testlog1\ntestlog2\n")
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+
+	expectValue := func(name string, expected float64) {
+		rows, err := view.RetrieveData(name)
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		require.Empty(t, rows[0].Tags)
+		switch data := rows[0].Data.(type) {
+		case *view.SumData:
+			require.Equal(t, expected, data.Value)
+		case *view.LastValueData:
+			require.Equal(t, expected, data.Value)
+		default:
+			require.Failf(t, "unexpected aggregation", "%T", data)
+		}
+	}
+
+	expectValue("fileconsumer/bytes_read", 9)
+	expectValue("fileconsumer/entries_read", 1)
+	expectValue("fileconsumer/file_lag", 9)
+}
+
+func TestReadMetricsPerFile(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.MaxBytesPerPoll = 5
+	cfg.MetricsPerFile = true
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	temp1 := openTemp(t, tempDir)
+	writeString(t, temp1, "testlog1\ntestlog2\n")
+	temp2 := openTemp(t, tempDir)
+	writeString(t, temp2, "log3\n")
+
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls, [][]byte{[]byte("testlog1"), []byte("log3")})
+
+	expectValues := func(name string, expected map[string]float64) {
+		rows, err := view.RetrieveData(name)
+		require.NoError(t, err)
+		actual := make(map[string]float64, len(rows))
+		for _, row := range rows {
+			require.Len(t, row.Tags, 1)
+			require.Equal(t, pathTagKey, row.Tags[0].Key)
+			switch data := row.Data.(type) {
+			case *view.SumData:
+				actual[row.Tags[0].Value] = data.Value
+			case *view.LastValueData:
+				actual[row.Tags[0].Value] = data.Value
+			default:
+				require.Failf(t, "unexpected aggregation", "%T", data)
+			}
+		}
+		require.Equal(t, expected, actual)
+	}
+
+	expectValues("fileconsumer/bytes_read", map[string]float64{temp1.Name(): 9, temp2.Name(): 5})
+	expectValues("fileconsumer/entries_read", map[string]float64{temp1.Name(): 1, temp2.Name(): 1})
+	expectValues("fileconsumer/file_lag", map[string]float64{temp1.Name(): 9, temp2.Name(): 0})
+}
+
+func TestFileTaggerLimit(t *testing.T) {
+	t.Parallel()
+
+	tagger := &fileTagger{limit: 2, paths: make(map[string]struct{})}
+	require.NotNil(t, tagger.mutators("a"))
+	require.NotNil(t, tagger.mutators("b"))
+	require.True(t, tagger.tagsAll())
+
+	// Files beyond the limit are not tagged, the ones already tagged still are
+	require.Nil(t, tagger.mutators("c"))
+	require.NotNil(t, tagger.mutators("a"))
+	require.False(t, tagger.tagsAll())
+
+	var disabled *fileTagger
+	require.Nil(t, disabled.mutators("a"))
+	require.False(t, disabled.tagsAll())
+}
+
+type syncPersister struct {
+	operator.Persister
+	err error
+}
+
+func (p *syncPersister) Sync(context.Context) error {
+	return p.err
+}
+
+func TestOffsetsCheckpointedAfterSync(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	operator, emitCalls := buildTestManager(t, cfg)
+	persister := &syncPersister{
+		Persister: testutil.NewUnscopedMockPersister(),
+		err:       errors.New("entries not consumed"),
+	}
+	operator.persister = persister
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "testlog1\n")
+
+	// The offsets are not checkpointed while the entries read are not consumed
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	encoded, err := persister.Get(context.Background(), knownFilesKey)
+	require.NoError(t, err)
+	require.Nil(t, encoded)
+
+	persister.err = nil
+	writeString(t, temp, "testlog2\n")
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog2"))
+	encoded, err = persister.Get(context.Background(), knownFilesKey)
+	require.NoError(t, err)
+	require.NotNil(t, encoded)
+}
+
+func TestRotateMatches(t *testing.T) {
+	t.Parallel()
+
+	m := &Manager{maxBatchFiles: 2}
+	matches := []string{"a", "b", "c", "d", "e"}
+
+	// Each poll starts with the batch following the first batch of the previous poll
+	require.Equal(t, []string{"a", "b", "c", "d", "e"}, m.rotate(matches))
+	require.Equal(t, []string{"c", "d", "e", "a", "b"}, m.rotate(matches))
+	require.Equal(t, []string{"e", "a", "b", "c", "d"}, m.rotate(matches))
+	require.Equal(t, []string{"b", "c", "d", "e", "a"}, m.rotate(matches))
+
+	// The matched paths are not modified
+	require.Equal(t, []string{"a", "b", "c", "d", "e"}, matches)
+	require.Empty(t, m.rotate(nil))
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"sync"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	mBytesRead   = stats.Int64("fileconsumer/bytes_read", "Number of bytes read from files", stats.UnitBytes)
+	mEntriesRead = stats.Int64("fileconsumer/entries_read", "Number of entries read from files", stats.UnitDimensionless)
+	mFileLag     = stats.Int64("fileconsumer/file_lag", "Number of bytes remaining to be read from all matched files, as of the end of the last poll", stats.UnitBytes)
+)
+
+var pathTagKey = tag.MustNewKey("path")
+
+// MetricViews returns the metrics views related to reading files.
+// The metrics are only tagged with the file path when metrics_per_file
+// is enabled, since the number of matched files is unbounded when files
+// are rotated.
+func MetricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        mBytesRead.Name(),
+			Measure:     mBytesRead,
+			Description: mBytesRead.Description(),
+			TagKeys:     []tag.Key{pathTagKey},
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        mEntriesRead.Name(),
+			Measure:     mEntriesRead,
+			Description: mEntriesRead.Description(),
+			TagKeys:     []tag.Key{pathTagKey},
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        mFileLag.Name(),
+			Measure:     mFileLag,
+			Description: mFileLag.Description(),
+			TagKeys:     []tag.Key{pathTagKey},
+			Aggregation: view.LastValue(),
+		},
+	}
+}
+
+// fileTagger tags the metrics of a file with its path. Once limit files
+// have been tagged, the metrics of other files are recorded without a path.
+type fileTagger struct {
+	sync.Mutex
+	limit int
+	paths map[string]struct{}
+	// overflowed is set once the metrics of a file were not tagged
+	overflowed bool
+}
+
+// mutators returns the tags of the metrics of the file at path. A nil
+// tagger never tags metrics.
+func (t *fileTagger) mutators(path string) []tag.Mutator {
+	if t == nil {
+		return nil
+	}
+	t.Lock()
+	defer t.Unlock()
+	if _, ok := t.paths[path]; !ok {
+		if len(t.paths) >= t.limit {
+			t.overflowed = true
+			return nil
+		}
+		t.paths[path] = struct{}{}
+	}
+	return []tag.Mutator{tag.Upsert(pathTagKey, path)}
+}
+
+// tagsAll returns whether the metrics of all files have been tagged so far
+func (t *fileTagger) tagsAll() bool {
+	if t == nil {
+		return false
+	}
+	t.Lock()
+	defer t.Unlock()
+	return !t.overflowed
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/time/rate"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+// RateLimitConfig limits the rate at which files are read.
+// A value of zero means that the rate is not limited.
+type RateLimitConfig struct {
+	BytesPerSecond   helper.ByteSize `mapstructure:"bytes_per_second,omitempty"`
+	EntriesPerSecond int             `mapstructure:"entries_per_second,omitempty"`
+}
+
+func (c RateLimitConfig) validate(name string) error {
+	if c.BytesPerSecond < 0 {
+		return fmt.Errorf("`%s.bytes_per_second` must not be negative", name)
+	}
+	if c.EntriesPerSecond < 0 {
+		return fmt.Errorf("`%s.entries_per_second` must not be negative", name)
+	}
+	return nil
+}
+
+// build returns a limiter for the configured rates, or nil if no rate is limited.
+func (c RateLimitConfig) build(maxLogSize int) *limiter {
+	if c.BytesPerSecond == 0 && c.EntriesPerSecond == 0 {
+		return nil
+	}
+
+	l := &limiter{}
+	if c.BytesPerSecond > 0 {
+		// The burst must allow for the largest possible entry to be read at once
+		burst := int(c.BytesPerSecond)
+		if burst < maxLogSize {
+			burst = maxLogSize
+		}
+		l.bytes = rate.NewLimiter(rate.Limit(c.BytesPerSecond), burst)
+	}
+	if c.EntriesPerSecond > 0 {
+		l.entries = rate.NewLimiter(rate.Limit(c.EntriesPerSecond), c.EntriesPerSecond)
+	}
+	return l
+}
+
+// limiter limits the rate of bytes and entries that are read.
+type limiter struct {
+	bytes   *rate.Limiter
+	entries *rate.Limiter
+}
+
+// wait blocks until an entry of the given size may be read, or until the context is done.
+func (l *limiter) wait(ctx context.Context, size int) error {
+	if l == nil {
+		return nil
+	}
+	if l.bytes != nil && size > 0 {
+		if err := l.bytes.WaitN(ctx, size); err != nil {
+			return err
+		}
+	}
+	if l.entries != nil {
+		if err := l.entries.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
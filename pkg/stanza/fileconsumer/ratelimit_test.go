@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitConfigBuild(t *testing.T) {
+	require.Nil(t, RateLimitConfig{}.build(1024))
+
+	l := RateLimitConfig{BytesPerSecond: 10}.build(1024)
+	require.NotNil(t, l.bytes)
+	require.Nil(t, l.entries)
+	require.Equal(t, 1024, l.bytes.Burst(), "burst must allow for the largest entry")
+
+	l = RateLimitConfig{BytesPerSecond: 4096, EntriesPerSecond: 5}.build(1024)
+	require.Equal(t, 4096, l.bytes.Burst())
+	require.Equal(t, 5, l.entries.Burst())
+}
+
+func TestLimiterWait(t *testing.T) {
+	// A nil limiter does not limit anything
+	var unlimited *limiter
+	require.NoError(t, unlimited.wait(context.Background(), 1<<20))
+
+	l := RateLimitConfig{EntriesPerSecond: 2}.build(1024)
+	require.NoError(t, l.wait(context.Background(), 10))
+	require.NoError(t, l.wait(context.Background(), 10))
+
+	// The burst has been used up, so the next entry must wait for longer than the context allows
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.Error(t, l.wait(ctx, 10))
+
+	l = RateLimitConfig{BytesPerSecond: 100}.build(10)
+	require.NoError(t, l.wait(context.Background(), 100))
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.Error(t, l.wait(ctx, 50))
+}
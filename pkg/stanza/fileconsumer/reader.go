@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 
+	"go.opencensus.io/stats"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
@@ -28,7 +29,12 @@ import (
 type readerConfig struct {
 	fingerprintSize int
 	maxLogSize      int
+	maxBytesPerPoll int64
 	emit            EmitFunc
+	// limiter is shared by all readers
+	limiter *limiter
+	// tagger is nil unless metrics are reported per file
+	tagger *fileTagger
 }
 
 // Reader manages a single file
@@ -43,6 +49,8 @@ type Reader struct {
 	generation     int
 	file           *os.File
 	fileAttributes *FileAttributes
+	// limiter is specific to this file
+	limiter *limiter
 }
 
 // offsetToEnd sets the starting offset
@@ -55,13 +63,20 @@ func (r *Reader) offsetToEnd() error {
 	return nil
 }
 
-// ReadToEnd will read until the end of the file
+// ReadToEnd will read until the end of the file, or until max_bytes_per_poll
+// bytes have been read
 func (r *Reader) ReadToEnd(ctx context.Context) {
 	if _, err := r.file.Seek(r.Offset, 0); err != nil {
 		r.Errorw("Failed to seek", zap.Error(err))
 		return
 	}
 
+	startOffset := r.Offset
+	var entries int64
+	defer func() {
+		r.recordMetrics(r.Offset-startOffset, entries)
+	}()
+
 	scanner := NewPositionalScanner(r, r.maxLogSize, r.Offset, r.splitFunc)
 
 	// Iterate over the tokenized file, emitting entries as we go
@@ -72,6 +87,11 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 		default:
 		}
 
+		// Leave the rest of the file to the next poll, so that other files get a chance to be read
+		if r.maxBytesPerPoll > 0 && r.Offset-startOffset >= r.maxBytesPerPoll {
+			return
+		}
+
 		ok := scanner.Scan()
 		if !ok {
 			if err := scanner.getError(); err != nil {
@@ -80,17 +100,42 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 			break
 		}
 
+		// The offset is not advanced past entries which were not emitted
+		size := int(scanner.Pos() - r.Offset)
+		if err := r.limiter.wait(ctx, size); err != nil {
+			return
+		}
+		if err := r.readerConfig.limiter.wait(ctx, size); err != nil {
+			return
+		}
+
 		token, err := r.encoding.Decode(scanner.Bytes())
 		if err != nil {
 			r.Errorw("decode: %w", zap.Error(err))
 		} else {
 			r.emit(ctx, r.fileAttributes, token)
+			entries++
 		}
 
 		r.Offset = scanner.Pos()
 	}
 }
 
+// recordMetrics records the bytes and entries read during a poll
+func (r *Reader) recordMetrics(bytesRead, entries int64) {
+	mutators := r.tagger.mutators(r.file.Name())
+	_ = stats.RecordWithTags(context.Background(), mutators, mBytesRead.M(bytesRead), mEntriesRead.M(entries))
+}
+
+// lag returns the number of bytes of the file that remain to be read
+func (r *Reader) lag() int64 {
+	info, err := r.file.Stat()
+	if err != nil || info.Size() < r.Offset {
+		return 0
+	}
+	return info.Size() - r.Offset
+}
+
 // Close will close the file
 func (r *Reader) Close() {
 	if r.file != nil {
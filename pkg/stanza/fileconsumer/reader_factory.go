@@ -25,10 +25,11 @@ import (
 
 type readerFactory struct {
 	*zap.SugaredLogger
-	readerConfig    *readerConfig
-	fromBeginning   bool
-	splitterFactory splitterFactory
-	encodingConfig  helper.EncodingConfig
+	readerConfig     *readerConfig
+	fromBeginning    bool
+	splitterFactory  splitterFactory
+	encodingConfig   helper.EncodingConfig
+	perFileRateLimit RateLimitConfig
 }
 
 func (f *readerFactory) newReader(file *os.File, fp *Fingerprint) (*Reader, error) {
@@ -45,6 +46,7 @@ func (f *readerFactory) copy(old *Reader, newFile *os.File) (*Reader, error) {
 		withFingerprint(old.Fingerprint.Copy()).
 		withOffset(old.Offset).
 		withSplitterFunc(old.splitFunc).
+		withLimiter(old.limiter).
 		build()
 }
 
@@ -62,6 +64,7 @@ type readerBuilder struct {
 	fp        *Fingerprint
 	offset    int64
 	splitFunc bufio.SplitFunc
+	limiter   *limiter
 }
 
 func (f *readerFactory) newReaderBuilder() *readerBuilder {
@@ -73,6 +76,12 @@ func (b *readerBuilder) withSplitterFunc(s bufio.SplitFunc) *readerBuilder {
 	return b
 }
 
+// withLimiter carries the per file rate limit over from a previous reader of the same file
+func (b *readerBuilder) withLimiter(l *limiter) *readerBuilder {
+	b.limiter = l
+	return b
+}
+
 func (b *readerBuilder) withFile(f *os.File) *readerBuilder {
 	b.file = f
 	return b
@@ -92,6 +101,11 @@ func (b *readerBuilder) build() (r *Reader, err error) {
 	r = &Reader{
 		readerConfig: b.readerConfig,
 		Offset:       b.offset,
+		limiter:      b.limiter,
+	}
+
+	if r.limiter == nil {
+		r.limiter = b.perFileRateLimit.build(b.readerConfig.maxLogSize)
 	}
 
 	if b.splitFunc != nil {
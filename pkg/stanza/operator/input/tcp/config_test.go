@@ -42,6 +42,8 @@ func TestUnmarshal(t *testing.T) {
 					cfg.MaxLogSize = 1000000
 					cfg.ListenAddress = "10.0.0.1:9000"
 					cfg.AddAttributes = true
+					cfg.ProxyProtocol = true
+					cfg.TrustedProxies = []string{"10.0.0.0/8"}
 					cfg.Encoding = helper.NewEncodingConfig()
 					cfg.Encoding.Encoding = "utf-8"
 					cfg.Multiline = helper.NewMultilineConfig()
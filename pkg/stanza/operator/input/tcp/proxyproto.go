@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcp // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/tcp"
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+const (
+	// proxyV1MaxLength is the maximum length of a PROXY protocol v1 header, including the CRLF.
+	proxyV1MaxLength = 107
+
+	proxyV2HeaderLength = 16
+)
+
+var (
+	proxyV1Prefix    = []byte("PROXY ")
+	proxyV2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+)
+
+// proxyConn is a connection whose addresses have been read from a PROXY protocol header.
+type proxyConn struct {
+	net.Conn
+	reader     *bufio.Reader
+	remoteAddr net.Addr
+	localAddr  net.Addr
+}
+
+// Read reads from the buffered reader, which may hold data received after the PROXY protocol header.
+func (c *proxyConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// RemoteAddr returns the source address of the proxied connection.
+func (c *proxyConn) RemoteAddr() net.Addr {
+	return c.remoteAddr
+}
+
+// LocalAddr returns the destination address of the proxied connection.
+func (c *proxyConn) LocalAddr() net.Addr {
+	return c.localAddr
+}
+
+// readProxyHeader reads a PROXY protocol v1 or v2 header from the connection, as described in
+// https://www.haproxy.org/download/2.6/doc/proxy-protocol.txt. The returned connection reports
+// the addresses from the header. If the header does not carry addresses, such as for health
+// checks of the proxy, the addresses of the underlying connection are kept.
+func readProxyHeader(conn net.Conn) (net.Conn, error) {
+	pc := &proxyConn{
+		Conn:       conn,
+		reader:     bufio.NewReader(conn),
+		remoteAddr: conn.RemoteAddr(),
+		localAddr:  conn.LocalAddr(),
+	}
+
+	// The shortest valid header is a v1 header for an unknown protocol
+	prefix, err := pc.reader.Peek(len(proxyV1Prefix))
+	if err != nil {
+		return nil, fmt.Errorf("read PROXY protocol header: %w", err)
+	}
+
+	switch {
+	case bytes.Equal(prefix, proxyV1Prefix):
+		err = pc.readV1()
+	case bytes.Equal(prefix, proxyV2Signature[:len(prefix)]):
+		err = pc.readV2()
+	default:
+		err = errors.New("missing PROXY protocol header")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return pc, nil
+}
+
+// readV1 reads a header of the form "PROXY TCP4 192.168.0.1 192.168.0.11 56324 443\r\n".
+func (c *proxyConn) readV1() error {
+	var line []byte
+	for {
+		b, err := c.reader.ReadByte()
+		if err != nil {
+			return fmt.Errorf("read PROXY protocol v1 header: %w", err)
+		}
+		line = append(line, b)
+		if b == '\n' {
+			break
+		}
+		if len(line) >= proxyV1MaxLength {
+			return errors.New("PROXY protocol v1 header is too long")
+		}
+	}
+
+	if !bytes.HasSuffix(line, []byte("\r\n")) {
+		return errors.New("PROXY protocol v1 header must end with CRLF")
+	}
+
+	fields := strings.Split(string(line[:len(line)-2]), " ")
+	if len(fields) < 2 {
+		return errors.New("invalid PROXY protocol v1 header")
+	}
+
+	switch fields[1] {
+	case "UNKNOWN":
+		return nil
+	case "TCP4", "TCP6":
+	default:
+		return fmt.Errorf("unsupported PROXY protocol v1 protocol %q", fields[1])
+	}
+
+	if len(fields) != 6 {
+		return errors.New("invalid PROXY protocol v1 header")
+	}
+
+	src, err := parseV1Addr(fields[2], fields[4])
+	if err != nil {
+		return fmt.Errorf("invalid PROXY protocol v1 source address: %w", err)
+	}
+	dst, err := parseV1Addr(fields[3], fields[5])
+	if err != nil {
+		return fmt.Errorf("invalid PROXY protocol v1 destination address: %w", err)
+	}
+
+	c.remoteAddr = src
+	c.localAddr = dst
+	return nil
+}
+
+func parseV1Addr(ip, port string) (*net.TCPAddr, error) {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return nil, fmt.Errorf("invalid ip %q", ip)
+	}
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %q", port)
+	}
+	return &net.TCPAddr{IP: addr, Port: int(p)}, nil
+}
+
+// readV2 reads a binary header, which consists of a 16 byte preamble followed by the addresses
+// and optional TLVs. TLVs are ignored.
+func (c *proxyConn) readV2() error {
+	header := make([]byte, proxyV2HeaderLength)
+	if _, err := io.ReadFull(c.reader, header); err != nil {
+		return fmt.Errorf("read PROXY protocol v2 header: %w", err)
+	}
+
+	if !bytes.Equal(header[:len(proxyV2Signature)], proxyV2Signature) {
+		return errors.New("invalid PROXY protocol v2 signature")
+	}
+
+	if version := header[12] >> 4; version != 2 {
+		return fmt.Errorf("unsupported PROXY protocol version %d", version)
+	}
+	command := header[12] & 0x0F
+	family := header[13]
+
+	payload := make([]byte, binary.BigEndian.Uint16(header[14:16]))
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return fmt.Errorf("read PROXY protocol v2 addresses: %w", err)
+	}
+
+	switch command {
+	case 0x0: // LOCAL
+		return nil
+	case 0x1: // PROXY
+	default:
+		return fmt.Errorf("unsupported PROXY protocol v2 command %d", command)
+	}
+
+	var ipLength int
+	switch family {
+	case 0x11: // TCP over IPv4
+		ipLength = net.IPv4len
+	case 0x21: // TCP over IPv6
+		ipLength = net.IPv6len
+	default:
+		// Other families do not carry TCP addresses
+		return nil
+	}
+
+	if len(payload) < 2*ipLength+4 {
+		return errors.New("PROXY protocol v2 addresses are too short")
+	}
+
+	c.remoteAddr = &net.TCPAddr{
+		IP:   net.IP(payload[:ipLength]),
+		Port: int(binary.BigEndian.Uint16(payload[2*ipLength:])),
+	}
+	c.localAddr = &net.TCPAddr{
+		IP:   net.IP(payload[ipLength : 2*ipLength]),
+		Port: int(binary.BigEndian.Uint16(payload[2*ipLength+2:])),
+	}
+	return nil
+}
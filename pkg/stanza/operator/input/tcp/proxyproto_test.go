@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func proxyV2Header(command, family byte, addresses []byte) []byte {
+	header := append([]byte{}, proxyV2Signature...)
+	header = append(header, 0x20|command, family)
+	length := make([]byte, 2)
+	binary.BigEndian.PutUint16(length, uint16(len(addresses)))
+	header = append(header, length...)
+	return append(header, addresses...)
+}
+
+func TestReadProxyHeader(t *testing.T) {
+	ipv4Addresses := []byte{
+		192, 168, 0, 1, // source
+		10, 0, 0, 1, // destination
+		0xdc, 0x04, // source port 56324
+		0x01, 0xbb, // destination port 443
+	}
+	ipv6Addresses := append(append(append(
+		net.ParseIP("2001:db8::1").To16(),
+		net.ParseIP("2001:db8::2").To16()...),
+		0xdc, 0x04),
+		0x01, 0xbb,
+	)
+
+	cases := []struct {
+		name           string
+		header         []byte
+		expectedRemote string
+		expectedLocal  string
+		expectedErr    string
+	}{
+		{
+			name:           "v1-tcp4",
+			header:         []byte("PROXY TCP4 192.168.0.1 10.0.0.1 56324 443\r\n"),
+			expectedRemote: "192.168.0.1:56324",
+			expectedLocal:  "10.0.0.1:443",
+		},
+		{
+			name:           "v1-tcp6",
+			header:         []byte("PROXY TCP6 2001:db8::1 2001:db8::2 56324 443\r\n"),
+			expectedRemote: "[2001:db8::1]:56324",
+			expectedLocal:  "[2001:db8::2]:443",
+		},
+		{
+			name:           "v1-unknown",
+			header:         []byte("PROXY UNKNOWN\r\n"),
+			expectedRemote: "pipe",
+			expectedLocal:  "pipe",
+		},
+		{
+			name:        "v1-missing-crlf",
+			header:      []byte("PROXY TCP4 192.168.0.1 10.0.0.1 56324 443\n"),
+			expectedErr: "must end with CRLF",
+		},
+		{
+			name:        "v1-invalid-ip",
+			header:      []byte("PROXY TCP4 192.168.0.256 10.0.0.1 56324 443\r\n"),
+			expectedErr: "invalid PROXY protocol v1 source address",
+		},
+		{
+			name:        "v1-invalid-port",
+			header:      []byte("PROXY TCP4 192.168.0.1 10.0.0.1 56324 65536\r\n"),
+			expectedErr: "invalid PROXY protocol v1 destination address",
+		},
+		{
+			name:        "v1-too-long",
+			header:      append([]byte("PROXY TCP4 "), bytes.Repeat([]byte("1"), proxyV1MaxLength)...),
+			expectedErr: "too long",
+		},
+		{
+			name:           "v2-tcp4",
+			header:         proxyV2Header(0x1, 0x11, ipv4Addresses),
+			expectedRemote: "192.168.0.1:56324",
+			expectedLocal:  "10.0.0.1:443",
+		},
+		{
+			name:           "v2-tcp6",
+			header:         proxyV2Header(0x1, 0x21, ipv6Addresses),
+			expectedRemote: "[2001:db8::1]:56324",
+			expectedLocal:  "[2001:db8::2]:443",
+		},
+		{
+			name:           "v2-tlvs",
+			header:         proxyV2Header(0x1, 0x11, append(append([]byte{}, ipv4Addresses...), 0x04, 0x00, 0x01, 0x00)),
+			expectedRemote: "192.168.0.1:56324",
+			expectedLocal:  "10.0.0.1:443",
+		},
+		{
+			name:           "v2-local",
+			header:         proxyV2Header(0x0, 0x00, nil),
+			expectedRemote: "pipe",
+			expectedLocal:  "pipe",
+		},
+		{
+			name:        "v2-short-addresses",
+			header:      proxyV2Header(0x1, 0x11, ipv4Addresses[:8]),
+			expectedErr: "too short",
+		},
+		{
+			name:        "v2-invalid-command",
+			header:      proxyV2Header(0x2, 0x11, ipv4Addresses),
+			expectedErr: "unsupported PROXY protocol v2 command",
+		},
+		{
+			name:        "missing",
+			header:      []byte("message\n"),
+			expectedErr: "missing PROXY protocol header",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server, client := net.Pipe()
+			defer server.Close()
+
+			go func() {
+				defer client.Close()
+				_, _ = client.Write(append(append([]byte{}, tc.header...), []byte("message\n")...))
+			}()
+
+			conn, err := readProxyHeader(server)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedRemote, conn.RemoteAddr().String())
+			require.Equal(t, tc.expectedLocal, conn.LocalAddr().String())
+
+			data, err := io.ReadAll(conn)
+			require.NoError(t, err)
+			require.Equal(t, "message\n", string(data))
+		})
+	}
+}
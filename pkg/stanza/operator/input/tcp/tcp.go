@@ -19,6 +19,7 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
 	"strconv"
@@ -43,6 +44,10 @@ const (
 	// DefaultMaxLogSize is the max buffer sized used
 	// if MaxLogSize is not set
 	DefaultMaxLogSize = 1024 * 1024
+
+	// proxyHeaderTimeout is the time allowed for a client to send the PROXY
+	// protocol header and complete the TLS handshake
+	proxyHeaderTimeout = 10 * time.Second
 )
 
 func init() {
@@ -73,12 +78,14 @@ type Config struct {
 
 // BaseConfig is the detailed configuration of a tcp input operator.
 type BaseConfig struct {
-	MaxLogSize    helper.ByteSize             `mapstructure:"max_log_size,omitempty"`
-	ListenAddress string                      `mapstructure:"listen_address,omitempty"`
-	TLS           *configtls.TLSServerSetting `mapstructure:"tls,omitempty"`
-	AddAttributes bool                        `mapstructure:"add_attributes,omitempty"`
-	Encoding      helper.EncodingConfig       `mapstructure:",squash,omitempty"`
-	Multiline     helper.MultilineConfig      `mapstructure:"multiline,omitempty"`
+	MaxLogSize     helper.ByteSize             `mapstructure:"max_log_size,omitempty"`
+	ListenAddress  string                      `mapstructure:"listen_address,omitempty"`
+	TLS            *configtls.TLSServerSetting `mapstructure:"tls,omitempty"`
+	AddAttributes  bool                        `mapstructure:"add_attributes,omitempty"`
+	ProxyProtocol  bool                        `mapstructure:"proxy_protocol,omitempty"`
+	TrustedProxies []string                    `mapstructure:"trusted_proxies,omitempty"`
+	Encoding       helper.EncodingConfig       `mapstructure:",squash,omitempty"`
+	Multiline      helper.MultilineConfig      `mapstructure:"multiline,omitempty"`
 }
 
 // Build will build a tcp input operator.
@@ -117,18 +124,32 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, err
 	}
 
+	var trustedProxies []*net.IPNet
+	for _, cidr := range c.TrustedProxies {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for parameter 'trusted_proxies': %w", err)
+		}
+		trustedProxies = append(trustedProxies, ipNet)
+	}
+	if c.ProxyProtocol && len(trustedProxies) == 0 {
+		return nil, fmt.Errorf("missing required parameter 'trusted_proxies' when 'proxy_protocol' is enabled")
+	}
+
 	var resolver *helper.IPResolver
 	if c.AddAttributes {
 		resolver = helper.NewIPResolver()
 	}
 
 	tcpInput := &Input{
-		InputOperator: inputOperator,
-		address:       c.ListenAddress,
-		MaxLogSize:    int(c.MaxLogSize),
-		addAttributes: c.AddAttributes,
-		encoding:      encoding,
-		splitFunc:     splitFunc,
+		InputOperator:  inputOperator,
+		address:        c.ListenAddress,
+		MaxLogSize:     int(c.MaxLogSize),
+		addAttributes:  c.AddAttributes,
+		proxyProtocol:  c.ProxyProtocol,
+		trustedProxies: trustedProxies,
+		encoding:       encoding,
+		splitFunc:      splitFunc,
 		backoff: backoff.Backoff{
 			Max: 3 * time.Second,
 		},
@@ -148,9 +169,11 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 // Input is an operator that listens for log entries over tcp.
 type Input struct {
 	helper.InputOperator
-	address       string
-	MaxLogSize    int
-	addAttributes bool
+	address        string
+	MaxLogSize     int
+	addAttributes  bool
+	proxyProtocol  bool
+	trustedProxies []*net.IPNet
 
 	listener net.Listener
 	cancel   context.CancelFunc
@@ -165,6 +188,11 @@ type Input struct {
 
 // Start will start listening for log entries over tcp.
 func (t *Input) Start(_ operator.Persister) error {
+	if t.tls != nil {
+		t.tls.Time = time.Now
+		t.tls.Rand = rand.Reader
+	}
+
 	if err := t.configureListener(); err != nil {
 		return fmt.Errorf("failed to listen on interface: %w", err)
 	}
@@ -176,7 +204,9 @@ func (t *Input) Start(_ operator.Persister) error {
 }
 
 func (t *Input) configureListener() error {
-	if t.tls == nil {
+	// With the PROXY protocol, the header precedes the TLS handshake, so TLS
+	// is set up for each connection once the header has been read
+	if t.tls == nil || t.proxyProtocol {
 		listener, err := net.Listen("tcp", t.address)
 		if err != nil {
 			return fmt.Errorf("failed to configure tcp listener: %w", err)
@@ -185,9 +215,6 @@ func (t *Input) configureListener() error {
 		return nil
 	}
 
-	t.tls.Time = time.Now
-	t.tls.Rand = rand.Reader
-
 	listener, err := tls.Listen("tcp", t.address, t.tls)
 	if err != nil {
 		return fmt.Errorf("failed to configure tls listener: %w", err)
@@ -248,6 +275,12 @@ func (t *Input) goHandleMessages(ctx context.Context, conn net.Conn, cancel cont
 		defer t.wg.Done()
 		defer cancel()
 
+		conn, peerAttributes, err := t.prepareConn(ctx, conn)
+		if err != nil {
+			t.Errorw("Failed to prepare connection", zap.Error(err), zap.String("remote_addr", conn.RemoteAddr().String()))
+			return
+		}
+
 		buf := make([]byte, 0, t.MaxLogSize)
 		scanner := bufio.NewScanner(conn)
 		scanner.Buffer(buf, t.MaxLogSize)
@@ -282,8 +315,15 @@ func (t *Input) goHandleMessages(ctx context.Context, conn net.Conn, cancel cont
 					entry.AddAttribute("net.host.port", strconv.FormatInt(int64(addr.Port), 10))
 					entry.AddAttribute("net.host.name", t.resolver.GetHostFromIP(ip))
 				}
+
+				for k, v := range peerAttributes {
+					entry.Attributes[k] = v
+				}
 			}
 
+			// Write blocks until the next operator accepts the entry. No more data is
+			// read from the connection meanwhile, so TCP flow control slows down the
+			// client while the pipeline applies backpressure.
 			t.Write(ctx, entry)
 		}
 		if err := scanner.Err(); err != nil {
@@ -292,6 +332,101 @@ func (t *Input) goHandleMessages(ctx context.Context, conn net.Conn, cancel cont
 	}()
 }
 
+// isTrustedProxy returns whether the address is in one of the trusted_proxies ranges
+func (t *Input) isTrustedProxy(addr net.Addr) bool {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+	for _, ipNet := range t.trustedProxies {
+		if ipNet.Contains(tcpAddr.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// prepareConn reads the PROXY protocol header, if enabled, and completes the TLS
+// handshake, if TLS is used. It returns the connection to read log entries from,
+// along with attributes describing the TLS client's certificate.
+func (t *Input) prepareConn(ctx context.Context, conn net.Conn) (net.Conn, map[string]interface{}, error) {
+	if t.proxyProtocol {
+		// Otherwise, any client could spoof its address with a PROXY protocol header
+		if !t.isTrustedProxy(conn.RemoteAddr()) {
+			return conn, nil, errors.New("PROXY protocol header from untrusted peer")
+		}
+
+		if err := conn.SetReadDeadline(time.Now().Add(proxyHeaderTimeout)); err != nil {
+			return conn, nil, err
+		}
+
+		proxied, err := readProxyHeader(conn)
+		if err != nil {
+			return conn, nil, err
+		}
+		conn = proxied
+
+		if t.tls != nil {
+			conn = tls.Server(conn, t.tls)
+		}
+	}
+
+	tlsConn, ok := conn.(*tls.Conn)
+	if !ok || !t.addAttributes {
+		return conn, nil, conn.SetReadDeadline(time.Time{})
+	}
+
+	// Complete the handshake now, so that the client's certificate is available
+	handshakeCtx, cancel := context.WithTimeout(ctx, proxyHeaderTimeout)
+	defer cancel()
+	if err := tlsConn.HandshakeContext(handshakeCtx); err != nil {
+		return conn, nil, fmt.Errorf("tls handshake: %w", err)
+	}
+
+	return conn, peerCertificateAttributes(tlsConn.ConnectionState()), conn.SetReadDeadline(time.Time{})
+}
+
+// peerCertificateAttributes returns attributes describing the subject and subject
+// alternative names of the client's certificate, if one was presented.
+func peerCertificateAttributes(state tls.ConnectionState) map[string]interface{} {
+	if len(state.PeerCertificates) == 0 {
+		return nil
+	}
+
+	cert := state.PeerCertificates[0]
+	attributes := map[string]interface{}{
+		"tls.client.subject": cert.Subject.String(),
+	}
+
+	addSANs := func(key string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+		sans := make([]interface{}, 0, len(values))
+		for _, v := range values {
+			sans = append(sans, v)
+		}
+		attributes[key] = sans
+	}
+
+	addSANs("tls.client.san.dns", cert.DNSNames)
+	addSANs("tls.client.san.email", cert.EmailAddresses)
+
+	ips := make([]string, 0, len(cert.IPAddresses))
+	for _, ip := range cert.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+	addSANs("tls.client.san.ip", ips)
+
+	uris := make([]string, 0, len(cert.URIs))
+	for _, uri := range cert.URIs {
+		uris = append(uris, uri.String())
+	}
+	addSANs("tls.client.san.uri", uris)
+
+	return attributes
+}
+
 // Stop will stop listening for log entries over TCP.
 func (t *Input) Stop() error {
 	t.cancel()
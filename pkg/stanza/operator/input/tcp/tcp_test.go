@@ -15,11 +15,22 @@
 package tcp
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	cryptorand "crypto/rand"
 	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
 	"math/rand"
 	"net"
+	"net/url"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -330,6 +341,38 @@ func TestBuild(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"proxy-protocol-trusted-proxies",
+			Config{
+				BaseConfig: BaseConfig{
+					ListenAddress:  "10.0.0.1:9000",
+					ProxyProtocol:  true,
+					TrustedProxies: []string{"10.0.0.0/8", "fd00::/8"},
+				},
+			},
+			false,
+		},
+		{
+			"proxy-protocol-missing-trusted-proxies",
+			Config{
+				BaseConfig: BaseConfig{
+					ListenAddress: "10.0.0.1:9000",
+					ProxyProtocol: true,
+				},
+			},
+			true,
+		},
+		{
+			"proxy-protocol-invalid-trusted-proxies",
+			Config{
+				BaseConfig: BaseConfig{
+					ListenAddress:  "10.0.0.1:9000",
+					ProxyProtocol:  true,
+					TrustedProxies: []string{"10.0.0.1"},
+				},
+			},
+			true,
+		},
 		{
 			"tls-enabled-with-no-such-file-error",
 			Config{
@@ -354,6 +397,8 @@ func TestBuild(t *testing.T) {
 			cfg.ListenAddress = tc.inputBody.ListenAddress
 			cfg.MaxLogSize = tc.inputBody.MaxLogSize
 			cfg.TLS = tc.inputBody.TLS
+			cfg.ProxyProtocol = tc.inputBody.ProxyProtocol
+			cfg.TrustedProxies = tc.inputBody.TrustedProxies
 			_, err := cfg.Build(testutil.Logger(t))
 			if tc.expectErr {
 				require.Error(t, err)
@@ -379,6 +424,265 @@ func TestTLSTCPInput(t *testing.T) {
 	t.Run("CarriageReturn", tlsInputTest([]byte("message\r\n"), []string{"message"}))
 }
 
+// writeTestClientCertificate creates a CA, writing it to the given directory,
+// and returns a client certificate signed by the CA.
+func writeTestClientCertificate(t *testing.T, dir string) tls.Certificate {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), cryptorand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(cryptorand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ca.crt"), caPEM, 0600))
+
+	clientKey, err := ecdsa.GenerateKey(elliptic.P256(), cryptorand.Reader)
+	require.NoError(t, err)
+	spiffeID, err := url.Parse("spiffe://example.com/host/web-1")
+	require.NoError(t, err)
+	clientTemplate := &x509.Certificate{
+		SerialNumber:   big.NewInt(2),
+		Subject:        pkix.Name{CommonName: "web-1", Organization: []string{"Example"}},
+		NotBefore:      time.Now().Add(-time.Hour),
+		NotAfter:       time.Now().Add(time.Hour),
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		DNSNames:       []string{"web-1.example.com", "web.example.com"},
+		IPAddresses:    []net.IP{net.ParseIP("192.168.0.1")},
+		URIs:           []*url.URL{spiffeID},
+		EmailAddresses: []string{"ops@example.com"},
+	}
+	clientDER, err := x509.CreateCertificate(cryptorand.Reader, clientTemplate, caTemplate, &clientKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	return tls.Certificate{
+		Certificate: [][]byte{clientDER},
+		PrivateKey:  clientKey,
+	}
+}
+
+func TestProxyProtocol(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "test.crt"), []byte(testTLSCertificate+"\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "test.key"), []byte(testTLSPrivateKey+"\n"), 0600))
+	clientCert := writeTestClientCertificate(t, dir)
+
+	cases := []struct {
+		name               string
+		tls                bool
+		header             string
+		expectedAttributes map[string]interface{}
+	}{
+		{
+			name:   "v1",
+			header: "PROXY TCP4 192.168.0.1 10.0.0.1 56324 514\r\n",
+			expectedAttributes: map[string]interface{}{
+				"net.transport": "IP.TCP",
+				"net.peer.ip":   "192.168.0.1",
+				"net.peer.port": "56324",
+				"net.host.ip":   "10.0.0.1",
+				"net.host.port": "514",
+			},
+		},
+		{
+			name:   "v1-mtls",
+			tls:    true,
+			header: "PROXY TCP4 192.168.0.1 10.0.0.1 56324 514\r\n",
+			expectedAttributes: map[string]interface{}{
+				"net.transport":        "IP.TCP",
+				"net.peer.ip":          "192.168.0.1",
+				"net.peer.port":        "56324",
+				"net.host.ip":          "10.0.0.1",
+				"net.host.port":        "514",
+				"tls.client.subject":   "CN=web-1,O=Example",
+				"tls.client.san.dns":   []interface{}{"web-1.example.com", "web.example.com"},
+				"tls.client.san.ip":    []interface{}{"192.168.0.1"},
+				"tls.client.san.uri":   []interface{}{"spiffe://example.com/host/web-1"},
+				"tls.client.san.email": []interface{}{"ops@example.com"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test_id")
+			cfg.ListenAddress = ":0"
+			cfg.AddAttributes = true
+			cfg.ProxyProtocol = true
+			cfg.TrustedProxies = []string{"127.0.0.0/8", "::1/128"}
+			if tc.tls {
+				cfg.TLS = &configtls.TLSServerSetting{
+					TLSSetting: configtls.TLSSetting{
+						CertFile: filepath.Join(dir, "test.crt"),
+						KeyFile:  filepath.Join(dir, "test.key"),
+					},
+					ClientCAFile: filepath.Join(dir, "ca.crt"),
+				}
+			}
+
+			op, err := cfg.Build(testutil.Logger(t))
+			require.NoError(t, err)
+
+			mockOutput := testutil.Operator{}
+			tcpInput := op.(*Input)
+			tcpInput.InputOperator.OutputOperators = []operator.Operator{&mockOutput}
+
+			entryChan := make(chan *entry.Entry, 1)
+			mockOutput.On("Process", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				entryChan <- args.Get(1).(*entry.Entry)
+			}).Return(nil)
+
+			require.NoError(t, tcpInput.Start(testutil.NewMockPersister("test")))
+			defer func() {
+				require.NoError(t, tcpInput.Stop(), "expected to stop tcp input operator without error")
+			}()
+
+			var conn net.Conn
+			conn, err = net.Dial("tcp", tcpInput.listener.Addr().String())
+			require.NoError(t, err)
+			defer conn.Close()
+
+			_, err = conn.Write([]byte(tc.header))
+			require.NoError(t, err)
+
+			if tc.tls {
+				conn = tls.Client(conn, &tls.Config{
+					InsecureSkipVerify: true, // #nosec G402
+					Certificates:       []tls.Certificate{clientCert},
+				})
+			}
+
+			_, err = conn.Write([]byte("message\n"))
+			require.NoError(t, err)
+
+			select {
+			case e := <-entryChan:
+				require.Equal(t, "message", e.Body)
+				for _, key := range []string{"net.peer.name", "net.host.name"} {
+					require.Contains(t, e.Attributes, key)
+					delete(e.Attributes, key)
+				}
+				require.Equal(t, tc.expectedAttributes, e.Attributes)
+			case <-time.After(time.Second):
+				require.FailNow(t, "Timed out waiting for message to be written")
+			}
+		})
+	}
+}
+
+func TestProxyProtocolMissingHeader(t *testing.T) {
+	cfg := NewConfigWithID("test_id")
+	cfg.ListenAddress = ":0"
+	cfg.ProxyProtocol = true
+	cfg.TrustedProxies = []string{"127.0.0.0/8", "::1/128"}
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	mockOutput := testutil.Operator{}
+	tcpInput := op.(*Input)
+	tcpInput.InputOperator.OutputOperators = []operator.Operator{&mockOutput}
+
+	require.NoError(t, tcpInput.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, tcpInput.Stop(), "expected to stop tcp input operator without error")
+	}()
+
+	conn, err := net.Dial("tcp", tcpInput.listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("message\n"))
+	require.NoError(t, err)
+
+	// The connection is closed without processing any entries
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	_, err = conn.Read(make([]byte, 1))
+	require.ErrorIs(t, err, io.EOF)
+	mockOutput.AssertNotCalled(t, "Process", mock.Anything, mock.Anything)
+}
+
+func TestBackpressure(t *testing.T) {
+	cfg := NewConfigWithID("test_id")
+	cfg.ListenAddress = ":0"
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	// The output does not accept entries until it is released
+	release := make(chan struct{})
+	mockOutput := testutil.Operator{}
+	mockOutput.On("Process", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		<-release
+	}).Return(nil)
+	tcpInput := op.(*Input)
+	tcpInput.InputOperator.OutputOperators = []operator.Operator{&mockOutput}
+
+	require.NoError(t, tcpInput.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, tcpInput.Stop(), "expected to stop tcp input operator without error")
+	}()
+
+	conn, err := net.Dial("tcp", tcpInput.listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// Writes eventually block once the socket buffers are full, since the input
+	// stops reading from the connection
+	line := []byte(strings.Repeat("a", 1023) + "\n")
+	var written int
+	for written < 1<<30 {
+		require.NoError(t, conn.SetWriteDeadline(time.Now().Add(time.Second)))
+		n, err := conn.Write(line)
+		written += n
+		if err != nil {
+			require.ErrorIs(t, err, os.ErrDeadlineExceeded)
+			break
+		}
+	}
+	require.Less(t, written, 1<<30, "writes never blocked")
+	close(release)
+}
+
+func TestProxyProtocolUntrustedPeer(t *testing.T) {
+	cfg := NewConfigWithID("test_id")
+	cfg.ListenAddress = "127.0.0.1:0"
+	cfg.ProxyProtocol = true
+	cfg.TrustedProxies = []string{"10.0.0.0/8"}
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	mockOutput := testutil.Operator{}
+	tcpInput := op.(*Input)
+	tcpInput.InputOperator.OutputOperators = []operator.Operator{&mockOutput}
+
+	require.NoError(t, tcpInput.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, tcpInput.Stop(), "expected to stop tcp input operator without error")
+	}()
+
+	conn, err := net.Dial("tcp", tcpInput.listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("PROXY TCP4 192.168.0.1 10.0.0.1 56324 514\r\nmessage\n"))
+	require.NoError(t, err)
+
+	// The connection is closed without reading the header
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	_, err = conn.Read(make([]byte, 1))
+	require.Error(t, err)
+	mockOutput.AssertNotCalled(t, "Process", mock.Anything, mock.Anything)
+}
+
 func TestFailToBind(t *testing.T) {
 	ip := "localhost"
 	port := 0
@@ -39,6 +39,7 @@ func TestUnmarshal(t *testing.T) {
 					cfg := NewConfig()
 					cfg.ListenAddress = "10.0.0.1:9000"
 					cfg.AddAttributes = true
+					cfg.MaxQueuedDatagrams = 500
 					cfg.Encoding = helper.NewEncodingConfig()
 					cfg.Encoding.Encoding = "utf-8"
 					cfg.Multiline = helper.NewMultilineConfig()
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package udp // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/udp"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	operatorTagKey = tag.MustNewKey("operator")
+
+	mDroppedDatagrams = stats.Int64("stanza/udp_dropped_datagrams", "Number of UDP datagrams that were dropped because the queue of received datagrams was full", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views related to receiving UDP datagrams.
+func MetricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        mDroppedDatagrams.Name(),
+			Measure:     mDroppedDatagrams,
+			Description: mDroppedDatagrams.Description(),
+			TagKeys:     []tag.Key{operatorTagKey},
+			Aggregation: view.Sum(),
+		},
+	}
+}
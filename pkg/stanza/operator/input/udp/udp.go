@@ -23,6 +23,8 @@ import (
 	"strconv"
 	"sync"
 
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
@@ -34,6 +36,8 @@ const (
 
 	// Maximum UDP packet size
 	MaxUDPSize = 64 * 1024
+
+	defaultMaxQueuedDatagrams = 1000
 )
 
 func init() {
@@ -67,10 +71,11 @@ type Config struct {
 
 // BaseConfig is the details configuration of a udp input operator.
 type BaseConfig struct {
-	ListenAddress string                 `mapstructure:"listen_address,omitempty"`
-	AddAttributes bool                   `mapstructure:"add_attributes,omitempty"`
-	Encoding      helper.EncodingConfig  `mapstructure:",squash,omitempty"`
-	Multiline     helper.MultilineConfig `mapstructure:"multiline,omitempty"`
+	ListenAddress      string                 `mapstructure:"listen_address,omitempty"`
+	AddAttributes      bool                   `mapstructure:"add_attributes,omitempty"`
+	MaxQueuedDatagrams int                    `mapstructure:"max_queued_datagrams,omitempty"`
+	Encoding           helper.EncodingConfig  `mapstructure:",squash,omitempty"`
+	Multiline          helper.MultilineConfig `mapstructure:"multiline,omitempty"`
 }
 
 // Build will build a udp input operator.
@@ -84,6 +89,14 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, fmt.Errorf("missing required parameter 'listen_address'")
 	}
 
+	maxQueuedDatagrams := c.MaxQueuedDatagrams
+	if maxQueuedDatagrams < 0 {
+		return nil, fmt.Errorf("'max_queued_datagrams' must not be negative")
+	}
+	if maxQueuedDatagrams == 0 {
+		maxQueuedDatagrams = defaultMaxQueuedDatagrams
+	}
+
 	address, err := net.ResolveUDPAddr("udp", c.ListenAddress)
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve listen_address: %w", err)
@@ -106,13 +119,14 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 	}
 
 	udpInput := &Input{
-		InputOperator: inputOperator,
-		address:       address,
-		buffer:        make([]byte, MaxUDPSize),
-		addAttributes: c.AddAttributes,
-		encoding:      encoding,
-		splitFunc:     splitFunc,
-		resolver:      resolver,
+		InputOperator:      inputOperator,
+		address:            address,
+		buffer:             make([]byte, MaxUDPSize),
+		addAttributes:      c.AddAttributes,
+		maxQueuedDatagrams: maxQueuedDatagrams,
+		encoding:           encoding,
+		splitFunc:          splitFunc,
+		resolver:           resolver,
 	}
 	return udpInput, nil
 }
@@ -121,10 +135,12 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 type Input struct {
 	buffer []byte
 	helper.InputOperator
-	address       *net.UDPAddr
-	addAttributes bool
+	address            *net.UDPAddr
+	addAttributes      bool
+	maxQueuedDatagrams int
 
 	connection net.PacketConn
+	queue      chan datagram
 	cancel     context.CancelFunc
 	wg         sync.WaitGroup
 
@@ -148,68 +164,111 @@ func (u *Input) Start(persister operator.Persister) error {
 	return nil
 }
 
-// goHandleMessages will handle messages from a udp connection.
+// datagram is a message received from a udp connection, waiting to be processed.
+type datagram struct {
+	message    []byte
+	remoteAddr net.Addr
+}
+
+// goHandleMessages will handle messages from a udp connection. Reading from the
+// connection is decoupled from processing through a bounded queue, so that a slow
+// pipeline causes datagrams to be dropped, and counted, rather than silently lost
+// in the socket's receive buffer.
 func (u *Input) goHandleMessages(ctx context.Context) {
-	u.wg.Add(1)
+	u.queue = make(chan datagram, u.maxQueuedDatagrams)
 
-	go func() {
-		defer u.wg.Done()
+	u.wg.Add(2)
+	go u.readMessages(ctx)
+	go u.processMessages(ctx)
+}
 
-		buf := make([]byte, 0, MaxUDPSize)
-		for {
-			message, remoteAddr, err := u.readMessage()
-			if err != nil {
-				select {
-				case <-ctx.Done():
-					return
-				default:
-					u.Errorw("Failed reading messages", zap.Error(err))
-				}
-				break
+// readMessages reads messages from the connection and adds them to the queue.
+func (u *Input) readMessages(ctx context.Context) {
+	defer u.wg.Done()
+	defer close(u.queue)
+
+	var dropped int64
+	for {
+		message, remoteAddr, err := u.readMessage()
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				u.Errorw("Failed reading messages", zap.Error(err))
+			}
+			break
+		}
+
+		// The buffer is reused for the next read, so the message must be copied
+		dg := datagram{
+			message:    append([]byte(nil), message...),
+			remoteAddr: remoteAddr,
+		}
+
+		select {
+		case u.queue <- dg:
+			if dropped > 0 {
+				u.Infow("Resumed queueing datagrams", zap.Int64("dropped", dropped))
+				dropped = 0
+			}
+		default:
+			if dropped == 0 {
+				u.Warnw("Queue of received datagrams is full, dropping datagrams", zap.Int("max_queued_datagrams", u.maxQueuedDatagrams))
 			}
+			dropped++
+			_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(operatorTagKey, u.ID())}, mDroppedDatagrams.M(1))
+		}
+	}
+}
 
-			scanner := bufio.NewScanner(bytes.NewReader(message))
-			scanner.Buffer(buf, MaxUDPSize)
+// processMessages creates entries from queued messages.
+func (u *Input) processMessages(ctx context.Context) {
+	defer u.wg.Done()
 
-			scanner.Split(u.splitFunc)
+	buf := make([]byte, 0, MaxUDPSize)
+	for dg := range u.queue {
+		scanner := bufio.NewScanner(bytes.NewReader(dg.message))
+		scanner.Buffer(buf, MaxUDPSize)
 
-			for scanner.Scan() {
-				decoded, err := u.encoding.Decode(scanner.Bytes())
-				if err != nil {
-					u.Errorw("Failed to decode data", zap.Error(err))
-					continue
-				}
+		scanner.Split(u.splitFunc)
 
-				entry, err := u.NewEntry(string(decoded))
-				if err != nil {
-					u.Errorw("Failed to create entry", zap.Error(err))
-					continue
-				}
+		for scanner.Scan() {
+			decoded, err := u.encoding.Decode(scanner.Bytes())
+			if err != nil {
+				u.Errorw("Failed to decode data", zap.Error(err))
+				continue
+			}
 
-				if u.addAttributes {
-					entry.AddAttribute("net.transport", "IP.UDP")
-					if addr, ok := u.connection.LocalAddr().(*net.UDPAddr); ok {
-						ip := addr.IP.String()
-						entry.AddAttribute("net.host.ip", addr.IP.String())
-						entry.AddAttribute("net.host.port", strconv.FormatInt(int64(addr.Port), 10))
-						entry.AddAttribute("net.host.name", u.resolver.GetHostFromIP(ip))
-					}
-
-					if addr, ok := remoteAddr.(*net.UDPAddr); ok {
-						ip := addr.IP.String()
-						entry.AddAttribute("net.peer.ip", ip)
-						entry.AddAttribute("net.peer.port", strconv.FormatInt(int64(addr.Port), 10))
-						entry.AddAttribute("net.peer.name", u.resolver.GetHostFromIP(ip))
-					}
+			entry, err := u.NewEntry(string(decoded))
+			if err != nil {
+				u.Errorw("Failed to create entry", zap.Error(err))
+				continue
+			}
+
+			if u.addAttributes {
+				entry.AddAttribute("net.transport", "IP.UDP")
+				if addr, ok := u.connection.LocalAddr().(*net.UDPAddr); ok {
+					ip := addr.IP.String()
+					entry.AddAttribute("net.host.ip", addr.IP.String())
+					entry.AddAttribute("net.host.port", strconv.FormatInt(int64(addr.Port), 10))
+					entry.AddAttribute("net.host.name", u.resolver.GetHostFromIP(ip))
 				}
 
-				u.Write(ctx, entry)
-			}
-			if err := scanner.Err(); err != nil {
-				u.Errorw("Scanner error", zap.Error(err))
+				if addr, ok := dg.remoteAddr.(*net.UDPAddr); ok {
+					ip := addr.IP.String()
+					entry.AddAttribute("net.peer.ip", ip)
+					entry.AddAttribute("net.peer.port", strconv.FormatInt(int64(addr.Port), 10))
+					entry.AddAttribute("net.peer.name", u.resolver.GetHostFromIP(ip))
+				}
 			}
+
+			u.Write(ctx, entry)
+		}
+		if err := scanner.Err(); err != nil {
+			u.Errorw("Scanner error", zap.Error(err))
 		}
-	}()
+	}
 }
 
 // readMessage will read log messages from the connection.
@@ -18,11 +18,13 @@ import (
 	"math/rand"
 	"net"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
@@ -162,6 +164,86 @@ func TestInputAttributes(t *testing.T) {
 	t.Run("NewlineInMessage", udpInputAttributesTest([]byte("message1\nmessage2\n"), []string{"message1\nmessage2"}))
 }
 
+func TestDropWhenQueueFull(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	cfg := NewConfigWithID("test_input")
+	cfg.ListenAddress = "127.0.0.1:0"
+	cfg.MaxQueuedDatagrams = 1
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	mockOutput := testutil.Operator{}
+	udpInput, ok := op.(*Input)
+	require.True(t, ok)
+
+	udpInput.InputOperator.OutputOperators = []operator.Operator{&mockOutput}
+
+	// Block processing of the first entry until released
+	processing := make(chan struct{})
+	release := make(chan struct{})
+	entryChan := make(chan *entry.Entry, 10)
+	var once sync.Once
+	mockOutput.On("Process", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		once.Do(func() { close(processing) })
+		<-release
+		entryChan <- args.Get(1).(*entry.Entry)
+	}).Return(nil)
+
+	require.NoError(t, udpInput.Start(testutil.NewMockPersister("test")))
+
+	conn, err := net.Dial("udp", udpInput.connection.LocalAddr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("message0"))
+	require.NoError(t, err)
+	select {
+	case <-processing:
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for message to be processed")
+	}
+
+	// One datagram fits in the queue, the rest are dropped
+	for i := 1; i <= 5; i++ {
+		_, err = conn.Write([]byte("message" + strconv.Itoa(i)))
+		require.NoError(t, err)
+	}
+
+	require.Eventually(t, func() bool {
+		rows, err := view.RetrieveData(mDroppedDatagrams.Name())
+		if err != nil || len(rows) != 1 {
+			return false
+		}
+		return rows[0].Data.(*view.SumData).Value == 4
+	}, 5*time.Second, 10*time.Millisecond)
+
+	close(release)
+	for _, expectedBody := range []string{"message0", "message1"} {
+		select {
+		case entry := <-entryChan:
+			require.Equal(t, expectedBody, entry.Body)
+		case <-time.After(time.Second):
+			require.FailNow(t, "Timed out waiting for message to be written")
+		}
+	}
+
+	require.NoError(t, udpInput.Stop(), "expected to stop udp input operator without error")
+	require.Len(t, entryChan, 0)
+}
+
+func TestBuildInvalidMaxQueuedDatagrams(t *testing.T) {
+	cfg := NewConfigWithID("test_input")
+	cfg.ListenAddress = ":0"
+	cfg.MaxQueuedDatagrams = -1
+
+	_, err := cfg.Build(testutil.Logger(t))
+	require.ErrorContains(t, err, "max_queued_datagrams")
+}
+
 func TestFailToBind(t *testing.T) {
 	ip := "localhost"
 	port := 0
@@ -0,0 +1,216 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cef // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/cef"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType = "cef_parser"
+
+	cefPrefix = "CEF:"
+
+	// headerFields is the number of pipe separated fields which precede the extension.
+	headerFields = 7
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new cef parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new cef parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig: helper.NewParserConfig(operatorID, operatorType),
+	}
+}
+
+// Config is the configuration of a cef parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash"`
+}
+
+// Build will build a cef parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Parser{
+		ParserOperator: parserOperator,
+	}, nil
+}
+
+// Parser is an operator that parses Common Event Format (CEF) messages.
+type Parser struct {
+	helper.ParserOperator
+}
+
+// Process will parse an entry for cef.
+func (p *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	return p.ParserOperator.ProcessWith(ctx, entry, p.parse)
+}
+
+// parse will parse a cef value.
+func (p *Parser) parse(value interface{}) (interface{}, error) {
+	switch m := value.(type) {
+	case string:
+		return parseCEF(m)
+	case []byte:
+		return parseCEF(string(m))
+	default:
+		return nil, fmt.Errorf("type %T cannot be parsed as cef", value)
+	}
+}
+
+// parseCEF parses a CEF message. Any content preceding the CEF prefix, such as
+// a syslog header, is ignored.
+func parseCEF(input string) (map[string]interface{}, error) {
+	start := strings.Index(input, cefPrefix)
+	if start < 0 {
+		return nil, errors.New("cef prefix not found")
+	}
+	input = input[start+len(cefPrefix):]
+
+	header, extension, err := splitHeader(input)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := map[string]interface{}{
+		"version":        header[0],
+		"device_vendor":  header[1],
+		"device_product": header[2],
+		"device_version": header[3],
+		"signature_id":   header[4],
+		"name":           header[5],
+		"severity":       header[6],
+	}
+
+	extensions, err := parseExtension(extension)
+	if err != nil {
+		return nil, err
+	}
+	if len(extensions) > 0 {
+		parsed["extensions"] = extensions
+	}
+
+	return parsed, nil
+}
+
+// splitHeader splits the header on unescaped pipes and returns the unescaped
+// header fields along with the remaining extension.
+func splitHeader(input string) ([]string, string, error) {
+	fields := make([]string, 0, headerFields)
+	var field strings.Builder
+
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+		switch {
+		case c == '\\' && i+1 < len(input) && (input[i+1] == '|' || input[i+1] == '\\'):
+			field.WriteByte(input[i+1])
+			i++
+		case c == '|':
+			fields = append(fields, field.String())
+			field.Reset()
+			if len(fields) == headerFields {
+				return fields, input[i+1:], nil
+			}
+		default:
+			field.WriteByte(c)
+		}
+	}
+
+	// The trailing pipe may be omitted when there is no extension
+	if len(fields) == headerFields-1 {
+		return append(fields, field.String()), "", nil
+	}
+
+	return nil, "", fmt.Errorf("expected %d header fields, got %d", headerFields, len(fields)+1)
+}
+
+// parseExtension parses the space separated key=value pairs of the extension.
+// Values may contain spaces, so a value ends where the next key begins.
+func parseExtension(extension string) (map[string]interface{}, error) {
+	extension = strings.TrimSpace(extension)
+	if extension == "" {
+		return nil, nil
+	}
+
+	parsed := make(map[string]interface{})
+	key := ""
+	valueStart := 0
+
+	for i := 0; i < len(extension); i++ {
+		switch extension[i] {
+		case '\\':
+			// skip the escaped character
+			i++
+		case '=':
+			keyStart := strings.LastIndexByte(extension[valueStart:i], ' ')
+			if key != "" && keyStart < 0 {
+				// an unescaped equals sign inside of a value
+				continue
+			}
+			keyStart += valueStart + 1
+
+			if key != "" {
+				parsed[key] = unescapeValue(strings.TrimRight(extension[valueStart:keyStart], " "))
+			} else if keyStart != 0 {
+				return nil, fmt.Errorf("invalid extension: unexpected value %q", extension[:keyStart-1])
+			}
+
+			key = extension[keyStart:i]
+			if key == "" {
+				return nil, fmt.Errorf("invalid extension: empty key at position %d", i)
+			}
+			valueStart = i + 1
+		}
+	}
+
+	if key == "" {
+		return nil, fmt.Errorf("invalid extension: expected key=value pairs, got %q", extension)
+	}
+	parsed[key] = unescapeValue(extension[valueStart:])
+
+	return parsed, nil
+}
+
+var extensionReplacer = strings.NewReplacer(
+	`\\`, `\`,
+	`\=`, `=`,
+	`\n`, "\n",
+	`\r`, "\r",
+)
+
+func unescapeValue(value string) string {
+	return extensionReplacer.Replace(value)
+}
@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cef
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newTestParser(t *testing.T) *Parser {
+	config := NewConfigWithID("test")
+	op, err := config.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestInit(t *testing.T) {
+	builder, ok := operator.DefaultRegistry.Lookup("cef_parser")
+	require.True(t, ok, "expected cef_parser to be registered")
+	require.Equal(t, "cef_parser", builder().Type())
+}
+
+func TestConfigBuild(t *testing.T) {
+	config := NewConfigWithID("test")
+	op, err := config.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	require.IsType(t, &Parser{}, op)
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	config := NewConfigWithID("test")
+	config.OnError = "invalid_on_error"
+	_, err := config.Build(testutil.Logger(t))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid `on_error` field")
+}
+
+func TestParserInvalidType(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse([]int{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "type []int cannot be parsed as cef")
+}
+
+func TestCEFImplementations(t *testing.T) {
+	require.Implements(t, (*operator.Operator)(nil), new(Parser))
+}
+
+func TestParseCEF(t *testing.T) {
+	cases := []struct {
+		name   string
+		input  string
+		expect map[string]interface{}
+		errMsg string
+	}{
+		{
+			"simple",
+			`CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1 dst=2.1.2.2 spt=1232`,
+			map[string]interface{}{
+				"version":        "0",
+				"device_vendor":  "Security",
+				"device_product": "threatmanager",
+				"device_version": "1.0",
+				"signature_id":   "100",
+				"name":           "worm successfully stopped",
+				"severity":       "10",
+				"extensions": map[string]interface{}{
+					"src": "10.0.0.1",
+					"dst": "2.1.2.2",
+					"spt": "1232",
+				},
+			},
+			"",
+		},
+		{
+			"syslog-prefix",
+			`Sep 19 08:26:10 host CEF:0|Vendor|Product|1.0|100|name|5|`,
+			map[string]interface{}{
+				"version":        "0",
+				"device_vendor":  "Vendor",
+				"device_product": "Product",
+				"device_version": "1.0",
+				"signature_id":   "100",
+				"name":           "name",
+				"severity":       "5",
+			},
+			"",
+		},
+		{
+			"no-trailing-pipe",
+			`CEF:1|Vendor|Product|1.0|100|name|Low`,
+			map[string]interface{}{
+				"version":        "1",
+				"device_vendor":  "Vendor",
+				"device_product": "Product",
+				"device_version": "1.0",
+				"signature_id":   "100",
+				"name":           "name",
+				"severity":       "Low",
+			},
+			"",
+		},
+		{
+			"escaped-header",
+			`CEF:0|security|threatmanager|1.0|100|detected a \| in message|10|`,
+			map[string]interface{}{
+				"version":        "0",
+				"device_vendor":  "security",
+				"device_product": "threatmanager",
+				"device_version": "1.0",
+				"signature_id":   "100",
+				"name":           "detected a | in message",
+				"severity":       "10",
+			},
+			"",
+		},
+		{
+			"values-with-spaces-and-escapes",
+			`CEF:0|Vendor|Product|1.0|100|name|3|msg=User logged in\nfrom a\=b  suser=alice request=http://example.com/?a=b cs1Label=Rule Name cs1=Allow all \\ traffic`,
+			map[string]interface{}{
+				"version":        "0",
+				"device_vendor":  "Vendor",
+				"device_product": "Product",
+				"device_version": "1.0",
+				"signature_id":   "100",
+				"name":           "name",
+				"severity":       "3",
+				"extensions": map[string]interface{}{
+					"msg":      "User logged in\nfrom a=b",
+					"suser":    "alice",
+					"request":  "http://example.com/?a=b",
+					"cs1Label": "Rule Name",
+					"cs1":      `Allow all \ traffic`,
+				},
+			},
+			"",
+		},
+		{
+			"missing-prefix",
+			`LEEF:1.0|Vendor|Product|1.0|100|`,
+			nil,
+			"cef prefix not found",
+		},
+		{
+			"too-few-header-fields",
+			`CEF:0|Vendor|Product|1.0`,
+			nil,
+			"expected 7 header fields, got 4",
+		},
+		{
+			"extension-without-key",
+			`CEF:0|Vendor|Product|1.0|100|name|3|garbage`,
+			nil,
+			"expected key=value pairs",
+		},
+		{
+			"extension-leading-value",
+			`CEF:0|Vendor|Product|1.0|100|name|3|garbage src=1.1.1.1`,
+			nil,
+			"unexpected value",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := parseCEF(tc.input)
+			if tc.errMsg != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, parsed)
+		})
+	}
+}
+
+func TestParser(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	sevField := entry.NewAttributeField("severity")
+	sevCfg := helper.NewSeverityConfig()
+	sevCfg.ParseFrom = &sevField
+	sevCfg.Mapping = map[interface{}]interface{}{
+		"info":  map[interface{}]interface{}{"min": 0, "max": 3},
+		"warn":  map[interface{}]interface{}{"min": 4, "max": 6},
+		"error": map[interface{}]interface{}{"min": 7, "max": 8},
+		"fatal": map[interface{}]interface{}{"min": 9, "max": 10},
+	}
+	cfg.SeverityConfig = &sevCfg
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+	body := `CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|7|src=10.0.0.1`
+	ots := time.Now()
+	input := &entry.Entry{
+		ObservedTimestamp: ots,
+		Body:              body,
+	}
+	require.NoError(t, op.Process(context.Background(), input))
+
+	fake.ExpectEntry(t, &entry.Entry{
+		ObservedTimestamp: ots,
+		Body:              body,
+		Severity:          entry.Error,
+		SeverityText:      "7",
+		Attributes: map[string]interface{}{
+			"version":        "0",
+			"device_vendor":  "Security",
+			"device_product": "threatmanager",
+			"device_version": "1.0",
+			"signature_id":   "100",
+			"name":           "worm successfully stopped",
+			"severity":       "7",
+			"extensions": map[string]interface{}{
+				"src": "10.0.0.1",
+			},
+		},
+	})
+}
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leef // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/leef"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType = "leef_parser"
+
+	leefPrefix = "LEEF:"
+
+	defaultDelimiter = "\t"
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new leef parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new leef parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig: helper.NewParserConfig(operatorID, operatorType),
+	}
+}
+
+// Config is the configuration of a leef parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash"`
+}
+
+// Build will build a leef parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Parser{
+		ParserOperator: parserOperator,
+	}, nil
+}
+
+// Parser is an operator that parses Log Event Extended Format (LEEF) messages.
+type Parser struct {
+	helper.ParserOperator
+}
+
+// Process will parse an entry for leef.
+func (p *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	return p.ParserOperator.ProcessWith(ctx, entry, p.parse)
+}
+
+// parse will parse a leef value.
+func (p *Parser) parse(value interface{}) (interface{}, error) {
+	switch m := value.(type) {
+	case string:
+		return parseLEEF(m)
+	case []byte:
+		return parseLEEF(string(m))
+	default:
+		return nil, fmt.Errorf("type %T cannot be parsed as leef", value)
+	}
+}
+
+// parseLEEF parses a LEEF 1.0 or 2.0 message. Any content preceding the LEEF
+// prefix, such as a syslog header, is ignored.
+func parseLEEF(input string) (map[string]interface{}, error) {
+	start := strings.Index(input, leefPrefix)
+	if start < 0 {
+		return nil, errors.New("leef prefix not found")
+	}
+	input = input[start+len(leefPrefix):]
+
+	// LEEF 2.0 adds a sixth header field which declares the attribute delimiter
+	headerFields := 5
+	if strings.HasPrefix(input, "2.") {
+		headerFields = 6
+	}
+
+	fields := strings.SplitN(input, "|", headerFields+1)
+	if len(fields) < headerFields {
+		return nil, fmt.Errorf("expected %d header fields, got %d", headerFields, len(fields))
+	}
+
+	parsed := map[string]interface{}{
+		"version":         fields[0],
+		"vendor":          fields[1],
+		"product":         fields[2],
+		"product_version": fields[3],
+		"event_id":        fields[4],
+	}
+
+	delimiter := defaultDelimiter
+	if headerFields == 6 {
+		d, err := parseDelimiter(fields[5])
+		if err != nil {
+			return nil, err
+		}
+		delimiter = d
+	}
+
+	if len(fields) <= headerFields {
+		return parsed, nil
+	}
+
+	attributes, err := parseAttributes(fields[headerFields], delimiter)
+	if err != nil {
+		return nil, err
+	}
+	if len(attributes) > 0 {
+		parsed["attributes"] = attributes
+	}
+
+	return parsed, nil
+}
+
+// parseDelimiter parses the LEEF 2.0 delimiter header field, which is either a
+// single character or a hex encoded character such as x09 or 0x5E.
+func parseDelimiter(field string) (string, error) {
+	switch {
+	case field == "":
+		return defaultDelimiter, nil
+	case len(field) == 1:
+		return field, nil
+	}
+
+	hex := strings.ToLower(field)
+	switch {
+	case strings.HasPrefix(hex, "0x"):
+		hex = hex[2:]
+	case strings.HasPrefix(hex, "x"):
+		hex = hex[1:]
+	default:
+		return "", fmt.Errorf("invalid delimiter %q", field)
+	}
+	code, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return "", fmt.Errorf("invalid delimiter %q: %w", field, err)
+	}
+	return string(rune(code)), nil
+}
+
+// parseAttributes parses the delimiter separated key=value event attributes.
+func parseAttributes(input, delimiter string) (map[string]interface{}, error) {
+	attributes := make(map[string]interface{})
+	for _, raw := range strings.Split(input, delimiter) {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(raw, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected '%s' to split by '=' into two items", raw)
+		}
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("invalid attribute '%s': empty key", raw)
+		}
+		attributes[key] = value
+	}
+	return attributes, nil
+}
@@ -0,0 +1,228 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leef
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newTestParser(t *testing.T) *Parser {
+	config := NewConfigWithID("test")
+	op, err := config.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestInit(t *testing.T) {
+	builder, ok := operator.DefaultRegistry.Lookup("leef_parser")
+	require.True(t, ok, "expected leef_parser to be registered")
+	require.Equal(t, "leef_parser", builder().Type())
+}
+
+func TestConfigBuild(t *testing.T) {
+	config := NewConfigWithID("test")
+	op, err := config.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	require.IsType(t, &Parser{}, op)
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	config := NewConfigWithID("test")
+	config.OnError = "invalid_on_error"
+	_, err := config.Build(testutil.Logger(t))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid `on_error` field")
+}
+
+func TestParserInvalidType(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse([]int{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "type []int cannot be parsed as leef")
+}
+
+func TestLEEFImplementations(t *testing.T) {
+	require.Implements(t, (*operator.Operator)(nil), new(Parser))
+}
+
+func TestParseLEEF(t *testing.T) {
+	cases := []struct {
+		name   string
+		input  string
+		expect map[string]interface{}
+		errMsg string
+	}{
+		{
+			"v1",
+			"LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tdst=172.50.123.1\tsev=5\tcat=anomaly\tmsg=there are spaces and = signs",
+			map[string]interface{}{
+				"version":         "1.0",
+				"vendor":          "Microsoft",
+				"product":         "MSExchange",
+				"product_version": "4.0 SP1",
+				"event_id":        "15345",
+				"attributes": map[string]interface{}{
+					"src": "192.0.2.0",
+					"dst": "172.50.123.1",
+					"sev": "5",
+					"cat": "anomaly",
+					"msg": "there are spaces and = signs",
+				},
+			},
+			"",
+		},
+		{
+			"v1-syslog-prefix-no-attributes",
+			"Jan 18 11:07:53 host LEEF:1.0|Vendor|Product|1.0|100|",
+			map[string]interface{}{
+				"version":         "1.0",
+				"vendor":          "Vendor",
+				"product":         "Product",
+				"product_version": "1.0",
+				"event_id":        "100",
+			},
+			"",
+		},
+		{
+			"v2-char-delimiter",
+			"LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5^sev=5",
+			map[string]interface{}{
+				"version":         "2.0",
+				"vendor":          "Lancope",
+				"product":         "StealthWatch",
+				"product_version": "1.0",
+				"event_id":        "41",
+				"attributes": map[string]interface{}{
+					"src": "10.0.1.8",
+					"dst": "10.0.0.5",
+					"sev": "5",
+				},
+			},
+			"",
+		},
+		{
+			"v2-hex-delimiter",
+			"LEEF:2.0|Lancope|StealthWatch|1.0|41|0x5E|src=10.0.1.8^dst=10.0.0.5",
+			map[string]interface{}{
+				"version":         "2.0",
+				"vendor":          "Lancope",
+				"product":         "StealthWatch",
+				"product_version": "1.0",
+				"event_id":        "41",
+				"attributes": map[string]interface{}{
+					"src": "10.0.1.8",
+					"dst": "10.0.0.5",
+				},
+			},
+			"",
+		},
+		{
+			"v2-default-delimiter",
+			"LEEF:2.0|Lancope|StealthWatch|1.0|41||src=10.0.1.8\tdst=10.0.0.5",
+			map[string]interface{}{
+				"version":         "2.0",
+				"vendor":          "Lancope",
+				"product":         "StealthWatch",
+				"product_version": "1.0",
+				"event_id":        "41",
+				"attributes": map[string]interface{}{
+					"src": "10.0.1.8",
+					"dst": "10.0.0.5",
+				},
+			},
+			"",
+		},
+		{
+			"missing-prefix",
+			"CEF:0|Vendor|Product|1.0|100|name|3|",
+			nil,
+			"leef prefix not found",
+		},
+		{
+			"too-few-header-fields",
+			"LEEF:1.0|Vendor|Product",
+			nil,
+			"expected 5 header fields, got 3",
+		},
+		{
+			"invalid-delimiter",
+			"LEEF:2.0|Vendor|Product|1.0|41|zz|src=10.0.1.8",
+			nil,
+			"invalid delimiter",
+		},
+		{
+			"invalid-attribute",
+			"LEEF:1.0|Vendor|Product|1.0|100|src=10.0.1.8\tgarbage",
+			nil,
+			"expected 'garbage' to split by '='",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := parseLEEF(tc.input)
+			if tc.errMsg != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, parsed)
+		})
+	}
+}
+
+func TestParser(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	cfg.ParseTo = entry.RootableField{Field: entry.NewBodyField("leef")}
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+	ots := time.Now()
+	input := &entry.Entry{
+		ObservedTimestamp: ots,
+		Body:              "LEEF:1.0|Vendor|Product|1.0|100|src=10.0.1.8",
+	}
+	require.NoError(t, op.Process(context.Background(), input))
+
+	fake.ExpectEntry(t, &entry.Entry{
+		ObservedTimestamp: ots,
+		Body: map[string]interface{}{
+			"leef": map[string]interface{}{
+				"version":         "1.0",
+				"vendor":          "Vendor",
+				"product":         "Product",
+				"product_version": "1.0",
+				"event_id":        "100",
+				"attributes": map[string]interface{}{
+					"src": "10.0.1.8",
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xml // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/xml"
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const operatorType = "xml_parser"
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new xml parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new xml parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig:    helper.NewParserConfig(operatorID, operatorType),
+		AttributePrefix: "@",
+		TextKey:         "#text",
+	}
+}
+
+// Config is the configuration of an xml parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash"`
+
+	AttributePrefix string   `mapstructure:"attribute_prefix"`
+	TextKey         string   `mapstructure:"text_key"`
+	ForceArray      []string `mapstructure:"force_array"`
+}
+
+// Build will build an xml parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.TextKey == "" {
+		return nil, errors.New("text_key is a required parameter")
+	}
+
+	if c.TextKey == c.AttributePrefix {
+		return nil, errors.New("text_key and attribute_prefix cannot be the same value")
+	}
+
+	forceArray := make(map[string]struct{}, len(c.ForceArray))
+	for _, name := range c.ForceArray {
+		forceArray[name] = struct{}{}
+	}
+
+	return &Parser{
+		ParserOperator:  parserOperator,
+		attributePrefix: c.AttributePrefix,
+		textKey:         c.TextKey,
+		forceArray:      forceArray,
+	}, nil
+}
+
+// Parser is an operator that parses xml documents.
+type Parser struct {
+	helper.ParserOperator
+	attributePrefix string
+	textKey         string
+	forceArray      map[string]struct{}
+}
+
+// Process will parse an entry for xml.
+func (p *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	return p.ParserOperator.ProcessWith(ctx, entry, p.parse)
+}
+
+// parse will parse an xml value.
+func (p *Parser) parse(value interface{}) (interface{}, error) {
+	switch m := value.(type) {
+	case string:
+		return p.parseXML([]byte(m))
+	case []byte:
+		return p.parseXML(m)
+	default:
+		return nil, fmt.Errorf("type %T cannot be parsed as xml", value)
+	}
+}
+
+// element is an xml element that is being decoded.
+type element struct {
+	name     string
+	attrs    map[string]interface{}
+	children map[string]interface{}
+	text     strings.Builder
+}
+
+func (p *Parser) parseXML(input []byte) (map[string]interface{}, error) {
+	if len(bytes.TrimSpace(input)) == 0 {
+		return nil, fmt.Errorf("parse from field %s is empty", p.ParseFrom.String())
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(input))
+	var (
+		stack  []*element
+		result map[string]interface{}
+	)
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse xml: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if result != nil {
+				return nil, errors.New("parse xml: multiple root elements")
+			}
+			el := &element{
+				name:     t.Name.Local,
+				children: make(map[string]interface{}),
+			}
+			if len(t.Attr) > 0 {
+				el.attrs = make(map[string]interface{}, len(t.Attr))
+				for _, attr := range t.Attr {
+					if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+						continue
+					}
+					el.attrs[p.attributePrefix+attr.Name.Local] = attr.Value
+				}
+			}
+			stack = append(stack, el)
+		case xml.EndElement:
+			el := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			value := p.elementValue(el)
+			if len(stack) == 0 {
+				result = map[string]interface{}{el.name: value}
+				if _, ok := p.forceArray[el.name]; ok {
+					result[el.name] = []interface{}{value}
+				}
+				continue
+			}
+			p.addChild(stack[len(stack)-1], el.name, value)
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		}
+	}
+
+	if result == nil {
+		return nil, errors.New("parse xml: no root element found")
+	}
+
+	return result, nil
+}
+
+// elementValue returns the value of an element. Elements which only contain text
+// are returned as strings; all others are returned as maps.
+func (p *Parser) elementValue(el *element) interface{} {
+	text := strings.TrimSpace(el.text.String())
+	if len(el.attrs) == 0 && len(el.children) == 0 {
+		return text
+	}
+
+	value := make(map[string]interface{}, len(el.attrs)+len(el.children)+1)
+	for k, v := range el.attrs {
+		value[k] = v
+	}
+	for k, v := range el.children {
+		value[k] = v
+	}
+	if text != "" {
+		value[p.textKey] = text
+	}
+	return value
+}
+
+// addChild adds a value to the parent element. Repeated elements, and elements
+// configured with force_array, are collected into arrays.
+func (p *Parser) addChild(parent *element, name string, value interface{}) {
+	existing, ok := parent.children[name]
+	if !ok {
+		if _, force := p.forceArray[name]; force {
+			parent.children[name] = []interface{}{value}
+			return
+		}
+		parent.children[name] = value
+		return
+	}
+
+	if arr, isArray := existing.([]interface{}); isArray {
+		parent.children[name] = append(arr, value)
+		return
+	}
+	parent.children[name] = []interface{}{existing, value}
+}
@@ -0,0 +1,269 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xml
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newTestParser(t *testing.T) *Parser {
+	config := NewConfigWithID("test")
+	op, err := config.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestInit(t *testing.T) {
+	builder, ok := operator.DefaultRegistry.Lookup("xml_parser")
+	require.True(t, ok, "expected xml_parser to be registered")
+	require.Equal(t, "xml_parser", builder().Type())
+}
+
+func TestConfigBuild(t *testing.T) {
+	config := NewConfigWithID("test")
+	op, err := config.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	require.IsType(t, &Parser{}, op)
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	config := NewConfigWithID("test")
+	config.OnError = "invalid_on_error"
+	_, err := config.Build(testutil.Logger(t))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid `on_error` field")
+}
+
+func TestBuild(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		expectErr bool
+	}{
+		{"default", func(cfg *Config) {}, false},
+		{"empty-attribute-prefix", func(cfg *Config) { cfg.AttributePrefix = "" }, false},
+		{"missing-text-key", func(cfg *Config) { cfg.TextKey = "" }, true},
+		{"same-text-key-and-attribute-prefix", func(cfg *Config) { cfg.TextKey = "_"; cfg.AttributePrefix = "_" }, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test_operator_id")
+			tc.configure(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestParserInvalidType(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse([]int{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "type []int cannot be parsed as xml")
+}
+
+func TestParserInvalidXML(t *testing.T) {
+	cases := []struct {
+		name   string
+		input  string
+		errMsg string
+	}{
+		{"empty", "   ", "is empty"},
+		{"unclosed", "<a><b></a>", "parse xml"},
+		{"multiple-roots", "<a/><b/>", "multiple root elements"},
+		{"no-root", "just text", "no root element found"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := newTestParser(t)
+			_, err := parser.parse(tc.input)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+}
+
+func TestXMLImplementations(t *testing.T) {
+	require.Implements(t, (*operator.Operator)(nil), new(Parser))
+}
+
+func TestParser(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		input     *entry.Entry
+		expect    *entry.Entry
+	}{
+		{
+			"simple",
+			func(p *Config) {},
+			&entry.Entry{
+				Body: `<event><user>alice</user><action>login</action></event>`,
+			},
+			&entry.Entry{
+				Attributes: map[string]interface{}{
+					"event": map[string]interface{}{
+						"user":   "alice",
+						"action": "login",
+					},
+				},
+				Body: `<event><user>alice</user><action>login</action></event>`,
+			},
+		},
+		{
+			"attributes-and-text",
+			func(p *Config) {},
+			&entry.Entry{
+				Body: `<audit id="42"><msg lang="en">access denied</msg></audit>`,
+			},
+			&entry.Entry{
+				Attributes: map[string]interface{}{
+					"audit": map[string]interface{}{
+						"@id": "42",
+						"msg": map[string]interface{}{
+							"@lang": "en",
+							"#text": "access denied",
+						},
+					},
+				},
+				Body: `<audit id="42"><msg lang="en">access denied</msg></audit>`,
+			},
+		},
+		{
+			"repeated-elements",
+			func(p *Config) {},
+			&entry.Entry{
+				Body: `<users><user>alice</user><user>bob</user><user>carol</user></users>`,
+			},
+			&entry.Entry{
+				Attributes: map[string]interface{}{
+					"users": map[string]interface{}{
+						"user": []interface{}{"alice", "bob", "carol"},
+					},
+				},
+				Body: `<users><user>alice</user><user>bob</user><user>carol</user></users>`,
+			},
+		},
+		{
+			"force-array",
+			func(p *Config) {
+				p.ForceArray = []string{"user"}
+			},
+			&entry.Entry{
+				Body: `<users><user>alice</user></users>`,
+			},
+			&entry.Entry{
+				Attributes: map[string]interface{}{
+					"users": map[string]interface{}{
+						"user": []interface{}{"alice"},
+					},
+				},
+				Body: `<users><user>alice</user></users>`,
+			},
+		},
+		{
+			"custom-prefix-and-text-key",
+			func(p *Config) {
+				p.AttributePrefix = "attr_"
+				p.TextKey = "value"
+			},
+			&entry.Entry{
+				Body: `<msg lang="en">hello</msg>`,
+			},
+			&entry.Entry{
+				Attributes: map[string]interface{}{
+					"msg": map[string]interface{}{
+						"attr_lang": "en",
+						"value":     "hello",
+					},
+				},
+				Body: `<msg lang="en">hello</msg>`,
+			},
+		},
+		{
+			"namespaces-and-declaration",
+			func(p *Config) {},
+			&entry.Entry{
+				Body: "<?xml version=\"1.0\"?>\n<e:Event xmlns:e=\"urn:example\" xmlns=\"urn:default\">\n  <e:Level>4</e:Level>\n  <!-- comment -->\n  <Empty/>\n</e:Event>",
+			},
+			&entry.Entry{
+				Attributes: map[string]interface{}{
+					"Event": map[string]interface{}{
+						"Level": "4",
+						"Empty": "",
+					},
+				},
+				Body: "<?xml version=\"1.0\"?>\n<e:Event xmlns:e=\"urn:example\" xmlns=\"urn:default\">\n  <e:Level>4</e:Level>\n  <!-- comment -->\n  <Empty/>\n</e:Event>",
+			},
+		},
+		{
+			"parse-to-body",
+			func(p *Config) {
+				p.ParseFrom = entry.NewAttributeField("raw")
+				p.ParseTo = entry.RootableField{Field: entry.NewBodyField()}
+			},
+			&entry.Entry{
+				Attributes: map[string]interface{}{
+					"raw": `<a><b>c</b></a>`,
+				},
+			},
+			&entry.Entry{
+				Attributes: map[string]interface{}{
+					"raw": `<a><b>c</b></a>`,
+				},
+				Body: map[string]interface{}{
+					"a": map[string]interface{}{
+						"b": "c",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.OutputIDs = []string{"fake"}
+			tc.configure(cfg)
+
+			op, err := cfg.Build(testutil.Logger(t))
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			ots := time.Now()
+			tc.input.ObservedTimestamp = ots
+			tc.expect.ObservedTimestamp = ots
+
+			require.NoError(t, op.Process(context.Background(), tc.input))
+			fake.ExpectEntry(t, tc.expect)
+		})
+	}
+}
@@ -26,6 +26,13 @@ type Persister interface {
 	Delete(context.Context, string) error
 }
 
+// Syncer is implemented by persisters which can wait until the entries
+// written by operators so far have been consumed, so that operators which
+// checkpoint their position in their input do not skip buffered entries.
+type Syncer interface {
+	Sync(context.Context) error
+}
+
 type scopedPersister struct {
 	Persister
 	scope string
@@ -47,3 +54,11 @@ func (p scopedPersister) Set(ctx context.Context, key string, value []byte) erro
 func (p scopedPersister) Delete(ctx context.Context, key string) error {
 	return p.Persister.Delete(ctx, fmt.Sprintf("%s.%s", p.scope, key))
 }
+
+// Sync waits for the entries written so far if the underlying persister is a Syncer
+func (p scopedPersister) Sync(ctx context.Context) error {
+	if syncer, ok := p.Persister.(Syncer); ok {
+		return syncer.Sync(ctx)
+	}
+	return nil
+}
@@ -85,6 +85,15 @@ func TestUnmarshal(t *testing.T) {
 					return cfg
 				}(),
 			},
+			{
+				Name:      "persist_batches",
+				ExpectErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.PersistBatches = true
+					return cfg
+				}(),
+			},
 		},
 	}.Run(t)
 }
@@ -15,7 +15,9 @@
 package recombine // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/recombine"
 
 import (
+	"bytes"
 	"context"
+	"encoding/gob"
 	"fmt"
 	"strings"
 	"sync"
@@ -37,6 +39,13 @@ const (
 
 func init() {
 	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+
+	// The types which entries may hold in their interface fields, so that batches
+	// can be persisted without changing the types of their values.
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+	gob.Register(map[string]string{})
+	gob.Register([]string{})
 }
 
 // NewConfig creates a new recombine config with default values
@@ -69,6 +78,7 @@ type Config struct {
 	OverwriteWith            string        `mapstructure:"overwrite_with"`
 	ForceFlushTimeout        time.Duration `mapstructure:"force_flush_period"`
 	MaxSources               int           `mapstructure:"max_sources"`
+	PersistBatches           bool          `mapstructure:"persist_batches"`
 }
 
 // Build creates a new Transformer from a config
@@ -130,6 +140,8 @@ func (c *Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		ticker:              time.NewTicker(c.ForceFlushTimeout),
 		chClose:             make(chan struct{}),
 		sourceIdentifier:    c.SourceIdentifier,
+		persistBatches:      c.PersistBatches,
+		persisted:           make(map[string]int),
 	}, nil
 }
 
@@ -148,12 +160,44 @@ type Transformer struct {
 	forceFlushTimeout   time.Duration
 	chClose             chan struct{}
 	sourceIdentifier    entry.Field
+	persistBatches      bool
 
 	sync.Mutex
 	batchMap map[string][]*entry.Entry
+
+	// persister is only set when persist_batches is enabled and the receiver has storage
+	persister operator.Persister
+	// persisted is the number of entries persisted for the batch of each source
+	persisted map[string]int
+}
+
+const (
+	batchesKey = "batches"
+	probeKey   = "probe"
+)
+
+// entryKey is the key of the entry at the given index in the persisted batch of a source
+func entryKey(source string, index int) string {
+	return fmt.Sprintf("batch.%s.%d", source, index)
 }
 
-func (r *Transformer) Start(_ operator.Persister) error {
+func (r *Transformer) Start(persister operator.Persister) error {
+	if r.persistBatches {
+		ctx := context.Background()
+		durable, err := isDurable(ctx, persister)
+		if err != nil {
+			return fmt.Errorf("failed to check persister: %w", err)
+		}
+		if durable {
+			r.persister = persister
+			if err := r.loadBatches(ctx); err != nil {
+				return fmt.Errorf("failed to load persisted batches: %w", err)
+			}
+		} else {
+			r.Warn("persist_batches is enabled, but the receiver has no storage extension configured. Batches are flushed on shutdown instead")
+		}
+	}
+
 	go r.flushLoop()
 
 	return nil
@@ -191,6 +235,12 @@ func (r *Transformer) Stop() error {
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
+
+	// Persisted batches are kept so that they can be combined with the rest
+	// of their entries after a restart, rather than being emitted partially.
+	if r.persister != nil {
+		r.batchMap = make(map[string][]*entry.Entry)
+	}
 	r.flushUncombined(ctx)
 
 	close(r.chClose)
@@ -266,7 +316,10 @@ func (r *Transformer) matchIndicatesLast() bool {
 }
 
 // addToBatch adds the current entry to the current batch of entries that will be combined
-func (r *Transformer) addToBatch(_ context.Context, e *entry.Entry, source string) {
+func (r *Transformer) addToBatch(ctx context.Context, e *entry.Entry, source string) {
+	if err := r.persistEntry(ctx, source, e); err != nil {
+		r.Errorw("Failed to persist batched entry", zap.Error(err))
+	}
 	if _, ok := r.batchMap[source]; !ok {
 		r.batchMap[source] = []*entry.Entry{e}
 		if len(r.batchMap) >= r.maxSources {
@@ -292,6 +345,9 @@ func (r *Transformer) flushUncombined(ctx context.Context) {
 		for _, entry := range r.batchMap[source] {
 			r.Write(ctx, entry)
 		}
+		if err := r.forgetBatch(ctx, source); err != nil {
+			r.Errorw("Failed to remove persisted batch", zap.Error(err))
+		}
 	}
 	r.batchMap = make(map[string][]*entry.Entry)
 	r.ticker.Reset(r.forceFlushTimeout)
@@ -341,5 +397,113 @@ func (r *Transformer) flushSource(source string) error {
 	r.Write(context.Background(), base)
 
 	delete(r.batchMap, source)
+	if err := r.forgetBatch(context.Background(), source); err != nil {
+		r.Errorw("Failed to remove persisted batch", zap.Error(err))
+	}
+	return nil
+}
+
+// persistEntry writes the entry added to the batch of the source, so that the batch
+// survives a crash as well as a shutdown.
+func (r *Transformer) persistEntry(ctx context.Context, source string, e *entry.Entry) error {
+	if r.persister == nil {
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(e); err != nil {
+		return fmt.Errorf("encode entry: %w", err)
+	}
+	index := r.persisted[source]
+	if err := r.persister.Set(ctx, entryKey(source, index), buf.Bytes()); err != nil {
+		return err
+	}
+	r.persisted[source] = index + 1
+	return r.saveIndex(ctx)
+}
+
+// forgetBatch removes the persisted entries of the source once its batch was emitted.
+// The index is written first, so that an emitted batch is never restored.
+func (r *Transformer) forgetBatch(ctx context.Context, source string) error {
+	count, ok := r.persisted[source]
+	if r.persister == nil || !ok {
+		return nil
+	}
+	delete(r.persisted, source)
+	if err := r.saveIndex(ctx); err != nil {
+		return err
+	}
+	for i := 0; i < count; i++ {
+		if err := r.persister.Delete(ctx, entryKey(source, i)); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// saveIndex writes the number of entries persisted for each source.
+func (r *Transformer) saveIndex(ctx context.Context) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(r.persisted); err != nil {
+		return fmt.Errorf("encode batches: %w", err)
+	}
+	return r.persister.Set(ctx, batchesKey, buf.Bytes())
+}
+
+// loadBatches restores the batches that were persisted by a previous run, whether it was
+// stopped or crashed. They remain persisted until they are emitted.
+func (r *Transformer) loadBatches(ctx context.Context) error {
+	r.Lock()
+	defer r.Unlock()
+
+	encoded, err := r.persister.Get(ctx, batchesKey)
+	if err != nil {
+		return err
+	}
+	if encoded == nil {
+		return nil
+	}
+
+	persisted := make(map[string]int)
+	if err := gob.NewDecoder(bytes.NewReader(encoded)).Decode(&persisted); err != nil {
+		r.Errorw("Failed to decode persisted batches, discarding them", zap.Error(err))
+		return r.persister.Delete(ctx, batchesKey)
+	}
+	for source, count := range persisted {
+		r.persisted[source] = count
+		for i := 0; i < count; i++ {
+			encoded, err := r.persister.Get(ctx, entryKey(source, i))
+			if err != nil {
+				return err
+			}
+			e := entry.New()
+			if encoded == nil {
+				r.Errorw("Persisted entry is missing, skipping it", zap.String("source", source))
+				continue
+			}
+			if err := gob.NewDecoder(bytes.NewReader(encoded)).Decode(e); err != nil {
+				r.Errorw("Failed to decode persisted entry, skipping it", zap.String("source", source), zap.Error(err))
+				continue
+			}
+			r.batchMap[source] = append(r.batchMap[source], e)
+		}
+	}
+	return nil
+}
+
+// isDurable checks whether values written to the persister can be read back. This is
+// not the case when the receiver has no storage extension configured, in which case
+// batches would be lost on shutdown if they were persisted rather than flushed.
+func isDurable(ctx context.Context, persister operator.Persister) (bool, error) {
+	probe := []byte{1}
+	if err := persister.Set(ctx, probeKey, probe); err != nil {
+		return false, err
+	}
+	value, err := persister.Get(ctx, probeKey)
+	if err != nil {
+		return false, err
+	}
+	if err := persister.Delete(ctx, probeKey); err != nil {
+		return false, err
+	}
+	return bytes.Equal(value, probe), nil
+}
@@ -15,7 +15,9 @@
 package recombine
 
 import (
+	"bytes"
 	"context"
+	"encoding/gob"
 	"testing"
 	"time"
 
@@ -422,3 +424,112 @@ func TestTimeout(t *testing.T) {
 
 	require.NoError(t, recombine.Stop())
 }
+
+func TestPersistBatches(t *testing.T) {
+	t.Parallel()
+
+	newEntry := func(source, body string) *entry.Entry {
+		e := entry.New()
+		e.Body = body
+		e.Attributes = map[string]interface{}{
+			"file.path": source,
+			"count":     int64(1),
+			"raw":       []byte("raw"),
+			"nested":    map[string]interface{}{"list": []interface{}{1, "two"}},
+		}
+		return e
+	}
+
+	ctx := context.Background()
+	persister := testutil.NewMockPersister("recombine")
+	persistedBatches := func() map[string]int {
+		encoded, err := persister.Get(ctx, batchesKey)
+		require.NoError(t, err)
+		persisted := map[string]int{}
+		if encoded != nil {
+			require.NoError(t, gob.NewDecoder(bytes.NewReader(encoded)).Decode(&persisted))
+		}
+		return persisted
+	}
+
+	crashed, _ := newPersistingRecombine(t)
+	require.NoError(t, crashed.Start(persister))
+	require.NoError(t, crashed.Process(ctx, newEntry("file1", "first")))
+	require.NoError(t, crashed.Process(ctx, newEntry("file1", "second")))
+	require.NoError(t, crashed.Process(ctx, newEntry("file2", "first")))
+
+	// Batches are persisted each time they change, so that they survive a crash
+	require.Equal(t, map[string]int{"file1": 2, "file2": 1}, persistedBatches())
+	defer func() { require.NoError(t, crashed.Stop()) }()
+
+	recombine, fake := newPersistingRecombine(t)
+	require.NoError(t, recombine.Start(persister))
+	require.NoError(t, recombine.Process(ctx, newEntry("file1", "third")))
+	require.NoError(t, recombine.Process(ctx, newEntry("file1", "first")))
+
+	// The types of the values are kept intact
+	expected := newEntry("file1", "first\nsecond\nthird")
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, expected.Body, e.Body)
+		require.Equal(t, expected.Attributes, e.Attributes)
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for restored batch to be flushed")
+	}
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+
+	// The emitted batch is removed, the new one is persisted
+	require.Equal(t, map[string]int{"file1": 1, "file2": 1}, persistedBatches())
+	value, err := persister.Get(ctx, entryKey("file1", 1))
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	// Partial batches are kept in the persister rather than flushed on shutdown
+	require.NoError(t, recombine.Stop())
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+
+	recombine, fake = newPersistingRecombine(t)
+	require.NoError(t, recombine.Start(persister))
+	require.NoError(t, recombine.flushSource("file2"))
+	fake.ExpectBody(t, "first")
+	require.Equal(t, map[string]int{"file1": 1}, persistedBatches())
+	require.NoError(t, recombine.Stop())
+}
+
+func TestPersistBatchesWithoutStorage(t *testing.T) {
+	t.Parallel()
+
+	recombine, fake := newPersistingRecombine(t)
+	require.NoError(t, recombine.Start(nopPersister{}))
+
+	e := entry.New()
+	e.Body = "first"
+	require.NoError(t, recombine.Process(context.Background(), e))
+
+	// Without storage, batches are flushed on shutdown rather than dropped
+	require.NoError(t, recombine.Stop())
+	fake.ExpectBody(t, "first")
+}
+
+func newPersistingRecombine(t *testing.T) (*Transformer, *testutil.FakeOutput) {
+	cfg := NewConfig()
+	cfg.CombineField = entry.NewBodyField()
+	cfg.IsFirstEntry = "body == 'first'"
+	cfg.OutputIDs = []string{"fake"}
+	cfg.ForceFlushTimeout = time.Hour
+	cfg.PersistBatches = true
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	recombine := op.(*Transformer)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, recombine.SetOutputs([]operator.Operator{fake}))
+	return recombine, fake
+}
+
+// nopPersister behaves like the persister of a receiver without storage extension
+type nopPersister struct{}
+
+func (nopPersister) Get(context.Context, string) ([]byte, error) { return nil, nil }
+func (nopPersister) Set(context.Context, string, []byte) error   { return nil }
+func (nopPersister) Delete(context.Context, string) error        { return nil }
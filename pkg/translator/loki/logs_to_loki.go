@@ -17,9 +17,10 @@ package loki // import "github.com/open-telemetry/opentelemetry-collector-contri
 import (
 	"fmt"
 
-	"github.com/grafana/loki/pkg/logproto"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki/logproto"
 )
 
 type PushRequest struct {
@@ -250,6 +250,8 @@ func validateMetrics(metric pmetric.Metric) bool {
 		return metric.Sum().DataPoints().Len() != 0 && metric.Sum().AggregationTemporality() == pmetric.AggregationTemporalityCumulative
 	case pmetric.MetricTypeHistogram:
 		return metric.Histogram().DataPoints().Len() != 0 && metric.Histogram().AggregationTemporality() == pmetric.AggregationTemporalityCumulative
+	case pmetric.MetricTypeExponentialHistogram:
+		return metric.ExponentialHistogram().DataPoints().Len() != 0 && metric.ExponentialHistogram().AggregationTemporality() == pmetric.AggregationTemporalityCumulative
 	case pmetric.MetricTypeSummary:
 		return metric.Summary().DataPoints().Len() != 0
 	}
@@ -354,10 +356,15 @@ func addSingleHistogramDataPoint(pt pmetric.HistogramDataPoint, resource pcommon
 }
 
 func getPromExemplars(pt pmetric.HistogramDataPoint) []prompb.Exemplar {
+	return convertExemplars(pt.Exemplars())
+}
+
+// convertExemplars converts exemplars to Prometheus exemplars, which include the trace and span IDs as labels.
+func convertExemplars(exemplars pmetric.ExemplarSlice) []prompb.Exemplar {
 	var promExemplars []prompb.Exemplar
 
-	for i := 0; i < pt.Exemplars().Len(); i++ {
-		exemplar := pt.Exemplars().At(i)
+	for i := 0; i < exemplars.Len(); i++ {
+		exemplar := exemplars.At(i)
 		exemplarRunes := 0
 
 		promExemplar := &prompb.Exemplar{
@@ -428,6 +435,11 @@ func mostRecentTimestampInMetric(metric pmetric.Metric) pcommon.Timestamp {
 		for x := 0; x < dataPoints.Len(); x++ {
 			ts = maxTimestamp(ts, dataPoints.At(x).Timestamp())
 		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dataPoints := metric.ExponentialHistogram().DataPoints()
+		for x := 0; x < dataPoints.Len(); x++ {
+			ts = maxTimestamp(ts, dataPoints.At(x).Timestamp())
+		}
 	case pmetric.MetricTypeSummary:
 		dataPoints := metric.Summary().DataPoints()
 		for x := 0; x < dataPoints.Len(); x++ {
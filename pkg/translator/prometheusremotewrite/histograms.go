@@ -0,0 +1,237 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+const (
+	// minNativeHistogramSchema and maxNativeHistogramSchema are the bounds of
+	// the schemas, equivalent to OTLP scales, supported by Prometheus native histograms.
+	minNativeHistogramSchema = -4
+	maxNativeHistogramSchema = 8
+
+	// defaultZeroThreshold is the zero threshold used for native histograms,
+	// as OTLP exponential histograms only count exact zeros in the zero bucket.
+	defaultZeroThreshold = 1e-128
+
+	// maxEmptyBuckets is the number of consecutive empty buckets after which a
+	// new bucket span is started, rather than encoding the empty buckets.
+	maxEmptyBuckets = 2
+)
+
+// addSingleExponentialHistogramDataPoint converts pt to a Prometheus native histogram,
+// and adds it to its corresponding time series in tsMap.
+func addSingleExponentialHistogramDataPoint(pt pmetric.ExponentialHistogramDataPoint, resource pcommon.Resource, metric pmetric.Metric,
+	settings Settings, tsMap map[string]*prompb.TimeSeries) error {
+	histogram, err := exponentialToNativeHistogram(pt)
+	if err != nil {
+		return fmt.Errorf("%w. %s is dropped", err, metric.Name())
+	}
+
+	name := prometheustranslator.BuildPromCompliantName(metric, settings.Namespace)
+	labels := createAttributes(resource, pt.Attributes(), settings.ExternalLabels, nameStr, name)
+
+	sig := timeSeriesSignature(metric.Type().String(), &labels)
+	ts, ok := tsMap[sig]
+	if !ok {
+		ts = &prompb.TimeSeries{Labels: labels}
+		tsMap[sig] = ts
+	}
+	ts.Histograms = append(ts.Histograms, histogram)
+	ts.Exemplars = append(ts.Exemplars, convertExemplars(pt.Exemplars())...)
+	return nil
+}
+
+// exponentialToNativeHistogram converts an OTLP exponential histogram data point to a
+// Prometheus native histogram. Scales above the maximum schema supported by Prometheus
+// are converted to the maximum schema by merging buckets.
+func exponentialToNativeHistogram(pt pmetric.ExponentialHistogramDataPoint) (prompb.Histogram, error) {
+	scale := pt.Scale()
+	if scale < minNativeHistogramSchema {
+		return prompb.Histogram{}, fmt.Errorf("cannot convert exponential histogram with scale %d to a native histogram, the minimum scale is %d",
+			scale, minNativeHistogramSchema)
+	}
+
+	var scaleDown int32
+	if scale > maxNativeHistogramSchema {
+		scaleDown = scale - maxNativeHistogramSchema
+		scale = maxNativeHistogramSchema
+	}
+
+	positiveSpans, positiveDeltas := convertBucketsLayout(pt.Positive(), scaleDown)
+	negativeSpans, negativeDeltas := convertBucketsLayout(pt.Negative(), scaleDown)
+
+	h := prompb.Histogram{
+		Count:          &prompb.Histogram_CountInt{CountInt: pt.Count()},
+		Sum:            pt.Sum(),
+		Schema:         scale,
+		ZeroThreshold:  defaultZeroThreshold,
+		ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: pt.ZeroCount()},
+		PositiveSpans:  positiveSpans,
+		PositiveDeltas: positiveDeltas,
+		NegativeSpans:  negativeSpans,
+		NegativeDeltas: negativeDeltas,
+		Timestamp:      convertTimeStamp(pt.Timestamp()),
+	}
+
+	if pt.Flags().NoRecordedValue() {
+		h.Sum = math.Float64frombits(value.StaleNaN)
+	}
+	return h, nil
+}
+
+// convertBucketsLayout converts OTLP exponential histogram buckets to the spans and deltas
+// of a Prometheus native histogram, merging each 2^scaleDown adjacent buckets into one.
+//
+// The bucket with index i covers (base^i, base^(i+1)] in OTLP, and (base^(i-1), base^i] in
+// Prometheus, so bucket indexes are incremented by one.
+func convertBucketsLayout(buckets pmetric.ExponentialHistogramDataPointBuckets, scaleDown int32) ([]*prompb.BucketSpan, []int64) {
+	bucketCounts := buckets.BucketCounts()
+	if bucketCounts.Len() == 0 {
+		return nil, nil
+	}
+
+	var (
+		spans     []*prompb.BucketSpan
+		deltas    []int64
+		prevCount int64
+		nextIndex int32
+	)
+	appendBucket := func(index int32, count int64) {
+		if len(spans) == 0 || index-nextIndex > maxEmptyBuckets {
+			// Start a new span, whose offset is relative to the end of the previous span
+			offset := index
+			if len(spans) > 0 {
+				offset = index - nextIndex
+			}
+			spans = append(spans, &prompb.BucketSpan{Offset: offset})
+		} else {
+			// Encode the empty buckets since the previous bucket
+			for ; nextIndex < index; nextIndex++ {
+				spans[len(spans)-1].Length++
+				deltas = append(deltas, -prevCount)
+				prevCount = 0
+			}
+		}
+		spans[len(spans)-1].Length++
+		deltas = append(deltas, count-prevCount)
+		prevCount = count
+		nextIndex = index + 1
+	}
+
+	var (
+		currentIndex = (buckets.Offset() >> scaleDown) + 1
+		count        int64
+	)
+	for i := 0; i < bucketCounts.Len(); i++ {
+		index := ((buckets.Offset() + int32(i)) >> scaleDown) + 1
+		if index != currentIndex {
+			if count > 0 {
+				appendBucket(currentIndex, count)
+			}
+			currentIndex = index
+			count = 0
+		}
+		count += int64(bucketCounts.At(i))
+	}
+	if count > 0 {
+		appendBucket(currentIndex, count)
+	}
+
+	return spans, deltas
+}
+
+// addSingleExponentialHistogramAsClassic converts pt to a classic histogram with explicit
+// bucket bounds, for backends which do not support native histograms.
+func addSingleExponentialHistogramAsClassic(pt pmetric.ExponentialHistogramDataPoint, resource pcommon.Resource, metric pmetric.Metric,
+	settings Settings, tsMap map[string]*prompb.TimeSeries) {
+	addSingleHistogramDataPoint(exponentialToExplicitHistogram(pt), resource, metric, settings, tsMap)
+}
+
+// exponentialToExplicitHistogram converts an OTLP exponential histogram data point to a histogram
+// data point with explicit bounds. Each populated exponential bucket, and the zero bucket, becomes
+// one explicit bucket whose upper bound is the upper bound of the exponential bucket.
+func exponentialToExplicitHistogram(pt pmetric.ExponentialHistogramDataPoint) pmetric.HistogramDataPoint {
+	hp := pmetric.NewHistogramDataPoint()
+	pt.Attributes().CopyTo(hp.Attributes())
+	pt.Exemplars().CopyTo(hp.Exemplars())
+	hp.SetStartTimestamp(pt.StartTimestamp())
+	hp.SetTimestamp(pt.Timestamp())
+	hp.SetFlags(pt.Flags())
+	hp.SetCount(pt.Count())
+	if pt.HasSum() {
+		hp.SetSum(pt.Sum())
+	}
+	if pt.HasMin() {
+		hp.SetMin(pt.Min())
+	}
+	if pt.HasMax() {
+		hp.SetMax(pt.Max())
+	}
+
+	var (
+		bounds []float64
+		counts []uint64
+	)
+
+	// Negative buckets, from the most negative, cover [-base^(i+1), -base^i)
+	negative := pt.Negative()
+	for i := negative.BucketCounts().Len() - 1; i >= 0; i-- {
+		if negative.BucketCounts().At(i) == 0 {
+			continue
+		}
+		bounds = append(bounds, -exponentialBucketBoundary(negative.Offset()+int32(i), pt.Scale()))
+		counts = append(counts, negative.BucketCounts().At(i))
+	}
+
+	if pt.ZeroCount() > 0 {
+		bounds = append(bounds, 0)
+		counts = append(counts, pt.ZeroCount())
+	}
+
+	// Positive buckets cover (base^i, base^(i+1)]
+	positive := pt.Positive()
+	for i := 0; i < positive.BucketCounts().Len(); i++ {
+		if positive.BucketCounts().At(i) == 0 {
+			continue
+		}
+		bounds = append(bounds, exponentialBucketBoundary(positive.Offset()+int32(i)+1, pt.Scale()))
+		counts = append(counts, positive.BucketCounts().At(i))
+	}
+
+	hp.ExplicitBounds().FromRaw(bounds)
+	// The +Inf bucket is empty, as all values are within the exponential buckets
+	hp.BucketCounts().FromRaw(append(counts, 0))
+	return hp
+}
+
+// exponentialBucketBoundary returns base^index, where base = 2^(2^-scale).
+func exponentialBucketBoundary(index, scale int32) float64 {
+	if scale <= 0 {
+		// Exact for non-positive scales, where boundaries are powers of two
+		return math.Ldexp(1, int(index)<<-scale)
+	}
+	return math.Exp2(math.Ldexp(float64(index), -int(scale)))
+}
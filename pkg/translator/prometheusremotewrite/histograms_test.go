@@ -0,0 +1,243 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func Test_convertBucketsLayout(t *testing.T) {
+	tests := []struct {
+		name           string
+		offset         int32
+		counts         []uint64
+		scaleDown      int32
+		expectedSpans  []*prompb.BucketSpan
+		expectedDeltas []int64
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:           "contiguous",
+			counts:         []uint64{4, 3, 2, 1},
+			expectedSpans:  []*prompb.BucketSpan{{Offset: 1, Length: 4}},
+			expectedDeltas: []int64{4, -1, -1, -1},
+		},
+		{
+			name:           "leading empty buckets",
+			counts:         []uint64{0, 0, 5},
+			expectedSpans:  []*prompb.BucketSpan{{Offset: 3, Length: 1}},
+			expectedDeltas: []int64{5},
+		},
+		{
+			name:           "short gap",
+			counts:         []uint64{1, 0, 0, 1},
+			expectedSpans:  []*prompb.BucketSpan{{Offset: 1, Length: 4}},
+			expectedDeltas: []int64{1, -1, 0, 1},
+		},
+		{
+			name:           "long gap",
+			counts:         []uint64{1, 0, 0, 0, 2},
+			expectedSpans:  []*prompb.BucketSpan{{Offset: 1, Length: 1}, {Offset: 3, Length: 1}},
+			expectedDeltas: []int64{1, 1},
+		},
+		{
+			name:           "negative offset",
+			offset:         -2,
+			counts:         []uint64{1, 1},
+			expectedSpans:  []*prompb.BucketSpan{{Offset: -1, Length: 2}},
+			expectedDeltas: []int64{1, 0},
+		},
+		{
+			name:           "scale down",
+			counts:         []uint64{1, 2, 3, 4},
+			scaleDown:      1,
+			expectedSpans:  []*prompb.BucketSpan{{Offset: 1, Length: 2}},
+			expectedDeltas: []int64{3, 4},
+		},
+		{
+			name:           "scale down negative offset",
+			offset:         -1,
+			counts:         []uint64{1, 1, 1},
+			scaleDown:      1,
+			expectedSpans:  []*prompb.BucketSpan{{Offset: 0, Length: 2}},
+			expectedDeltas: []int64{1, 1},
+		},
+		{
+			name:           "scale down by two",
+			offset:         3,
+			counts:         []uint64{1, 1, 1, 1, 1},
+			scaleDown:      2,
+			expectedSpans:  []*prompb.BucketSpan{{Offset: 1, Length: 2}},
+			expectedDeltas: []int64{1, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buckets := pmetric.NewExponentialHistogramDataPointBuckets()
+			buckets.SetOffset(tt.offset)
+			buckets.BucketCounts().FromRaw(tt.counts)
+
+			spans, deltas := convertBucketsLayout(buckets, tt.scaleDown)
+			assert.Equal(t, tt.expectedSpans, spans)
+			assert.Equal(t, tt.expectedDeltas, deltas)
+		})
+	}
+}
+
+func getExponentialHistogramDataPoint() pmetric.ExponentialHistogramDataPoint {
+	pt := pmetric.NewExponentialHistogramDataPoint()
+	pt.SetTimestamp(pcommon.Timestamp(time1))
+	pt.SetScale(0)
+	pt.SetCount(10)
+	pt.SetSum(20)
+	pt.SetZeroCount(4)
+	pt.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+	pt.Negative().BucketCounts().FromRaw([]uint64{3})
+	return pt
+}
+
+func Test_exponentialToNativeHistogram(t *testing.T) {
+	t.Run("scale 0", func(t *testing.T) {
+		h, err := exponentialToNativeHistogram(getExponentialHistogramDataPoint())
+		require.NoError(t, err)
+		assert.Equal(t, prompb.Histogram{
+			Count:          &prompb.Histogram_CountInt{CountInt: 10},
+			Sum:            20,
+			Schema:         0,
+			ZeroThreshold:  defaultZeroThreshold,
+			ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 4},
+			PositiveSpans:  []*prompb.BucketSpan{{Offset: 1, Length: 2}},
+			PositiveDeltas: []int64{1, 1},
+			NegativeSpans:  []*prompb.BucketSpan{{Offset: 1, Length: 1}},
+			NegativeDeltas: []int64{3},
+			Timestamp:      msTime1,
+		}, h)
+	})
+
+	t.Run("scale above maximum", func(t *testing.T) {
+		pt := getExponentialHistogramDataPoint()
+		pt.SetScale(10)
+		h, err := exponentialToNativeHistogram(pt)
+		require.NoError(t, err)
+		assert.Equal(t, int32(maxNativeHistogramSchema), h.Schema)
+		assert.Equal(t, []*prompb.BucketSpan{{Offset: 1, Length: 1}}, h.PositiveSpans)
+		assert.Equal(t, []int64{3}, h.PositiveDeltas)
+	})
+
+	t.Run("scale below minimum", func(t *testing.T) {
+		pt := getExponentialHistogramDataPoint()
+		pt.SetScale(-5)
+		_, err := exponentialToNativeHistogram(pt)
+		assert.ErrorContains(t, err, "the minimum scale is -4")
+	})
+
+	t.Run("no recorded value", func(t *testing.T) {
+		pt := getExponentialHistogramDataPoint()
+		pt.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		h, err := exponentialToNativeHistogram(pt)
+		require.NoError(t, err)
+		assert.True(t, value.IsStaleNaN(h.Sum))
+	})
+}
+
+func Test_exponentialToExplicitHistogram(t *testing.T) {
+	pt := getExponentialHistogramDataPoint()
+	pt.Attributes().PutStr(label11, value11)
+
+	hp := exponentialToExplicitHistogram(pt)
+	assert.Equal(t, []float64{-1, 0, 2, 4}, hp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{3, 4, 1, 2, 0}, hp.BucketCounts().AsRaw())
+	assert.Equal(t, uint64(10), hp.Count())
+	assert.Equal(t, 20.0, hp.Sum())
+	assert.Equal(t, pcommon.Timestamp(time1), hp.Timestamp())
+	assert.Equal(t, map[string]interface{}{label11: value11}, hp.Attributes().AsRaw())
+}
+
+func Test_exponentialBucketBoundary(t *testing.T) {
+	tests := []struct {
+		index    int32
+		scale    int32
+		expected float64
+	}{
+		{index: 0, scale: 0, expected: 1},
+		{index: 1, scale: 0, expected: 2},
+		{index: -1, scale: 0, expected: 0.5},
+		{index: 1, scale: -1, expected: 4},
+		{index: -3, scale: -2, expected: math.Ldexp(1, -12)},
+		{index: 1, scale: 1, expected: math.Sqrt2},
+		{index: 3, scale: 2, expected: math.Pow(2, 0.75)},
+	}
+	for _, tt := range tests {
+		assert.InDelta(t, tt.expected, exponentialBucketBoundary(tt.index, tt.scale), 1e-12)
+	}
+}
+
+func TestFromMetricsExponentialHistogram(t *testing.T) {
+	md := pmetric.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("test_exponential_histogram")
+	metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	getExponentialHistogramDataPoint().CopyTo(metric.ExponentialHistogram().DataPoints().AppendEmpty())
+
+	t.Run("native", func(t *testing.T) {
+		tsMap, err := FromMetrics(md, Settings{})
+		require.NoError(t, err)
+		require.Len(t, tsMap, 1)
+		for _, ts := range tsMap {
+			assert.Equal(t, getPromLabels(nameStr, "test_exponential_histogram"), ts.Labels)
+			assert.Empty(t, ts.Samples)
+			require.Len(t, ts.Histograms, 1)
+			assert.Equal(t, int32(0), ts.Histograms[0].Schema)
+		}
+	})
+
+	t.Run("classic", func(t *testing.T) {
+		tsMap, err := FromMetrics(md, Settings{ExponentialHistogramsAsClassic: true})
+		require.NoError(t, err)
+
+		// count, sum and 5 buckets including +Inf
+		require.Len(t, tsMap, 7)
+		buckets := map[string]float64{}
+		for _, ts := range tsMap {
+			assert.Empty(t, ts.Histograms)
+			require.Len(t, ts.Samples, 1)
+			for _, l := range ts.Labels {
+				if l.Name == leStr {
+					buckets[l.Value] = ts.Samples[0].Value
+				}
+			}
+		}
+		assert.Equal(t, map[string]float64{"-1": 3, "0": 7, "2": 8, "4": 10, pInfStr: 10}, buckets)
+	})
+
+	t.Run("delta", func(t *testing.T) {
+		metric.ExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		defer metric.ExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+		tsMap, err := FromMetrics(md, Settings{})
+		assert.ErrorContains(t, err, "invalid temporality and type combination")
+		assert.Empty(t, tsMap)
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinalitylimiterprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimiterprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Action is what the processor does with the data points of the new series exceeding a limit.
+type Action string
+
+const (
+	// ActionDrop drops the data points of the new series.
+	ActionDrop Action = "drop"
+	// ActionStripAttributes removes the attributes with the most distinct values from the data points
+	// of the new series, until they belong to an active series.
+	ActionStripAttributes Action = "strip_attributes"
+	// ActionOverflow aggregates the data points of the new series into a single overflow series per metric.
+	ActionOverflow Action = "overflow"
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// MaxSeriesPerMetric is the number of active series allowed for a metric name, across all resources.
+	// Set to 0 to disable the limit.
+	MaxSeriesPerMetric int `mapstructure:"max_series_per_metric"`
+
+	// MetricLimits overrides MaxSeriesPerMetric for the given metric names.
+	MetricLimits map[string]int `mapstructure:"metric_limits"`
+
+	// MaxSeriesPerResource is the number of active series allowed for a resource, across all metrics.
+	// Set to 0 to disable the limit.
+	MaxSeriesPerResource int `mapstructure:"max_series_per_resource"`
+
+	// Window is the time during which a series is considered active after its last data point.
+	Window time.Duration `mapstructure:"window"`
+
+	// Action is applied to the data points of the new series exceeding a limit.
+	Action Action `mapstructure:"action"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks whether the input configuration has all of the required fields for the processor.
+// An error is returned if there are any invalid inputs.
+func (cfg *Config) Validate() error {
+	if cfg.MaxSeriesPerMetric < 0 {
+		return errors.New("max_series_per_metric must not be negative")
+	}
+	if cfg.MaxSeriesPerResource < 0 {
+		return errors.New("max_series_per_resource must not be negative")
+	}
+	for name, limit := range cfg.MetricLimits {
+		if limit < 0 {
+			return fmt.Errorf("limit of metric %q must not be negative", name)
+		}
+	}
+	if cfg.Window <= 0 {
+		return errors.New("window must be positive")
+	}
+	switch cfg.Action {
+	case ActionDrop, ActionStripAttributes, ActionOverflow:
+	default:
+		return fmt.Errorf("unsupported action %q, must be one of %q, %q or %q", cfg.Action, ActionDrop, ActionStripAttributes, ActionOverflow)
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinalitylimiterprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimiterprocessor"
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	limitMetric   = "metric"
+	limitResource = "resource"
+)
+
+type attribute struct {
+	key   string
+	value string
+}
+
+type seriesInfo struct {
+	lastSeen   time.Time
+	resource   string
+	metric     string
+	attributes []attribute
+	// excess is the limit exceeded by the series whose data points were limited into this series,
+	// or empty. Excess series are not counted against the limits, but against the excess of the limit.
+	excess string
+}
+
+type metricState struct {
+	series int
+	// excessSeries counts the excess series of the metric created for the metric limit
+	excessSeries int
+	// values counts the active series having each value of each attribute key, the number of
+	// distinct values of a key tells how much it contributes to the cardinality of the metric.
+	values map[string]map[string]int
+}
+
+// limiter tracks the active series, i.e. the series that received a data point during the last window.
+// It is not safe for concurrent use.
+type limiter struct {
+	maxSeriesPerMetric   int
+	metricLimits         map[string]int
+	maxSeriesPerResource int
+	window               time.Duration
+
+	lastSweep time.Time
+	series    map[string]*seriesInfo
+	metrics   map[string]*metricState
+	resources map[string]int
+	// excessResources counts the excess series of each resource created for the resource limit
+	excessResources map[string]int
+}
+
+func newLimiter(cfg *Config) *limiter {
+	return &limiter{
+		maxSeriesPerMetric:   cfg.MaxSeriesPerMetric,
+		metricLimits:         cfg.MetricLimits,
+		maxSeriesPerResource: cfg.MaxSeriesPerResource,
+		window:               cfg.Window,
+		lastSweep:            time.Now(),
+		series:               map[string]*seriesInfo{},
+		metrics:              map[string]*metricState{},
+		resources:            map[string]int{},
+		excessResources:      map[string]int{},
+	}
+}
+
+// touch marks the series as seen, and reports whether it is active.
+func (l *limiter) touch(key string, now time.Time) bool {
+	s, ok := l.series[key]
+	if !ok {
+		return false
+	}
+	if now.Sub(s.lastSeen) > l.window {
+		// not swept yet
+		l.remove(key, s)
+		return false
+	}
+	s.lastSeen = now
+	return true
+}
+
+// exceeded returns the name and the value of the limit that prevents a new series of the metric and
+// resource, or an empty name if there is none.
+func (l *limiter) exceeded(resource, metric string) (string, int) {
+	limit, ok := l.metricLimits[metric]
+	if !ok {
+		limit = l.maxSeriesPerMetric
+	}
+	if m := l.metrics[metric]; limit > 0 && m != nil && m.series >= limit {
+		return limitMetric, limit
+	}
+	if l.maxSeriesPerResource > 0 && l.resources[resource] >= l.maxSeriesPerResource {
+		return limitResource, l.maxSeriesPerResource
+	}
+	return "", 0
+}
+
+// canAddExcess reports whether less than limit excess series were created for the exceeded limit,
+// which are counted per metric for the metric limit and per resource for the resource limit.
+func (l *limiter) canAddExcess(limitName, resource, metric string, limit int) bool {
+	if limitName == limitResource {
+		return l.excessResources[resource] < limit
+	}
+	m := l.metrics[metric]
+	return m == nil || m.excessSeries < limit
+}
+
+// add tracks a new series, which is an excess series of the exceeded limit if excess is not empty.
+func (l *limiter) add(key, resource, metric string, attrs pcommon.Map, now time.Time, excess string) {
+	s := &seriesInfo{lastSeen: now, resource: resource, metric: metric, excess: excess}
+	l.series[key] = s
+	if excess == limitResource {
+		l.excessResources[resource]++
+		return
+	}
+	m, ok := l.metrics[metric]
+	if !ok {
+		m = &metricState{values: map[string]map[string]int{}}
+		l.metrics[metric] = m
+	}
+	if excess == limitMetric {
+		m.excessSeries++
+		return
+	}
+
+	s.attributes = make([]attribute, 0, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		value := v.AsString()
+		s.attributes = append(s.attributes, attribute{key: k, value: value})
+		if m.values[k] == nil {
+			m.values[k] = map[string]int{}
+		}
+		m.values[k][value]++
+		return true
+	})
+	m.series++
+	l.resources[resource]++
+}
+
+func (l *limiter) remove(key string, s *seriesInfo) {
+	delete(l.series, key)
+	if s.excess == limitResource {
+		if l.excessResources[s.resource]--; l.excessResources[s.resource] <= 0 {
+			delete(l.excessResources, s.resource)
+		}
+		return
+	}
+	m := l.metrics[s.metric]
+	defer func() {
+		if m.series <= 0 && m.excessSeries <= 0 {
+			delete(l.metrics, s.metric)
+		}
+	}()
+	if s.excess == limitMetric {
+		m.excessSeries--
+		return
+	}
+
+	if l.resources[s.resource]--; l.resources[s.resource] <= 0 {
+		delete(l.resources, s.resource)
+	}
+	m.series--
+	for _, attr := range s.attributes {
+		values := m.values[attr.key]
+		if values[attr.value]--; values[attr.value] <= 0 {
+			delete(values, attr.value)
+		}
+		if len(values) == 0 {
+			delete(m.values, attr.key)
+		}
+	}
+}
+
+// maybeSweep removes the series that are no longer active, at most twice per window.
+func (l *limiter) maybeSweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.window/2 {
+		return
+	}
+	for key, s := range l.series {
+		if now.Sub(s.lastSeen) > l.window {
+			l.remove(key, s)
+		}
+	}
+	l.lastSweep = now
+}
+
+// offenders returns the attribute keys of the data point, from the one with the most distinct
+// values in the metric to the one with the least.
+func (l *limiter) offenders(metric string, attrs pcommon.Map) []string {
+	keys := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	var values map[string]map[string]int
+	if m := l.metrics[metric]; m != nil {
+		values = m.values
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(values[keys[i]]) != len(values[keys[j]]) {
+			return len(values[keys[i]]) > len(values[keys[j]])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+func seriesKey(resource, metric string, attrs pcommon.Map) string {
+	return resource + "\x00" + metric + "\x00" + attributesSignature(attrs)
+}
+
+// attributesSignature identifies a set of attributes independently of their order.
+func attributesSignature(attrs pcommon.Map) string {
+	pairs := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		pairs = append(pairs, k+"="+v.AsString())
+		return true
+	})
+	sort.Strings(pairs)
+	return strings.Join(pairs, "\x1f")
+}
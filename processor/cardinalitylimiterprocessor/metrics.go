@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinalitylimiterprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimiterprocessor"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+var (
+	tagMetricKey    = tag.MustNewKey("metric")
+	tagLimitKey     = tag.MustNewKey("limit")
+	tagActionKey    = tag.MustNewKey("action")
+	tagAttributeKey = tag.MustNewKey("attribute")
+
+	mLimitedDataPoints   = stats.Int64("limited_data_points", "Number of data points of new series exceeding a cardinality limit", stats.UnitDimensionless)
+	mOffendingAttributes = stats.Int64("offending_attributes", "Number of data points of new series exceeding a cardinality limit, by the attribute of their metric with the most distinct values", stats.UnitDimensionless)
+	mActiveSeries        = stats.Int64("active_series", "Number of active series tracked by the processor", stats.UnitDimensionless)
+)
+
+// MetricViews return the metrics views according to given telemetry level.
+func MetricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(typeStr, mLimitedDataPoints.Name()),
+			Measure:     mLimitedDataPoints,
+			Description: mLimitedDataPoints.Description(),
+			TagKeys:     []tag.Key{tagMetricKey, tagLimitKey, tagActionKey},
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(typeStr, mOffendingAttributes.Name()),
+			Measure:     mOffendingAttributes,
+			Description: mOffendingAttributes.Description(),
+			TagKeys:     []tag.Key{tagMetricKey, tagAttributeKey},
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(typeStr, mActiveSeries.Name()),
+			Measure:     mActiveSeries,
+			Description: mActiveSeries.Description(),
+			Aggregation: view.LastValue(),
+		},
+	}
+}
@@ -0,0 +1,338 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinalitylimiterprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimiterprocessor"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+// overflowAttribute marks the series aggregating the data points of the series exceeding a limit.
+const overflowAttribute = "otel.metric.overflow"
+
+type cardinalityLimiterProcessor struct {
+	logger *zap.Logger
+	action Action
+
+	// The mutex protects the limiter, it is held for the whole processing of a batch.
+	sync.Mutex
+	limiter *limiter
+}
+
+func newCardinalityLimiterProcessor(cfg *Config, logger *zap.Logger) *cardinalityLimiterProcessor {
+	return &cardinalityLimiterProcessor{
+		logger:  logger,
+		action:  cfg.Action,
+		limiter: newLimiter(cfg),
+	}
+}
+
+// processMetrics implements the ProcessMetricsFunc type.
+func (p *cardinalityLimiterProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	now := time.Now()
+	p.limiter.maybeSweep(now)
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		resource := attributesSignature(rm.Resource().Attributes())
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				return p.limitMetric(ctx, resource, m, now)
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	stats.Record(ctx, mActiveSeries.M(int64(len(p.limiter.series))))
+	return md, nil
+}
+
+// limitMetric applies the limits to the data points of the metric, and reports whether all of them were removed.
+// The stripped and overflow data points aggregate several series, which is only consistent across batches for gauges
+// and delta temporality: the limited data points of cumulative metrics and summaries are dropped.
+func (p *cardinalityLimiterProcessor) limitMetric(ctx context.Context, resource string, m pmetric.Metric, now time.Time) bool {
+	name := m.Name()
+	series := batchSeries{}
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		if dps.Len() == 0 {
+			return false
+		}
+		points := map[string]pmetric.NumberDataPoint{}
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+			kept, limited := p.limitDataPoint(ctx, resource, name, p.action, dp.Attributes(), now)
+			if !kept {
+				return true
+			}
+			signature, merge := series.merge(dp.Attributes(), limited)
+			if merge {
+				mergeNumber(points[signature], dp, false)
+				return true
+			}
+			points[signature] = dp
+			return false
+		})
+		return dps.Len() == 0
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		if dps.Len() == 0 {
+			return false
+		}
+		action := p.actionFor(m.Sum().AggregationTemporality())
+		points := map[string]pmetric.NumberDataPoint{}
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+			kept, limited := p.limitDataPoint(ctx, resource, name, action, dp.Attributes(), now)
+			if !kept {
+				return true
+			}
+			signature, merge := series.merge(dp.Attributes(), limited)
+			if merge {
+				mergeNumber(points[signature], dp, true)
+				return true
+			}
+			points[signature] = dp
+			return false
+		})
+		return dps.Len() == 0
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		if dps.Len() == 0 {
+			return false
+		}
+		action := p.actionFor(m.Histogram().AggregationTemporality())
+		points := map[string]pmetric.HistogramDataPoint{}
+		dps.RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+			kept, limited := p.limitDataPoint(ctx, resource, name, action, dp.Attributes(), now)
+			if !kept {
+				return true
+			}
+			signature, merge := series.merge(dp.Attributes(), limited)
+			if merge {
+				// histograms with different bucket boundaries cannot be merged, the data point is dropped
+				mergeHistogram(points[signature], dp)
+				return true
+			}
+			points[signature] = dp
+			return false
+		})
+		return dps.Len() == 0
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		if dps.Len() == 0 {
+			return false
+		}
+		action := p.actionFor(m.ExponentialHistogram().AggregationTemporality())
+		points := map[string]pmetric.ExponentialHistogramDataPoint{}
+		dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+			kept, limited := p.limitDataPoint(ctx, resource, name, action, dp.Attributes(), now)
+			if !kept {
+				return true
+			}
+			signature, merge := series.merge(dp.Attributes(), limited)
+			if merge {
+				// exponential histograms of different scales are not merged, the latest one is kept
+				if merged := points[signature]; dp.Timestamp() >= merged.Timestamp() {
+					dp.CopyTo(merged)
+				}
+				return true
+			}
+			points[signature] = dp
+			return false
+		})
+		return dps.Len() == 0
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		if dps.Len() == 0 {
+			return false
+		}
+		// summaries are cumulative and their quantiles cannot be merged
+		dps.RemoveIf(func(dp pmetric.SummaryDataPoint) bool {
+			kept, _ := p.limitDataPoint(ctx, resource, name, ActionDrop, dp.Attributes(), now)
+			return !kept
+		})
+		return dps.Len() == 0
+	}
+	return false
+}
+
+// actionFor returns the action applied to the limited data points of a sum or a histogram with the temporality.
+func (p *cardinalityLimiterProcessor) actionFor(temporality pmetric.AggregationTemporality) Action {
+	if temporality == pmetric.AggregationTemporalityDelta {
+		return p.action
+	}
+	// the aggregate of several cumulative series would not be monotonic across batches
+	return ActionDrop
+}
+
+// limitDataPoint tracks the series of the data point, and applies the action if it is a new series exceeding
+// a limit. It reports whether the data point is kept, and whether its attributes were limited.
+func (p *cardinalityLimiterProcessor) limitDataPoint(ctx context.Context, resource, metric string, action Action, attrs pcommon.Map, now time.Time) (bool, bool) {
+	key := seriesKey(resource, metric, attrs)
+	if p.limiter.touch(key, now) {
+		return true, false
+	}
+	limitName, limit := p.limiter.exceeded(resource, metric)
+	if limitName == "" {
+		p.limiter.add(key, resource, metric, attrs, now, "")
+		return true, false
+	}
+
+	offenders := p.limiter.offenders(metric, attrs)
+	p.recordLimited(ctx, metric, limitName, action, offenders)
+	switch action {
+	case ActionStripAttributes:
+		for _, k := range offenders {
+			attrs.Remove(k)
+			key = seriesKey(resource, metric, attrs)
+			if p.limiter.touch(key, now) {
+				return true, true
+			}
+			// the series of the stripped data points have their own budget, up to the exceeded limit
+			if p.limiter.canAddExcess(limitName, resource, metric, limit) {
+				p.limiter.add(key, resource, metric, attrs, now, limitName)
+				return true, true
+			}
+		}
+		// only reached for a new series without attributes, there is at most one per metric and resource
+		p.limiter.add(key, resource, metric, attrs, now, limitName)
+		return true, true
+	case ActionOverflow:
+		attrs.Clear()
+		attrs.PutBool(overflowAttribute, true)
+		key = seriesKey(resource, metric, attrs)
+		if !p.limiter.touch(key, now) {
+			// there is at most one overflow series per metric and resource
+			p.limiter.add(key, resource, metric, attrs, now, limitName)
+		}
+		return true, true
+	default:
+		return false, true
+	}
+}
+
+func (p *cardinalityLimiterProcessor) recordLimited(ctx context.Context, metric, limit string, action Action, offenders []string) {
+	_ = stats.RecordWithTags(
+		ctx,
+		[]tag.Mutator{tag.Upsert(tagMetricKey, metric), tag.Upsert(tagLimitKey, limit), tag.Upsert(tagActionKey, string(action))},
+		mLimitedDataPoints.M(1),
+	)
+	if len(offenders) > 0 {
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Upsert(tagMetricKey, metric), tag.Upsert(tagAttributeKey, offenders[0])},
+			mOffendingAttributes.M(1),
+		)
+	}
+}
+
+// batchSeries tracks the attributes of the data points kept for a metric in a batch, and whether one of
+// the data points with these attributes was limited.
+type batchSeries map[string]bool
+
+// merge records the attributes of a kept data point, and reports whether the data point must be merged into
+// the previous one with the same attributes. The limited data points are merged with each other and with
+// the data points of the series they were limited to, the data points of a same series are otherwise left as is.
+func (s batchSeries) merge(attrs pcommon.Map, limited bool) (string, bool) {
+	signature := attributesSignature(attrs)
+	previous, found := s[signature]
+	if found && (previous || limited) {
+		s[signature] = true
+		return signature, true
+	}
+	s[signature] = limited
+	return signature, false
+}
+
+// mergeNumber aggregates dp into merged: sums are added and the latest gauge value is kept.
+func mergeNumber(merged, dp pmetric.NumberDataPoint, sum bool) {
+	if !sum {
+		if dp.Timestamp() >= merged.Timestamp() {
+			dp.CopyTo(merged)
+		}
+		return
+	}
+	switch {
+	case merged.ValueType() == pmetric.NumberDataPointValueTypeInt && dp.ValueType() == pmetric.NumberDataPointValueTypeInt:
+		merged.SetIntValue(merged.IntValue() + dp.IntValue())
+	default:
+		merged.SetDoubleValue(numberValue(merged) + numberValue(dp))
+	}
+	mergeTimestamps(merged, dp)
+}
+
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
+
+// mergeHistogram aggregates dp into merged if they have the same bucket boundaries.
+func mergeHistogram(merged, dp pmetric.HistogramDataPoint) {
+	if !equalBounds(merged.ExplicitBounds(), dp.ExplicitBounds()) || merged.BucketCounts().Len() != dp.BucketCounts().Len() {
+		return
+	}
+	for i := 0; i < dp.BucketCounts().Len(); i++ {
+		merged.BucketCounts().SetAt(i, merged.BucketCounts().At(i)+dp.BucketCounts().At(i))
+	}
+	merged.SetCount(merged.Count() + dp.Count())
+	if merged.HasSum() && dp.HasSum() {
+		merged.SetSum(merged.Sum() + dp.Sum())
+	}
+	if merged.HasMin() && dp.HasMin() && dp.Min() < merged.Min() {
+		merged.SetMin(dp.Min())
+	}
+	if merged.HasMax() && dp.HasMax() && dp.Max() > merged.Max() {
+		merged.SetMax(dp.Max())
+	}
+	mergeTimestamps(merged, dp)
+}
+func equalBounds(a, b pcommon.Float64Slice) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	for i := 0; i < a.Len(); i++ {
+		if a.At(i) != b.At(i) {
+			return false
+		}
+	}
+	return true
+}
+
+type dataPoint interface {
+	StartTimestamp() pcommon.Timestamp
+	SetStartTimestamp(pcommon.Timestamp)
+	Timestamp() pcommon.Timestamp
+	SetTimestamp(pcommon.Timestamp)
+}
+
+// mergeTimestamps extends the time range of merged to cover the one of dp.
+func mergeTimestamps(merged, dp dataPoint) {
+	if dp.StartTimestamp() < merged.StartTimestamp() {
+		merged.SetStartTimestamp(dp.StartTimestamp())
+	}
+	if dp.Timestamp() > merged.Timestamp() {
+		merged.SetTimestamp(dp.Timestamp())
+	}
+}
@@ -0,0 +1,468 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinalitylimiterprocessor
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+// testPoint is a data point of a sum, or of a gauge or a histogram when the type is set.
+type testPoint struct {
+	resource   string
+	name       string
+	attributes map[string]string
+	value      int64
+	timestamp  pcommon.Timestamp
+	metricType pmetric.MetricType
+}
+
+func buildMetrics(points ...testPoint) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, tp := range points {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("service.name", tp.resource)
+		metric := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		metric.SetName(tp.name)
+		var attrs pcommon.Map
+		switch tp.metricType {
+		case pmetric.MetricTypeGauge:
+			dp := metric.SetEmptyGauge().DataPoints().AppendEmpty()
+			dp.SetTimestamp(tp.timestamp)
+			dp.SetIntValue(tp.value)
+			attrs = dp.Attributes()
+		case pmetric.MetricTypeHistogram:
+			histogram := metric.SetEmptyHistogram()
+			histogram.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+			dp := histogram.DataPoints().AppendEmpty()
+			dp.SetTimestamp(tp.timestamp)
+			dp.SetCount(uint64(tp.value))
+			dp.SetSum(float64(tp.value))
+			dp.ExplicitBounds().FromRaw([]float64{10})
+			dp.BucketCounts().FromRaw([]uint64{uint64(tp.value), 0})
+			attrs = dp.Attributes()
+		default:
+			sum := metric.SetEmptySum()
+			sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+			dp := sum.DataPoints().AppendEmpty()
+			dp.SetTimestamp(tp.timestamp)
+			dp.SetIntValue(tp.value)
+			attrs = dp.Attributes()
+		}
+		for k, v := range tp.attributes {
+			attrs.PutStr(k, v)
+		}
+	}
+	return md
+}
+
+// signatures returns the sorted "<resource>/<metric>{<attributes>}" of the data points.
+func signatures(md pmetric.Metrics) []string {
+	var got []string
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		service, _ := rms.At(i).Resource().Attributes().Get("service.name")
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				m := metrics.At(k)
+				add := func(attrs pcommon.Map) {
+					got = append(got, service.Str()+"/"+m.Name()+"{"+attributesSignature(attrs)+"}")
+				}
+				switch m.Type() {
+				case pmetric.MetricTypeGauge:
+					for l := 0; l < m.Gauge().DataPoints().Len(); l++ {
+						add(m.Gauge().DataPoints().At(l).Attributes())
+					}
+				case pmetric.MetricTypeSum:
+					for l := 0; l < m.Sum().DataPoints().Len(); l++ {
+						add(m.Sum().DataPoints().At(l).Attributes())
+					}
+				case pmetric.MetricTypeHistogram:
+					for l := 0; l < m.Histogram().DataPoints().Len(); l++ {
+						add(m.Histogram().DataPoints().At(l).Attributes())
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(got)
+	return got
+}
+
+func newTestConfig(action Action, maxSeriesPerMetric int) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Action = action
+	cfg.MaxSeriesPerMetric = maxSeriesPerMetric
+	return cfg
+}
+
+func user(u string) map[string]string {
+	return map[string]string{"user": u}
+}
+
+func TestProcessMetrics(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *Config
+		batches  [][]testPoint
+		expected [][]string
+	}{
+		{
+			name: "drop",
+			cfg:  newTestConfig(ActionDrop, 2),
+			batches: [][]testPoint{
+				{
+					{resource: "r1", name: "m", attributes: user("a")},
+					{resource: "r1", name: "m", attributes: user("b")},
+					{resource: "r1", name: "m", attributes: user("c")},
+					{resource: "r1", name: "other", attributes: user("c")},
+				},
+				{
+					{resource: "r1", name: "m", attributes: user("a")},
+					{resource: "r1", name: "m", attributes: user("c")},
+				},
+			},
+			expected: [][]string{
+				{"r1/m{user=a}", "r1/m{user=b}", "r1/other{user=c}"},
+				{"r1/m{user=a}"},
+			},
+		},
+		{
+			name: "metric_limits",
+			cfg: func() *Config {
+				cfg := newTestConfig(ActionDrop, 1)
+				cfg.MetricLimits = map[string]int{"unlimited": 0, "limited": 2}
+				return cfg
+			}(),
+			batches: [][]testPoint{
+				{
+					{resource: "r1", name: "unlimited", attributes: user("a")},
+					{resource: "r1", name: "unlimited", attributes: user("b")},
+					{resource: "r1", name: "unlimited", attributes: user("c")},
+					{resource: "r1", name: "limited", attributes: user("a")},
+					{resource: "r1", name: "limited", attributes: user("b")},
+					{resource: "r1", name: "limited", attributes: user("c")},
+					{resource: "r1", name: "default", attributes: user("a")},
+					{resource: "r1", name: "default", attributes: user("b")},
+				},
+			},
+			expected: [][]string{
+				{
+					"r1/default{user=a}",
+					"r1/limited{user=a}", "r1/limited{user=b}",
+					"r1/unlimited{user=a}", "r1/unlimited{user=b}", "r1/unlimited{user=c}",
+				},
+			},
+		},
+		{
+			name: "resource_limit",
+			cfg: func() *Config {
+				cfg := newTestConfig(ActionDrop, 0)
+				cfg.MaxSeriesPerResource = 2
+				return cfg
+			}(),
+			batches: [][]testPoint{
+				{
+					{resource: "r1", name: "m1", attributes: user("a")},
+					{resource: "r1", name: "m2", attributes: user("a")},
+					{resource: "r1", name: "m3", attributes: user("a")},
+					{resource: "r2", name: "m3", attributes: user("a")},
+				},
+			},
+			expected: [][]string{
+				{"r1/m1{user=a}", "r1/m2{user=a}", "r2/m3{user=a}"},
+			},
+		},
+		{
+			name: "strip_attributes",
+			cfg:  newTestConfig(ActionStripAttributes, 2),
+			batches: [][]testPoint{
+				{
+					{resource: "r1", name: "m", attributes: map[string]string{"route": "/a", "request_id": "1"}},
+					{resource: "r1", name: "m", attributes: map[string]string{"route": "/a", "request_id": "2"}},
+					// request_id has the most distinct values, the stripped series are added up to the limit
+					{resource: "r1", name: "m", attributes: map[string]string{"route": "/a", "request_id": "3"}},
+					{resource: "r1", name: "m", attributes: map[string]string{"route": "/b", "request_id": "4"}},
+					{resource: "r1", name: "m", attributes: map[string]string{"route": "/a", "request_id": "5"}},
+					// the route is stripped as well once the stripped series exceed the limit
+					{resource: "r1", name: "m", attributes: map[string]string{"route": "/c", "request_id": "6"}},
+					{resource: "r1", name: "m", attributes: map[string]string{"route": "/d", "request_id": "7"}},
+				},
+			},
+			expected: [][]string{
+				{
+					"r1/m{request_id=1\x1froute=/a}", "r1/m{request_id=2\x1froute=/a}",
+					"r1/m{route=/a}", "r1/m{route=/a}", "r1/m{route=/b}",
+					"r1/m{}", "r1/m{}",
+				},
+			},
+		},
+		{
+			name: "strip_attributes_metric_and_resource_limits",
+			cfg: func() *Config {
+				cfg := newTestConfig(ActionStripAttributes, 1)
+				cfg.MaxSeriesPerResource = 2
+				return cfg
+			}(),
+			batches: [][]testPoint{
+				{
+					{resource: "r1", name: "m", attributes: map[string]string{"route": "/a", "request_id": "1"}},
+					// the stripped series of the metric limit are counted per metric
+					{resource: "r1", name: "m", attributes: map[string]string{"route": "/a", "request_id": "2"}},
+					{resource: "r1", name: "m", attributes: map[string]string{"route": "/b", "request_id": "3"}},
+					{resource: "r1", name: "n", attributes: map[string]string{"route": "/a", "request_id": "4"}},
+					// the stripped series of the resource limit are counted per resource, across metrics
+					{resource: "r1", name: "o", attributes: map[string]string{"route": "/a", "request_id": "5"}},
+					{resource: "r1", name: "o", attributes: map[string]string{"route": "/b", "request_id": "6"}},
+					{resource: "r1", name: "p", attributes: map[string]string{"route": "/a", "request_id": "7"}},
+				},
+			},
+			expected: [][]string{
+				{
+					"r1/m{request_id=1\x1froute=/a}", "r1/m{route=/a}", "r1/m{}",
+					"r1/n{request_id=4\x1froute=/a}",
+					"r1/o{route=/a}", "r1/o{route=/b}",
+					"r1/p{}",
+				},
+			},
+		},
+		{
+			name: "overflow",
+			cfg:  newTestConfig(ActionOverflow, 1),
+			batches: [][]testPoint{
+				{
+					{resource: "r1", name: "m", attributes: user("a")},
+					{resource: "r1", name: "m", attributes: user("b")},
+					{resource: "r1", name: "m", attributes: user("c")},
+				},
+				{
+					{resource: "r1", name: "m", attributes: user("d")},
+				},
+			},
+			expected: [][]string{
+				{"r1/m{otel.metric.overflow=true}", "r1/m{otel.metric.overflow=true}", "r1/m{user=a}"},
+				{"r1/m{otel.metric.overflow=true}"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.MetricsSink)
+			mp, err := NewFactory().CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), tt.cfg, sink)
+			require.NoError(t, err)
+			require.NoError(t, mp.Start(context.Background(), componenttest.NewNopHost()))
+
+			for _, batch := range tt.batches {
+				require.NoError(t, mp.ConsumeMetrics(context.Background(), buildMetrics(batch...)))
+			}
+			require.NoError(t, mp.Shutdown(context.Background()))
+
+			got := sink.AllMetrics()
+			require.Len(t, got, len(tt.expected))
+			for i, expected := range tt.expected {
+				assert.Equal(t, expected, signatures(got[i]), "batch %d", i)
+			}
+		})
+	}
+}
+
+// buildMetric returns the data points of a single metric, each point with its own attributes.
+func buildMetric(metricType pmetric.MetricType, points ...testPoint) pmetric.Metrics {
+	md := buildMetrics(points[0])
+	metric := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	for _, tp := range points[1:] {
+		other := buildMetrics(tp).ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+		switch metricType {
+		case pmetric.MetricTypeGauge:
+			other.Gauge().DataPoints().MoveAndAppendTo(metric.Gauge().DataPoints())
+		case pmetric.MetricTypeHistogram:
+			other.Histogram().DataPoints().MoveAndAppendTo(metric.Histogram().DataPoints())
+		default:
+			other.Sum().DataPoints().MoveAndAppendTo(metric.Sum().DataPoints())
+		}
+	}
+	return md
+}
+
+func TestOverflowMerge(t *testing.T) {
+	p := newCardinalityLimiterProcessor(newTestConfig(ActionOverflow, 1), zap.NewNop())
+	process := func(metricType pmetric.MetricType, name string) pmetric.Metric {
+		points := []testPoint{
+			{resource: "r1", name: name, attributes: user("a"), value: 1, timestamp: 1, metricType: metricType},
+			{resource: "r1", name: name, attributes: user("b"), value: 2, timestamp: 3, metricType: metricType},
+			{resource: "r1", name: name, attributes: user("c"), value: 4, timestamp: 2, metricType: metricType},
+		}
+		md, err := p.processMetrics(context.Background(), buildMetric(metricType, points...))
+		require.NoError(t, err)
+		return md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	}
+
+	sum := process(pmetric.MetricTypeSum, "sum").Sum().DataPoints()
+	require.Equal(t, 2, sum.Len())
+	assert.Equal(t, int64(1), sum.At(0).IntValue())
+	assert.Equal(t, int64(6), sum.At(1).IntValue())
+	assert.Equal(t, pcommon.Timestamp(3), sum.At(1).Timestamp())
+	assert.True(t, isOverflow(sum.At(1).Attributes()))
+
+	gauge := process(pmetric.MetricTypeGauge, "gauge").Gauge().DataPoints()
+	require.Equal(t, 2, gauge.Len())
+	assert.Equal(t, int64(2), gauge.At(1).IntValue())
+	assert.Equal(t, pcommon.Timestamp(3), gauge.At(1).Timestamp())
+
+	histogram := process(pmetric.MetricTypeHistogram, "histogram").Histogram().DataPoints()
+	require.Equal(t, 2, histogram.Len())
+	assert.Equal(t, uint64(6), histogram.At(1).Count())
+	assert.Equal(t, float64(6), histogram.At(1).Sum())
+	assert.Equal(t, []uint64{6, 0}, histogram.At(1).BucketCounts().AsRaw())
+}
+
+func isOverflow(attrs pcommon.Map) bool {
+	_, ok := attrs.Get(overflowAttribute)
+	return ok && attrs.Len() == 1
+}
+
+func TestStripAttributesMerge(t *testing.T) {
+	p := newCardinalityLimiterProcessor(newTestConfig(ActionStripAttributes, 1), zap.NewNop())
+	md, err := p.processMetrics(context.Background(), buildMetric(pmetric.MetricTypeSum,
+		testPoint{resource: "r1", name: "m", attributes: user("a"), value: 1, timestamp: 1},
+		testPoint{resource: "r1", name: "m", attributes: user("b"), value: 2, timestamp: 2},
+		testPoint{resource: "r1", name: "m", attributes: user("c"), value: 4, timestamp: 3},
+		// the series without attributes is active, the stripped data points are merged into its data point
+		testPoint{resource: "r1", name: "m", value: 8, timestamp: 2},
+	))
+	require.NoError(t, err)
+
+	sum := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	require.Equal(t, 2, sum.Len())
+	assert.Equal(t, int64(1), sum.At(0).IntValue())
+	assert.Equal(t, 0, sum.At(1).Attributes().Len())
+	assert.Equal(t, int64(14), sum.At(1).IntValue())
+	assert.Equal(t, pcommon.Timestamp(3), sum.At(1).Timestamp())
+}
+
+func TestCumulativeLimited(t *testing.T) {
+	for _, action := range []Action{ActionStripAttributes, ActionOverflow} {
+		t.Run(string(action), func(t *testing.T) {
+			p := newCardinalityLimiterProcessor(newTestConfig(action, 1), zap.NewNop())
+			points := []testPoint{
+				{resource: "r1", name: "m", attributes: user("a"), value: 1},
+				{resource: "r1", name: "m", attributes: user("b"), value: 2},
+				{resource: "r1", name: "m", attributes: user("c"), value: 4},
+			}
+			md := buildMetric(pmetric.MetricTypeSum, points...)
+			md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+			md, err := p.processMetrics(context.Background(), md)
+			require.NoError(t, err)
+			// the limited data points of cumulative sums are dropped whatever the action
+			assert.Equal(t, []string{"r1/m{user=a}"}, signatures(md))
+		})
+	}
+}
+
+func TestWindowExpiry(t *testing.T) {
+	cfg := newTestConfig(ActionDrop, 1)
+	cfg.Window = time.Millisecond
+	p := newCardinalityLimiterProcessor(cfg, zap.NewNop())
+	process := func(u string) []string {
+		md, err := p.processMetrics(context.Background(), buildMetrics(testPoint{resource: "r1", name: "m", attributes: user(u)}))
+		require.NoError(t, err)
+		return signatures(md)
+	}
+
+	assert.Equal(t, []string{"r1/m{user=a}"}, process("a"))
+	assert.Empty(t, process("b"))
+	time.Sleep(2 * time.Millisecond)
+	// the series of user a is no longer active
+	assert.Equal(t, []string{"r1/m{user=b}"}, process("b"))
+	assert.Len(t, p.limiter.series, 1)
+	assert.Empty(t, process("a"))
+}
+
+func TestOffenders(t *testing.T) {
+	l := newLimiter(newTestConfig(ActionDrop, 0))
+	for i, id := range []string{"1", "2", "3"} {
+		attrs := pcommon.NewMap()
+		attrs.PutStr("request_id", id)
+		attrs.PutStr("route", "/a")
+		attrs.PutStr("method", []string{"GET", "POST", "GET"}[i])
+		l.add(seriesKey("r1", "m", attrs), "r1", "m", attrs, time.Now(), "")
+	}
+
+	attrs := pcommon.NewMap()
+	attrs.PutStr("route", "/b")
+	attrs.PutStr("request_id", "4")
+	attrs.PutStr("method", "PUT")
+	attrs.PutStr("host", "h")
+	assert.Equal(t, []string{"request_id", "method", "route", "host"}, l.offenders("m", attrs))
+}
+
+func TestSelfMetrics(t *testing.T) {
+	// the views are registered by the factory
+	NewFactory()
+	views := MetricViews()
+
+	p := newCardinalityLimiterProcessor(newTestConfig(ActionDrop, 1), zap.NewNop())
+	_, err := p.processMetrics(context.Background(), buildMetrics(
+		testPoint{resource: "r1", name: "self_metrics", attributes: user("a")},
+		testPoint{resource: "r1", name: "self_metrics", attributes: user("b")},
+		testPoint{resource: "r1", name: "self_metrics", attributes: user("c")},
+	))
+	require.NoError(t, err)
+
+	// other tests record data points of the same views
+	row := func(v *view.View) *view.Row {
+		rows, err := view.RetrieveData(v.Name)
+		require.NoError(t, err)
+		for _, row := range rows {
+			for _, tg := range row.Tags {
+				if tg.Key == tagMetricKey && tg.Value == "self_metrics" {
+					return row
+				}
+			}
+		}
+		require.Fail(t, "no row of the self_metrics metric", v.Name)
+		return nil
+	}
+
+	limited := row(views[0])
+	assert.Equal(t, float64(2), limited.Data.(*view.SumData).Value)
+	assert.Contains(t, limited.Tags, tag.Tag{Key: tagLimitKey, Value: limitMetric})
+	assert.Contains(t, limited.Tags, tag.Tag{Key: tagActionKey, Value: string(ActionDrop)})
+
+	offending := row(views[1])
+	assert.Equal(t, float64(2), offending.Data.(*view.SumData).Value)
+	assert.Contains(t, offending.Tags, tag.Tag{Key: tagAttributeKey, Value: "user"})
+
+	rows, err := view.RetrieveData(views[2].Name)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, float64(1), rows[0].Data.(*view.LastValueData).Value)
+}
@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstarttimeprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricstarttimeprocessor"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/metricsadjuster"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+// startTimeProcessor keeps one metricsadjuster.TimeseriesMap per resource. The maps, and the
+// timeseries in them, that were not accessed during a whole maxStaleness period are removed
+// using the same mark-and-sweep approach as the Prometheus receiver.
+type startTimeProcessor struct {
+	includeFS    filterset.FilterSet
+	excludeFS    filterset.FilterSet
+	logger       *zap.Logger
+	maxStaleness time.Duration
+
+	// The mutex protects resources and lastGC. It is not held while the metrics are adjusted,
+	// the lock of the relevant TimeseriesMap is used for that.
+	sync.Mutex
+	lastGC    time.Time
+	resources map[string]*metricsadjuster.TimeseriesMap
+}
+
+func newStartTimeProcessor(config *Config, logger *zap.Logger) (*startTimeProcessor, error) {
+	p := &startTimeProcessor{
+		logger:       logger,
+		maxStaleness: config.MaxStaleness,
+		lastGC:       time.Now(),
+		resources:    map[string]*metricsadjuster.TimeseriesMap{},
+	}
+	var err error
+	if len(config.Include.Metrics) > 0 {
+		if p.includeFS, err = filterset.CreateFilterSet(config.Include.Metrics, &config.Include.Config); err != nil {
+			return nil, err
+		}
+	}
+	if len(config.Exclude.Metrics) > 0 {
+		if p.excludeFS, err = filterset.CreateFilterSet(config.Exclude.Metrics, &config.Exclude.Config); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// processMetrics implements the ProcessMetricsFunc type.
+func (p *startTimeProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		p.adjustResourceMetrics(p.get(metricsadjuster.AttributesSignature(rm.Resource().Attributes())), rm)
+	}
+	p.maybeGC()
+	return md, nil
+}
+
+func (p *startTimeProcessor) adjustResourceMetrics(tsm *metricsadjuster.TimeseriesMap, rm pmetric.ResourceMetrics) {
+	tsm.Lock()
+	defer tsm.Unlock()
+	for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+		ms := rm.ScopeMetrics().At(j).Metrics()
+		for k := 0; k < ms.Len(); k++ {
+			metric := ms.At(k)
+			if !p.shouldAdjustMetric(metric.Name()) {
+				continue
+			}
+			setMissingStartTimestamps(metric)
+			if !tsm.AdjustMetric(metric) {
+				p.logger.Debug("Skipping metric of unexpected type", zap.String("name", metric.Name()), zap.String("type", metric.Type().String()))
+			}
+		}
+	}
+}
+
+func (p *startTimeProcessor) shouldAdjustMetric(metricName string) bool {
+	return (p.includeFS == nil || p.includeFS.Matches(metricName)) &&
+		(p.excludeFS == nil || !p.excludeFS.Matches(metricName))
+}
+
+func (p *startTimeProcessor) get(sig string) *metricsadjuster.TimeseriesMap {
+	p.Lock()
+	defer p.Unlock()
+	tsm, ok := p.resources[sig]
+	if !ok {
+		tsm = metricsadjuster.NewTimeseriesMap()
+		p.resources[sig] = tsm
+	}
+	return tsm
+}
+
+// maybeGC removes the resources and timeseries that have not been seen since the previous gc.
+func (p *startTimeProcessor) maybeGC() {
+	if p.maxStaleness == 0 {
+		return
+	}
+	p.Lock()
+	defer p.Unlock()
+	if time.Since(p.lastGC) < p.maxStaleness {
+		return
+	}
+	for sig, tsm := range p.resources {
+		tsm.RLock()
+		tsmNotMarked := !tsm.IsMarked()
+		tsm.RUnlock()
+		if tsmNotMarked {
+			delete(p.resources, sig)
+		} else {
+			tsm.GC()
+		}
+	}
+	p.lastGC = time.Now()
+}
+
+// setMissingStartTimestamps sets the start timestamp of the cumulative points that don't have
+// one to the point timestamp, so that the first point seen for a timeseries starts it.
+func setMissingStartTimestamps(metric pmetric.Metric) {
+	switch metric.Type() {
+	case pmetric.MetricTypeSum:
+		if metric.Sum().AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+			return
+		}
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			setMissingStartTimestamp(dps.At(i))
+		}
+	case pmetric.MetricTypeHistogram:
+		if metric.Histogram().AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+			return
+		}
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			setMissingStartTimestamp(dps.At(i))
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		if metric.ExponentialHistogram().AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+			return
+		}
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			setMissingStartTimestamp(dps.At(i))
+		}
+	case pmetric.MetricTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			setMissingStartTimestamp(dps.At(i))
+		}
+	}
+}
+
+type dataPoint interface {
+	StartTimestamp() pcommon.Timestamp
+	SetStartTimestamp(pcommon.Timestamp)
+	Timestamp() pcommon.Timestamp
+}
+
+func setMissingStartTimestamp(dp dataPoint) {
+	if dp.StartTimestamp() == 0 {
+		dp.SetStartTimestamp(dp.Timestamp())
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstarttimeprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+const (
+	t1 = pcommon.Timestamp(1_000_000_000)
+	t2 = pcommon.Timestamp(2_000_000_000)
+	t3 = pcommon.Timestamp(3_000_000_000)
+	t4 = pcommon.Timestamp(4_000_000_000)
+)
+
+type testPoint struct {
+	resource    string
+	name        string
+	temporality pmetric.AggregationTemporality
+	start       pcommon.Timestamp
+	timestamp   pcommon.Timestamp
+	value       int64
+	histogram   bool
+}
+
+func (tp testPoint) metrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", tp.resource)
+	metric := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName(tp.name)
+	if tp.histogram {
+		histogram := metric.SetEmptyHistogram()
+		histogram.SetAggregationTemporality(tp.temporality)
+		dp := histogram.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(tp.start)
+		dp.SetTimestamp(tp.timestamp)
+		dp.SetCount(uint64(tp.value))
+		dp.SetSum(float64(tp.value))
+		return md
+	}
+	sum := metric.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(tp.temporality)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("host", "a")
+	dp.SetStartTimestamp(tp.start)
+	dp.SetTimestamp(tp.timestamp)
+	dp.SetIntValue(tp.value)
+	return md
+}
+
+func startTimestamp(md pmetric.Metrics) pcommon.Timestamp {
+	metric := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	if metric.Type() == pmetric.MetricTypeHistogram {
+		return metric.Histogram().DataPoints().At(0).StartTimestamp()
+	}
+	return metric.Sum().DataPoints().At(0).StartTimestamp()
+}
+
+func TestProcessMetrics(t *testing.T) {
+	cumulative := pmetric.AggregationTemporalityCumulative
+	tests := []struct {
+		name     string
+		exclude  []string
+		points   []testPoint
+		expected []pcommon.Timestamp
+	}{
+		{
+			name: "missing_start_time",
+			points: []testPoint{
+				{resource: "r1", name: "m", temporality: cumulative, timestamp: t1, value: 10},
+				{resource: "r1", name: "m", temporality: cumulative, timestamp: t2, value: 15},
+			},
+			expected: []pcommon.Timestamp{t1, t1},
+		},
+		{
+			name: "reset",
+			points: []testPoint{
+				{resource: "r1", name: "m", temporality: cumulative, timestamp: t1, value: 10},
+				{resource: "r1", name: "m", temporality: cumulative, timestamp: t2, value: 15},
+				{resource: "r1", name: "m", temporality: cumulative, timestamp: t3, value: 3},
+				{resource: "r1", name: "m", temporality: cumulative, timestamp: t4, value: 5},
+			},
+			expected: []pcommon.Timestamp{t1, t1, t3, t3},
+		},
+		{
+			name: "start_time_of_first_point",
+			points: []testPoint{
+				{resource: "r1", name: "m", temporality: cumulative, start: t1, timestamp: t2, value: 10},
+				{resource: "r1", name: "m", temporality: cumulative, start: t3, timestamp: t3, value: 15},
+			},
+			expected: []pcommon.Timestamp{t1, t1},
+		},
+		{
+			name: "histogram_reset",
+			points: []testPoint{
+				{resource: "r1", name: "h", temporality: cumulative, timestamp: t1, value: 10, histogram: true},
+				{resource: "r1", name: "h", temporality: cumulative, timestamp: t2, value: 20, histogram: true},
+				{resource: "r1", name: "h", temporality: cumulative, timestamp: t3, value: 1, histogram: true},
+			},
+			expected: []pcommon.Timestamp{t1, t1, t3},
+		},
+		{
+			name: "delta",
+			points: []testPoint{
+				{resource: "r1", name: "m", temporality: pmetric.AggregationTemporalityDelta, timestamp: t1, value: 10},
+				{resource: "r1", name: "m", temporality: pmetric.AggregationTemporalityDelta, timestamp: t2, value: 15},
+			},
+			expected: []pcommon.Timestamp{0, 0},
+		},
+		{
+			name: "resources_tracked_separately",
+			points: []testPoint{
+				{resource: "r1", name: "m", temporality: cumulative, timestamp: t1, value: 10},
+				{resource: "r2", name: "m", temporality: cumulative, timestamp: t2, value: 10},
+				{resource: "r1", name: "m", temporality: cumulative, timestamp: t3, value: 15},
+				{resource: "r2", name: "m", temporality: cumulative, timestamp: t4, value: 15},
+			},
+			expected: []pcommon.Timestamp{t1, t2, t1, t2},
+		},
+		{
+			name:    "excluded",
+			exclude: []string{"m"},
+			points: []testPoint{
+				{resource: "r1", name: "m", temporality: cumulative, timestamp: t1, value: 10},
+				{resource: "r1", name: "m", temporality: cumulative, timestamp: t2, value: 15},
+			},
+			expected: []pcommon.Timestamp{0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			if len(tt.exclude) > 0 {
+				cfg.Exclude = MatchMetrics{Config: filterset.Config{MatchType: filterset.Strict}, Metrics: tt.exclude}
+			}
+			sink := new(consumertest.MetricsSink)
+			mp, err := NewFactory().CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, sink)
+			require.NoError(t, err)
+			require.NoError(t, mp.Start(context.Background(), componenttest.NewNopHost()))
+
+			for _, point := range tt.points {
+				require.NoError(t, mp.ConsumeMetrics(context.Background(), point.metrics()))
+			}
+			require.NoError(t, mp.Shutdown(context.Background()))
+
+			got := sink.AllMetrics()
+			require.Len(t, got, len(tt.expected))
+			for i, expected := range tt.expected {
+				assert.Equal(t, expected, startTimestamp(got[i]), "point %d", i)
+			}
+		})
+	}
+}
+
+func TestGC(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		MaxStaleness:      time.Millisecond,
+	}
+	p, err := newStartTimeProcessor(cfg, zap.NewNop())
+	require.NoError(t, err)
+
+	process := func(tp testPoint) pcommon.Timestamp {
+		md, err := p.processMetrics(context.Background(), tp.metrics())
+		require.NoError(t, err)
+		return startTimestamp(md)
+	}
+
+	cumulative := pmetric.AggregationTemporalityCumulative
+	assert.Equal(t, t1, process(testPoint{resource: "r1", name: "m", temporality: cumulative, timestamp: t1, value: 10}))
+	// the first gc unmarks r1, the second one removes it as it was not seen in between
+	time.Sleep(2 * time.Millisecond)
+	process(testPoint{resource: "r2", name: "m", temporality: cumulative, timestamp: t2, value: 10})
+	time.Sleep(2 * time.Millisecond)
+	process(testPoint{resource: "r2", name: "m", temporality: cumulative, timestamp: t3, value: 10})
+
+	p.Lock()
+	assert.Len(t, p.resources, 1)
+	assert.Contains(t, p.resources, "service.name=r2")
+	p.Unlock()
+
+	// r1 starts over
+	assert.Equal(t, t4, process(testPoint{resource: "r1", name: "m", temporality: cumulative, timestamp: t4, value: 15}))
+}
@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package samplingthroughputprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/samplingthroughputprocessor"
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+)
+
+const (
+	// samplerTypeAttribute and samplerParamAttribute are set by the Jaeger clients on the root spans.
+	samplerTypeAttribute  = "sampler.type"
+	samplerParamAttribute = "sampler.param"
+)
+
+// throughputRecorder is implemented by the extensions calculating the sampling strategies from the throughput.
+type throughputRecorder interface {
+	RecordThroughput(service, operation, samplerType string, probability float64)
+}
+
+type samplingThroughputProcessor struct {
+	cfg      *Config
+	logger   *zap.Logger
+	recorder throughputRecorder
+}
+
+func newSamplingThroughputProcessor(cfg *Config, logger *zap.Logger) *samplingThroughputProcessor {
+	return &samplingThroughputProcessor{
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+func (stp *samplingThroughputProcessor) start(_ context.Context, host component.Host) error {
+	ext, found := host.GetExtensions()[stp.cfg.Extension]
+	if !found {
+		return fmt.Errorf("extension %q not found", stp.cfg.Extension)
+	}
+	recorder, ok := ext.(throughputRecorder)
+	if !ok {
+		return fmt.Errorf("extension %q does not record the sampling throughput", stp.cfg.Extension)
+	}
+	stp.recorder = recorder
+	return nil
+}
+
+func (stp *samplingThroughputProcessor) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		service := "unknown_service"
+		if v, ok := rs.Resource().Attributes().Get(conventions.AttributeServiceName); ok {
+			service = v.AsString()
+		}
+
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				stp.recordSpan(service, spans.At(k))
+			}
+		}
+	}
+	return td, nil
+}
+
+// recordSpan records the root spans carrying the sampler which sampled their trace.
+func (stp *samplingThroughputProcessor) recordSpan(service string, span ptrace.Span) {
+	if !span.ParentSpanID().IsEmpty() {
+		return
+	}
+	samplerType, ok := span.Attributes().Get(samplerTypeAttribute)
+	if !ok {
+		return
+	}
+	samplerParam, ok := span.Attributes().Get(samplerParamAttribute)
+	if !ok {
+		return
+	}
+	probability, ok := toProbability(samplerParam)
+	if !ok {
+		stp.logger.Debug("Unsupported sampler parameter",
+			zap.String("service", service),
+			zap.String("operation", span.Name()),
+			zap.String("value", samplerParam.AsString()))
+		return
+	}
+	stp.recorder.RecordThroughput(service, span.Name(), samplerType.AsString(), probability)
+}
+
+func toProbability(v pcommon.Value) (float64, bool) {
+	switch v.Type() {
+	case pcommon.ValueTypeDouble:
+		return v.Double(), true
+	case pcommon.ValueTypeInt:
+		return float64(v.Int()), true
+	case pcommon.ValueTypeStr:
+		f, err := strconv.ParseFloat(v.Str(), 64)
+		return f, err == nil
+	case pcommon.ValueTypeBool:
+		// the const sampler reports whether it samples everything or nothing
+		if v.Bool() {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
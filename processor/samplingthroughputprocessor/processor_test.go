@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package samplingthroughputprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type throughput struct {
+	service     string
+	operation   string
+	samplerType string
+	probability float64
+}
+
+type nopExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+type mockRecorder struct {
+	nopExtension
+	recorded []throughput
+}
+
+func (m *mockRecorder) RecordThroughput(service, operation, samplerType string, probability float64) {
+	m.recorded = append(m.recorded, throughput{service, operation, samplerType, probability})
+}
+
+type mockHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *mockHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+func TestProcessTraces(t *testing.T) {
+	recorder := &mockRecorder{}
+	host := &mockHost{extensions: map[config.ComponentID]component.Extension{
+		config.NewComponentID("jaegerremotesampling"): recorder,
+	}}
+	sink := new(consumertest.TracesSink)
+	tp, err := NewFactory().CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), createDefaultConfig(), sink)
+	require.NoError(t, err)
+	require.NoError(t, tp.Start(context.Background(), host))
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+
+	probabilistic := spans.AppendEmpty()
+	probabilistic.SetName("GET /cart")
+	probabilistic.Attributes().PutStr("sampler.type", "probabilistic")
+	probabilistic.Attributes().PutDouble("sampler.param", 0.1)
+
+	lowerBound := spans.AppendEmpty()
+	lowerBound.SetName("GET /cart")
+	lowerBound.Attributes().PutStr("sampler.type", "lowerbound")
+	lowerBound.Attributes().PutStr("sampler.param", "0.1")
+
+	constant := spans.AppendEmpty()
+	constant.SetName("POST /order")
+	constant.Attributes().PutStr("sampler.type", "const")
+	constant.Attributes().PutBool("sampler.param", true)
+
+	child := spans.AppendEmpty()
+	child.SetName("SELECT")
+	child.SetParentSpanID(pcommon.SpanID([8]byte{1}))
+	child.Attributes().PutStr("sampler.type", "probabilistic")
+	child.Attributes().PutDouble("sampler.param", 0.1)
+
+	noSampler := spans.AppendEmpty()
+	noSampler.SetName("GET /health")
+
+	unsupported := spans.AppendEmpty()
+	unsupported.SetName("GET /health")
+	unsupported.Attributes().PutStr("sampler.type", "probabilistic")
+	unsupported.Attributes().PutEmptyMap("sampler.param")
+
+	unknown := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	unknown.SetName("GET /")
+	unknown.Attributes().PutStr("sampler.type", "probabilistic")
+	unknown.Attributes().PutInt("sampler.param", 1)
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+
+	assert.Equal(t, []throughput{
+		{"checkout", "GET /cart", "probabilistic", 0.1},
+		{"checkout", "GET /cart", "lowerbound", 0.1},
+		{"checkout", "POST /order", "const", 1},
+		{"unknown_service", "GET /", "probabilistic", 1},
+	}, recorder.recorded)
+	assert.Equal(t, td.SpanCount(), sink.SpanCount())
+	assert.NoError(t, tp.Shutdown(context.Background()))
+}
+
+func TestStartErrors(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		extensions map[config.ComponentID]component.Extension
+		err        string
+	}{
+		{
+			desc: "missing extension",
+			err:  `extension "jaegerremotesampling" not found`,
+		},
+		{
+			desc: "not a throughput recorder",
+			extensions: map[config.ComponentID]component.Extension{
+				config.NewComponentID("jaegerremotesampling"): &nopExtension{},
+			},
+			err: `extension "jaegerremotesampling" does not record the sampling throughput`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			tp, err := NewFactory().CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), createDefaultConfig(), consumertest.NewNop())
+			require.NoError(t, err)
+			assert.EqualError(t, tp.Start(context.Background(), &mockHost{extensions: tC.extensions}), tC.err)
+		})
+	}
+}
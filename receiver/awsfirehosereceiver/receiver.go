@@ -29,6 +29,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/connctx"
 )
 
 const (
@@ -128,6 +130,7 @@ func (fmr *firehoseReceiver) Start(_ context.Context, host component.Host) error
 	if err != nil {
 		return err
 	}
+	fmr.server.ConnContext = connctx.ConnContext
 
 	var listener net.Listener
 	listener, err = fmr.config.HTTPServerSettings.ToListener()
@@ -88,6 +88,23 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "pickle"),
+			expected: &Config{
+				ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+				NetAddr: confignet.NetAddr{
+					Endpoint:  "localhost:2004",
+					Transport: "tcp",
+				},
+				TCPIdleTimeout: 30 * time.Second,
+				Parser: &protocol.Config{
+					Type: "pickle",
+					Config: &protocol.PickleConfig{
+						MaxMessageSize: 65536,
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -55,6 +55,27 @@ func TestLoadParserConfig(t *testing.T) {
 					}},
 			},
 		},
+		{
+			name:   "default_pickle",
+			cfgMap: map[string]interface{}{"type": "pickle"},
+			cfg:    Config{Type: "pickle"},
+			want: Config{
+				Type:   "pickle",
+				Config: &PickleConfig{MaxMessageSize: defaultPickleMaxMessageSize},
+			},
+		},
+		{
+			name: "custom_pickle",
+			cfgMap: map[string]interface{}{
+				"type":   "pickle",
+				"config": map[string]interface{}{"max_message_size": 1024},
+			},
+			cfg: Config{Type: "pickle"},
+			want: Config{
+				Type:   "pickle",
+				Config: &PickleConfig{MaxMessageSize: 1024},
+			},
+		},
 		{
 			name:   "default_regex",
 			cfgMap: map[string]interface{}{"type": "regex"},
@@ -15,6 +15,8 @@
 package protocol // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/carbonreceiver/protocol"
 
 import (
+	"io"
+
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -38,6 +40,22 @@ type Parser interface {
 	Parse(line string) (*metricspb.Metric, error)
 }
 
+// FramedParser is implemented by parsers of protocols that delimit messages
+// with a length header instead of new lines, e.g. the Carbon pickle protocol.
+// Stream transports read the data using ReadFrame instead of splitting it
+// into lines.
+type FramedParser interface {
+	Parser
+
+	// ReadFrame reads the next message from the reader.
+	ReadFrame(r io.Reader) ([]byte, error)
+
+	// ParseFrame transforms a message returned by ReadFrame into the
+	// collector metric format. Implementations can return metrics together
+	// with an error if only part of the message could be parsed.
+	ParseFrame(frame []byte) ([]*metricspb.Metric, error)
+}
+
 // Below a few helper functions useful to different parsers.
 func buildMetricForSinglePoint(
 	metricName string,
@@ -120,36 +120,48 @@ func (pph *PathParserHelper) Parse(line string) (*metricspb.Metric, error) {
 		return nil, fmt.Errorf("invalid carbon metric time [%s]: %w", line, err)
 	}
 
+	var value interface{}
+	if intVal, err := strconv.ParseInt(valueStr, 10, 64); err == nil {
+		value = intVal
+	} else {
+		dblVal, err := strconv.ParseFloat(valueStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid carbon metric value [%s]: %w", line, err)
+		}
+		value = dblVal
+	}
+
+	return buildMetricForParsedPath(parsedPath, unixTime, value), nil
+}
+
+// buildMetricForParsedPath creates the metric for a single Carbon data point.
+// The value is expected to be either an int64 or a float64.
+func buildMetricForParsedPath(parsedPath ParsedPath, unixTime int64, value interface{}) *metricspb.Metric {
 	var metricType metricspb.MetricDescriptor_Type
 	point := metricspb.Point{
 		Timestamp: convertUnixSec(unixTime),
 	}
-	intVal, err := strconv.ParseInt(valueStr, 10, 64)
-	if err == nil {
+	switch v := value.(type) {
+	case int64:
 		if parsedPath.MetricType == CumulativeMetricType {
 			metricType = metricspb.MetricDescriptor_CUMULATIVE_INT64
 		} else {
 			metricType = metricspb.MetricDescriptor_GAUGE_INT64
 		}
-		point.Value = &metricspb.Point_Int64Value{Int64Value: intVal}
-	} else {
-		dblVal, err := strconv.ParseFloat(valueStr, 64)
-		if err != nil {
-			return nil, fmt.Errorf("invalid carbon metric value [%s]: %w", line, err)
-		}
+		point.Value = &metricspb.Point_Int64Value{Int64Value: v}
+	case float64:
 		if parsedPath.MetricType == CumulativeMetricType {
 			metricType = metricspb.MetricDescriptor_CUMULATIVE_DOUBLE
 		} else {
 			metricType = metricspb.MetricDescriptor_GAUGE_DOUBLE
 		}
-		point.Value = &metricspb.Point_DoubleValue{DoubleValue: dblVal}
+		point.Value = &metricspb.Point_DoubleValue{DoubleValue: v}
 	}
 
-	metric := buildMetricForSinglePoint(
+	return buildMetricForSinglePoint(
 		parsedPath.MetricName,
 		metricType,
 		parsedPath.LabelKeys,
 		parsedPath.LabelValues,
 		&point)
-	return metric, nil
 }
@@ -0,0 +1,654 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/carbonreceiver/protocol"
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Pickle opcodes accepted by unpickle, see
+// https://github.com/python/cpython/blob/main/Lib/pickletools.py. Only the
+// opcodes needed to build lists, tuples, strings and numbers are supported,
+// any opcode that could import or call Python objects (GLOBAL, REDUCE, BUILD,
+// INST, OBJ, NEWOBJ, etc.) is rejected.
+const (
+	opMark            = '('
+	opStop            = '.'
+	opPop             = '0'
+	opPopMark         = '1'
+	opDup             = '2'
+	opFloat           = 'F'
+	opInt             = 'I'
+	opBinInt          = 'J'
+	opBinInt1         = 'K'
+	opLong            = 'L'
+	opBinInt2         = 'M'
+	opNone            = 'N'
+	opString          = 'S'
+	opBinString       = 'T'
+	opShortBinString  = 'U'
+	opUnicode         = 'V'
+	opBinUnicode      = 'X'
+	opAppend          = 'a'
+	opGet             = 'g'
+	opBinGet          = 'h'
+	opLongBinGet      = 'j'
+	opList            = 'l'
+	opPut             = 'p'
+	opBinPut          = 'q'
+	opLongBinPut      = 'r'
+	opTuple           = 't'
+	opAppends         = 'e'
+	opEmptyList       = ']'
+	opEmptyTuple      = ')'
+	opBinFloat        = 'G'
+	opProto           = 0x80
+	opTuple1          = 0x85
+	opTuple2          = 0x86
+	opTuple3          = 0x87
+	opNewTrue         = 0x88
+	opNewFalse        = 0x89
+	opLong1           = 0x8a
+	opLong4           = 0x8b
+	opBinBytes        = 'B'
+	opShortBinBytes   = 'C'
+	opShortBinUnicode = 0x8c
+	opBinUnicode8     = 0x8d
+	opBinBytes8       = 0x8e
+	opMemoize         = 0x94
+	opFrame           = 0x95
+
+	// maxPickleProtocol is the highest pickle protocol version accepted.
+	maxPickleProtocol = 5
+
+	// The following limits bound the memory used to decode a message, since
+	// values on the stack and in the memo can be referenced many times.
+	maxPickleStackDepth = 1 << 16
+	maxPickleItems      = 1 << 20
+	maxPickleMemoSize   = 1 << 16
+)
+
+var errPickleMarkNotFound = errors.New("pickle mark not found")
+
+// pickleList is the result of unpickling a Python list. It is a pointer type
+// so that list references kept in the memo see the items appended later.
+type pickleList struct {
+	items []interface{}
+}
+
+// pickleTuple is the result of unpickling a Python tuple.
+type pickleTuple []interface{}
+
+// pickleMark is pushed to the stack by the MARK opcode.
+type pickleMark struct{}
+
+// unpickle decodes the given Python pickle data. It is a restricted
+// implementation that only builds lists, tuples, strings, integers, floats,
+// booleans and None, which is enough to decode the messages of the Carbon
+// pickle protocol without the risks of a full unpickler. Unpickled values are
+// returned as *pickleList, pickleTuple, string, int64, float64, bool or nil.
+func unpickle(data []byte) (interface{}, error) {
+	u := unpickler{
+		r:    bufio.NewReader(bytes.NewReader(data)),
+		size: len(data),
+		memo: make(map[int64]interface{}),
+	}
+	return u.run()
+}
+
+type unpickler struct {
+	r     *bufio.Reader
+	size  int
+	stack []interface{}
+	memo  map[int64]interface{}
+	// items is the number of items added to lists and tuples so far
+	items int
+}
+
+func (u *unpickler) run() (interface{}, error) {
+	for {
+		// Each opcode pushes at most one value
+		if len(u.stack) > maxPickleStackDepth {
+			return nil, fmt.Errorf("pickle stack exceeds %d values", maxPickleStackDepth)
+		}
+
+		op, err := u.r.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("pickle data truncated: %w", err)
+		}
+
+		switch op {
+		case opStop:
+			v, err := u.pop()
+			if err != nil {
+				return nil, err
+			}
+			if len(u.stack) != 0 {
+				return nil, errors.New("pickle stack not empty on STOP")
+			}
+			return v, nil
+		case opProto:
+			proto, err := u.r.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			if proto > maxPickleProtocol {
+				return nil, fmt.Errorf("unsupported pickle protocol %d", proto)
+			}
+		case opFrame:
+			// Frames are only a hint for buffering, the data is already in memory.
+			if _, err := u.readN(8); err != nil {
+				return nil, err
+			}
+		case opMark:
+			u.push(pickleMark{})
+		case opPop:
+			if _, err := u.pop(); err != nil {
+				return nil, err
+			}
+		case opPopMark:
+			if _, err := u.popMark(); err != nil {
+				return nil, err
+			}
+		case opDup:
+			v, err := u.top()
+			if err != nil {
+				return nil, err
+			}
+			u.push(v)
+		case opNone:
+			u.push(nil)
+		case opNewTrue:
+			u.push(true)
+		case opNewFalse:
+			u.push(false)
+		case opInt:
+			line, err := u.readLine()
+			if err != nil {
+				return nil, err
+			}
+			// Protocol 0 encodes booleans as "I01" and "I00", keep them as integers.
+			i, err := strconv.ParseInt(line, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pickle INT: %w", err)
+			}
+			u.push(i)
+		case opLong:
+			line, err := u.readLine()
+			if err != nil {
+				return nil, err
+			}
+			i, err := strconv.ParseInt(strings.TrimSuffix(line, "L"), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pickle LONG: %w", err)
+			}
+			u.push(i)
+		case opBinInt:
+			b, err := u.readN(4)
+			if err != nil {
+				return nil, err
+			}
+			u.push(int64(int32(binary.LittleEndian.Uint32(b))))
+		case opBinInt1:
+			b, err := u.r.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			u.push(int64(b))
+		case opBinInt2:
+			b, err := u.readN(2)
+			if err != nil {
+				return nil, err
+			}
+			u.push(int64(binary.LittleEndian.Uint16(b)))
+		case opLong1:
+			n, err := u.r.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			i, err := u.readLong(int(n))
+			if err != nil {
+				return nil, err
+			}
+			u.push(i)
+		case opLong4:
+			n, err := u.readLength(4)
+			if err != nil {
+				return nil, err
+			}
+			i, err := u.readLong(n)
+			if err != nil {
+				return nil, err
+			}
+			u.push(i)
+		case opFloat:
+			line, err := u.readLine()
+			if err != nil {
+				return nil, err
+			}
+			f, err := strconv.ParseFloat(line, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pickle FLOAT: %w", err)
+			}
+			u.push(f)
+		case opBinFloat:
+			b, err := u.readN(8)
+			if err != nil {
+				return nil, err
+			}
+			u.push(math.Float64frombits(binary.BigEndian.Uint64(b)))
+		case opString:
+			line, err := u.readLine()
+			if err != nil {
+				return nil, err
+			}
+			s, err := unquotePythonString(line)
+			if err != nil {
+				return nil, err
+			}
+			u.push(s)
+		case opUnicode:
+			line, err := u.readLine()
+			if err != nil {
+				return nil, err
+			}
+			s, err := decodeRawUnicodeEscape(line)
+			if err != nil {
+				return nil, err
+			}
+			u.push(s)
+		case opShortBinString, opShortBinBytes, opShortBinUnicode:
+			if err := u.pushString(1); err != nil {
+				return nil, err
+			}
+		case opBinString, opBinBytes, opBinUnicode:
+			if err := u.pushString(4); err != nil {
+				return nil, err
+			}
+		case opBinBytes8, opBinUnicode8:
+			if err := u.pushString(8); err != nil {
+				return nil, err
+			}
+		case opEmptyList:
+			u.push(&pickleList{})
+		case opList:
+			items, err := u.popMark()
+			if err != nil {
+				return nil, err
+			}
+			if err := u.addItems(len(items)); err != nil {
+				return nil, err
+			}
+			u.push(&pickleList{items: items})
+		case opAppend:
+			v, err := u.pop()
+			if err != nil {
+				return nil, err
+			}
+			l, err := u.topList()
+			if err != nil {
+				return nil, err
+			}
+			if err := u.addItems(1); err != nil {
+				return nil, err
+			}
+			l.items = append(l.items, v)
+		case opAppends:
+			items, err := u.popMark()
+			if err != nil {
+				return nil, err
+			}
+			l, err := u.topList()
+			if err != nil {
+				return nil, err
+			}
+			if err := u.addItems(len(items)); err != nil {
+				return nil, err
+			}
+			l.items = append(l.items, items...)
+		case opEmptyTuple:
+			u.push(pickleTuple{})
+		case opTuple:
+			items, err := u.popMark()
+			if err != nil {
+				return nil, err
+			}
+			if err := u.addItems(len(items)); err != nil {
+				return nil, err
+			}
+			u.push(pickleTuple(items))
+		case opTuple1, opTuple2, opTuple3:
+			n := int(op-opTuple1) + 1
+			if len(u.stack) < n {
+				return nil, errors.New("pickle stack underflow")
+			}
+			if err := u.addItems(n); err != nil {
+				return nil, err
+			}
+			items := make(pickleTuple, n)
+			copy(items, u.stack[len(u.stack)-n:])
+			u.stack = u.stack[:len(u.stack)-n]
+			for _, item := range items {
+				if _, ok := item.(pickleMark); ok {
+					return nil, errors.New("unexpected pickle mark in tuple")
+				}
+			}
+			u.push(items)
+		case opPut:
+			line, err := u.readLine()
+			if err != nil {
+				return nil, err
+			}
+			idx, err := strconv.ParseInt(line, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pickle PUT: %w", err)
+			}
+			if err := u.put(idx); err != nil {
+				return nil, err
+			}
+		case opBinPut:
+			b, err := u.r.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			if err := u.put(int64(b)); err != nil {
+				return nil, err
+			}
+		case opLongBinPut:
+			b, err := u.readN(4)
+			if err != nil {
+				return nil, err
+			}
+			if err := u.put(int64(binary.LittleEndian.Uint32(b))); err != nil {
+				return nil, err
+			}
+		case opMemoize:
+			if err := u.put(int64(len(u.memo))); err != nil {
+				return nil, err
+			}
+		case opGet:
+			line, err := u.readLine()
+			if err != nil {
+				return nil, err
+			}
+			idx, err := strconv.ParseInt(line, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pickle GET: %w", err)
+			}
+			if err := u.get(idx); err != nil {
+				return nil, err
+			}
+		case opBinGet:
+			b, err := u.r.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			if err := u.get(int64(b)); err != nil {
+				return nil, err
+			}
+		case opLongBinGet:
+			b, err := u.readN(4)
+			if err != nil {
+				return nil, err
+			}
+			if err := u.get(int64(binary.LittleEndian.Uint32(b))); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unsupported pickle opcode 0x%02x", op)
+		}
+	}
+}
+
+func (u *unpickler) push(v interface{}) {
+	u.stack = append(u.stack, v)
+}
+
+func (u *unpickler) top() (interface{}, error) {
+	if len(u.stack) == 0 {
+		return nil, errors.New("pickle stack underflow")
+	}
+	v := u.stack[len(u.stack)-1]
+	if _, ok := v.(pickleMark); ok {
+		return nil, errors.New("unexpected pickle mark")
+	}
+	return v, nil
+}
+
+func (u *unpickler) pop() (interface{}, error) {
+	v, err := u.top()
+	if err != nil {
+		return nil, err
+	}
+	u.stack = u.stack[:len(u.stack)-1]
+	return v, nil
+}
+
+func (u *unpickler) topList() (*pickleList, error) {
+	v, err := u.top()
+	if err != nil {
+		return nil, err
+	}
+	l, ok := v.(*pickleList)
+	if !ok {
+		return nil, fmt.Errorf("cannot append to pickle value of type %T", v)
+	}
+	return l, nil
+}
+
+// popMark removes from the stack all the values after the last mark and the
+// mark itself, returning the removed values.
+func (u *unpickler) popMark() ([]interface{}, error) {
+	for i := len(u.stack) - 1; i >= 0; i-- {
+		if _, ok := u.stack[i].(pickleMark); ok {
+			items := make([]interface{}, len(u.stack)-i-1)
+			copy(items, u.stack[i+1:])
+			u.stack = u.stack[:i]
+			return items, nil
+		}
+	}
+	return nil, errPickleMarkNotFound
+}
+
+// addItems counts the items added to lists and tuples against maxPickleItems.
+func (u *unpickler) addItems(n int) error {
+	u.items += n
+	if u.items > maxPickleItems {
+		return fmt.Errorf("pickle data exceeds %d list and tuple items", maxPickleItems)
+	}
+	return nil
+}
+
+func (u *unpickler) put(idx int64) error {
+	v, err := u.top()
+	if err != nil {
+		return err
+	}
+	if _, ok := u.memo[idx]; !ok && len(u.memo) >= maxPickleMemoSize {
+		return fmt.Errorf("pickle memo exceeds %d values", maxPickleMemoSize)
+	}
+	u.memo[idx] = v
+	return nil
+}
+
+func (u *unpickler) get(idx int64) error {
+	v, ok := u.memo[idx]
+	if !ok {
+		return fmt.Errorf("pickle memo key %d not found", idx)
+	}
+	u.push(v)
+	return nil
+}
+
+// readN reads exactly n bytes.
+func (u *unpickler) readN(n int) ([]byte, error) {
+	if n < 0 || n > u.size {
+		return nil, fmt.Errorf("invalid pickle length %d", n)
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(u.r, b); err != nil {
+		return nil, fmt.Errorf("pickle data truncated: %w", err)
+	}
+	return b, nil
+}
+
+// readLength reads an unsigned little endian length of the given number of
+// bytes, the length is validated against the size of the data to avoid
+// large allocations.
+func (u *unpickler) readLength(numBytes int) (int, error) {
+	b, err := u.readN(numBytes)
+	if err != nil {
+		return 0, err
+	}
+	var length uint64
+	switch numBytes {
+	case 1:
+		length = uint64(b[0])
+	case 4:
+		length = uint64(binary.LittleEndian.Uint32(b))
+	case 8:
+		length = binary.LittleEndian.Uint64(b)
+	}
+	if length > uint64(u.size) {
+		return 0, fmt.Errorf("invalid pickle length %d", length)
+	}
+	return int(length), nil
+}
+
+func (u *unpickler) pushString(numLengthBytes int) error {
+	n, err := u.readLength(numLengthBytes)
+	if err != nil {
+		return err
+	}
+	b, err := u.readN(n)
+	if err != nil {
+		return err
+	}
+	u.push(string(b))
+	return nil
+}
+
+// readLong reads a little endian two's complement integer of n bytes.
+func (u *unpickler) readLong(n int) (int64, error) {
+	if n > 8 {
+		return 0, fmt.Errorf("pickle integer of %d bytes does not fit in int64", n)
+	}
+	b, err := u.readN(n)
+	if err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	var v uint64
+	for i := n - 1; i >= 0; i-- {
+		v = v<<8 | uint64(b[i])
+	}
+	if n < 8 && b[n-1]&0x80 != 0 {
+		// Negative number, extend the sign.
+		v |= math.MaxUint64 << (8 * uint(n))
+	}
+	return int64(v), nil
+}
+
+func (u *unpickler) readLine() (string, error) {
+	line, err := u.r.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("pickle data truncated: %w", err)
+	}
+	return strings.TrimSuffix(line, "\n"), nil
+}
+
+// unquotePythonString decodes the repr of a Python 2 string, as used by the
+// STRING opcode of the pickle protocol 0.
+func unquotePythonString(s string) (string, error) {
+	if len(s) < 2 || s[0] != s[len(s)-1] || (s[0] != '\'' && s[0] != '"') {
+		return "", fmt.Errorf("invalid pickle STRING: %s", s)
+	}
+	s = s[1 : len(s)-1]
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' {
+			sb.WriteByte(c)
+			continue
+		}
+		i++
+		if i == len(s) {
+			return "", errors.New("invalid pickle STRING: trailing backslash")
+		}
+		switch s[i] {
+		case '\\', '\'', '"':
+			sb.WriteByte(s[i])
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 't':
+			sb.WriteByte('\t')
+		case 'x':
+			if i+2 >= len(s) {
+				return "", errors.New("invalid pickle STRING: truncated escape")
+			}
+			b, err := strconv.ParseUint(s[i+1:i+3], 16, 8)
+			if err != nil {
+				return "", fmt.Errorf("invalid pickle STRING: %w", err)
+			}
+			sb.WriteByte(byte(b))
+			i += 2
+		default:
+			return "", fmt.Errorf("invalid pickle STRING: unsupported escape \\%c", s[i])
+		}
+	}
+	return sb.String(), nil
+}
+
+// decodeRawUnicodeEscape decodes the "raw-unicode-escape" encoding used by
+// the UNICODE opcode of the pickle protocol 0.
+func decodeRawUnicodeEscape(s string) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i+1 == len(s) || (s[i+1] != 'u' && s[i+1] != 'U') {
+			// raw-unicode-escape encodes code points below 256 as Latin-1.
+			sb.WriteRune(rune(c))
+			continue
+		}
+		n := 4
+		if s[i+1] == 'U' {
+			n = 8
+		}
+		if i+2+n > len(s) {
+			return "", errors.New("invalid pickle UNICODE: truncated escape")
+		}
+		r, err := strconv.ParseUint(s[i+2:i+2+n], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(r)) {
+			return "", fmt.Errorf("invalid pickle UNICODE escape: %s", s[i:i+2+n])
+		}
+		sb.WriteRune(rune(r))
+		i += 1 + n
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/carbonreceiver/protocol"
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"go.uber.org/multierr"
+)
+
+const (
+	// defaultPickleMaxMessageSize is the default limit for the size of a
+	// single pickle message.
+	defaultPickleMaxMessageSize = 1024 * 1024
+
+	// pickleHeaderSize is the size of the length header that precedes each
+	// pickle message.
+	pickleHeaderSize = 4
+)
+
+var errPickleLineProtocol = errors.New("the pickle parser does not support line based transports")
+
+// PickleConfig holds the configuration for the pickle parser.
+type PickleConfig struct {
+	// MaxMessageSize is the maximum size in bytes of a single pickle message.
+	// Connections sending bigger messages are closed. The default is 1MiB.
+	MaxMessageSize int `mapstructure:"max_message_size"`
+}
+
+var _ (ParserConfig) = (*PickleConfig)(nil)
+
+// BuildParser creates a new Parser instance that receives Carbon data using
+// the pickle protocol.
+func (p *PickleConfig) BuildParser() (Parser, error) {
+	if p.MaxMessageSize <= 0 {
+		return nil, fmt.Errorf("invalid max_message_size: %d", p.MaxMessageSize)
+	}
+	return &PickleParser{
+		pathParser:     &PlaintextPathParser{},
+		maxMessageSize: p.MaxMessageSize,
+	}, nil
+}
+
+// PickleParser decodes messages of the Carbon pickle protocol, see
+// https://graphite.readthedocs.io/en/latest/feeding-carbon.html#the-pickle-protocol.
+// Each message is a 4 bytes big endian length header followed by a pickled
+// list of datapoints:
+//
+//	[(<metric_path>, (<metric_timestamp>, <metric_value>)), ...]
+//
+// The <metric_path> is parsed as in the plaintext protocol, so Graphite
+// tagged series are supported. The pickle data is decoded by a restricted
+// unpickler that only accepts lists, tuples, strings and numbers.
+type PickleParser struct {
+	pathParser     PathParser
+	maxMessageSize int
+}
+
+var _ FramedParser = (*PickleParser)(nil)
+
+// Parse always fails since the pickle protocol is not line based.
+func (p *PickleParser) Parse(string) (*metricspb.Metric, error) {
+	return nil, errPickleLineProtocol
+}
+
+// ReadFrame reads the next pickle message from the reader.
+func (p *PickleParser) ReadFrame(r io.Reader) ([]byte, error) {
+	var header [pickleHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(header[:])
+	if uint64(size) > uint64(p.maxMessageSize) {
+		return nil, fmt.Errorf("pickle message size %d exceeds the maximum of %d bytes", size, p.maxMessageSize)
+	}
+
+	frame := make([]byte, size)
+	if _, err := io.ReadFull(r, frame); err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
+// ParseFrame transforms a pickle message into the collector metric format.
+// Datapoints that cannot be converted are skipped and reported in the
+// returned error together with the metrics that were converted.
+func (p *PickleParser) ParseFrame(frame []byte) ([]*metricspb.Metric, error) {
+	v, err := unpickle(frame)
+	if err != nil {
+		return nil, fmt.Errorf("invalid carbon pickle message: %w", err)
+	}
+
+	list, ok := v.(*pickleList)
+	if !ok {
+		return nil, fmt.Errorf("invalid carbon pickle message: expected a list, got %T", v)
+	}
+
+	var errs error
+	metrics := make([]*metricspb.Metric, 0, len(list.items))
+	for _, item := range list.items {
+		metric, err := p.parseDatapoint(item)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics, errs
+}
+
+// parseDatapoint converts an item of the form
+// (<metric_path>, (<metric_timestamp>, <metric_value>)).
+func (p *PickleParser) parseDatapoint(item interface{}) (*metricspb.Metric, error) {
+	pair, ok := pickleSequence(item)
+	if !ok || len(pair) != 2 {
+		return nil, fmt.Errorf("invalid carbon pickle datapoint %v", item)
+	}
+
+	path, ok := pair[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid carbon pickle metric path %v", pair[0])
+	}
+
+	point, ok := pickleSequence(pair[1])
+	if !ok || len(point) != 2 {
+		return nil, fmt.Errorf("invalid carbon pickle datapoint for [%s]", path)
+	}
+
+	parsedPath := ParsedPath{}
+	if err := p.pathParser.ParsePath(path, &parsedPath); err != nil {
+		return nil, fmt.Errorf("invalid carbon pickle datapoint for [%s]: %w", path, err)
+	}
+
+	unixTime, err := pickleTimestamp(point[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid carbon pickle metric time for [%s]: %w", path, err)
+	}
+
+	value, err := pickleValue(point[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid carbon pickle metric value for [%s]: %w", path, err)
+	}
+
+	return buildMetricForParsedPath(parsedPath, unixTime, value), nil
+}
+
+func pickleSequence(v interface{}) ([]interface{}, bool) {
+	switch s := v.(type) {
+	case pickleTuple:
+		return s, true
+	case *pickleList:
+		return s.items, true
+	}
+	return nil, false
+}
+
+func pickleTimestamp(v interface{}) (int64, error) {
+	switch ts := v.(type) {
+	case int64:
+		return ts, nil
+	case float64:
+		if math.IsNaN(ts) || math.IsInf(ts, 0) {
+			return 0, fmt.Errorf("invalid timestamp %v", ts)
+		}
+		return int64(ts), nil
+	case string:
+		if i, err := strconv.ParseInt(ts, 10, 64); err == nil {
+			return i, nil
+		}
+		f, err := strconv.ParseFloat(ts, 64)
+		if err != nil {
+			return 0, err
+		}
+		return pickleTimestamp(f)
+	}
+	return 0, fmt.Errorf("unexpected timestamp type %T", v)
+}
+
+func pickleValue(v interface{}) (interface{}, error) {
+	switch val := v.(type) {
+	case int64, float64:
+		return val, nil
+	case string:
+		if i, err := strconv.ParseInt(val, 10, 64); err == nil {
+			return i, nil
+		}
+		return strconv.ParseFloat(val, 64)
+	}
+	return nil, fmt.Errorf("unexpected value type %T", v)
+}
+
+func pickleDefaultConfig() ParserConfig {
+	return &PickleConfig{
+		MaxMessageSize: defaultPickleMaxMessageSize,
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The pickle test data below was generated with Python 3 using:
+//
+//	pickle.dumps([
+//		("test.int", (1582230020, 1)),
+//		("test.dbl;k0=v0", (1582230020.5, 3.14)),
+//		(u"test.unicodeé", (1582230020, -2)),
+//	], protocol=<protocol>)
+var picklePayloads = map[string]string{
+	"protocol_0": "(lp0\x0a(Vtest.int\x0ap1\x0a(I1582230020\x0aI1\x0atp2\x0atp3\x0aa(Vtest.dbl;k0=v0\x0ap4\x0a(F1582230020.5\x0aF3.14\x0atp5\x0atp6\x0aa(Vtest.unicode\xe9\x0ap7\x0a(I1582230020\x0aI-2\x0atp8\x0atp9\x0aa.",
+	"protocol_1": "]q\x00((X\x08\x00\x00\x00test.intq\x01(J\x04\xeaN^K\x01tq\x02tq\x03(X\x0e\x00\x00\x00test.dbl;k0=v0q\x04(GA\xd7\x93\xba\x81 \x00\x00G@\x09\x1e\xb8Q\xeb\x85\x1ftq\x05tq\x06(X\x0e\x00\x00\x00test.unicode\xc3\xa9q\x07(J\x04\xeaN^J\xfe\xff\xff\xfftq\x08tq\x09e.",
+	"protocol_2": "\x80\x02]q\x00(X\x08\x00\x00\x00test.intq\x01J\x04\xeaN^K\x01\x86q\x02\x86q\x03X\x0e\x00\x00\x00test.dbl;k0=v0q\x04GA\xd7\x93\xba\x81 \x00\x00G@\x09\x1e\xb8Q\xeb\x85\x1f\x86q\x05\x86q\x06X\x0e\x00\x00\x00test.unicode\xc3\xa9q\x07J\x04\xeaN^J\xfe\xff\xff\xff\x86q\x08\x86q\x09e.",
+	"protocol_4": "\x80\x04\x95a\x00\x00\x00\x00\x00\x00\x00]\x94(\x8c\x08test.int\x94J\x04\xeaN^K\x01\x86\x94\x86\x94\x8c\x0etest.dbl;k0=v0\x94GA\xd7\x93\xba\x81 \x00\x00G@\x09\x1e\xb8Q\xeb\x85\x1f\x86\x94\x86\x94\x8c\x0etest.unicode\xc3\xa9\x94J\x04\xeaN^J\xfe\xff\xff\xff\x86\x94\x86\x94e.",
+	"protocol_5": "\x80\x05\x95a\x00\x00\x00\x00\x00\x00\x00]\x94(\x8c\x08test.int\x94J\x04\xeaN^K\x01\x86\x94\x86\x94\x8c\x0etest.dbl;k0=v0\x94GA\xd7\x93\xba\x81 \x00\x00G@\x09\x1e\xb8Q\xeb\x85\x1f\x86\x94\x86\x94\x8c\x0etest.unicode\xc3\xa9\x94J\x04\xeaN^J\xfe\xff\xff\xff\x86\x94\x86\x94e.",
+}
+
+func Test_pickleParser_ParseFrame(t *testing.T) {
+	p, err := pickleDefaultConfig().BuildParser()
+	require.NoError(t, err)
+	fp := p.(FramedParser)
+
+	want := []*metricspb.Metric{
+		buildMetric(
+			metricspb.MetricDescriptor_GAUGE_INT64,
+			"test.int",
+			nil,
+			nil,
+			&metricspb.Point{
+				Timestamp: &timestamppb.Timestamp{Seconds: 1582230020},
+				Value:     &metricspb.Point_Int64Value{Int64Value: 1},
+			},
+		),
+		buildMetric(
+			metricspb.MetricDescriptor_GAUGE_DOUBLE,
+			"test.dbl",
+			[]string{"k0"},
+			[]string{"v0"},
+			&metricspb.Point{
+				Timestamp: &timestamppb.Timestamp{Seconds: 1582230020},
+				Value:     &metricspb.Point_DoubleValue{DoubleValue: 3.14},
+			},
+		),
+		buildMetric(
+			metricspb.MetricDescriptor_GAUGE_INT64,
+			"test.unicodeé",
+			nil,
+			nil,
+			&metricspb.Point{
+				Timestamp: &timestamppb.Timestamp{Seconds: 1582230020},
+				Value:     &metricspb.Point_Int64Value{Int64Value: -2},
+			},
+		),
+	}
+
+	for name, payload := range picklePayloads {
+		t.Run(name, func(t *testing.T) {
+			got, err := fp.ParseFrame([]byte(payload))
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func Test_pickleParser_ParseFrame_partial(t *testing.T) {
+	p, err := pickleDefaultConfig().BuildParser()
+	require.NoError(t, err)
+
+	// pickle.dumps([("ok", (1, 2)), ("bad;k=~v", (1, 2)), ("a", ("x", 1)),
+	//	("s", ("1582230020", "1.5"))], protocol=2)
+	payload := "\x80\x02]q\x00(X\x02\x00\x00\x00okq\x01K\x01K\x02\x86q\x02\x86q\x03X\x08\x00\x00\x00bad;k=~vq\x04h\x02\x86q\x05X\x01\x00\x00\x00aq\x06X\x01\x00\x00\x00xq\x07K\x01\x86q\x08\x86q\x09X\x01\x00\x00\x00sq\x0aX\x0a\x00\x00\x001582230020q\x0bX\x03\x00\x00\x001.5q\x0c\x86q\x0d\x86q\x0ee."
+	got, err := p.(FramedParser).ParseFrame([]byte(payload))
+	assert.ErrorContains(t, err, "invalid carbon pickle datapoint for [bad;k=~v]")
+	assert.ErrorContains(t, err, "invalid carbon pickle metric time for [a]")
+	assert.Equal(t, []*metricspb.Metric{
+		buildMetric(
+			metricspb.MetricDescriptor_GAUGE_INT64,
+			"ok",
+			nil,
+			nil,
+			&metricspb.Point{
+				Timestamp: &timestamppb.Timestamp{Seconds: 1},
+				Value:     &metricspb.Point_Int64Value{Int64Value: 2},
+			},
+		),
+		buildMetric(
+			metricspb.MetricDescriptor_GAUGE_DOUBLE,
+			"s",
+			nil,
+			nil,
+			&metricspb.Point{
+				Timestamp: &timestamppb.Timestamp{Seconds: 1582230020},
+				Value:     &metricspb.Point_DoubleValue{DoubleValue: 1.5},
+			},
+		),
+	}, got)
+}
+
+func Test_pickleParser_ParseFrame_errors(t *testing.T) {
+	p, err := pickleDefaultConfig().BuildParser()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		payload string
+		wantErr string
+	}{
+		{
+			// A pickle that would call os.system("echo pwned") on a regular unpickler.
+			name:    "global_and_reduce",
+			payload: "\x80\x02]q\x00X\x01\x00\x00\x00aq\x01K\x01cposix\x0asystem\x0aq\x02X\x0a\x00\x00\x00echo pwnedq\x03\x85q\x04Rq\x05\x86q\x06\x86q\x07a.",
+			wantErr: "unsupported pickle opcode 0x63",
+		},
+		{
+			name:    "dict",
+			payload: "\x80\x02}q\x00X\x01\x00\x00\x00aq\x01K\x01s.",
+			wantErr: "unsupported pickle opcode 0x7d",
+		},
+		{
+			name:    "not_a_list",
+			payload: "\x80\x02K\x01.",
+			wantErr: "expected a list",
+		},
+		{
+			name:    "integer_too_big",
+			payload: "\x80\x02]q\x00X\x01\x00\x00\x00aq\x01K\x01\x8a\x09\x00\x00\x00\x00\x00\x00\x00\x00@\x86q\x02\x86q\x03a.",
+			wantErr: "does not fit in int64",
+		},
+		{
+			name:    "truncated",
+			payload: "\x80\x02]q\x00(X\x08\x00\x00\x00test",
+			wantErr: "pickle data truncated",
+		},
+		{
+			name:    "string_length_too_big",
+			payload: "\x80\x02]q\x00(X\xff\xff\xff\xfftest",
+			wantErr: "invalid pickle length",
+		},
+		{
+			name:    "unsupported_protocol",
+			payload: "\x80\x06].",
+			wantErr: "unsupported pickle protocol 6",
+		},
+		{
+			name:    "missing_mark",
+			payload: "\x80\x02]K\x01e.",
+			wantErr: "pickle mark not found",
+		},
+		{
+			name:    "unknown_memo",
+			payload: "\x80\x02h\x01.",
+			wantErr: "pickle memo key 1 not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.(FramedParser).ParseFrame([]byte(tt.payload))
+			assert.ErrorContains(t, err, tt.wantErr)
+			assert.Nil(t, got)
+		})
+	}
+}
+
+func Test_pickleParser_ReadFrame(t *testing.T) {
+	p, err := (&PickleConfig{MaxMessageSize: 8}).BuildParser()
+	require.NoError(t, err)
+	fp := p.(FramedParser)
+
+	buf := &bytes.Buffer{}
+	writeFrame := func(payload string) {
+		var header [pickleHeaderSize]byte
+		binary.BigEndian.PutUint32(header[:], uint32(len(payload)))
+		buf.Write(header[:])
+		buf.WriteString(payload)
+	}
+	writeFrame("first")
+	writeFrame("second")
+	writeFrame("too large")
+
+	frame, err := fp.ReadFrame(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(frame))
+
+	frame, err = fp.ReadFrame(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(frame))
+
+	_, err = fp.ReadFrame(buf)
+	assert.EqualError(t, err, "pickle message size 9 exceeds the maximum of 8 bytes")
+
+	_, err = fp.ReadFrame(bytes.NewBufferString("\x00\x00\x00\x05abc"))
+	assert.Error(t, err)
+}
+
+func Test_pickleParser_Parse(t *testing.T) {
+	p, err := pickleDefaultConfig().BuildParser()
+	require.NoError(t, err)
+	_, err = p.Parse("test.metric 1 1582230020")
+	assert.Equal(t, errPickleLineProtocol, err)
+}
+
+func TestPickleConfig_BuildParser(t *testing.T) {
+	_, err := (&PickleConfig{}).BuildParser()
+	assert.EqualError(t, err, "invalid max_message_size: 0")
+}
@@ -0,0 +1,158 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_unpickle(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want interface{}
+	}{
+		{
+			name: "protocol_0_python2",
+			data: "(lp0\n(S'test.\\'quoted\\'\\x41'\np1\n(L1582230020L\nI01\ntp2\ntp3\naNa.",
+			want: &pickleList{items: []interface{}{
+				pickleTuple{"test.'quoted'A", pickleTuple{int64(1582230020), int64(1)}},
+				nil,
+			}},
+		},
+		{
+			name: "protocol_0_unicode_escape",
+			data: "(lp0\nVa\\u00e9\\U0001F600\np1\na.",
+			want: &pickleList{items: []interface{}{"aé😀"}},
+		},
+		{
+			name: "negative_long1",
+			data: "\x80\x02\x8a\x05\x00H\x9b&\xba.",
+			want: int64(-300000000000),
+		},
+		{
+			name: "booleans_and_empty_tuple",
+			data: "\x80\x02\x88\x89)\x87.",
+			want: pickleTuple{true, false, pickleTuple{}},
+		},
+		{
+			name: "list_and_dup",
+			data: "(K\x01K\x022l.",
+			want: &pickleList{items: []interface{}{int64(1), int64(2), int64(2)}},
+		},
+		{
+			name: "memoized_list_updated_after_put",
+			data: "](]q\x00h\x00K\x01a\x85e.",
+			want: func() interface{} {
+				inner := &pickleList{items: []interface{}{int64(1)}}
+				return &pickleList{items: []interface{}{inner, pickleTuple{inner}}}
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := unpickle([]byte(tt.data))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_unpickle_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name:    "empty",
+			data:    "",
+			wantErr: "pickle data truncated",
+		},
+		{
+			name:    "stack_underflow",
+			data:    ".",
+			wantErr: "pickle stack underflow",
+		},
+		{
+			name:    "stack_not_empty",
+			data:    "K\x01K\x02.",
+			wantErr: "pickle stack not empty on STOP",
+		},
+		{
+			name:    "append_to_tuple",
+			data:    ")K\x01a.",
+			wantErr: "cannot append to pickle value of type protocol.pickleTuple",
+		},
+		{
+			name:    "mark_in_tuple",
+			data:    "(K\x01\x86.",
+			wantErr: "unexpected pickle mark in tuple",
+		},
+		{
+			name:    "invalid_string",
+			data:    "Sabc\n.",
+			wantErr: "invalid pickle STRING: abc",
+		},
+		{
+			name:    "invalid_unicode_escape",
+			data:    "V\\uZZZZ\n.",
+			wantErr: "invalid pickle UNICODE escape",
+		},
+		{
+			name:    "stack_global",
+			data:    "\x80\x04\x8c\x05posix\x8c\x06system\x93.",
+			wantErr: "unsupported pickle opcode 0x93",
+		},
+		{
+			name:    "build",
+			data:    "]]b.",
+			wantErr: "unsupported pickle opcode 0x62",
+		},
+		{
+			name:    "stack_depth",
+			data:    "N" + strings.Repeat("2", maxPickleStackDepth) + ".",
+			wantErr: "pickle stack exceeds 65536 values",
+		},
+		{
+			name:    "items",
+			data:    "]q\x00" + strings.Repeat("h\x00a", maxPickleItems+1) + ".",
+			wantErr: "pickle data exceeds 1048576 list and tuple items",
+		},
+		{
+			name: "memo_size",
+			data: func() string {
+				var sb strings.Builder
+				sb.WriteString("N")
+				for i := 0; i <= maxPickleMemoSize; i++ {
+					sb.WriteString("p" + strconv.Itoa(i) + "\n")
+				}
+				return sb.String() + "."
+			}(),
+			wantErr: "pickle memo exceeds 65536 values",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := unpickle([]byte(tt.data))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
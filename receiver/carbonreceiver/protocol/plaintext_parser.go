@@ -16,6 +16,7 @@ package protocol // import "github.com/open-telemetry/opentelemetry-collector-co
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
@@ -47,7 +48,9 @@ type PlaintextPathParser struct{}
 // or at the end of the path.
 //
 // tag is of the form "key=val", where key can contain any char except ";!^=" and
-// val can contain any char except ";~".
+// val can contain any char except ";" and must not start with "~". As Graphite
+// does for tagged series, the tags are sorted by key and, if a key is repeated,
+// the last value is the one kept.
 func (p *PlaintextPathParser) ParsePath(path string, parsedPath *ParsedPath) error {
 	parts := strings.SplitN(path, ";", 2)
 	if len(parts) < 1 || parts[0] == "" {
@@ -66,8 +69,7 @@ func (p *PlaintextPathParser) ParsePath(path string, parsedPath *ParsedPath) err
 	}
 
 	tags := strings.Split(parts[1], ";")
-	keys := make([]*metricspb.LabelKey, 0, len(tags))
-	values := make([]*metricspb.LabelValue, 0, len(tags))
+	tagValues := make(map[string]string, len(tags))
 	for _, tag := range tags {
 		idx := strings.IndexByte(tag, '=')
 		if idx < 1 {
@@ -75,11 +77,29 @@ func (p *PlaintextPathParser) ParsePath(path string, parsedPath *ParsedPath) err
 		}
 
 		key := tag[:idx]
-		keys = append(keys, &metricspb.LabelKey{Key: key})
+		if strings.ContainsAny(key, "!^") {
+			return fmt.Errorf("cannot parse metric path [%s]: invalid tag key [%s]", path, key)
+		}
 
 		value := tag[idx+1:] // If value is empty, ie.: tag == "k=", this will return "".
+		if strings.HasPrefix(value, "~") {
+			return fmt.Errorf("cannot parse metric path [%s]: invalid tag value [%s]", path, value)
+		}
+		tagValues[key] = value
+	}
+
+	keys := make([]*metricspb.LabelKey, 0, len(tagValues))
+	for key := range tagValues {
+		keys = append(keys, &metricspb.LabelKey{Key: key})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Key < keys[j].Key
+	})
+
+	values := make([]*metricspb.LabelValue, 0, len(keys))
+	for _, key := range keys {
 		values = append(values, &metricspb.LabelValue{
-			Value:    value,
+			Value:    tagValues[key.Key],
 			HasValue: true,
 		})
 	}
@@ -155,6 +155,46 @@ func TestPlaintextParser_parsePath(t *testing.T) {
 				{Value: "v1", HasValue: true},
 			},
 		},
+		{
+			name:     "unsorted_tags",
+			path:     "unsorted.tags;k1=v1;k0=v0;k2=v2",
+			wantName: "unsorted.tags",
+			wantKeys: []*metricspb.LabelKey{{Key: "k0"}, {Key: "k1"}, {Key: "k2"}},
+			wantValues: []*metricspb.LabelValue{
+				{Value: "v0", HasValue: true},
+				{Value: "v1", HasValue: true},
+				{Value: "v2", HasValue: true},
+			},
+		},
+		{
+			name:     "repeated_tag",
+			path:     "repeated.tag;k0=v0;k1=v1;k0=v2",
+			wantName: "repeated.tag",
+			wantKeys: []*metricspb.LabelKey{{Key: "k0"}, {Key: "k1"}},
+			wantValues: []*metricspb.LabelValue{
+				{Value: "v2", HasValue: true},
+				{Value: "v1", HasValue: true},
+			},
+		},
+		{
+			name:     "tag_value_with_separator",
+			path:     "tag.value.separator;k0=a=b",
+			wantName: "tag.value.separator",
+			wantKeys: []*metricspb.LabelKey{{Key: "k0"}},
+			wantValues: []*metricspb.LabelValue{
+				{Value: "a=b", HasValue: true},
+			},
+		},
+		{
+			name:    "invalid_tag_key",
+			path:    "invalid.tag.key;k!0=v0",
+			wantErr: true,
+		},
+		{
+			name:    "invalid_tag_value",
+			path:    "invalid.tag.value;k0=~v0",
+			wantErr: true,
+		},
 		{
 			name:     "empty_tag_value_end",
 			path:     "empty.tag.value.end;k0=v0;k1=",
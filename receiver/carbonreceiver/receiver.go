@@ -73,6 +73,10 @@ func New(
 		return nil, err
 	}
 
+	if _, ok := parser.(protocol.FramedParser); ok && strings.ToLower(config.Transport) == "udp" {
+		return nil, fmt.Errorf("parser %q requires the tcp transport for receiver %v", config.Parser.Type, config.ID())
+	}
+
 	// This should be the last one built, or if any other error is raised after
 	// it, the server should be closed.
 	server, err := buildTransportServer(config)
@@ -127,6 +127,24 @@ func Test_carbonreceiver_New(t *testing.T) {
 				nextConsumer: consumertest.NewNop(),
 			},
 		},
+		{
+			name: "pickle_parser_udp",
+			args: args{
+				config: Config{
+					ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "pickle")),
+					NetAddr: confignet.NetAddr{
+						Endpoint:  "localhost:2004",
+						Transport: "udp",
+					},
+					Parser: &protocol.Config{
+						Type:   "pickle",
+						Config: &protocol.PickleConfig{MaxMessageSize: 1024},
+					},
+				},
+				nextConsumer: consumertest.NewNop(),
+			},
+			wantErr: errors.New("parser \"pickle\" requires the tcp transport for receiver carbon/pickle"),
+		},
 		{
 			name: "negative_tcp_idle_timeout",
 			args: args{
@@ -167,6 +185,7 @@ func Test_carbonreceiver_EndToEnd(t *testing.T) {
 		name     string
 		configFn func() *Config
 		clientFn func(t *testing.T) *client.Graphite
+		sendFn   func(c *client.Graphite, metric client.Metric) error
 	}{
 		{
 			name: "default_config",
@@ -192,6 +211,25 @@ func Test_carbonreceiver_EndToEnd(t *testing.T) {
 				return c
 			},
 		},
+		{
+			name: "pickle",
+			configFn: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Parser = &protocol.Config{
+					Type:   "pickle",
+					Config: &protocol.PickleConfig{MaxMessageSize: 1024},
+				}
+				return cfg
+			},
+			clientFn: func(t *testing.T) *client.Graphite {
+				c, err := client.NewGraphite(client.TCP, addr)
+				require.NoError(t, err)
+				return c
+			},
+			sendFn: func(c *client.Graphite, metric client.Metric) error {
+				return c.SendMetricsPickle([]client.Metric{metric})
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -219,7 +257,11 @@ func Test_carbonreceiver_EndToEnd(t *testing.T) {
 				Value:     1.23,
 				Timestamp: ts,
 			}
-			err = snd.SendMetric(carbonMetric)
+			sendFn := tt.sendFn
+			if sendFn == nil {
+				sendFn = (*client.Graphite).SendMetric
+			}
+			err = sendFn(snd, carbonMetric)
 			require.NoError(t, err)
 
 			mr.WaitAllOnMetricsProcessedCalls()
@@ -15,8 +15,11 @@
 package client // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/carbonreceiver/transport/client"
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
 	"io"
+	"math"
 	"net"
 	"strconv"
 	"strings"
@@ -133,6 +136,47 @@ func (g *Graphite) SendMetrics(metrics []Metric) error {
 	return nil
 }
 
+// SendMetricsPickle method can be used to pass a set of metrics and have
+// them sent to the Graphite host as a single message of the pickle protocol.
+func (g *Graphite) SendMetricsPickle(metrics []Metric) error {
+	_, err := g.Conn.Write(encodePickle(metrics))
+	return err
+}
+
+// encodePickle encodes the metrics as a message of the Carbon pickle protocol
+// equivalent to the result of the Python code below, but using the BINFLOAT
+// opcode for all values:
+//
+//	payload = pickle.dumps([(name, (timestamp, value)), ...], protocol=2)
+//	message = struct.pack("!L", len(payload)) + payload
+func encodePickle(metrics []Metric) []byte {
+	var buf bytes.Buffer
+	var b [8]byte
+	buf.Write([]byte{0x80, 0x02, ']', '('}) // PROTO 2, EMPTY_LIST, MARK
+	for _, metric := range metrics {
+		buf.WriteByte('X') // BINUNICODE
+		binary.LittleEndian.PutUint32(b[:4], uint32(len(metric.Name)))
+		buf.Write(b[:4])
+		buf.WriteString(metric.Name)
+
+		buf.WriteByte(0x8a) // LONG1
+		buf.WriteByte(8)
+		binary.LittleEndian.PutUint64(b[:], uint64(metric.Timestamp.Unix()))
+		buf.Write(b[:])
+
+		buf.WriteByte('G') // BINFLOAT
+		binary.BigEndian.PutUint64(b[:], math.Float64bits(metric.Value))
+		buf.Write(b[:])
+
+		buf.Write([]byte{0x86, 0x86}) // TUPLE2, TUPLE2
+	}
+	buf.Write([]byte{'e', '.'}) // APPENDS, STOP
+
+	message := make([]byte, 4, 4+buf.Len())
+	binary.BigEndian.PutUint32(message, uint32(buf.Len()))
+	return append(message, buf.Bytes()...)
+}
+
 // Metric contains the metric fields expected by Graphite.
 type Metric struct {
 	Name      string
@@ -16,6 +16,7 @@ package transport
 
 import (
 	"runtime"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -35,6 +36,8 @@ func Test_Server_ListenAndServe(t *testing.T) {
 		name          string
 		buildServerFn func(addr string) (Server, error)
 		buildClientFn func(addr string) (*client.Graphite, error)
+		parserConfig  protocol.ParserConfig
+		sendFn        func(gc *client.Graphite, metric client.Metric) error
 	}{
 		{
 			name: "tcp",
@@ -45,6 +48,19 @@ func Test_Server_ListenAndServe(t *testing.T) {
 				return client.NewGraphite(client.TCP, addr)
 			},
 		},
+		{
+			name: "tcp_pickle",
+			buildServerFn: func(addr string) (Server, error) {
+				return NewTCPServer(addr, 1*time.Second)
+			},
+			buildClientFn: func(addr string) (*client.Graphite, error) {
+				return client.NewGraphite(client.TCP, addr)
+			},
+			parserConfig: &protocol.PickleConfig{MaxMessageSize: 1024},
+			sendFn: func(gc *client.Graphite, metric client.Metric) error {
+				return gc.SendMetricsPickle([]client.Metric{metric})
+			},
+		},
 		{
 			name:          "udp",
 			buildServerFn: NewUDPServer,
@@ -55,14 +71,18 @@ func Test_Server_ListenAndServe(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			addr := testutil.GetAvailableLocalNetworkAddress(t, tt.name)
+			addr := testutil.GetAvailableLocalNetworkAddress(t, strings.TrimSuffix(tt.name, "_pickle"))
 
 			svr, err := tt.buildServerFn(addr)
 			require.NoError(t, err)
 			require.NotNil(t, svr)
 
 			mc := new(consumertest.MetricsSink)
-			p, err := (&protocol.PlaintextConfig{}).BuildParser()
+			parserConfig := tt.parserConfig
+			if parserConfig == nil {
+				parserConfig = &protocol.PlaintextConfig{}
+			}
+			p, err := parserConfig.BuildParser()
 			require.NoError(t, err)
 			mr := NewMockReporter(1)
 
@@ -80,7 +100,11 @@ func Test_Server_ListenAndServe(t *testing.T) {
 			require.NotNil(t, gc)
 
 			ts := time.Date(2020, 2, 20, 20, 20, 20, 20, time.UTC)
-			err = gc.SendMetric(client.Metric{
+			sendFn := tt.sendFn
+			if sendFn == nil {
+				sendFn = (*client.Graphite).SendMetric
+			}
+			err = sendFn(gc, client.Metric{
 				Name: "test.metric", Value: 1, Timestamp: ts})
 			assert.NoError(t, err)
 			runtime.Gosched()
@@ -145,6 +145,11 @@ func (t *tcpServer) handleConnection(
 	nextConsumer consumer.Metrics,
 	conn net.Conn,
 ) {
+	if fp, ok := p.(protocol.FramedParser); ok {
+		t.handleFramedConnection(fp, nextConsumer, conn)
+		return
+	}
+
 	defer conn.Close()
 	var span *trace.Span
 	reader := bufio.NewReader(conn)
@@ -218,3 +223,52 @@ func (t *tcpServer) handleConnection(
 		}
 	}
 }
+
+// handleFramedConnection handles connections for parsers of protocols that
+// use a length header to delimit messages, e.g. the Carbon pickle protocol.
+func (t *tcpServer) handleFramedConnection(
+	p protocol.FramedParser,
+	nextConsumer consumer.Metrics,
+	conn net.Conn,
+) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		if err := conn.SetDeadline(time.Now().Add(t.idleTimeout)); err != nil {
+			t.reporter.OnDebugf(
+				"TCP Transport (%s) - conn.SetDeadLine error: %v",
+				t.ln.Addr(),
+				err)
+			return
+		}
+
+		frame, err := p.ReadFrame(reader)
+		if err != nil {
+			// Either the connection was closed, it timed out or the client
+			// sent an invalid header, in all cases the connection can't be
+			// used anymore.
+			t.reporter.OnDebugf(
+				"TCP Transport (%s) - error: %v",
+				t.ln.Addr(),
+				err)
+			return
+		}
+
+		ctx := t.reporter.OnDataReceived(context.Background())
+		metrics, err := p.ParseFrame(frame)
+		if err != nil {
+			t.reporter.OnTranslationError(ctx, err)
+		}
+		if len(metrics) == 0 {
+			continue
+		}
+
+		err = nextConsumer.ConsumeMetrics(ctx, internaldata.OCToMetrics(nil, nil, metrics))
+		t.reporter.OnMetricsProcessed(ctx, len(metrics), err)
+		if err != nil {
+			// See handleConnection, closing the connection is the only way
+			// to report the error back to the client.
+			return
+		}
+	}
+}
@@ -15,10 +15,14 @@
 package filelogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filelogreceiver"
 
 import (
+	"sync"
+
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/file"
 )
@@ -28,8 +32,15 @@ const (
 	stability = component.StabilityLevelAlpha
 )
 
+var once sync.Once
+
 // NewFactory creates a factory for filelog receiver
 func NewFactory() component.ReceiverFactory {
+	once.Do(func() {
+		// TODO: as with other -contrib factories registering metrics, this is causing the error being ignored
+		_ = view.Register(fileconsumer.MetricViews()...)
+	})
+
 	return adapter.NewFactory(ReceiverType{}, stability)
 }
 
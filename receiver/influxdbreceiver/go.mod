@@ -9,13 +9,11 @@ require (
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/common v0.63.0
 	go.opentelemetry.io/collector v0.63.0
 	go.uber.org/zap v1.23.0
-
 )
 
 require (
 	github.com/felixge/httpsnoop v1.0.3 // indirect
 	github.com/frankban/quicktest v1.14.0 // indirect
-	github.com/fsnotify/fsnotify v1.6.0 // indirect
 	github.com/go-logr/logr v1.2.3 // indirect
 	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/gogo/protobuf v1.3.2 // indirect
@@ -30,6 +30,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/connctx"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/sanitize"
 )
 
@@ -77,6 +78,7 @@ func (r *metricsReceiver) Start(_ context.Context, host component.Host) error {
 	if err != nil {
 		return err
 	}
+	r.server.ConnContext = connctx.ConnContext
 	go func() {
 		defer r.wg.Done()
 		if errHTTP := r.server.Serve(ln); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
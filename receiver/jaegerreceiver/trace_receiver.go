@@ -49,6 +49,7 @@ import (
 	"go.uber.org/multierr"
 	"google.golang.org/grpc"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/connctx"
 	jaegertranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/jaeger"
 )
 
@@ -381,6 +382,7 @@ func (jr *jReceiver) startCollector(host component.Host) error {
 		if err != nil {
 			return err
 		}
+		jr.collectorServer.ConnContext = connctx.ConnContext
 
 		jr.goroutines.Add(1)
 		go func() {
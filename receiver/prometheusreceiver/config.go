@@ -34,6 +34,7 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/confmap"
 	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 const (
@@ -59,6 +60,10 @@ type Config struct {
 	// in incorrect rate calculations.
 	UseStartTimeMetric   bool   `mapstructure:"use_start_time_metric"`
 	StartTimeMetricRegex string `mapstructure:"start_time_metric_regex"`
+	// EnableProtobufNegotiation enables negotiating the protobuf exposition format with
+	// scrape targets. Native histograms are only exposed in the protobuf format, and are
+	// converted into exponential histograms.
+	EnableProtobufNegotiation bool `mapstructure:"enable_protobuf_negotiation"`
 
 	TargetAllocator *targetAllocator `mapstructure:"target_allocator"`
 
@@ -72,6 +77,9 @@ type targetAllocator struct {
 	Endpoint    string        `mapstructure:"endpoint"`
 	Interval    time.Duration `mapstructure:"interval"`
 	CollectorID string        `mapstructure:"collector_id"`
+	// Embedded shards the targets of the scrape configs among the collector replicas found by the peer
+	// discovery, instead of retrieving the jobs and targets from the target allocator at Endpoint.
+	Embedded *embeddedAllocator `mapstructure:"embedded"`
 	// ConfigPlaceholder is just an entry to make the configuration pass a check
 	// that requires that all keys present in the config actually exist on the
 	// structure, ie.: it will error if an unknown key is present.
@@ -79,6 +87,25 @@ type targetAllocator struct {
 	HTTPSDConfig      *promHTTP.SDConfig `mapstructure:"-"`
 }
 
+type embeddedAllocator struct {
+	// DNS discovers the collector replicas from the A or AAAA records of a DNS name, e.g. a headless service.
+	DNS *dnsPeerDiscovery `mapstructure:"dns"`
+	// Kubernetes discovers the collector replicas from the running pods matching a label selector.
+	Kubernetes *kubernetesPeerDiscovery `mapstructure:"kubernetes"`
+}
+
+type dnsPeerDiscovery struct {
+	Name string `mapstructure:"name"`
+	// Type is the type of the DNS records, A or AAAA. Defaults to A.
+	Type string `mapstructure:"type"`
+}
+
+type kubernetesPeerDiscovery struct {
+	// Namespace of the collector pods. Defaults to the namespace of the collector.
+	Namespace     string `mapstructure:"namespace"`
+	LabelSelector string `mapstructure:"label_selector"`
+}
+
 var _ config.Receiver = (*Config)(nil)
 var _ confmap.Unmarshaler = (*Config)(nil)
 
@@ -241,6 +268,9 @@ func (cfg *Config) validateTargetAllocatorConfig() error {
 	if targetAllocatorConfig == nil {
 		return nil
 	}
+	if targetAllocatorConfig.Embedded != nil {
+		return targetAllocatorConfig.validateEmbedded(cfg.PrometheusConfig)
+	}
 	// ensure valid endpoint
 	if _, err := url.ParseRequestURI(targetAllocatorConfig.Endpoint); err != nil {
 		return fmt.Errorf("TargetAllocator endpoint is not valid: %s", targetAllocatorConfig.Endpoint)
@@ -253,6 +283,41 @@ func (cfg *Config) validateTargetAllocatorConfig() error {
 	return nil
 }
 
+func (ta *targetAllocator) validateEmbedded(promConfig *promconfig.Config) error {
+	if ta.Endpoint != "" {
+		return errors.New("TargetAllocator endpoint cannot be used with the embedded target allocation")
+	}
+	if promConfig == nil || len(promConfig.ScrapeConfigs) == 0 {
+		return errors.New("embedded target allocation requires Prometheus scrape_configs")
+	}
+	// the collector ID is optional, the replica identifies itself from its pod name or IP address by default
+	if strings.Contains(ta.CollectorID, "${") {
+		return fmt.Errorf("CollectorID is not a valid ID")
+	}
+
+	embedded := ta.Embedded
+	if (embedded.DNS == nil) == (embedded.Kubernetes == nil) {
+		return errors.New("embedded target allocation requires exactly one of dns or kubernetes peer discovery")
+	}
+	if embedded.DNS != nil {
+		if embedded.DNS.Name == "" {
+			return errors.New("embedded target allocation DNS name must be set")
+		}
+		if t := embedded.DNS.Type; t != "" && t != "A" && t != "AAAA" {
+			return fmt.Errorf("embedded target allocation DNS record type %q is not supported, must be A or AAAA", t)
+		}
+	}
+	if embedded.Kubernetes != nil {
+		if embedded.Kubernetes.LabelSelector == "" {
+			return errors.New("embedded target allocation Kubernetes label_selector must be set")
+		}
+		if _, err := labels.Parse(embedded.Kubernetes.LabelSelector); err != nil {
+			return fmt.Errorf("embedded target allocation Kubernetes label_selector is not valid: %w", err)
+		}
+	}
+	return nil
+}
+
 // Unmarshal a config.Parser into the config struct.
 func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
 	if componentParser == nil {
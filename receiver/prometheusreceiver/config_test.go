@@ -51,6 +51,7 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, time.Duration(r1.PrometheusConfig.ScrapeConfigs[0].ScrapeInterval), 5*time.Second)
 	assert.Equal(t, r1.UseStartTimeMetric, true)
 	assert.Equal(t, r1.StartTimeMetricRegex, "^(.+_)*process_start_time_seconds$")
+	assert.True(t, r1.EnableProtobufNegotiation)
 
 	assert.Equal(t, "http://my-targetallocator-service", r1.TargetAllocator.Endpoint)
 	assert.Equal(t, 30*time.Second, r1.TargetAllocator.Interval)
@@ -102,6 +103,55 @@ func TestLoadTargetAllocatorConfig(t *testing.T) {
 	assert.Equal(t, promModel.Duration(5*time.Second), r2.PrometheusConfig.ScrapeConfigs[0].ScrapeInterval)
 }
 
+func TestLoadEmbeddedTargetAllocatorConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config_target_allocator_embedded.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+
+	cfg := factory.CreateDefaultConfig()
+	sub, err := cm.Sub(config.NewComponentIDWithName(typeStr, "dns").String())
+	require.NoError(t, err)
+	require.NoError(t, config.UnmarshalReceiver(sub, cfg))
+	require.NoError(t, cfg.Validate())
+
+	r0 := cfg.(*Config)
+	assert.Equal(t, 15*time.Second, r0.TargetAllocator.Interval)
+	assert.Equal(t, &embeddedAllocator{
+		DNS: &dnsPeerDiscovery{Name: "otel-collector-headless.monitoring.svc.cluster.local"},
+	}, r0.TargetAllocator.Embedded)
+
+	cfg = factory.CreateDefaultConfig()
+	sub, err = cm.Sub(config.NewComponentIDWithName(typeStr, "kubernetes").String())
+	require.NoError(t, err)
+	require.NoError(t, config.UnmarshalReceiver(sub, cfg))
+	require.NoError(t, cfg.Validate())
+
+	r1 := cfg.(*Config)
+	assert.Equal(t, "collector-1", r1.TargetAllocator.CollectorID)
+	assert.Equal(t, &embeddedAllocator{
+		Kubernetes: &kubernetesPeerDiscovery{Namespace: "monitoring", LabelSelector: "app.kubernetes.io/name=otel-collector"},
+	}, r1.TargetAllocator.Embedded)
+
+	invalid := map[string]string{
+		"noScrape":          "embedded target allocation requires Prometheus scrape_configs",
+		"withEndpoint":      "TargetAllocator endpoint cannot be used with the embedded target allocation",
+		"bothDiscoveries":   "embedded target allocation requires exactly one of dns or kubernetes peer discovery",
+		"invalidRecordType": `embedded target allocation DNS record type "SRV" is not supported, must be A or AAAA`,
+		"invalidSelector":   "embedded target allocation Kubernetes label_selector is not valid",
+	}
+	for name, expectedErr := range invalid {
+		t.Run(name, func(t *testing.T) {
+			cfg := factory.CreateDefaultConfig()
+			sub, err := cm.Sub(config.NewComponentIDWithName(typeStr, name).String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalReceiver(sub, cfg))
+			err = cfg.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), expectedErr)
+		})
+	}
+}
+
 func TestLoadConfigFailsOnUnknownSection(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "invalid-config-section.yaml"))
 	require.NoError(t, err)
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver"
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/discovery"
+	"github.com/prometheus/prometheus/discovery/dns"
+	"github.com/prometheus/prometheus/discovery/kubernetes"
+	"github.com/prometheus/prometheus/discovery/targetgroup"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver/internal"
+)
+
+const (
+	// defaultPeerRefreshInterval is the interval at which the DNS peer discovery is refreshed
+	// when the target allocator interval is not set.
+	defaultPeerRefreshInterval = 30 * time.Second
+	// peerDiscoveryJob is the name under which the peer discovery config is applied.
+	peerDiscoveryJob = "collector-replicas"
+
+	podNameLabel  = model.MetaLabelPrefix + "kubernetes_pod_name"
+	podPhaseLabel = model.MetaLabelPrefix + "kubernetes_pod_phase"
+)
+
+// startEmbeddedAllocator starts discovering the collector replicas, and returns the channel of the
+// targets of targetsCh that are assigned to this replica.
+func (r *pReceiver) startEmbeddedAllocator(ctx context.Context, host component.Host, logger log.Logger, targetsCh <-chan map[string][]*targetgroup.Group) (<-chan map[string][]*targetgroup.Group, error) {
+	allocConf := r.cfg.TargetAllocator
+	self, err := embeddedCollectorID(allocConf)
+	if err != nil {
+		return nil, err
+	}
+	sdConfig, peersFn := peerDiscoveryConfig(allocConf)
+
+	peerManager := discovery.NewManager(ctx, logger, discovery.Name("peers"))
+	if err = peerManager.ApplyConfig(map[string]discovery.Configs{peerDiscoveryJob: {sdConfig}}); err != nil {
+		return nil, err
+	}
+	go func() {
+		r.settings.Logger.Info("Starting collector replicas discovery", zap.String("collector_id", self))
+		if err := peerManager.Run(); err != nil {
+			r.settings.Logger.Error("Collector replicas discovery failed", zap.Error(err))
+			host.ReportFatalError(err)
+		}
+	}()
+
+	peersCh := make(chan []string)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case groups := <-peerManager.SyncCh():
+				select {
+				case <-ctx.Done():
+					return
+				case peersCh <- peersFn(groups[peerDiscoveryJob]):
+				}
+			}
+		}
+	}()
+
+	sharder := internal.NewTargetSharder(self, r.settings.Logger)
+	go sharder.Run(ctx, targetsCh, peersCh)
+	return sharder.SyncCh(), nil
+}
+
+// peerDiscoveryConfig returns the service discovery config finding the collector replicas, and the
+// function extracting the replica IDs from the discovered target groups.
+func peerDiscoveryConfig(allocConf *targetAllocator) (discovery.Config, func([]*targetgroup.Group) []string) {
+	if k8s := allocConf.Embedded.Kubernetes; k8s != nil {
+		sdConfig := kubernetes.DefaultSDConfig
+		sdConfig.Role = kubernetes.RolePod
+		sdConfig.Selectors = []kubernetes.SelectorConfig{{Role: kubernetes.RolePod, Label: k8s.LabelSelector}}
+		if k8s.Namespace != "" {
+			sdConfig.NamespaceDiscovery.Names = []string{k8s.Namespace}
+		} else {
+			sdConfig.NamespaceDiscovery.IncludeOwnNamespace = true
+		}
+		return &sdConfig, kubernetesPeers
+	}
+
+	sdConfig := dns.DefaultSDConfig
+	sdConfig.Names = []string{allocConf.Embedded.DNS.Name}
+	sdConfig.Type = dnsRecordType(allocConf.Embedded.DNS)
+	// the port is required for A and AAAA records, it is not part of the replica IDs
+	sdConfig.Port = 1
+	sdConfig.RefreshInterval = model.Duration(defaultPeerRefreshInterval)
+	if allocConf.Interval > 0 {
+		sdConfig.RefreshInterval = model.Duration(allocConf.Interval)
+	}
+	return &sdConfig, dnsPeers
+}
+
+func dnsRecordType(cfg *dnsPeerDiscovery) string {
+	if cfg.Type == "" {
+		return "A"
+	}
+	return cfg.Type
+}
+
+// kubernetesPeers returns the names of the running pods.
+func kubernetesPeers(groups []*targetgroup.Group) []string {
+	var peers []string
+	for _, group := range groups {
+		if group == nil || len(group.Targets) == 0 {
+			// the pod was deleted
+			continue
+		}
+		if group.Labels[podPhaseLabel] != "Running" {
+			continue
+		}
+		if name := string(group.Labels[podNameLabel]); name != "" {
+			peers = append(peers, name)
+		}
+	}
+	sort.Strings(peers)
+	return peers
+}
+
+// dnsPeers returns the resolved IP addresses.
+func dnsPeers(groups []*targetgroup.Group) []string {
+	var peers []string
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+		for _, target := range group.Targets {
+			host, _, err := net.SplitHostPort(string(target[model.AddressLabel]))
+			if err != nil {
+				continue
+			}
+			peers = append(peers, host)
+		}
+	}
+	sort.Strings(peers)
+	return peers
+}
+
+// embeddedCollectorID returns the ID of this replica among the discovered replicas: the configured
+// collector ID, the pod name for the Kubernetes discovery or the IP address for the DNS discovery.
+func embeddedCollectorID(allocConf *targetAllocator) (string, error) {
+	if allocConf.CollectorID != "" {
+		return allocConf.CollectorID, nil
+	}
+	if allocConf.Embedded.Kubernetes != nil {
+		// the hostname of a pod is its name
+		return os.Hostname()
+	}
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return "", err
+	}
+	return localIP(addrs, dnsRecordType(allocConf.Embedded.DNS) == "AAAA")
+}
+
+func localIP(addrs []net.Addr, ipv6 bool) (string, error) {
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || ipNet.IP.IsLoopback() || ipNet.IP.IsLinkLocalUnicast() {
+			continue
+		}
+		if (ipNet.IP.To4() == nil) == ipv6 {
+			return ipNet.IP.String(), nil
+		}
+	}
+	return "", errors.New("no IP address found to identify the collector among its replicas, set collector_id")
+}
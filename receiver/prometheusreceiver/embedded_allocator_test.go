@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/discovery/dns"
+	"github.com/prometheus/prometheus/discovery/kubernetes"
+	"github.com/prometheus/prometheus/discovery/targetgroup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerDiscoveryConfig(t *testing.T) {
+	sdConfig, _ := peerDiscoveryConfig(&targetAllocator{
+		Interval: 10 * time.Second,
+		Embedded: &embeddedAllocator{DNS: &dnsPeerDiscovery{Name: "otel-collector-headless", Type: "AAAA"}},
+	})
+	dnsConfig, ok := sdConfig.(*dns.SDConfig)
+	require.True(t, ok)
+	assert.Equal(t, []string{"otel-collector-headless"}, dnsConfig.Names)
+	assert.Equal(t, "AAAA", dnsConfig.Type)
+	assert.Equal(t, model.Duration(10*time.Second), dnsConfig.RefreshInterval)
+
+	sdConfig, _ = peerDiscoveryConfig(&targetAllocator{
+		Embedded: &embeddedAllocator{Kubernetes: &kubernetesPeerDiscovery{LabelSelector: "app=otel-collector"}},
+	})
+	k8sConfig, ok := sdConfig.(*kubernetes.SDConfig)
+	require.True(t, ok)
+	assert.Equal(t, kubernetes.RolePod, k8sConfig.Role)
+	assert.Equal(t, []kubernetes.SelectorConfig{{Role: kubernetes.RolePod, Label: "app=otel-collector"}}, k8sConfig.Selectors)
+	assert.True(t, k8sConfig.NamespaceDiscovery.IncludeOwnNamespace)
+}
+
+func TestKubernetesPeers(t *testing.T) {
+	pod := func(name, phase string, targets int) *targetgroup.Group {
+		group := &targetgroup.Group{Labels: model.LabelSet{podNameLabel: model.LabelValue(name), podPhaseLabel: model.LabelValue(phase)}}
+		for i := 0; i < targets; i++ {
+			group.Targets = append(group.Targets, model.LabelSet{model.AddressLabel: "10.0.0.1:8888"})
+		}
+		return group
+	}
+
+	peers := kubernetesPeers([]*targetgroup.Group{
+		pod("collector-1", "Running", 2),
+		pod("collector-0", "Running", 1),
+		pod("collector-2", "Pending", 1),
+		pod("collector-3", "Running", 0),
+		nil,
+	})
+	assert.Equal(t, []string{"collector-0", "collector-1"}, peers)
+}
+
+func TestDNSPeers(t *testing.T) {
+	peers := dnsPeers([]*targetgroup.Group{{
+		Targets: []model.LabelSet{
+			{model.AddressLabel: "10.0.0.2:1"},
+			{model.AddressLabel: "[fd00::1]:1"},
+			{model.AddressLabel: "10.0.0.1:1"},
+		},
+	}})
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2", "fd00::1"}, peers)
+}
+
+func TestEmbeddedCollectorID(t *testing.T) {
+	id, err := embeddedCollectorID(&targetAllocator{
+		CollectorID: "collector-1",
+		Embedded:    &embeddedAllocator{DNS: &dnsPeerDiscovery{Name: "otel-collector-headless"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "collector-1", id)
+
+	addrs := []net.Addr{
+		&net.IPNet{IP: net.ParseIP("127.0.0.1")},
+		&net.IPNet{IP: net.ParseIP("fe80::1")},
+		&net.IPNet{IP: net.ParseIP("fd00::1")},
+		&net.IPNet{IP: net.ParseIP("10.0.0.1")},
+	}
+	ip, err := localIP(addrs, false)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", ip)
+
+	ip, err = localIP(addrs, true)
+	require.NoError(t, err)
+	assert.Equal(t, "fd00::1", ip)
+
+	_, err = localIP(addrs[:2], false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver/internal"
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+// virtualNodesPerMember is the number of points each member owns on the ring. More points give a more
+// even distribution of the keys at the cost of a larger ring.
+const virtualNodesPerMember = 128
+
+// hashRing assigns keys to members using consistent hashing: when a member joins or leaves, only the
+// keys it owns, or the keys it takes over, move to a different member.
+type hashRing struct {
+	points  []uint64
+	members map[uint64]string
+}
+
+func newHashRing(members []string) *hashRing {
+	r := &hashRing{
+		points:  make([]uint64, 0, len(members)*virtualNodesPerMember),
+		members: make(map[uint64]string, len(members)*virtualNodesPerMember),
+	}
+	for _, member := range members {
+		for i := 0; i < virtualNodesPerMember; i++ {
+			point := xxhash.Sum64String(member + "#" + strconv.Itoa(i))
+			if owner, ok := r.members[point]; ok {
+				// keep the ownership of colliding points independent of the order of the members
+				if member < owner {
+					r.members[point] = member
+				}
+				continue
+			}
+			r.points = append(r.points, point)
+			r.members[point] = member
+		}
+	}
+	sort.Slice(r.points, func(i, j int) bool { return r.points[i] < r.points[j] })
+	return r
+}
+
+// owner returns the member that owns the key, or an empty string if the ring has no members.
+func (r *hashRing) owner(key string) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+	h := xxhash.Sum64String(key)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.members[r.points[i]]
+}
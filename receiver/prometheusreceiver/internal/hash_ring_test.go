@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashRingEmpty(t *testing.T) {
+	assert.Equal(t, "", newHashRing(nil).owner("key"))
+}
+
+func TestHashRingOrderIndependent(t *testing.T) {
+	r1 := newHashRing([]string{"a", "b", "c"})
+	r2 := newHashRing([]string{"c", "a", "b"})
+	for i := 0; i < 1000; i++ {
+		key := strconv.Itoa(i)
+		assert.Equal(t, r1.owner(key), r2.owner(key))
+	}
+}
+
+func TestHashRingDistribution(t *testing.T) {
+	members := []string{"collector-0", "collector-1", "collector-2"}
+	r := newHashRing(members)
+	counts := map[string]int{}
+	for i := 0; i < 3000; i++ {
+		counts[r.owner("job/"+strconv.Itoa(i))]++
+	}
+	for _, member := range members {
+		assert.InDelta(t, 1000, counts[member], 250, member)
+	}
+}
+
+func TestHashRingMemberAdded(t *testing.T) {
+	before := newHashRing([]string{"collector-0", "collector-1", "collector-2"})
+	after := newHashRing([]string{"collector-0", "collector-1", "collector-2", "collector-3"})
+	moved := 0
+	for i := 0; i < 3000; i++ {
+		key := "job/" + strconv.Itoa(i)
+		if before.owner(key) != after.owner(key) {
+			// keys only move to the new member
+			assert.Equal(t, "collector-3", after.owner(key))
+			moved++
+		}
+	}
+	assert.InDelta(t, 750, moved, 250)
+}
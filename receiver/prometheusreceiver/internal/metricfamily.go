@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/histogram"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/value"
 	"github.com/prometheus/prometheus/scrape"
@@ -57,6 +58,7 @@ type metricGroup struct {
 	hasSum       bool
 	value        float64
 	complexValue []*dataPoint
+	hValue       *histogram.Histogram
 	exemplars    pmetric.ExemplarSlice
 }
 
@@ -152,6 +154,60 @@ func (mg *metricGroup) toDistributionPoint(dest pmetric.HistogramDataPointSlice)
 	mg.setExemplars(point.Exemplars())
 }
 
+func (mg *metricGroup) toExponentialHistogramDataPoint(dest pmetric.ExponentialHistogramDataPointSlice) {
+	if mg.hValue == nil {
+		return
+	}
+
+	point := dest.AppendEmpty()
+	if value.IsStaleNaN(mg.hValue.Sum) {
+		point.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	} else {
+		point.SetScale(mg.hValue.Schema)
+		point.SetCount(mg.hValue.Count)
+		point.SetSum(mg.hValue.Sum)
+		point.SetZeroCount(mg.hValue.ZeroCount)
+		convertBucketsLayout(mg.hValue.PositiveSpans, mg.hValue.PositiveBuckets, point.Positive())
+		convertBucketsLayout(mg.hValue.NegativeSpans, mg.hValue.NegativeBuckets, point.Negative())
+	}
+
+	// The timestamp MUST be in retrieved from milliseconds and converted to nanoseconds.
+	tsNanos := timestampFromMs(mg.ts)
+	point.SetStartTimestamp(tsNanos) // metrics_adjuster adjusts the startTimestamp to the initial scrape timestamp
+	point.SetTimestamp(tsNanos)
+	populateAttributes(pmetric.MetricTypeExponentialHistogram, mg.ls, point.Attributes())
+	mg.setExemplars(point.Exemplars())
+}
+
+// convertBucketsLayout converts the sparse, delta encoded buckets of a native histogram
+// into the dense buckets of an exponential histogram. The bucket with index i in
+// Prometheus covers the same range as the bucket with index i-1 in OpenTelemetry.
+func convertBucketsLayout(spans []histogram.Span, deltas []int64, buckets pmetric.ExponentialHistogramDataPointBuckets) {
+	if len(spans) == 0 {
+		return
+	}
+
+	bucketCounts := make([]uint64, 0, len(deltas))
+	var count int64
+	pos := 0
+	for i, span := range spans {
+		if i > 0 {
+			// Gaps between spans are empty buckets.
+			for j := int32(0); j < span.Offset; j++ {
+				bucketCounts = append(bucketCounts, 0)
+			}
+		}
+		for j := uint32(0); j < span.Length && pos < len(deltas); j++ {
+			count += deltas[pos]
+			bucketCounts = append(bucketCounts, uint64(count))
+			pos++
+		}
+	}
+
+	buckets.SetOffset(spans[0].Offset - 1)
+	buckets.BucketCounts().FromRaw(bucketCounts)
+}
+
 func (mg *metricGroup) setExemplars(exemplars pmetric.ExemplarSlice) {
 	if mg == nil {
 		return
@@ -274,6 +330,11 @@ func (mf *metricFamily) Add(metricName string, ls labels.Labels, t int64, v floa
 			mg.ts = t
 			mg.count = v
 			mg.hasCount = true
+		case mf.mtype == pmetric.MetricTypeHistogram && metricName == mf.name && value.IsStaleNaN(v):
+			// Classic histograms have no series named after the family itself, so this is
+			// the staleness marker of a native histogram which is no longer exposed.
+			mf.mtype = pmetric.MetricTypeExponentialHistogram
+			mg.hValue = &histogram.Histogram{Sum: v}
 		default:
 			boundary, err := getBoundary(mf.mtype, ls)
 			if err != nil {
@@ -281,6 +342,12 @@ func (mf *metricFamily) Add(metricName string, ls labels.Labels, t int64, v floa
 			}
 			mg.complexValue = append(mg.complexValue, &dataPoint{value: v, boundary: boundary})
 		}
+	case pmetric.MetricTypeExponentialHistogram:
+		// Native histograms are only ever marked as stale with a float sample.
+		if !value.IsStaleNaN(v) {
+			return fmt.Errorf("unexpected float sample for native histogram metric %v", metricName)
+		}
+		mg.hValue = &histogram.Histogram{Sum: v}
 	default:
 		mg.value = v
 	}
@@ -288,6 +355,19 @@ func (mf *metricFamily) Add(metricName string, ls labels.Labels, t int64, v floa
 	return nil
 }
 
+// addExponentialHistogram adds a native histogram to the family, which turns the family
+// into an exponential histogram.
+func (mf *metricFamily) addExponentialHistogram(metricName string, ls labels.Labels, t int64, h *histogram.Histogram) error {
+	mf.mtype = pmetric.MetricTypeExponentialHistogram
+	groupKey := mf.getGroupKey(ls)
+	mg := mf.loadMetricGroupOrCreate(groupKey, ls, t)
+	if mg.ts != t {
+		return fmt.Errorf("inconsistent timestamps on metric points for metric %v", metricName)
+	}
+	mg.hValue = h
+	return nil
+}
+
 func (mf *metricFamily) appendMetric(metrics pmetric.MetricSlice) {
 	metric := pmetric.NewMetric()
 	metric.SetName(mf.name)
@@ -306,6 +386,15 @@ func (mf *metricFamily) appendMetric(metrics pmetric.MetricSlice) {
 		}
 		pointCount = hdpL.Len()
 
+	case pmetric.MetricTypeExponentialHistogram:
+		histogram := metric.SetEmptyExponentialHistogram()
+		histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		hdpL := histogram.DataPoints()
+		for _, mg := range mf.groupOrders {
+			mg.toExponentialHistogramDataPoint(hdpL)
+		}
+		pointCount = hdpL.Len()
+
 	case pmetric.MetricTypeSummary:
 		summary := metric.SetEmptySummary()
 		sdpL := summary.DataPoints()
@@ -16,14 +16,14 @@ package internal // import "github.com/open-telemetry/opentelemetry-collector-co
 
 import (
 	"errors"
-	"strings"
 	"sync"
 	"time"
 
-	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	semconv "go.opentelemetry.io/collector/semconv/v1.6.1"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/metricsadjuster"
 )
 
 // Notes on garbage collection (gc):
@@ -38,18 +38,13 @@ import (
 // Some jobs that the Prometheus receiver is collecting from may export timeseries based on metrics
 // from other jobs (e.g. cAdvisor). In order to keep the timeseriesMap from leaking memory for entries
 // of no-longer existing jobs, the timeseriesMap for each job needs to remove entries that haven't
-// been accessed for a long period of time.
-//
-// The gc strategy uses a standard mark-and-sweep approach - each time a timeseriesMap is accessed,
-// it is marked. Similarly, each time a timeseriesInfo is accessed, it is also marked.
+// been accessed for a long period of time. The timeseries tracking and its mark-and-sweep gc are
+// implemented by metricsadjuster.TimeseriesMap.
 //
 // At the end of each JobsMap.get(), if the last time the JobsMap was gc'd exceeds the 'gcInterval',
 // the JobsMap is locked and any timeseriesMaps that are unmarked are removed from the JobsMap
 // otherwise the timeseriesMap is gc'd
 //
-// The gc for the timeseriesMap is straightforward - the map is locked and, for each timeseriesInfo
-// in the map, if it has not been marked, it is removed otherwise it is unmarked.
-//
 // Alternative Strategies
 // 1. If the job-level gc doesn't run often enough, or runs too often, a separate go routine can
 //    be spawned at JobMap creation time that gc's at periodic intervals. This approach potentially
@@ -60,110 +55,6 @@ import (
 //    approach requires adding 'lastGC' Time and (potentially) a gcInterval duration to
 //    timeseriesMap so the current approach is used instead.
 
-// timeseriesInfo contains the information necessary to adjust from the initial point and to detect resets.
-type timeseriesInfo struct {
-	mark bool
-
-	number    numberInfo
-	histogram histogramInfo
-	summary   summaryInfo
-}
-
-type numberInfo struct {
-	startTime     pcommon.Timestamp
-	previousValue float64
-}
-
-type histogramInfo struct {
-	startTime     pcommon.Timestamp
-	previousCount uint64
-	previousSum   float64
-}
-
-type summaryInfo struct {
-	startTime     pcommon.Timestamp
-	previousCount uint64
-	previousSum   float64
-}
-
-type timeseriesKey struct {
-	name           string
-	attributes     string
-	aggTemporality pmetric.AggregationTemporality
-}
-
-// timeseriesMap maps from a timeseries instance (metric * label values) to the timeseries info for
-// the instance.
-type timeseriesMap struct {
-	sync.RWMutex
-	// The mutex is used to protect access to the member fields. It is acquired for the entirety of
-	// AdjustMetricSlice() and also acquired by gc().
-
-	mark   bool
-	tsiMap map[timeseriesKey]*timeseriesInfo
-}
-
-// Get the timeseriesInfo for the timeseries associated with the metric and label values.
-func (tsm *timeseriesMap) get(metric pmetric.Metric, kv pcommon.Map) (*timeseriesInfo, bool) {
-	// This should only be invoked be functions called (directly or indirectly) by AdjustMetricSlice().
-	// The lock protecting tsm.tsiMap is acquired there.
-	name := metric.Name()
-	key := timeseriesKey{
-		name:       name,
-		attributes: getAttributesSignature(kv),
-	}
-	if metric.Type() == pmetric.MetricTypeHistogram {
-		// There are 2 types of Histograms whose aggregation temporality needs distinguishing:
-		// * CumulativeHistogram
-		// * GaugeHistogram
-		key.aggTemporality = metric.Histogram().AggregationTemporality()
-	}
-
-	tsm.mark = true
-	tsi, ok := tsm.tsiMap[key]
-	if !ok {
-		tsi = &timeseriesInfo{}
-		tsm.tsiMap[key] = tsi
-	}
-	tsi.mark = true
-	return tsi, ok
-}
-
-// Create a unique timeseries signature consisting of the metric name and label values.
-func getAttributesSignature(kv pcommon.Map) string {
-	labelValues := make([]string, 0, kv.Len())
-	kv.Sort().Range(func(_ string, attrValue pcommon.Value) bool {
-		value := attrValue.Str()
-		if value != "" {
-			labelValues = append(labelValues, value)
-		}
-		return true
-	})
-	return strings.Join(labelValues, ",")
-}
-
-// Remove timeseries that have aged out.
-func (tsm *timeseriesMap) gc() {
-	tsm.Lock()
-	defer tsm.Unlock()
-	// this shouldn't happen under the current gc() strategy
-	if !tsm.mark {
-		return
-	}
-	for ts, tsi := range tsm.tsiMap {
-		if !tsi.mark {
-			delete(tsm.tsiMap, ts)
-		} else {
-			tsi.mark = false
-		}
-	}
-	tsm.mark = false
-}
-
-func newTimeseriesMap() *timeseriesMap {
-	return &timeseriesMap{mark: true, tsiMap: map[timeseriesKey]*timeseriesInfo{}}
-}
-
 // JobsMap maps from a job instance to a map of timeseries instances for the job.
 type JobsMap struct {
 	sync.RWMutex
@@ -172,12 +63,12 @@ type JobsMap struct {
 
 	gcInterval time.Duration
 	lastGC     time.Time
-	jobsMap    map[string]*timeseriesMap
+	jobsMap    map[string]*metricsadjuster.TimeseriesMap
 }
 
 // NewJobsMap creates a new (empty) JobsMap.
 func NewJobsMap(gcInterval time.Duration) *JobsMap {
-	return &JobsMap{gcInterval: gcInterval, lastGC: time.Now(), jobsMap: make(map[string]*timeseriesMap)}
+	return &JobsMap{gcInterval: gcInterval, lastGC: time.Now(), jobsMap: make(map[string]*metricsadjuster.TimeseriesMap)}
 }
 
 // Remove jobs and timeseries that have aged out.
@@ -188,14 +79,14 @@ func (jm *JobsMap) gc() {
 	if time.Since(jm.lastGC) > jm.gcInterval {
 		for sig, tsm := range jm.jobsMap {
 			tsm.RLock()
-			tsmNotMarked := !tsm.mark
+			tsmNotMarked := !tsm.IsMarked()
 			// take a read lock here, no need to get a full lock as we have a lock on the JobsMap
 			tsm.RUnlock()
 			if tsmNotMarked {
 				delete(jm.jobsMap, sig)
 			} else {
 				// a full lock will be obtained in here, if required.
-				tsm.gc()
+				tsm.GC()
 			}
 		}
 		jm.lastGC = time.Now()
@@ -211,7 +102,7 @@ func (jm *JobsMap) maybeGC() {
 	}
 }
 
-func (jm *JobsMap) get(job, instance string) *timeseriesMap {
+func (jm *JobsMap) get(job, instance string) *metricsadjuster.TimeseriesMap {
 	sig := job + ":" + instance
 	// a read locke is taken here as we will not need to modify jobsMap if the target timeseriesMap is available.
 	jm.RLock()
@@ -229,7 +120,7 @@ func (jm *JobsMap) get(job, instance string) *timeseriesMap {
 	if ok2 {
 		return tsm2
 	}
-	tsm2 = newTimeseriesMap()
+	tsm2 = metricsadjuster.NewTimeseriesMap()
 	jm.jobsMap[sig] = tsm2
 	return tsm2
 }
@@ -280,136 +171,12 @@ func (ma *initialPointAdjuster) AdjustMetrics(metrics pmetric.Metrics) error {
 			ilm := rm.ScopeMetrics().At(j)
 			for k := 0; k < ilm.Metrics().Len(); k++ {
 				metric := ilm.Metrics().At(k)
-				switch dataType := metric.Type(); dataType {
-				case pmetric.MetricTypeGauge:
-					// gauges don't need to be adjusted so no additional processing is necessary
-
-				case pmetric.MetricTypeHistogram:
-					adjustMetricHistogram(tsm, metric)
-
-				case pmetric.MetricTypeSummary:
-					adjustMetricSummary(tsm, metric)
-
-				case pmetric.MetricTypeSum:
-					adjustMetricSum(tsm, metric)
-
-				default:
+				if !tsm.AdjustMetric(metric) {
 					// this shouldn't happen
-					ma.logger.Info("Adjust - skipping unexpected point", zap.String("type", dataType.String()))
+					ma.logger.Info("Adjust - skipping unexpected point", zap.String("type", metric.Type().String()))
 				}
 			}
 		}
 	}
 	return nil
 }
-
-func adjustMetricHistogram(tsm *timeseriesMap, current pmetric.Metric) {
-	histogram := current.Histogram()
-	if histogram.AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
-		// Only dealing with CumulativeDistributions.
-		return
-	}
-
-	currentPoints := histogram.DataPoints()
-	for i := 0; i < currentPoints.Len(); i++ {
-		currentDist := currentPoints.At(i)
-		tsi, found := tsm.get(current, currentDist.Attributes())
-		if !found {
-			// initialize everything.
-			tsi.histogram.startTime = currentDist.StartTimestamp()
-			tsi.histogram.previousCount = currentDist.Count()
-			tsi.histogram.previousSum = currentDist.Sum()
-			continue
-		}
-
-		if currentDist.Flags().NoRecordedValue() {
-			// TODO: Investigate why this does not reset.
-			currentDist.SetStartTimestamp(tsi.histogram.startTime)
-			continue
-		}
-
-		if currentDist.Count() < tsi.histogram.previousCount || currentDist.Sum() < tsi.histogram.previousSum {
-			// reset re-initialize everything.
-			tsi.histogram.startTime = currentDist.StartTimestamp()
-			tsi.histogram.previousCount = currentDist.Count()
-			tsi.histogram.previousSum = currentDist.Sum()
-			continue
-		}
-
-		// Update only previous values.
-		tsi.histogram.previousCount = currentDist.Count()
-		tsi.histogram.previousSum = currentDist.Sum()
-		currentDist.SetStartTimestamp(tsi.histogram.startTime)
-	}
-}
-
-func adjustMetricSum(tsm *timeseriesMap, current pmetric.Metric) {
-	currentPoints := current.Sum().DataPoints()
-	for i := 0; i < currentPoints.Len(); i++ {
-		currentSum := currentPoints.At(i)
-		tsi, found := tsm.get(current, currentSum.Attributes())
-		if !found {
-			// initialize everything.
-			tsi.number.startTime = currentSum.StartTimestamp()
-			tsi.number.previousValue = currentSum.DoubleValue()
-			continue
-		}
-
-		if currentSum.Flags().NoRecordedValue() {
-			// TODO: Investigate why this does not reset.
-			currentSum.SetStartTimestamp(tsi.number.startTime)
-			continue
-		}
-
-		if currentSum.DoubleValue() < tsi.number.previousValue {
-			// reset re-initialize everything.
-			tsi.number.startTime = currentSum.StartTimestamp()
-			tsi.number.previousValue = currentSum.DoubleValue()
-			continue
-		}
-
-		// Update only previous values.
-		tsi.number.previousValue = currentSum.DoubleValue()
-		currentSum.SetStartTimestamp(tsi.number.startTime)
-	}
-}
-
-func adjustMetricSummary(tsm *timeseriesMap, current pmetric.Metric) {
-	currentPoints := current.Summary().DataPoints()
-
-	for i := 0; i < currentPoints.Len(); i++ {
-		currentSummary := currentPoints.At(i)
-		tsi, found := tsm.get(current, currentSummary.Attributes())
-		if !found {
-			// initialize everything.
-			tsi.summary.startTime = currentSummary.StartTimestamp()
-			tsi.summary.previousCount = currentSummary.Count()
-			tsi.summary.previousSum = currentSummary.Sum()
-			continue
-		}
-
-		if currentSummary.Flags().NoRecordedValue() {
-			// TODO: Investigate why this does not reset.
-			currentSummary.SetStartTimestamp(tsi.summary.startTime)
-			continue
-		}
-
-		if (currentSummary.Count() != 0 &&
-			tsi.summary.previousCount != 0 &&
-			currentSummary.Count() < tsi.summary.previousCount) ||
-			(currentSummary.Sum() != 0 &&
-				tsi.summary.previousSum != 0 &&
-				currentSummary.Sum() < tsi.summary.previousSum) {
-			// reset re-initialize everything.
-			tsi.summary.startTime = currentSummary.StartTimestamp()
-			tsi.summary.previousCount = currentSummary.Count()
-			tsi.summary.previousSum = currentSummary.Sum()
-			continue
-		}
-
-		// Update only previous values.
-		tsi.summary.previousCount = currentSummary.Count()
-		tsi.summary.previousSum = currentSummary.Sum()
-		currentSummary.SetStartTimestamp(tsi.summary.startTime)
-	}
-}
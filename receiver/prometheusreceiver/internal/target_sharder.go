@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver/internal"
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/discovery/targetgroup"
+	"go.uber.org/zap"
+)
+
+// TargetSharder sits between the discovery manager and the scrape manager, and only forwards the
+// targets that the hash ring of the collector replicas assigns to this replica. All the replicas
+// see the same discovered targets and the same peers, so every target is scraped by exactly one of them.
+type TargetSharder struct {
+	self   string
+	logger *zap.Logger
+	syncCh chan map[string][]*targetgroup.Group
+}
+
+// NewTargetSharder creates a TargetSharder for the replica identified by self.
+func NewTargetSharder(self string, logger *zap.Logger) *TargetSharder {
+	return &TargetSharder{
+		self:   self,
+		logger: logger,
+		syncCh: make(chan map[string][]*targetgroup.Group),
+	}
+}
+
+// SyncCh returns the channel of the target groups assigned to this replica, to be passed to the scrape manager.
+func (s *TargetSharder) SyncCh() <-chan map[string][]*targetgroup.Group {
+	return s.syncCh
+}
+
+// Run shards the target groups received from targetsCh among the replicas received from peersCh,
+// until the context is cancelled. Nothing is forwarded before the first list of peers is received,
+// so that the replicas don't all scrape every target while they discover each other.
+func (s *TargetSharder) Run(ctx context.Context, targetsCh <-chan map[string][]*targetgroup.Group, peersCh <-chan []string) {
+	var (
+		targets map[string][]*targetgroup.Group
+		members []string
+		ring    *hashRing
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case targets = <-targetsCh:
+		case peers := <-peersCh:
+			newMembers := s.members(peers)
+			if ring != nil && equalMembers(members, newMembers) {
+				continue
+			}
+			members = newMembers
+			ring = newHashRing(members)
+			s.logger.Info("Collector replicas changed, rebalancing targets", zap.Strings("replicas", members))
+		}
+		if targets == nil || ring == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case s.syncCh <- s.shard(ring, targets):
+		}
+	}
+}
+
+// members returns the sorted and deduplicated peers, always including this replica so that its
+// targets are still scraped while the peer discovery doesn't report it yet.
+func (s *TargetSharder) members(peers []string) []string {
+	set := map[string]struct{}{s.self: {}}
+	for _, peer := range peers {
+		set[peer] = struct{}{}
+	}
+	members := make([]string, 0, len(set))
+	for member := range set {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+	return members
+}
+
+func equalMembers(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// shard returns a copy of the target groups only containing the targets owned by this replica.
+// Groups left without targets are kept, so that the scrape manager stops the targets that moved away.
+func (s *TargetSharder) shard(ring *hashRing, targets map[string][]*targetgroup.Group) map[string][]*targetgroup.Group {
+	total, owned := 0, 0
+	sharded := make(map[string][]*targetgroup.Group, len(targets))
+	for job, groups := range targets {
+		shardedGroups := make([]*targetgroup.Group, 0, len(groups))
+		for _, group := range groups {
+			if group == nil {
+				continue
+			}
+			shardedGroup := &targetgroup.Group{Labels: group.Labels, Source: group.Source}
+			for _, target := range group.Targets {
+				total++
+				if ring.owner(targetKey(job, group, target)) == s.self {
+					owned++
+					shardedGroup.Targets = append(shardedGroup.Targets, target)
+				}
+			}
+			shardedGroups = append(shardedGroups, shardedGroup)
+		}
+		sharded[job] = shardedGroups
+	}
+	s.logger.Debug("Sharded targets", zap.Int("total", total), zap.Int("owned", owned))
+	return sharded
+}
+
+// targetKey identifies a target by its job and all of its discovered labels.
+func targetKey(job string, group *targetgroup.Group, target model.LabelSet) string {
+	return job + "/" + strconv.FormatUint(uint64(group.Labels.Merge(target).Fingerprint()), 16)
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/discovery/targetgroup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func testTargets(n int) map[string][]*targetgroup.Group {
+	group := &targetgroup.Group{
+		Source: "static/0",
+		Labels: model.LabelSet{"env": "test"},
+	}
+	for i := 0; i < n; i++ {
+		group.Targets = append(group.Targets, model.LabelSet{model.AddressLabel: model.LabelValue(fmt.Sprintf("10.0.0.%d:9090", i))})
+	}
+	return map[string][]*targetgroup.Group{"job": {group}}
+}
+
+func receive(t *testing.T, ch <-chan map[string][]*targetgroup.Group) map[string][]*targetgroup.Group {
+	select {
+	case targets := <-ch:
+		return targets
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "no targets received")
+		return nil
+	}
+}
+
+func TestTargetSharderSplitsTargets(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peers := []string{"collector-0", "collector-1"}
+	seen := map[model.LabelValue]string{}
+	for _, self := range peers {
+		targetsCh := make(chan map[string][]*targetgroup.Group)
+		peersCh := make(chan []string)
+		s := NewTargetSharder(self, zap.NewNop())
+		go s.Run(ctx, targetsCh, peersCh)
+
+		targetsCh <- testTargets(50)
+		peersCh <- peers
+
+		sharded := receive(t, s.SyncCh())
+		require.Len(t, sharded["job"], 1)
+		group := sharded["job"][0]
+		assert.Equal(t, "static/0", group.Source)
+		assert.Equal(t, model.LabelSet{"env": "test"}, group.Labels)
+		assert.NotEmpty(t, group.Targets)
+		for _, target := range group.Targets {
+			address := target[model.AddressLabel]
+			assert.NotContains(t, seen, address, "target scraped by two replicas")
+			seen[address] = self
+		}
+	}
+	assert.Len(t, seen, 50)
+}
+
+func TestTargetSharderWaitsForPeers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	targetsCh := make(chan map[string][]*targetgroup.Group)
+	peersCh := make(chan []string)
+	s := NewTargetSharder("collector-0", zap.NewNop())
+	go s.Run(ctx, targetsCh, peersCh)
+
+	targetsCh <- testTargets(10)
+	select {
+	case <-s.SyncCh():
+		require.Fail(t, "targets sent before the peers are known")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// this replica is not discovered yet, it is part of the replicas anyway
+	peersCh <- nil
+	sharded := receive(t, s.SyncCh())
+	assert.Len(t, sharded["job"][0].Targets, 10)
+}
+
+func TestTargetSharderRebalances(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	targetsCh := make(chan map[string][]*targetgroup.Group)
+	peersCh := make(chan []string)
+	s := NewTargetSharder("collector-0", zap.NewNop())
+	go s.Run(ctx, targetsCh, peersCh)
+
+	targetsCh <- testTargets(50)
+	peersCh <- []string{"collector-0"}
+	assert.Len(t, receive(t, s.SyncCh())["job"][0].Targets, 50)
+
+	// the same replicas don't trigger an update
+	peersCh <- []string{"collector-0"}
+	peersCh <- []string{"collector-0", "collector-1"}
+	owned := len(receive(t, s.SyncCh())["job"][0].Targets)
+	assert.Greater(t, owned, 0)
+	assert.Less(t, owned, 50)
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/histogram"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/metadata"
 	"github.com/prometheus/prometheus/model/value"
@@ -176,6 +177,40 @@ func (t *transaction) AppendExemplar(ref storage.SeriesRef, l labels.Labels, e e
 	return 0, nil
 }
 
+// AppendHistogram adds a native histogram, which is converted into an exponential histogram.
+// Native histograms are only scraped when protobuf negotiation is enabled.
+func (t *transaction) AppendHistogram(ref storage.SeriesRef, ls labels.Labels, atMs int64, h *histogram.Histogram) (storage.SeriesRef, error) {
+	select {
+	case <-t.ctx.Done():
+		return 0, errTransactionAborted
+	default:
+	}
+
+	if len(t.externalLabels) != 0 {
+		ls = append(ls, t.externalLabels...)
+		sort.Sort(ls)
+	}
+
+	if t.isNew {
+		if err := t.initTransaction(ls); err != nil {
+			return 0, err
+		}
+	}
+
+	if dupLabel, hasDup := ls.HasDuplicateLabelNames(); hasDup {
+		return 0, fmt.Errorf("invalid sample: non-unique label names: %q", dupLabel)
+	}
+
+	metricName := ls.Get(model.MetricNameLabel)
+	if metricName == "" {
+		return 0, errMetricNameNotFound
+	}
+
+	curMF := t.getOrCreateMetricFamily(metricName)
+
+	return 0, curMF.addExponentialHistogram(metricName, ls, atMs, h)
+}
+
 // getMetrics returns all metrics to the given slice.
 // The only error returned by this function is errNoDataToBuild.
 func (t *transaction) getMetrics(resource pcommon.Resource) (pmetric.Metrics, error) {
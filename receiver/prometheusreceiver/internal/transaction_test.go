@@ -17,13 +17,16 @@ package internal
 import (
 	"context"
 	"errors"
+	"math"
 	"testing"
 	"time"
 
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/histogram"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/metadata"
+	"github.com/prometheus/prometheus/model/value"
 	"github.com/prometheus/prometheus/scrape"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -176,6 +179,19 @@ func TestTransactionAppendHistogramNoLe(t *testing.T) {
 	require.ErrorIs(t, err, errEmptyLeLabel)
 }
 
+func TestTransactionAppendNativeHistogramNoMetricName(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
+
+	noMetricName := labels.FromStrings(
+		model.InstanceLabel, "0.0.0.0:8855",
+		model.JobLabel, "test",
+	)
+
+	_, err := tr.AppendHistogram(0, noMetricName, 1917, &histogram.Histogram{})
+	require.ErrorIs(t, err, errMetricNameNotFound)
+}
+
 func TestTransactionAppendSummaryNoQuantile(t *testing.T) {
 	sink := new(consumertest.MetricsSink)
 	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
@@ -1211,6 +1227,123 @@ func TestMetricBuilderHistogram(t *testing.T) {
 	}
 }
 
+func TestMetricBuilderNativeHistogram(t *testing.T) {
+	h := &histogram.Histogram{
+		Schema:        1,
+		ZeroThreshold: 0.001,
+		ZeroCount:     2,
+		Count:         15,
+		Sum:           42,
+		// Buckets 0, 1, 4 and 5.
+		PositiveSpans:   []histogram.Span{{Offset: 0, Length: 2}, {Offset: 2, Length: 2}},
+		PositiveBuckets: []int64{3, 1, -2, 0},
+		// Bucket -1.
+		NegativeSpans:   []histogram.Span{{Offset: -1, Length: 1}},
+		NegativeBuckets: []int64{3},
+	}
+
+	tests := []buildTestData{
+		{
+			name: "single item",
+			inputs: []*testScrapedPage{
+				{
+					pts: []*testDataPoint{
+						createHistogramDataPoint("hist_test", h, nil, "foo", "bar"),
+					},
+				},
+			},
+			wants: func() []pmetric.Metrics {
+				md0 := pmetric.NewMetrics()
+				mL0 := md0.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+				m0 := mL0.AppendEmpty()
+				m0.SetName("hist_test")
+				hist0 := m0.SetEmptyExponentialHistogram()
+				hist0.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				pt0 := hist0.DataPoints().AppendEmpty()
+				pt0.SetScale(1)
+				pt0.SetCount(15)
+				pt0.SetSum(42)
+				pt0.SetZeroCount(2)
+				pt0.Positive().SetOffset(-1)
+				pt0.Positive().BucketCounts().FromRaw([]uint64{3, 4, 0, 0, 2, 2})
+				pt0.Negative().SetOffset(-2)
+				pt0.Negative().BucketCounts().FromRaw([]uint64{3})
+				pt0.SetTimestamp(tsNanos)
+				pt0.SetStartTimestamp(startTimestamp)
+				pt0.Attributes().PutStr("foo", "bar")
+
+				return []pmetric.Metrics{md0}
+			},
+		},
+		{
+			name: "multi-groups",
+			inputs: []*testScrapedPage{
+				{
+					pts: []*testDataPoint{
+						createHistogramDataPoint("hist_test", &histogram.Histogram{Count: 1, Sum: 3, ZeroCount: 1}, nil, "key1", "a"),
+						createHistogramDataPoint("hist_test", &histogram.Histogram{Count: 2, Sum: 7, ZeroCount: 2}, nil, "key1", "b"),
+					},
+				},
+			},
+			wants: func() []pmetric.Metrics {
+				md0 := pmetric.NewMetrics()
+				mL0 := md0.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+				m0 := mL0.AppendEmpty()
+				m0.SetName("hist_test")
+				hist0 := m0.SetEmptyExponentialHistogram()
+				hist0.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				pt0 := hist0.DataPoints().AppendEmpty()
+				pt0.SetCount(1)
+				pt0.SetSum(3)
+				pt0.SetZeroCount(1)
+				pt0.SetTimestamp(tsNanos)
+				pt0.SetStartTimestamp(startTimestamp)
+				pt0.Attributes().PutStr("key1", "a")
+				pt1 := hist0.DataPoints().AppendEmpty()
+				pt1.SetCount(2)
+				pt1.SetSum(7)
+				pt1.SetZeroCount(2)
+				pt1.SetTimestamp(tsNanos)
+				pt1.SetStartTimestamp(startTimestamp)
+				pt1.Attributes().PutStr("key1", "b")
+
+				return []pmetric.Metrics{md0}
+			},
+		},
+		{
+			name: "staleness marker",
+			inputs: []*testScrapedPage{
+				{
+					pts: []*testDataPoint{
+						createDataPoint("hist_test", math.Float64frombits(value.StaleNaN), nil, "foo", "bar"),
+					},
+				},
+			},
+			wants: func() []pmetric.Metrics {
+				md0 := pmetric.NewMetrics()
+				mL0 := md0.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+				m0 := mL0.AppendEmpty()
+				m0.SetName("hist_test")
+				hist0 := m0.SetEmptyExponentialHistogram()
+				hist0.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				pt0 := hist0.DataPoints().AppendEmpty()
+				pt0.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+				pt0.SetTimestamp(tsNanos)
+				pt0.SetStartTimestamp(startTimestamp)
+				pt0.Attributes().PutStr("foo", "bar")
+
+				return []pmetric.Metrics{md0}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t)
+		})
+	}
+}
+
 func TestMetricBuilderSummary(t *testing.T) {
 	tests := []buildTestData{
 		{
@@ -1370,7 +1503,12 @@ func (tt buildTestData) run(t *testing.T) {
 		for _, pt := range page.pts {
 			// set ts for testing
 			pt.t = st
-			_, err := tr.Append(0, pt.lb, pt.t, pt.v)
+			var err error
+			if pt.h != nil {
+				_, err = tr.AppendHistogram(0, pt.lb, pt.t, pt.h)
+			} else {
+				_, err = tr.Append(0, pt.lb, pt.t, pt.v)
+			}
 			assert.NoError(t, err)
 
 			for _, e := range pt.exemplars {
@@ -1427,6 +1565,11 @@ func (s *startTimeAdjuster) AdjustMetrics(metrics pmetric.Metrics) error {
 					for l := 0; l < dps.Len(); l++ {
 						dps.At(l).SetStartTimestamp(s.startTime)
 					}
+				case pmetric.MetricTypeExponentialHistogram:
+					dps := metric.ExponentialHistogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dps.At(l).SetStartTimestamp(s.startTime)
+					}
 				}
 			}
 		}
@@ -1438,6 +1581,7 @@ type testDataPoint struct {
 	lb        labels.Labels
 	t         int64
 	v         float64
+	h         *histogram.Histogram
 	exemplars []exemplar.Exemplar
 }
 
@@ -1460,6 +1604,12 @@ func createDataPoint(mname string, value float64, es []exemplar.Exemplar, tagPai
 	}
 }
 
+func createHistogramDataPoint(mname string, h *histogram.Histogram, es []exemplar.Exemplar, tagPairs ...string) *testDataPoint {
+	dataPoint := createDataPoint(mname, 0, es, tagPairs...)
+	dataPoint.h = h
+	return dataPoint
+}
+
 func assertEquivalentMetrics(t *testing.T, want, got pmetric.Metrics) {
 	require.Equal(t, want.ResourceMetrics().Len(), got.ResourceMetrics().Len())
 	if want.ResourceMetrics().Len() == 0 {
@@ -96,7 +96,7 @@ func (r *pReceiver) Start(_ context.Context, host component.Host) error {
 	}
 
 	allocConf := r.cfg.TargetAllocator
-	if allocConf != nil {
+	if allocConf != nil && allocConf.Embedded == nil {
 		err = r.startTargetAllocator(allocConf, baseCfg)
 		if err != nil {
 			return err
@@ -273,13 +273,25 @@ func (r *pReceiver) initPrometheusComponents(ctx context.Context, host component
 		r.cfg.ID(),
 		r.cfg.PrometheusConfig.GlobalConfig.ExternalLabels,
 	)
-	r.scrapeManager = scrape.NewManager(&scrape.Options{PassMetadataInContext: true}, logger, store)
+	syncCh := r.discoveryManager.SyncCh()
+	if r.cfg.TargetAllocator != nil && r.cfg.TargetAllocator.Embedded != nil {
+		var err error
+		syncCh, err = r.startEmbeddedAllocator(ctx, host, logger, syncCh)
+		if err != nil {
+			return err
+		}
+	}
+
+	r.scrapeManager = scrape.NewManager(&scrape.Options{
+		PassMetadataInContext:     true,
+		EnableProtobufNegotiation: r.cfg.EnableProtobufNegotiation,
+	}, logger, store)
 
 	go func() {
 		// The scrape manager needs to wait for the configuration to be loaded before beginning
 		<-r.configLoaded
 		r.settings.Logger.Info("Starting scrape manager")
-		if err := r.scrapeManager.Run(r.discoveryManager.SyncCh()); err != nil {
+		if err := r.scrapeManager.Run(syncCh); err != nil {
 			r.settings.Logger.Error("Scrape manager failed", zap.Error(err))
 			host.ReportFatalError(err)
 		}
@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -44,10 +45,14 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver/internal"
 )
 
+// protobufContentType is the media type of the protobuf exposition format.
+const protobufContentType = "application/vnd.google.protobuf"
+
 type mockPrometheusResponse struct {
 	code           int
 	data           string
 	useOpenMetrics bool
+	useProtoBuf    bool
 }
 
 type mockPrometheus struct {
@@ -96,6 +101,14 @@ func (mp *mockPrometheus) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	if pages[index].useOpenMetrics {
 		rw.Header().Set("Content-Type", "application/openmetrics-text")
 	}
+	if pages[index].useProtoBuf {
+		// Only serve the protobuf format when it was negotiated by the scraper.
+		if !strings.Contains(req.Header.Get("Accept"), protobufContentType) {
+			rw.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		rw.Header().Set("Content-Type", protobufContentType+";proto=io.prometheus.client.MetricFamily;encoding=delimited")
+	}
 	rw.WriteHeader(pages[index].code)
 	_, _ = rw.Write([]byte(pages[index].data))
 }
@@ -283,6 +296,12 @@ func isFirstFailedScrape(metrics []pmetric.Metric) bool {
 					return false
 				}
 			}
+		case pmetric.MetricTypeExponentialHistogram:
+			for i := 0; i < m.ExponentialHistogram().DataPoints().Len(); i++ {
+				if !m.ExponentialHistogram().DataPoints().At(i).Flags().NoRecordedValue() {
+					return false
+				}
+			}
 		case pmetric.MetricTypeSummary:
 			for i := 0; i < m.Summary().DataPoints().Len(); i++ {
 				if !m.Summary().DataPoints().At(i).Flags().NoRecordedValue() {
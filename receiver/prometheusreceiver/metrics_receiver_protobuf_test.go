@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	dto "github.com/prometheus/prometheus/prompb/io/prometheus/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// encodeMetricFamilies encodes the metric families in the varint length-delimited
+// protobuf exposition format.
+func encodeMetricFamilies(t *testing.T, mfs ...*dto.MetricFamily) string {
+	var buf []byte
+	varint := make([]byte, binary.MaxVarintLen64)
+	for _, mf := range mfs {
+		b, err := proto.Marshal(mf)
+		require.NoError(t, err)
+		n := binary.PutUvarint(varint, uint64(len(b)))
+		buf = append(buf, varint[:n]...)
+		buf = append(buf, b...)
+	}
+	return string(buf)
+}
+
+func nativeHistogramFamily() *dto.MetricFamily {
+	return &dto.MetricFamily{
+		Name: "rpc_durations_seconds",
+		Help: "RPC latency distributions.",
+		Type: dto.MetricType_HISTOGRAM,
+		Metric: []*dto.Metric{
+			{
+				Label: []*dto.LabelPair{{Name: "service", Value: "exponential"}},
+				Histogram: &dto.Histogram{
+					SampleCount:   10,
+					SampleSum:     12.5,
+					Schema:        1,
+					ZeroThreshold: 1e-128,
+					ZeroCount:     1,
+					PositiveSpan: []*dto.BucketSpan{
+						{Offset: 0, Length: 2},
+						{Offset: 1, Length: 1},
+					},
+					// Absolute bucket counts are 2, 3 and 1.
+					PositiveDelta: []int64{2, 1, -2},
+					NegativeSpan:  []*dto.BucketSpan{{Offset: -1, Length: 1}},
+					NegativeDelta: []int64{3},
+				},
+			},
+		},
+	}
+}
+
+func TestNativeHistogramScrape(t *testing.T) {
+	targets := []*testData{
+		{
+			name: "target1",
+			pages: []mockPrometheusResponse{
+				{code: 200, data: encodeMetricFamilies(t, nativeHistogramFamily()), useProtoBuf: true},
+			},
+			validateFunc: verifyNativeHistogram,
+		},
+	}
+
+	ctx := context.Background()
+	mp, cfg, err := setupMockPrometheus(targets...)
+	require.Nilf(t, err, "Failed to create Prometheus config: %v", err)
+	defer mp.Close()
+
+	cms := new(consumertest.MetricsSink)
+	receiver := newPrometheusReceiver(componenttest.NewNopReceiverCreateSettings(), &Config{
+		ReceiverSettings:          config.NewReceiverSettings(config.NewComponentID(typeStr)),
+		PrometheusConfig:          cfg,
+		EnableProtobufNegotiation: true,
+	}, cms)
+
+	require.NoError(t, receiver.Start(ctx, componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, receiver.Shutdown(context.Background()))
+	})
+
+	mp.wg.Wait()
+	waitForScrapeResults(t, targets, cms)
+
+	pResults := splitMetricsByTarget(cms.AllMetrics())
+	for _, target := range targets {
+		target.validateFunc(t, target, getValidScrapes(t, pResults[target.name]))
+	}
+}
+
+func verifyNativeHistogram(t *testing.T, td *testData, resourceMetrics []pmetric.ResourceMetrics) {
+	require.Greater(t, len(resourceMetrics), 0, "At least one resource metric should be present")
+
+	var found bool
+	for _, m := range getMetrics(resourceMetrics[0]) {
+		if m.Name() != "rpc_durations_seconds" {
+			continue
+		}
+		found = true
+		require.Equal(t, pmetric.MetricTypeExponentialHistogram, m.Type())
+		assert.Equal(t, "RPC latency distributions.", m.Description())
+		assert.Equal(t, pmetric.AggregationTemporalityCumulative, m.ExponentialHistogram().AggregationTemporality())
+		require.Equal(t, 1, m.ExponentialHistogram().DataPoints().Len())
+
+		dp := m.ExponentialHistogram().DataPoints().At(0)
+		assert.Equal(t, int32(1), dp.Scale())
+		assert.Equal(t, uint64(10), dp.Count())
+		assert.Equal(t, 12.5, dp.Sum())
+		assert.Equal(t, uint64(1), dp.ZeroCount())
+		assert.Equal(t, int32(-1), dp.Positive().Offset())
+		assert.Equal(t, []uint64{2, 3, 0, 1}, dp.Positive().BucketCounts().AsRaw())
+		assert.Equal(t, int32(-2), dp.Negative().Offset())
+		assert.Equal(t, []uint64{3}, dp.Negative().BucketCounts().AsRaw())
+
+		val, ok := dp.Attributes().Get("service")
+		require.True(t, ok)
+		assert.Equal(t, "exponential", val.Str())
+		assert.NotZero(t, dp.StartTimestamp())
+		assert.NotZero(t, dp.Timestamp())
+	}
+	assert.True(t, found, "native histogram %q not found in scrape of %s", "rpc_durations_seconds", td.name)
+}
@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/connctx"
+)
+
+const (
+	writePath  = "/api/v1/write"
+	dataFormat = "protobuf"
+)
+
+var errRequestTooLarge = errors.New("request body is too large")
+
+type metricsReceiver struct {
+	config       *Config
+	settings     component.ReceiverCreateSettings
+	nextConsumer consumer.Metrics
+
+	server *http.Server
+	wg     sync.WaitGroup
+
+	obsrecv *obsreport.Receiver
+}
+
+func newMetricsReceiver(config *Config, settings component.ReceiverCreateSettings, nextConsumer consumer.Metrics) (*metricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+	transport := "http"
+	if config.TLSSetting != nil {
+		transport = "https"
+	}
+	return &metricsReceiver{
+		config:       config,
+		settings:     settings,
+		nextConsumer: nextConsumer,
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             config.ID(),
+			Transport:              transport,
+			ReceiverCreateSettings: settings,
+		}),
+	}, nil
+}
+
+func (r *metricsReceiver) Start(_ context.Context, host component.Host) error {
+	ln, err := r.config.HTTPServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", r.config.Endpoint, err)
+	}
+
+	router := http.NewServeMux()
+	router.HandleFunc(writePath, r.handleWrite)
+
+	r.server, err = r.config.HTTPServerSettings.ToServer(host, r.settings.TelemetrySettings, router)
+	if err != nil {
+		return err
+	}
+	r.server.ConnContext = connctx.ConnContext
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		if errHTTP := r.server.Serve(ln); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+
+	return nil
+}
+
+func (r *metricsReceiver) Shutdown(context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	err := r.server.Close()
+	r.wg.Wait()
+	return err
+}
+
+func (r *metricsReceiver) handleWrite(w http.ResponseWriter, req *http.Request) {
+	defer func() {
+		_ = req.Body.Close()
+	}()
+
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if encoding := req.Header.Get("Content-Encoding"); encoding != "" && encoding != "snappy" {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		_, _ = fmt.Fprintf(w, "unsupported content encoding %q, only snappy is supported", encoding)
+		return
+	}
+
+	maxSize := r.config.MaxRequestBodySize
+	if maxSize <= 0 {
+		maxSize = defaultMaxRequestBodySize
+	}
+	writeRequest, err := decodeWriteRequest(http.MaxBytesReader(w, req.Body, maxSize), maxSize)
+	if errors.Is(err, errRequestTooLarge) {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		_, _ = fmt.Fprintf(w, "request body exceeds %d bytes", maxSize)
+		return
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprintf(w, "failed to decode write request: %s", err)
+		return
+	}
+
+	ctx := r.obsrecv.StartMetricsOp(req.Context())
+	metrics, err := translateWriteRequest(writeRequest, r.settings.BuildInfo.Version)
+	if err != nil {
+		r.obsrecv.EndMetricsOp(ctx, dataFormat, 0, err)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprintf(w, "failed to translate write request: %s", err)
+		return
+	}
+
+	err = r.nextConsumer.ConsumeMetrics(ctx, metrics)
+	r.obsrecv.EndMetricsOp(ctx, dataFormat, metrics.DataPointCount(), err)
+	if err != nil {
+		// Prometheus retries requests which fail with a 5xx status code and
+		// drops those which fail with a 4xx status code.
+		if consumererror.IsPermanent(err) {
+			w.WriteHeader(http.StatusBadRequest)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		r.settings.Logger.Debug("failed to pass metrics to next consumer", zap.Error(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeWriteRequest reads a snappy compressed, protobuf encoded remote write
+// request. Requests whose compressed or decompressed size exceeds maxSize are
+// rejected with errRequestTooLarge, before they are decompressed.
+func decodeWriteRequest(r io.Reader, maxSize int64) (*prompb.WriteRequest, error) {
+	compressed, err := io.ReadAll(r)
+	if err != nil {
+		// The reader returned by http.MaxBytesReader fails once maxSize bytes have been read
+		if int64(len(compressed)) >= maxSize {
+			return nil, errRequestTooLarge
+		}
+		return nil, err
+	}
+
+	size, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		return nil, err
+	}
+	if int64(size) > maxSize {
+		return nil, errRequestTooLarge
+	}
+
+	raw, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, err
+	}
+
+	var writeRequest prompb.WriteRequest
+	if err = proto.Unmarshal(raw, &writeRequest); err != nil {
+		return nil, err
+	}
+	return &writeRequest, nil
+}
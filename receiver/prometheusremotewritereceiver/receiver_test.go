@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func startReceiver(t *testing.T, next consumer.Metrics, maxRequestBodySize int64) string {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	if maxRequestBodySize > 0 {
+		cfg.MaxRequestBodySize = maxRequestBodySize
+	}
+
+	r, err := newMetricsReceiver(cfg, componenttest.NewNopReceiverCreateSettings(), next)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, r.Shutdown(context.Background()))
+	})
+	return "http://" + cfg.Endpoint + writePath
+}
+
+func encodeWriteRequest(t *testing.T, req *prompb.WriteRequest) []byte {
+	raw, err := proto.Marshal(req)
+	require.NoError(t, err)
+	return snappy.Encode(nil, raw)
+}
+
+func postWriteRequest(t *testing.T, url string, body []byte, encoding string) *http.Response {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	if encoding != "" {
+		req.Header.Set("Content-Encoding", encoding)
+	}
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp
+}
+
+func TestHandleWrite(t *testing.T) {
+	writeRequest := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "http_requests_total"},
+					{Name: "instance", Value: "localhost:8080"},
+					{Name: "job", Value: "api"},
+				},
+				Samples: []prompb.Sample{{Value: 10, Timestamp: 1000}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		body               []byte
+		encoding           string
+		maxRequestBodySize int64
+		consumer           consumer.Metrics
+		expectedStatus     int
+		expectedPoints     int
+	}{
+		{
+			name:           "success",
+			body:           encodeWriteRequest(t, writeRequest),
+			encoding:       "snappy",
+			consumer:       new(consumertest.MetricsSink),
+			expectedStatus: http.StatusNoContent,
+			expectedPoints: 1,
+		},
+		{
+			name:           "not snappy compressed",
+			body:           []byte("not a write request"),
+			encoding:       "snappy",
+			consumer:       new(consumertest.MetricsSink),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:               "body too large",
+			body:               encodeWriteRequest(t, writeRequest),
+			maxRequestBodySize: 16,
+			consumer:           new(consumertest.MetricsSink),
+			expectedStatus:     http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:               "decompressed body too large",
+			body:               snappy.Encode(nil, make([]byte, 1024)),
+			maxRequestBodySize: 512,
+			consumer:           new(consumertest.MetricsSink),
+			expectedStatus:     http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "unsupported encoding",
+			body:           encodeWriteRequest(t, writeRequest),
+			encoding:       "br",
+			consumer:       new(consumertest.MetricsSink),
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name: "missing metric name",
+			body: encodeWriteRequest(t, &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{{
+					Labels:  []prompb.Label{{Name: "job", Value: "api"}},
+					Samples: []prompb.Sample{{Value: 1, Timestamp: 1000}},
+				}},
+			}),
+			consumer:       new(consumertest.MetricsSink),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "retryable consumer error",
+			body:           encodeWriteRequest(t, writeRequest),
+			consumer:       consumertest.NewErr(assert.AnError),
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "permanent consumer error",
+			body:           encodeWriteRequest(t, writeRequest),
+			consumer:       consumertest.NewErr(consumererror.NewPermanent(assert.AnError)),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := startReceiver(t, tt.consumer, tt.maxRequestBodySize)
+
+			resp := postWriteRequest(t, url, tt.body, tt.encoding)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if sink, ok := tt.consumer.(*consumertest.MetricsSink); ok {
+				assert.Equal(t, tt.expectedPoints, sink.DataPointCount())
+			}
+		})
+	}
+}
+
+func TestHandleWriteMethodNotAllowed(t *testing.T) {
+	url := startReceiver(t, consumertest.NewNop(), 0)
+
+	resp, err := http.Get(url) //nolint:gosec
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
@@ -0,0 +1,722 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+const (
+	scopeName            = "otelcol/prometheusremotewritereceiver"
+	targetInfoMetricName = "target_info"
+	traceIDKey           = "trace_id"
+	spanIDKey            = "span_id"
+
+	bucketSuffix = "_bucket"
+	sumSuffix    = "_sum"
+	countSuffix  = "_count"
+	totalSuffix  = "_total"
+)
+
+var errMissingMetricName = errors.New("time series without a metric name")
+
+// seriesKind is the role a single time series plays in the metric family it belongs to.
+type seriesKind int
+
+const (
+	seriesGauge seriesKind = iota
+	seriesCounter
+	seriesHistogramBucket
+	seriesHistogramSum
+	seriesHistogramCount
+	seriesSummaryQuantile
+	seriesSummarySum
+	seriesSummaryCount
+	seriesNativeHistogram
+)
+
+// resourceKey identifies the target a time series was collected from.
+type resourceKey struct {
+	job      string
+	instance string
+}
+
+// pointKey identifies a histogram or summary data point, which is assembled
+// from several time series sharing the same labels and timestamp.
+type pointKey struct {
+	family    string
+	signature string
+	timestamp int64
+}
+
+type bucket struct {
+	upperBound float64
+	count      float64
+}
+
+type histogramPoint struct {
+	point    pmetric.HistogramDataPoint
+	buckets  []bucket
+	hasCount bool
+}
+
+type resourceBuilder struct {
+	resourceMetrics pmetric.ResourceMetrics
+	metrics         pmetric.MetricSlice
+	families        map[string]pmetric.Metric
+	histograms      map[pointKey]*histogramPoint
+	summaries       map[pointKey]pmetric.SummaryDataPoint
+}
+
+// writeRequestTranslator converts the time series of a single remote write
+// request into pmetric.Metrics.
+type writeRequestTranslator struct {
+	version   string
+	metadata  map[string]prompb.MetricMetadata
+	histogram map[string]bool
+	summary   map[string]bool
+
+	metrics   pmetric.Metrics
+	resources map[resourceKey]*resourceBuilder
+	// histogramOrder keeps the order in which classic histogram points were
+	// created so that they are finalized deterministically.
+	histogramOrder []*histogramPoint
+	// nativeBuckets is the number of dense buckets allocated so far for the
+	// native histograms of the request.
+	nativeBuckets int64
+}
+
+// translateWriteRequest converts a remote write request into pmetric.Metrics.
+// Time series are grouped into resources by their job and instance labels, and
+// the labels of the target_info series of a target are used as resource attributes.
+// Classic histograms and summaries are reassembled from their _bucket, _sum,
+// _count and quantile series.
+func translateWriteRequest(req *prompb.WriteRequest, version string) (pmetric.Metrics, error) {
+	t := &writeRequestTranslator{
+		version:   version,
+		metadata:  make(map[string]prompb.MetricMetadata, len(req.Metadata)),
+		histogram: make(map[string]bool),
+		summary:   make(map[string]bool),
+		metrics:   pmetric.NewMetrics(),
+		resources: make(map[resourceKey]*resourceBuilder),
+	}
+
+	for _, md := range req.Metadata {
+		t.metadata[md.MetricFamilyName] = md
+		switch md.Type {
+		case prompb.MetricMetadata_HISTOGRAM, prompb.MetricMetadata_GAUGEHISTOGRAM:
+			t.histogram[md.MetricFamilyName] = true
+		case prompb.MetricMetadata_SUMMARY:
+			t.summary[md.MetricFamilyName] = true
+		}
+	}
+
+	// Metadata is only sent periodically, so classic histograms and summaries
+	// are also detected by the presence of their le and quantile labels.
+	for i := range req.Timeseries {
+		ts := &req.Timeseries[i]
+		name := labelValue(ts.Labels, model.MetricNameLabel)
+		if strings.HasSuffix(name, bucketSuffix) && hasLabel(ts.Labels, model.BucketLabel) {
+			t.histogram[strings.TrimSuffix(name, bucketSuffix)] = true
+		}
+		if hasLabel(ts.Labels, model.QuantileLabel) {
+			t.summary[name] = true
+		}
+	}
+
+	// target_info series are handled first so that resource attributes do not
+	// depend on the order of the series in the request.
+	for i := range req.Timeseries {
+		ts := &req.Timeseries[i]
+		if labelValue(ts.Labels, model.MetricNameLabel) == targetInfoMetricName {
+			t.addTargetInfo(ts)
+		}
+	}
+
+	for i := range req.Timeseries {
+		ts := &req.Timeseries[i]
+		name := labelValue(ts.Labels, model.MetricNameLabel)
+		if name == "" {
+			return pmetric.Metrics{}, errMissingMetricName
+		}
+		if name == targetInfoMetricName {
+			continue
+		}
+		if err := t.addTimeSeries(name, ts); err != nil {
+			return pmetric.Metrics{}, fmt.Errorf("time series %q: %w", name, err)
+		}
+	}
+
+	for _, hp := range t.histogramOrder {
+		finalizeHistogramPoint(hp)
+	}
+
+	return t.metrics, nil
+}
+
+// resource returns the builder for the resource identified by the job and
+// instance labels, creating it if it does not exist yet.
+func (t *writeRequestTranslator) resource(labels []prompb.Label) *resourceBuilder {
+	key := resourceKey{
+		job:      labelValue(labels, model.JobLabel),
+		instance: labelValue(labels, model.InstanceLabel),
+	}
+	if rb, ok := t.resources[key]; ok {
+		return rb
+	}
+
+	rm := t.metrics.ResourceMetrics().AppendEmpty()
+	attrs := rm.Resource().Attributes()
+	if key.job != "" {
+		// The translator in the remote write exporter encodes the service
+		// namespace as a prefix of the job label.
+		if namespace, name, found := strings.Cut(key.job, "/"); found {
+			attrs.PutStr(conventions.AttributeServiceNamespace, namespace)
+			attrs.PutStr(conventions.AttributeServiceName, name)
+		} else {
+			attrs.PutStr(conventions.AttributeServiceName, key.job)
+		}
+	}
+	if key.instance != "" {
+		attrs.PutStr(conventions.AttributeServiceInstanceID, key.instance)
+		host, port, err := net.SplitHostPort(key.instance)
+		if err == nil {
+			attrs.PutStr(conventions.AttributeNetHostName, host)
+			attrs.PutStr(conventions.AttributeNetHostPort, port)
+		}
+	}
+
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(scopeName)
+	sm.Scope().SetVersion(t.version)
+
+	rb := &resourceBuilder{
+		resourceMetrics: rm,
+		metrics:         sm.Metrics(),
+		families:        make(map[string]pmetric.Metric),
+		histograms:      make(map[pointKey]*histogramPoint),
+		summaries:       make(map[pointKey]pmetric.SummaryDataPoint),
+	}
+	t.resources[key] = rb
+	return rb
+}
+
+// addTargetInfo copies the labels of a target_info series, other than the
+// ones identifying the target, to the attributes of its resource.
+func (t *writeRequestTranslator) addTargetInfo(ts *prompb.TimeSeries) {
+	attrs := t.resource(ts.Labels).resourceMetrics.Resource().Attributes()
+	for _, l := range ts.Labels {
+		switch l.Name {
+		case model.MetricNameLabel, model.JobLabel, model.InstanceLabel:
+			continue
+		}
+		attrs.PutStr(l.Name, l.Value)
+	}
+}
+
+// classify returns the metric family a series belongs to and the role it plays in it.
+func (t *writeRequestTranslator) classify(name string, ts *prompb.TimeSeries) (string, seriesKind) {
+	if len(ts.Histograms) > 0 {
+		return name, seriesNativeHistogram
+	}
+	if base := strings.TrimSuffix(name, bucketSuffix); base != name && t.histogram[base] && hasLabel(ts.Labels, model.BucketLabel) {
+		return base, seriesHistogramBucket
+	}
+	if base := strings.TrimSuffix(name, sumSuffix); base != name {
+		if t.histogram[base] {
+			return base, seriesHistogramSum
+		}
+		if t.summary[base] {
+			return base, seriesSummarySum
+		}
+	}
+	if base := strings.TrimSuffix(name, countSuffix); base != name {
+		if t.histogram[base] {
+			return base, seriesHistogramCount
+		}
+		if t.summary[base] {
+			return base, seriesSummaryCount
+		}
+	}
+	if t.summary[name] && hasLabel(ts.Labels, model.QuantileLabel) {
+		return name, seriesSummaryQuantile
+	}
+	if md, ok := t.lookupMetadata(name); ok {
+		if md.Type == prompb.MetricMetadata_COUNTER {
+			return name, seriesCounter
+		}
+		return name, seriesGauge
+	}
+	// Without metadata, follow the Prometheus naming convention for counters.
+	if strings.HasSuffix(name, totalSuffix) {
+		return name, seriesCounter
+	}
+	return name, seriesGauge
+}
+
+// lookupMetadata returns the metadata of a metric family. Depending on the
+// exposition format the family of a counter may be named with or without the
+// _total suffix.
+func (t *writeRequestTranslator) lookupMetadata(name string) (prompb.MetricMetadata, bool) {
+	if md, ok := t.metadata[name]; ok {
+		return md, true
+	}
+	md, ok := t.metadata[strings.TrimSuffix(name, totalSuffix)]
+	return md, ok
+}
+
+// metric returns the metric of a family within a resource, creating it with
+// the given data type if it does not exist yet.
+func (t *writeRequestTranslator) metric(rb *resourceBuilder, family string, kind seriesKind) pmetric.Metric {
+	if m, ok := rb.families[family]; ok {
+		return m
+	}
+
+	m := rb.metrics.AppendEmpty()
+	m.SetName(family)
+	if md, ok := t.lookupMetadata(family); ok {
+		m.SetDescription(md.Help)
+		m.SetUnit(md.Unit)
+	}
+
+	switch kind {
+	case seriesGauge:
+		m.SetEmptyGauge()
+	case seriesCounter:
+		sum := m.SetEmptySum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	case seriesHistogramBucket, seriesHistogramSum, seriesHistogramCount:
+		m.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	case seriesSummaryQuantile, seriesSummarySum, seriesSummaryCount:
+		m.SetEmptySummary()
+	case seriesNativeHistogram:
+		m.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	}
+	rb.families[family] = m
+	return m
+}
+
+func (t *writeRequestTranslator) addTimeSeries(name string, ts *prompb.TimeSeries) error {
+	family, kind := t.classify(name, ts)
+	rb := t.resource(ts.Labels)
+	m := t.metric(rb, family, kind)
+
+	switch kind {
+	case seriesGauge:
+		if m.Type() != pmetric.MetricTypeGauge {
+			return fmt.Errorf("conflicting types for metric family %q", family)
+		}
+		addNumberDataPoints(m.Gauge().DataPoints(), ts)
+	case seriesCounter:
+		if m.Type() != pmetric.MetricTypeSum {
+			return fmt.Errorf("conflicting types for metric family %q", family)
+		}
+		addNumberDataPoints(m.Sum().DataPoints(), ts)
+	case seriesHistogramBucket, seriesHistogramSum, seriesHistogramCount:
+		if m.Type() != pmetric.MetricTypeHistogram {
+			return fmt.Errorf("conflicting types for metric family %q", family)
+		}
+		return t.addHistogramSeries(rb, m, family, kind, ts)
+	case seriesSummaryQuantile, seriesSummarySum, seriesSummaryCount:
+		if m.Type() != pmetric.MetricTypeSummary {
+			return fmt.Errorf("conflicting types for metric family %q", family)
+		}
+		return addSummarySeries(rb, m, family, kind, ts)
+	case seriesNativeHistogram:
+		if m.Type() != pmetric.MetricTypeExponentialHistogram {
+			return fmt.Errorf("conflicting types for metric family %q", family)
+		}
+		return t.addNativeHistogramSeries(m, ts)
+	}
+	return nil
+}
+
+func addNumberDataPoints(dps pmetric.NumberDataPointSlice, ts *prompb.TimeSeries) {
+	var dp pmetric.NumberDataPoint
+	for _, sample := range ts.Samples {
+		dp = dps.AppendEmpty()
+		setAttributes(dp.Attributes(), ts.Labels, "")
+		dp.SetTimestamp(fromMillis(sample.Timestamp))
+		if value.IsStaleNaN(sample.Value) {
+			dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+			continue
+		}
+		dp.SetDoubleValue(sample.Value)
+	}
+	if len(ts.Samples) > 0 {
+		addExemplars(dp.Exemplars(), ts.Exemplars)
+	}
+}
+
+func (t *writeRequestTranslator) addHistogramSeries(rb *resourceBuilder, m pmetric.Metric, family string, kind seriesKind, ts *prompb.TimeSeries) error {
+	var upperBound float64
+	if kind == seriesHistogramBucket {
+		var err error
+		if upperBound, err = strconv.ParseFloat(labelValue(ts.Labels, model.BucketLabel), 64); err != nil {
+			return fmt.Errorf("invalid %s label: %w", model.BucketLabel, err)
+		}
+	}
+
+	signature := labelSignature(ts.Labels, model.BucketLabel)
+	var hp *histogramPoint
+	for _, sample := range ts.Samples {
+		key := pointKey{family: family, signature: signature, timestamp: sample.Timestamp}
+		var ok bool
+		if hp, ok = rb.histograms[key]; !ok {
+			hp = &histogramPoint{point: m.Histogram().DataPoints().AppendEmpty()}
+			setAttributes(hp.point.Attributes(), ts.Labels, model.BucketLabel)
+			hp.point.SetTimestamp(fromMillis(sample.Timestamp))
+			rb.histograms[key] = hp
+			t.histogramOrder = append(t.histogramOrder, hp)
+		}
+
+		if value.IsStaleNaN(sample.Value) {
+			hp.point.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+			continue
+		}
+		switch kind {
+		case seriesHistogramBucket:
+			hp.buckets = append(hp.buckets, bucket{upperBound: upperBound, count: sample.Value})
+		case seriesHistogramSum:
+			hp.point.SetSum(sample.Value)
+		case seriesHistogramCount:
+			hp.point.SetCount(uint64(sample.Value))
+			hp.hasCount = true
+		}
+	}
+	if hp != nil {
+		addExemplars(hp.point.Exemplars(), ts.Exemplars)
+	}
+	return nil
+}
+
+// finalizeHistogramPoint converts the cumulative bucket counts collected from
+// the _bucket series of a histogram point into explicit bounds and counts.
+func finalizeHistogramPoint(hp *histogramPoint) {
+	if hp.point.Flags().NoRecordedValue() {
+		return
+	}
+	sort.Slice(hp.buckets, func(i, j int) bool {
+		return hp.buckets[i].upperBound < hp.buckets[j].upperBound
+	})
+
+	bounds := make([]float64, 0, len(hp.buckets))
+	counts := make([]uint64, 0, len(hp.buckets)+1)
+	var previous float64
+	for _, b := range hp.buckets {
+		count := b.count - previous
+		if count < 0 {
+			count = 0
+		}
+		previous = b.count
+		counts = append(counts, uint64(count))
+		if !math.IsInf(b.upperBound, 1) {
+			bounds = append(bounds, b.upperBound)
+		}
+	}
+	if len(hp.buckets) > 0 && !math.IsInf(hp.buckets[len(hp.buckets)-1].upperBound, 1) {
+		// The +Inf bucket is missing, observations above the last bound are
+		// only known from the _count series.
+		overflow := uint64(0)
+		if hp.hasCount && hp.point.Count() > uint64(previous) {
+			overflow = hp.point.Count() - uint64(previous)
+		}
+		counts = append(counts, overflow)
+	}
+	if !hp.hasCount {
+		hp.point.SetCount(uint64(previous))
+	}
+
+	if len(counts) > 0 {
+		hp.point.ExplicitBounds().FromRaw(bounds)
+		hp.point.BucketCounts().FromRaw(counts)
+	}
+}
+
+func addSummarySeries(rb *resourceBuilder, m pmetric.Metric, family string, kind seriesKind, ts *prompb.TimeSeries) error {
+	var quantile float64
+	if kind == seriesSummaryQuantile {
+		var err error
+		if quantile, err = strconv.ParseFloat(labelValue(ts.Labels, model.QuantileLabel), 64); err != nil {
+			return fmt.Errorf("invalid %s label: %w", model.QuantileLabel, err)
+		}
+	}
+
+	signature := labelSignature(ts.Labels, model.QuantileLabel)
+	for _, sample := range ts.Samples {
+		key := pointKey{family: family, signature: signature, timestamp: sample.Timestamp}
+		dp, ok := rb.summaries[key]
+		if !ok {
+			dp = m.Summary().DataPoints().AppendEmpty()
+			setAttributes(dp.Attributes(), ts.Labels, model.QuantileLabel)
+			dp.SetTimestamp(fromMillis(sample.Timestamp))
+			rb.summaries[key] = dp
+		}
+
+		if value.IsStaleNaN(sample.Value) {
+			dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+			continue
+		}
+		switch kind {
+		case seriesSummaryQuantile:
+			qv := dp.QuantileValues().AppendEmpty()
+			qv.SetQuantile(quantile)
+			qv.SetValue(sample.Value)
+			dp.QuantileValues().Sort(func(a, b pmetric.SummaryDataPointValueAtQuantile) bool {
+				return a.Quantile() < b.Quantile()
+			})
+		case seriesSummarySum:
+			dp.SetSum(sample.Value)
+		case seriesSummaryCount:
+			dp.SetCount(uint64(sample.Value))
+		}
+	}
+	return nil
+}
+
+func (t *writeRequestTranslator) addNativeHistogramSeries(m pmetric.Metric, ts *prompb.TimeSeries) error {
+	dps := m.ExponentialHistogram().DataPoints()
+	var dp pmetric.ExponentialHistogramDataPoint
+	for i := range ts.Histograms {
+		h := &ts.Histograms[i]
+		dp = dps.AppendEmpty()
+		setAttributes(dp.Attributes(), ts.Labels, "")
+		dp.SetTimestamp(fromMillis(h.Timestamp))
+		if value.IsStaleNaN(h.Sum) {
+			dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+			continue
+		}
+
+		if h.Schema < minNativeSchema || h.Schema > maxNativeSchema {
+			return fmt.Errorf("unsupported native histogram schema %d", h.Schema)
+		}
+		dp.SetScale(h.Schema)
+		dp.SetSum(h.Sum)
+		if _, isFloat := h.Count.(*prompb.Histogram_CountFloat); isFloat {
+			dp.SetCount(uint64(h.GetCountFloat()))
+			dp.SetZeroCount(uint64(h.GetZeroCountFloat()))
+		} else {
+			dp.SetCount(h.GetCountInt())
+			dp.SetZeroCount(h.GetZeroCountInt())
+		}
+
+		if err := t.convertNativeBuckets(h.Schema, h.PositiveSpans, h.PositiveDeltas, h.PositiveCounts, dp.Positive()); err != nil {
+			return fmt.Errorf("positive buckets: %w", err)
+		}
+		if err := t.convertNativeBuckets(h.Schema, h.NegativeSpans, h.NegativeDeltas, h.NegativeCounts, dp.Negative()); err != nil {
+			return fmt.Errorf("negative buckets: %w", err)
+		}
+	}
+	if len(ts.Histograms) > 0 {
+		addExemplars(dp.Exemplars(), ts.Exemplars)
+	}
+	return nil
+}
+
+// Native histograms support the schemas from -4 to 8, that is scales of the
+// exponential histogram from -4 to 8.
+const (
+	minNativeSchema = -4
+	maxNativeSchema = 8
+
+	// maxNativeBucketIndex bounds the bucket indexes of a native histogram
+	// with schema 0, whose buckets cover all float64 values from index -1074
+	// for the smallest subnormal number to index 1024.
+	maxNativeBucketIndex = 1100
+
+	// maxNativeBucketsPerRequest bounds the number of dense buckets allocated
+	// for all the native histograms of a request, since each histogram may
+	// have up to 2*maxNativeBucketIndex<<maxNativeSchema buckets.
+	maxNativeBucketsPerRequest = 1 << 20
+)
+
+var errNativeBucketBudget = fmt.Errorf("native histograms of the request exceed %d buckets", maxNativeBucketsPerRequest)
+
+// convertNativeBuckets converts the sparse buckets of a native histogram into
+// the dense buckets of an exponential histogram. Integer histograms encode
+// bucket counts as deltas to the previous bucket, float histograms as absolute
+// counts. The bucket with index i in Prometheus covers the same range as the
+// bucket with index i-1 in OpenTelemetry.
+func (t *writeRequestTranslator) convertNativeBuckets(schema int32, spans []*prompb.BucketSpan, deltas []int64, counts []float64, buckets pmetric.ExponentialHistogramDataPointBuckets) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	// Validate the spans before allocating the dense buckets, so that a
+	// request cannot make the receiver allocate more buckets than can exist
+	// at the schema of the histogram.
+	limit := int64(maxNativeBucketIndex)
+	if schema > 0 {
+		limit <<= schema
+	} else {
+		limit >>= -schema
+	}
+	var (
+		first = int64(spans[0].Offset)
+		index = first
+		count int64
+	)
+	if first < -limit {
+		return fmt.Errorf("bucket indexes exceed the range of schema %d", schema)
+	}
+	for i, span := range spans {
+		if i > 0 {
+			if span.Offset < 0 {
+				return fmt.Errorf("negative span offset %d", span.Offset)
+			}
+			index += int64(span.Offset)
+		}
+		index += int64(span.Length)
+		count += int64(span.Length)
+		if index > limit {
+			return fmt.Errorf("bucket indexes exceed the range of schema %d", schema)
+		}
+	}
+	if count != int64(len(deltas)) && count != int64(len(counts)) {
+		return fmt.Errorf("spans cover %d buckets but %d deltas and %d counts were sent", count, len(deltas), len(counts))
+	}
+	length := int(count)
+
+	// Charge the dense buckets, including the ones between spans, to the
+	// budget of the request before allocating them.
+	t.nativeBuckets += index - first
+	if t.nativeBuckets > maxNativeBucketsPerRequest {
+		return errNativeBucketBudget
+	}
+
+	var (
+		bucketCounts = make([]uint64, 0, index-first)
+		pos          int
+		current      int64
+	)
+	for i, span := range spans {
+		if i > 0 {
+			for j := int32(0); j < span.Offset; j++ {
+				bucketCounts = append(bucketCounts, 0)
+			}
+		}
+		for j := uint32(0); j < span.Length; j++ {
+			if len(deltas) == length {
+				current += deltas[pos]
+				bucketCounts = append(bucketCounts, uint64(current))
+			} else {
+				bucketCounts = append(bucketCounts, uint64(counts[pos]))
+			}
+			pos++
+		}
+	}
+
+	buckets.SetOffset(spans[0].Offset - 1)
+	buckets.BucketCounts().FromRaw(bucketCounts)
+	return nil
+}
+
+// addExemplars converts Prometheus exemplars, restoring the trace and span
+// IDs added by the remote write exporter.
+func addExemplars(dest pmetric.ExemplarSlice, exemplars []prompb.Exemplar) {
+	for _, e := range exemplars {
+		exemplar := dest.AppendEmpty()
+		exemplar.SetTimestamp(fromMillis(e.Timestamp))
+		exemplar.SetDoubleValue(e.Value)
+		for _, l := range e.Labels {
+			switch l.Name {
+			case traceIDKey:
+				var traceID [16]byte
+				if b, err := hex.DecodeString(l.Value); err == nil && len(b) == len(traceID) {
+					copy(traceID[:], b)
+					exemplar.SetTraceID(traceID)
+					continue
+				}
+			case spanIDKey:
+				var spanID [8]byte
+				if b, err := hex.DecodeString(l.Value); err == nil && len(b) == len(spanID) {
+					copy(spanID[:], b)
+					exemplar.SetSpanID(spanID)
+					continue
+				}
+			}
+			exemplar.FilteredAttributes().PutStr(l.Name, l.Value)
+		}
+	}
+}
+
+// setAttributes sets the labels of a series as data point attributes. The
+// labels identifying the metric family and the target are skipped, as well
+// as the label which distinguishes the series contributing to the same point.
+func setAttributes(attrs pcommon.Map, labels []prompb.Label, pointLabel string) {
+	for _, l := range labels {
+		if isIdentifyingLabel(l.Name) || l.Name == pointLabel {
+			continue
+		}
+		attrs.PutStr(l.Name, l.Value)
+	}
+}
+
+// labelSignature returns a string which uniquely identifies the attributes
+// of the data point a series contributes to.
+func labelSignature(labels []prompb.Label, pointLabel string) string {
+	var b strings.Builder
+	for _, l := range labels {
+		if isIdentifyingLabel(l.Name) || l.Name == pointLabel {
+			continue
+		}
+		b.WriteString(l.Name)
+		b.WriteByte(0xff)
+		b.WriteString(l.Value)
+		b.WriteByte(0xff)
+	}
+	return b.String()
+}
+
+func isIdentifyingLabel(name string) bool {
+	return name == model.MetricNameLabel || name == model.JobLabel || name == model.InstanceLabel
+}
+
+func labelValue(labels []prompb.Label, name string) string {
+	for _, l := range labels {
+		if l.Name == name {
+			return l.Value
+		}
+	}
+	return ""
+}
+
+func hasLabel(labels []prompb.Label, name string) bool {
+	for _, l := range labels {
+		if l.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func fromMillis(ms int64) pcommon.Timestamp {
+	return pcommon.Timestamp(ms * 1e6)
+}
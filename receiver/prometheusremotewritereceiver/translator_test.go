@@ -0,0 +1,394 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func series(name string, v float64, ts int64, labels ...string) prompb.TimeSeries {
+	pl := []prompb.Label{{Name: "__name__", Value: name}}
+	for i := 0; i < len(labels); i += 2 {
+		pl = append(pl, prompb.Label{Name: labels[i], Value: labels[i+1]})
+	}
+	return prompb.TimeSeries{
+		Labels:  pl,
+		Samples: []prompb.Sample{{Value: v, Timestamp: ts}},
+	}
+}
+
+func metricsByName(t *testing.T, rm pmetric.ResourceMetrics) map[string]pmetric.Metric {
+	require.Equal(t, 1, rm.ScopeMetrics().Len())
+	ms := rm.ScopeMetrics().At(0).Metrics()
+	metrics := make(map[string]pmetric.Metric, ms.Len())
+	for i := 0; i < ms.Len(); i++ {
+		metrics[ms.At(i).Name()] = ms.At(i)
+	}
+	return metrics
+}
+
+func TestTranslateResources(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series("up", 1, 1000, "instance", "10.0.0.1:8080", "job", "shop/checkout"),
+			series("up", 1, 1000, "instance", "10.0.0.2:8080", "job", "shop/checkout"),
+			series("target_info", 1, 1000, "instance", "10.0.0.1:8080", "job", "shop/checkout", "deployment_environment", "prod"),
+		},
+	}
+
+	md, err := translateWriteRequest(req, "1.2.3")
+	require.NoError(t, err)
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+
+	rm := md.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]interface{}{
+		"service.namespace":      "shop",
+		"service.name":           "checkout",
+		"service.instance.id":    "10.0.0.1:8080",
+		"net.host.name":          "10.0.0.1",
+		"net.host.port":          "8080",
+		"deployment_environment": "prod",
+	}, rm.Resource().Attributes().AsRaw())
+	scope := rm.ScopeMetrics().At(0).Scope()
+	assert.Equal(t, scopeName, scope.Name())
+	assert.Equal(t, "1.2.3", scope.Version())
+	assert.Contains(t, metricsByName(t, rm), "up")
+	assert.NotContains(t, metricsByName(t, rm), "target_info")
+
+	rm = md.ResourceMetrics().At(1)
+	instance, ok := rm.Resource().Attributes().Get("service.instance.id")
+	require.True(t, ok)
+	assert.Equal(t, "10.0.0.2:8080", instance.Str())
+	_, ok = rm.Resource().Attributes().Get("deployment_environment")
+	assert.False(t, ok)
+}
+
+func TestTranslateGaugesAndCounters(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series("temperature", 21.5, 1000, "job", "sensors", "room", "kitchen"),
+			series("http_requests_total", 42, 2000, "job", "api", "code", "200"),
+			series("process_cpu_seconds", 3.5, 2000, "job", "api"),
+			series("queue_length", math.Float64frombits(value.StaleNaN), 3000, "job", "api"),
+		},
+		Metadata: []prompb.MetricMetadata{
+			{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "process_cpu_seconds", Help: "CPU time.", Unit: "seconds"},
+			{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "temperature", Help: "Room temperature."},
+		},
+	}
+
+	md, err := translateWriteRequest(req, "")
+	require.NoError(t, err)
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+
+	sensors := metricsByName(t, md.ResourceMetrics().At(0))
+	temperature := sensors["temperature"]
+	require.Equal(t, pmetric.MetricTypeGauge, temperature.Type())
+	assert.Equal(t, "Room temperature.", temperature.Description())
+	dp := temperature.Gauge().DataPoints().At(0)
+	assert.Equal(t, 21.5, dp.DoubleValue())
+	assert.Equal(t, pcommon.Timestamp(1000*1e6), dp.Timestamp())
+	assert.Equal(t, map[string]interface{}{"room": "kitchen"}, dp.Attributes().AsRaw())
+
+	api := metricsByName(t, md.ResourceMetrics().At(1))
+	for _, name := range []string{"http_requests_total", "process_cpu_seconds"} {
+		m := api[name]
+		require.Equal(t, pmetric.MetricTypeSum, m.Type(), name)
+		assert.True(t, m.Sum().IsMonotonic())
+		assert.Equal(t, pmetric.AggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+	}
+	assert.Equal(t, "seconds", api["process_cpu_seconds"].Unit())
+	assert.Equal(t, 42.0, api["http_requests_total"].Sum().DataPoints().At(0).DoubleValue())
+
+	stale := api["queue_length"].Gauge().DataPoints().At(0)
+	assert.True(t, stale.Flags().NoRecordedValue())
+}
+
+func TestTranslateClassicHistogram(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series("request_duration_seconds_bucket", 10, 1000, "job", "api", "le", "+Inf", "method", "GET"),
+			series("request_duration_seconds_bucket", 2, 1000, "job", "api", "le", "0.1", "method", "GET"),
+			series("request_duration_seconds_bucket", 7, 1000, "job", "api", "le", "0.5", "method", "GET"),
+			series("request_duration_seconds_sum", 3.2, 1000, "job", "api", "method", "GET"),
+			series("request_duration_seconds_count", 10, 1000, "job", "api", "method", "GET"),
+			series("request_duration_seconds_bucket", 1, 1000, "job", "api", "le", "0.1", "method", "POST"),
+			series("request_duration_seconds_bucket", 4, 1000, "job", "api", "le", "+Inf", "method", "POST"),
+		},
+	}
+
+	md, err := translateWriteRequest(req, "")
+	require.NoError(t, err)
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+
+	metrics := metricsByName(t, md.ResourceMetrics().At(0))
+	require.Len(t, metrics, 1)
+	m := metrics["request_duration_seconds"]
+	require.Equal(t, pmetric.MetricTypeHistogram, m.Type())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, m.Histogram().AggregationTemporality())
+	require.Equal(t, 2, m.Histogram().DataPoints().Len())
+
+	get := m.Histogram().DataPoints().At(0)
+	assert.Equal(t, map[string]interface{}{"method": "GET"}, get.Attributes().AsRaw())
+	assert.Equal(t, []float64{0.1, 0.5}, get.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{2, 5, 3}, get.BucketCounts().AsRaw())
+	assert.Equal(t, uint64(10), get.Count())
+	assert.Equal(t, 3.2, get.Sum())
+
+	// Without a _count series the count is taken from the +Inf bucket.
+	post := m.Histogram().DataPoints().At(1)
+	assert.Equal(t, []float64{0.1}, post.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{1, 3}, post.BucketCounts().AsRaw())
+	assert.Equal(t, uint64(4), post.Count())
+}
+
+func TestTranslateSummary(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series("rpc_duration_seconds", 0.9, 1000, "job", "api", "quantile", "0.99"),
+			series("rpc_duration_seconds", 0.2, 1000, "job", "api", "quantile", "0.5"),
+			series("rpc_duration_seconds_sum", 17.5, 1000, "job", "api"),
+			series("rpc_duration_seconds_count", 60, 1000, "job", "api"),
+		},
+	}
+
+	md, err := translateWriteRequest(req, "")
+	require.NoError(t, err)
+
+	m := metricsByName(t, md.ResourceMetrics().At(0))["rpc_duration_seconds"]
+	require.Equal(t, pmetric.MetricTypeSummary, m.Type())
+	require.Equal(t, 1, m.Summary().DataPoints().Len())
+	dp := m.Summary().DataPoints().At(0)
+	assert.Equal(t, 17.5, dp.Sum())
+	assert.Equal(t, uint64(60), dp.Count())
+	require.Equal(t, 2, dp.QuantileValues().Len())
+	assert.Equal(t, 0.5, dp.QuantileValues().At(0).Quantile())
+	assert.Equal(t, 0.2, dp.QuantileValues().At(0).Value())
+	assert.Equal(t, 0.99, dp.QuantileValues().At(1).Quantile())
+	assert.Equal(t, 0.9, dp.QuantileValues().At(1).Value())
+}
+
+func TestTranslateNativeHistogram(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{{
+			Labels: []prompb.Label{
+				{Name: "__name__", Value: "latency"},
+				{Name: "job", Value: "api"},
+			},
+			Histograms: []prompb.Histogram{{
+				Count:     &prompb.Histogram_CountInt{CountInt: 12},
+				Sum:       20,
+				Schema:    1,
+				ZeroCount: &prompb.Histogram_ZeroCountInt{ZeroCountInt: 2},
+				// Buckets 2, 3 and 6 are populated.
+				PositiveSpans:  []*prompb.BucketSpan{{Offset: 2, Length: 2}, {Offset: 2, Length: 1}},
+				PositiveDeltas: []int64{4, -1, -2},
+				NegativeSpans:  []*prompb.BucketSpan{{Offset: 0, Length: 1}},
+				NegativeDeltas: []int64{1},
+				Timestamp:      5000,
+			}},
+		}},
+	}
+
+	md, err := translateWriteRequest(req, "")
+	require.NoError(t, err)
+
+	m := metricsByName(t, md.ResourceMetrics().At(0))["latency"]
+	require.Equal(t, pmetric.MetricTypeExponentialHistogram, m.Type())
+	dp := m.ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, pcommon.Timestamp(5000*1e6), dp.Timestamp())
+	assert.Equal(t, int32(1), dp.Scale())
+	assert.Equal(t, uint64(12), dp.Count())
+	assert.Equal(t, uint64(2), dp.ZeroCount())
+	assert.Equal(t, 20.0, dp.Sum())
+	assert.Equal(t, int32(1), dp.Positive().Offset())
+	assert.Equal(t, []uint64{4, 3, 0, 0, 1}, dp.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, int32(-1), dp.Negative().Offset())
+	assert.Equal(t, []uint64{1}, dp.Negative().BucketCounts().AsRaw())
+}
+
+func TestTranslateNativeHistogramBucketBudget(t *testing.T) {
+	// The buckets of the histograms of a request are counted together, up to the budget
+	metrics, err := translateWriteRequest(&prompb.WriteRequest{Timeseries: []prompb.TimeSeries{
+		{
+			Labels:     []prompb.Label{{Name: "__name__", Value: "latency"}},
+			Histograms: sparseHistograms(2, maxNativeBucketsPerRequest/4),
+		},
+		{
+			Labels:     []prompb.Label{{Name: "__name__", Value: "size"}},
+			Histograms: sparseHistograms(2, maxNativeBucketsPerRequest/4),
+		},
+	}}, "")
+	require.NoError(t, err)
+	assert.Equal(t, 4, metrics.DataPointCount())
+}
+
+func TestTranslateExemplars(t *testing.T) {
+	ts := series("http_requests_total", 5, 1000, "job", "api")
+	ts.Exemplars = []prompb.Exemplar{{
+		Labels: []prompb.Label{
+			{Name: "trace_id", Value: "0102030405060708090a0b0c0d0e0f10"},
+			{Name: "span_id", Value: "0102030405060708"},
+			{Name: "user", Value: "alice"},
+		},
+		Value:     1.5,
+		Timestamp: 900,
+	}}
+
+	md, err := translateWriteRequest(&prompb.WriteRequest{Timeseries: []prompb.TimeSeries{ts}}, "")
+	require.NoError(t, err)
+
+	dp := metricsByName(t, md.ResourceMetrics().At(0))["http_requests_total"].Sum().DataPoints().At(0)
+	require.Equal(t, 1, dp.Exemplars().Len())
+	exemplar := dp.Exemplars().At(0)
+	assert.Equal(t, 1.5, exemplar.DoubleValue())
+	assert.Equal(t, pcommon.Timestamp(900*1e6), exemplar.Timestamp())
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", exemplar.TraceID().HexString())
+	assert.Equal(t, "0102030405060708", exemplar.SpanID().HexString())
+	assert.Equal(t, map[string]interface{}{"user": "alice"}, exemplar.FilteredAttributes().AsRaw())
+}
+
+// sparseHistograms returns n native histograms with two buckets which are
+// width buckets apart, so that each one is converted to width dense buckets.
+func sparseHistograms(n int, width int32) []prompb.Histogram {
+	histograms := make([]prompb.Histogram, n)
+	for i := range histograms {
+		histograms[i] = prompb.Histogram{
+			Schema:         maxNativeSchema,
+			PositiveSpans:  []*prompb.BucketSpan{{Offset: -width / 2, Length: 1}, {Offset: width - 2, Length: 1}},
+			PositiveDeltas: []int64{1, 0},
+			Timestamp:      int64(i),
+		}
+	}
+	return histograms
+}
+
+func TestTranslateErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		series []prompb.TimeSeries
+		err    string
+	}{
+		{
+			name:   "invalid bucket bound",
+			series: []prompb.TimeSeries{series("latency_bucket", 1, 1000, "le", "fast")},
+			err:    `time series "latency_bucket": invalid le label`,
+		},
+		{
+			name: "conflicting types",
+			series: []prompb.TimeSeries{
+				series("latency_bucket", 1, 1000, "le", "+Inf"),
+				{
+					Labels:     []prompb.Label{{Name: "__name__", Value: "latency"}},
+					Histograms: []prompb.Histogram{{Sum: math.NaN()}},
+				},
+			},
+			err: `time series "latency": conflicting types for metric family "latency"`,
+		},
+		{
+			name: "native histogram span mismatch",
+			series: []prompb.TimeSeries{{
+				Labels: []prompb.Label{{Name: "__name__", Value: "latency"}},
+				Histograms: []prompb.Histogram{{
+					PositiveSpans:  []*prompb.BucketSpan{{Offset: 0, Length: 3}},
+					PositiveDeltas: []int64{1},
+				}},
+			}},
+			err: `time series "latency": positive buckets: spans cover 3 buckets but 1 deltas and 0 counts were sent`,
+		},
+		{
+			name: "native histogram unsupported schema",
+			series: []prompb.TimeSeries{{
+				Labels:     []prompb.Label{{Name: "__name__", Value: "latency"}},
+				Histograms: []prompb.Histogram{{Schema: 9}},
+			}},
+			err: `time series "latency": unsupported native histogram schema 9`,
+		},
+		{
+			name: "native histogram negative span offset",
+			series: []prompb.TimeSeries{{
+				Labels: []prompb.Label{{Name: "__name__", Value: "latency"}},
+				Histograms: []prompb.Histogram{{
+					PositiveSpans:  []*prompb.BucketSpan{{Offset: 0, Length: 1}, {Offset: -1, Length: 1}},
+					PositiveDeltas: []int64{1, 1},
+				}},
+			}},
+			err: `time series "latency": positive buckets: negative span offset -1`,
+		},
+		{
+			name: "native histogram span offset out of range",
+			series: []prompb.TimeSeries{{
+				Labels: []prompb.Label{{Name: "__name__", Value: "latency"}},
+				Histograms: []prompb.Histogram{{
+					NegativeSpans:  []*prompb.BucketSpan{{Offset: 0, Length: 1}, {Offset: math.MaxInt32, Length: 1}},
+					NegativeDeltas: []int64{1, 1},
+				}},
+			}},
+			err: `time series "latency": negative buckets: bucket indexes exceed the range of schema 0`,
+		},
+		{
+			name: "native histogram span length out of range",
+			series: []prompb.TimeSeries{{
+				Labels: []prompb.Label{{Name: "__name__", Value: "latency"}},
+				Histograms: []prompb.Histogram{{
+					Schema:         -4,
+					PositiveSpans:  []*prompb.BucketSpan{{Offset: -60, Length: math.MaxUint32}},
+					PositiveDeltas: []int64{1},
+				}},
+			}},
+			err: `time series "latency": positive buckets: bucket indexes exceed the range of schema -4`,
+		},
+		{
+			name: "native histogram first span offset out of range",
+			series: []prompb.TimeSeries{{
+				Labels: []prompb.Label{{Name: "__name__", Value: "latency"}},
+				Histograms: []prompb.Histogram{{
+					PositiveSpans:  []*prompb.BucketSpan{{Offset: math.MinInt32, Length: 1}},
+					PositiveDeltas: []int64{1},
+				}},
+			}},
+			err: `time series "latency": positive buckets: bucket indexes exceed the range of schema 0`,
+		},
+		{
+			name: "native histograms exceed the bucket budget of the request",
+			series: []prompb.TimeSeries{
+				{
+					Labels:     []prompb.Label{{Name: "__name__", Value: "latency"}},
+					Histograms: sparseHistograms(2, 280000),
+				},
+				{
+					Labels:     []prompb.Label{{Name: "__name__", Value: "size"}},
+					Histograms: sparseHistograms(2, 280000),
+				},
+			},
+			err: `time series "size": positive buckets: native histograms of the request exceed 1048576 buckets`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := translateWriteRequest(&prompb.WriteRequest{Timeseries: tt.series}, "")
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
@@ -105,14 +105,25 @@ func TestLoadConfig(t *testing.T) {
 					config.NewComponentIDWithName("mock_observer", "with_name"),
 				},
 				ResourceAttributes: map[observer.EndpointType]map[string]string{
-					observer.ContainerType: {"container.key": "container.value"},
-					observer.PodType:       {"pod.key": "pod.value"},
-					observer.PortType:      {"port.key": "port.value"},
-					observer.HostPortType:  {"hostport.key": "hostport.value"},
-					observer.K8sNodeType:   {"k8s.node.key": "k8s.node.value"},
+					observer.ContainerType:  {"container.key": "container.value"},
+					observer.PodType:        {"pod.key": "pod.value"},
+					observer.PortType:       {"port.key": "port.value"},
+					observer.HostPortType:   {"hostport.key": "hostport.value"},
+					observer.K8sNodeType:    {"k8s.node.key": "k8s.node.value"},
+					observer.K8sServiceType: {"k8s.service.key": "k8s.service.value"},
+					observer.K8sIngressType: {"k8s.ingress.key": "k8s.ingress.value"},
 				},
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "discovery"),
+			expected: func() config.Receiver {
+				cfg := createDefaultConfig().(*Config)
+				cfg.WatchObservers = []config.ComponentID{config.NewComponentID("k8s_observer")}
+				cfg.Discovery = discoveryConfig{Enabled: true, AllowedReceivers: []config.Type{"redis", "nginx"}}
+				return cfg
+			}(),
+		},
 	}
 
 	for _, tt := range tests {
@@ -143,6 +154,17 @@ func TestInvalidResourceAttributeEndpointType(t *testing.T) {
 	require.Nil(t, cfg)
 }
 
+func TestInvalidDiscovery(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "invalid-discovery.yaml"), factories)
+	require.Contains(t, err.Error(), "error reading receivers configuration for \"receiver_creator\": discovery requires at least one receiver type in allowed_receivers")
+	require.Nil(t, cfg)
+}
+
 func TestInvalidReceiverResourceAttributeValueType(t *testing.T) {
 	factories, err := componenttest.NopFactories()
 	require.Nil(t, err)
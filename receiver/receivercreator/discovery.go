@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/receivercreator"
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/config"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+const (
+	// discoveryPrefix is the prefix of the annotations and labels declaring a receiver. The prefix
+	// followed by .<port> only applies to the endpoints of that port.
+	discoveryPrefix = "io.opentelemetry.discovery.metrics"
+	// discoveryScraperKey is the key of the type of the receiver.
+	discoveryScraperKey = "scraper"
+	// discoveryConfigKey is the key of the YAML configuration of the receiver.
+	discoveryConfigKey = "config"
+	// discoveryEnabledKey is the key disabling the receiver when set to false.
+	discoveryEnabledKey = "enabled"
+	// discoveryPortKey is the key of the port the keys without port apply to.
+	discoveryPortKey = "port"
+)
+
+// discoveryConfig configures the receivers declared by the annotations or labels of the endpoints.
+type discoveryConfig struct {
+	// Enabled starts the receivers declared by the pod annotations of port endpoints and the labels of
+	// container endpoints.
+	Enabled bool `mapstructure:"enabled"`
+	// AllowedReceivers are the types of the receivers the endpoints can declare.
+	AllowedReceivers []config.Type `mapstructure:"allowed_receivers"`
+}
+
+func (d *discoveryConfig) validate() error {
+	if !d.Enabled {
+		return nil
+	}
+	if len(d.AllowedReceivers) == 0 {
+		return errors.New("discovery requires at least one receiver type in allowed_receivers")
+	}
+	for _, receiverType := range d.AllowedReceivers {
+		if receiverType == "" || strings.Contains(string(receiverType), "/") {
+			return fmt.Errorf("invalid receiver type %q in allowed_receivers", receiverType)
+		}
+	}
+	return nil
+}
+
+func (d *discoveryConfig) allowed(receiverType config.Type) bool {
+	for _, allowed := range d.AllowedReceivers {
+		if allowed == receiverType {
+			return true
+		}
+	}
+	return false
+}
+
+// discoveredReceiver returns the receiver declared by the annotations or labels of the endpoint,
+// if any. The returned receiver has no rule, it always applies to the endpoint.
+func (d *discoveryConfig) discoveredReceiver(e observer.Endpoint) (receiverTemplate, bool, error) {
+	var metadata map[string]string
+	var port uint16
+	switch details := e.Details.(type) {
+	case *observer.Port:
+		metadata, port = details.Pod.Annotations, details.Port
+	case *observer.Container:
+		metadata, port = details.Labels, details.Port
+	default:
+		return receiverTemplate{}, false, nil
+	}
+
+	// The annotations of a pod and the labels of a container apply to all their ports, so the keys
+	// without port only apply to the port they declare.
+	portString := strconv.Itoa(int(port))
+	withoutPort := strings.TrimSpace(metadata[discoveryPrefix+"/"+discoveryPortKey]) == portString
+	lookup := func(key string) (string, bool) {
+		if value, ok := metadata[discoveryPrefix+"."+portString+"/"+key]; ok {
+			return value, true
+		}
+		if !withoutPort {
+			return "", false
+		}
+		value, ok := metadata[discoveryPrefix+"/"+key]
+		return value, ok
+	}
+
+	scraper, ok := lookup(discoveryScraperKey)
+	if !ok {
+		return receiverTemplate{}, false, nil
+	}
+	if enabled, ok := lookup(discoveryEnabledKey); ok && strings.EqualFold(strings.TrimSpace(enabled), "false") {
+		return receiverTemplate{}, false, nil
+	}
+
+	receiverType := config.Type(strings.TrimSpace(scraper))
+	if !d.allowed(receiverType) {
+		return receiverTemplate{}, false, fmt.Errorf("receiver type %q is not in allowed_receivers", receiverType)
+	}
+
+	// unmarshalled into a plain map as nested maps get the type of the target map
+	cfg := map[string]interface{}{}
+	if rawConfig, ok := lookup(discoveryConfigKey); ok {
+		if err := yaml.Unmarshal([]byte(rawConfig), &cfg); err != nil {
+			return receiverTemplate{}, false, fmt.Errorf("invalid %s/%s: %w", discoveryPrefix, discoveryConfigKey, err)
+		}
+		if key, found := restrictedKey(cfg); found {
+			return receiverTemplate{}, false, fmt.Errorf("%s/%s cannot set %q", discoveryPrefix, discoveryConfigKey, key)
+		}
+	}
+
+	return receiverTemplate{
+		receiverConfig: receiverConfig{
+			id:         config.NewComponentIDWithName(receiverType, "discovery"),
+			config:     userConfigMap(cfg),
+			endpointID: e.ID,
+		},
+	}, true, nil
+}
+
+// restrictedKey returns the first key of the configuration, or of its nested mappings, that the endpoints
+// cannot set: the endpoint of the receiver, which is always the target of the endpoint, and the settings
+// naming paths, files or directories.
+func restrictedKey(cfg interface{}) (string, bool) {
+	switch value := cfg.(type) {
+	case map[string]interface{}:
+		for key, nested := range value {
+			if isRestrictedKey(key) {
+				return key, true
+			}
+			if restricted, found := restrictedKey(nested); found {
+				return key + "::" + restricted, true
+			}
+		}
+	case []interface{}:
+		for _, nested := range value {
+			if restricted, found := restrictedKey(nested); found {
+				return restricted, true
+			}
+		}
+	}
+	return "", false
+}
+
+func isRestrictedKey(key string) bool {
+	key = strings.ToLower(key)
+	if key == "endpoint" {
+		return true
+	}
+	for _, name := range []string{"path", "paths", "file", "files", "dir", "directory"} {
+		if key == name || strings.HasSuffix(key, "_"+name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func annotatedPortEndpoint(annotations map[string]string) observer.Endpoint {
+	annotatedPod := pod
+	annotatedPod.Annotations = annotations
+	return observer.Endpoint{
+		ID:     "port-1",
+		Target: "localhost:1234",
+		Details: &observer.Port{
+			Name:      "http",
+			Pod:       annotatedPod,
+			Port:      1234,
+			Transport: observer.ProtocolTCP,
+		},
+	}
+}
+
+func TestDiscoveredReceiver(t *testing.T) {
+	discovery := discoveryConfig{Enabled: true, AllowedReceivers: []config.Type{"redis", "nginx"}}
+
+	labeledContainer := container
+	labeledContainer.Labels = map[string]string{
+		"io.opentelemetry.discovery.metrics/port":    "8080",
+		"io.opentelemetry.discovery.metrics/scraper": "nginx",
+		"io.opentelemetry.discovery.metrics/config":  "collection_interval: 20s",
+	}
+
+	tests := []struct {
+		name        string
+		endpoint    observer.Endpoint
+		expected    userConfigMap
+		expectedID  config.ComponentID
+		notDeclared bool
+		expectedErr string
+	}{
+		{
+			name: "pod_annotations",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics/port":    "1234",
+				"io.opentelemetry.discovery.metrics/scraper": "redis",
+				"io.opentelemetry.discovery.metrics/config":  "collection_interval: 20s\nmetrics:\n  redis.maxmemory:\n    enabled: true\n",
+			}),
+			expectedID: config.NewComponentIDWithName("redis", "discovery"),
+			expected: userConfigMap{
+				"collection_interval": "20s",
+				"metrics": map[string]interface{}{
+					"redis.maxmemory": map[string]interface{}{"enabled": true},
+				},
+			},
+		},
+		{
+			name: "port_annotations",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics/scraper":      "redis",
+				"io.opentelemetry.discovery.metrics.1234/scraper": "nginx",
+				"io.opentelemetry.discovery.metrics.8080/config":  "endpoint: other",
+			}),
+			expectedID: config.NewComponentIDWithName("nginx", "discovery"),
+			expected:   userConfigMap{},
+		},
+		{
+			name:       "container_labels",
+			endpoint:   observer.Endpoint{ID: "container-1", Target: "localhost:1234", Details: &labeledContainer},
+			expectedID: config.NewComponentIDWithName("nginx", "discovery"),
+			expected:   userConfigMap{"collection_interval": "20s"},
+		},
+		{
+			name: "pod_annotations_other_port",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics/port":    "8080",
+				"io.opentelemetry.discovery.metrics/scraper": "redis",
+			}),
+			notDeclared: true,
+		},
+		{
+			name:        "pod_annotations_without_port",
+			endpoint:    annotatedPortEndpoint(map[string]string{"io.opentelemetry.discovery.metrics/scraper": "redis"}),
+			notDeclared: true,
+		},
+		{
+			name: "disabled_port",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics/port":         "1234",
+				"io.opentelemetry.discovery.metrics/scraper":      "redis",
+				"io.opentelemetry.discovery.metrics.1234/enabled": "false",
+			}),
+			notDeclared: true,
+		},
+		{
+			name:        "no_scraper",
+			endpoint:    annotatedPortEndpoint(map[string]string{"io.opentelemetry.discovery.metrics/config": "endpoint: other"}),
+			notDeclared: true,
+		},
+		{
+			name:        "pod_endpoint",
+			endpoint:    podEndpoint,
+			notDeclared: true,
+		},
+		{
+			name:        "not_allowed",
+			endpoint:    annotatedPortEndpoint(map[string]string{"io.opentelemetry.discovery.metrics.1234/scraper": "hostmetrics"}),
+			expectedErr: `receiver type "hostmetrics" is not in allowed_receivers`,
+		},
+		{
+			name: "invalid_config",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics.1234/scraper": "redis",
+				"io.opentelemetry.discovery.metrics.1234/config":  "not a map",
+			}),
+			expectedErr: "invalid io.opentelemetry.discovery.metrics/config",
+		},
+		{
+			name: "endpoint_override",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics.1234/scraper": "redis",
+				"io.opentelemetry.discovery.metrics.1234/config":  "endpoint: other:6379",
+			}),
+			expectedErr: `io.opentelemetry.discovery.metrics/config cannot set "endpoint"`,
+		},
+		{
+			name: "path_override",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics.1234/scraper": "nginx",
+				"io.opentelemetry.discovery.metrics.1234/config":  "metrics_path: /other",
+			}),
+			expectedErr: `io.opentelemetry.discovery.metrics/config cannot set "metrics_path"`,
+		},
+		{
+			name: "nested_file_override",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics.1234/scraper": "redis",
+				"io.opentelemetry.discovery.metrics.1234/config":  "tls:\n  ca_file: /etc/passwd\n",
+			}),
+			expectedErr: `io.opentelemetry.discovery.metrics/config cannot set "tls::ca_file"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template, ok, err := discovery.discoveredReceiver(tt.endpoint)
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.notDeclared {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedID, template.id)
+			assert.Equal(t, tt.expected, template.config)
+			assert.Equal(t, tt.endpoint.ID, template.endpointID)
+		})
+	}
+}
+
+func TestDiscoveryConfigValidate(t *testing.T) {
+	assert.NoError(t, (&discoveryConfig{}).validate())
+	assert.NoError(t, (&discoveryConfig{Enabled: true, AllowedReceivers: []config.Type{"redis"}}).validate())
+	assert.EqualError(t, (&discoveryConfig{Enabled: true}).validate(), "discovery requires at least one receiver type in allowed_receivers")
+	assert.EqualError(t, (&discoveryConfig{Enabled: true, AllowedReceivers: []config.Type{"redis/1"}}).validate(), `invalid receiver type "redis/1" in allowed_receivers`)
+}
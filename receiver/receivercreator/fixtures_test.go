@@ -103,6 +103,43 @@ var k8sNodeEndpoint = observer.Endpoint{
 	},
 }
 
+var k8sServiceEndpoint = observer.Endpoint{
+	ID:     "k8s.service-1",
+	Target: "redis.default.svc:9121",
+	Details: &observer.K8sService{
+		Annotations: map[string]string{
+			"prometheus.io/scrape": "true",
+		},
+		Labels: map[string]string{
+			"app": "redis",
+		},
+		Name:        "redis",
+		Namespace:   "default",
+		UID:         "a7b1f5e0-1c2d-4e3f-9a8b-7c6d5e4f3a2b",
+		ServiceType: "ClusterIP",
+		ClusterIP:   "10.0.0.1",
+		Port:        9121,
+		PortName:    "metrics",
+		Ports:       map[string]uint16{"redis": 6379, "metrics": 9121},
+	},
+}
+
+var k8sIngressEndpoint = observer.Endpoint{
+	ID:     "k8s.ingress-1",
+	Target: "https://example.com/api",
+	Details: &observer.K8sIngress{
+		Labels: map[string]string{
+			"app": "api",
+		},
+		Name:      "api",
+		Namespace: "default",
+		UID:       "c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f",
+		Scheme:    "https",
+		Host:      "example.com",
+		Path:      "/api",
+	},
+}
+
 var unsupportedEndpoint = observer.Endpoint{
 	ID:      "endpoint-1",
 	Target:  "localhost:1234",
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"sync"
 
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -29,6 +30,12 @@ var (
 	_ observer.Notify = (*observerHandler)(nil)
 )
 
+// startFailureReporter is implemented by the extensions recording the receivers that failed to start,
+// such as the health_check extension.
+type startFailureReporter interface {
+	ReportReceiverStartFailure(dataType config.DataType, id config.ComponentID, err error)
+}
+
 // observerHandler manages endpoint change notifications.
 type observerHandler struct {
 	sync.Mutex
@@ -40,6 +47,8 @@ type observerHandler struct {
 	nextConsumer consumer.Metrics
 	// runner starts and stops receiver instances.
 	runner runner
+	// reporters are notified of the receivers that failed to start.
+	reporters []startFailureReporter
 }
 
 // shutdown all receivers started at runtime.
@@ -89,75 +98,94 @@ func (obs *observerHandler) OnAdd(added []observer.Endpoint) {
 			} else if !matches {
 				continue
 			}
+			obs.startReceiver(template, env, e)
+		}
 
-			obs.logger.Info("starting receiver",
-				zap.String("name", template.id.String()),
-				zap.String("endpoint", e.Target),
-				zap.String("endpoint_id", string(e.ID)))
-
-			resolvedConfig, err := expandMap(template.config, env)
-			if err != nil {
-				obs.logger.Error("unable to resolve template config", zap.String("receiver", template.id.String()), zap.Error(err))
-				continue
-			}
+		if !obs.config.Discovery.Enabled {
+			continue
+		}
+		template, ok, err := obs.config.Discovery.discoveredReceiver(e)
+		if err != nil {
+			obs.logger.Warn("ignoring receiver declared by endpoint", zap.String("endpoint_id", string(e.ID)), zap.Error(err))
+			continue
+		}
+		if ok {
+			obs.startReceiver(template, env, e)
+		}
+	}
+}
 
-			discoveredConfig := userConfigMap{}
+// startReceiver starts a receiver of the template for the endpoint.
+func (obs *observerHandler) startReceiver(template receiverTemplate, env observer.EndpointEnv, e observer.Endpoint) {
+	obs.logger.Info("starting receiver",
+		zap.String("name", template.id.String()),
+		zap.String("endpoint", e.Target),
+		zap.String("endpoint_id", string(e.ID)))
+
+	resolvedConfig, err := expandMap(template.config, env)
+	if err != nil {
+		obs.logger.Error("unable to resolve template config", zap.String("receiver", template.id.String()), zap.Error(err))
+		return
+	}
 
-			// If user didn't set endpoint set to default value.
-			if _, ok := resolvedConfig[endpointConfigKey]; !ok {
-				discoveredConfig[endpointConfigKey] = e.Target
-			}
+	discoveredConfig := userConfigMap{}
 
-			resolvedDiscoveredConfig, err := expandMap(discoveredConfig, env)
+	// If user didn't set endpoint set to default value.
+	if _, ok := resolvedConfig[endpointConfigKey]; !ok {
+		discoveredConfig[endpointConfigKey] = e.Target
+	}
 
-			if err != nil {
-				obs.logger.Error("unable to resolve discovered config", zap.String("receiver", template.id.String()), zap.Error(err))
-				continue
-			}
+	resolvedDiscoveredConfig, err := expandMap(discoveredConfig, env)
 
-			resAttrs := map[string]string{}
-			for k, v := range template.ResourceAttributes {
-				strVal, ok := v.(string)
-				if !ok {
-					obs.logger.Info(fmt.Sprintf("ignoring unsupported `resource_attributes` %q value %v", k, v))
-					continue
-				}
-				resAttrs[k] = strVal
-			}
+	if err != nil {
+		obs.logger.Error("unable to resolve discovered config", zap.String("receiver", template.id.String()), zap.Error(err))
+		return
+	}
 
-			// Adds default and/or configured resource attributes (e.g. k8s.pod.uid) to resources
-			// as telemetry is emitted.
-			resourceEnhancer, err := newResourceEnhancer(
-				obs.config.ResourceAttributes,
-				resAttrs,
-				env,
-				e,
-				obs.nextConsumer,
-			)
-
-			if err != nil {
-				obs.logger.Error("failed creating resource enhancer", zap.String("receiver", template.id.String()), zap.Error(err))
-				continue
-			}
+	resAttrs := map[string]string{}
+	for k, v := range template.ResourceAttributes {
+		strVal, ok := v.(string)
+		if !ok {
+			obs.logger.Info(fmt.Sprintf("ignoring unsupported `resource_attributes` %q value %v", k, v))
+			continue
+		}
+		resAttrs[k] = strVal
+	}
 
-			rcvr, err := obs.runner.start(
-				receiverConfig{
-					id:         template.id,
-					config:     resolvedConfig,
-					endpointID: e.ID,
-				},
-				resolvedDiscoveredConfig,
-				resourceEnhancer,
-			)
-
-			if err != nil {
-				obs.logger.Error("failed to start receiver", zap.String("receiver", template.id.String()), zap.Error(err))
-				continue
-			}
+	// Adds default and/or configured resource attributes (e.g. k8s.pod.uid) to resources
+	// as telemetry is emitted.
+	resourceEnhancer, err := newResourceEnhancer(
+		obs.config.ResourceAttributes,
+		resAttrs,
+		env,
+		e,
+		obs.nextConsumer,
+	)
+
+	if err != nil {
+		obs.logger.Error("failed creating resource enhancer", zap.String("receiver", template.id.String()), zap.Error(err))
+		return
+	}
 
-			obs.receiversByEndpointID.Put(e.ID, rcvr)
+	rcvr, err := obs.runner.start(
+		receiverConfig{
+			id:         template.id,
+			config:     resolvedConfig,
+			endpointID: e.ID,
+		},
+		resolvedDiscoveredConfig,
+		resourceEnhancer,
+	)
+
+	if err != nil {
+		obs.logger.Error("failed to start receiver", zap.String("receiver", template.id.String()), zap.Error(err))
+		for _, reporter := range obs.reporters {
+			reporter.ReportReceiverStartFailure(config.MetricsDataType, obs.config.ID(), fmt.Errorf("%s: %w", template.id, err))
 		}
+		return
 	}
+
+	obs.receiversByEndpointID.Put(e.ID, rcvr)
 }
 
 // OnRemove responds to endpoint removal notifications.
@@ -15,6 +15,7 @@
 package receivercreator
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -47,6 +48,14 @@ func (run *mockRunner) shutdown(rcvr component.Receiver) error {
 
 var _ runner = (*mockRunner)(nil)
 
+type mockStartFailureReporter struct {
+	mock.Mock
+}
+
+func (r *mockStartFailureReporter) ReportReceiverStartFailure(dataType config.DataType, id config.ComponentID, err error) {
+	r.Called(dataType, id, err.Error())
+}
+
 func TestOnAdd(t *testing.T) {
 	runner := &mockRunner{}
 
@@ -78,6 +87,76 @@ func TestOnAdd(t *testing.T) {
 	assert.Equal(t, 1, handler.receiversByEndpointID.Size())
 }
 
+func TestOnAddStartFailure(t *testing.T) {
+	runner := &mockRunner{}
+	reporter := &mockStartFailureReporter{}
+
+	rcvrCfg := receiverConfig{id: config.NewComponentIDWithName("name", "1"), config: userConfigMap{"foo": "bar"}, endpointID: portEndpoint.ID}
+	cfg := createDefaultConfig().(*Config)
+	cfg.receiverTemplates = map[string]receiverTemplate{
+		"name/1": {rcvrCfg, "", map[string]interface{}{}, newRuleOrPanic(`type == "port"`)},
+	}
+	handler := &observerHandler{
+		config:                cfg,
+		logger:                zap.NewNop(),
+		receiversByEndpointID: receiverMap{},
+		runner:                runner,
+		reporters:             []startFailureReporter{reporter},
+	}
+
+	runner.On(
+		"start",
+		rcvrCfg,
+		userConfigMap{endpointConfigKey: "localhost:1234"},
+		mock.IsType(&resourceEnhancer{}),
+	).Return(&nopWithEndpointReceiver{}, errors.New("connection refused"))
+	reporter.On("ReportReceiverStartFailure", config.MetricsDataType, cfg.ID(), "name/1: connection refused")
+
+	handler.OnAdd([]observer.Endpoint{portEndpoint})
+
+	runner.AssertExpectations(t)
+	reporter.AssertExpectations(t)
+	assert.Equal(t, 0, handler.receiversByEndpointID.Size())
+}
+
+func TestOnAddDiscovery(t *testing.T) {
+	runner := &mockRunner{}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Discovery = discoveryConfig{Enabled: true, AllowedReceivers: []config.Type{"redis"}}
+	handler := &observerHandler{
+		config:                cfg,
+		logger:                zap.NewNop(),
+		receiversByEndpointID: receiverMap{},
+		runner:                runner,
+	}
+
+	runner.On(
+		"start",
+		receiverConfig{
+			id:         config.NewComponentIDWithName("redis", "discovery"),
+			config:     userConfigMap{"password": "secret"},
+			endpointID: "port-1",
+		},
+		userConfigMap{endpointConfigKey: "localhost:1234"},
+		mock.IsType(&resourceEnhancer{}),
+	).Return(&nopWithEndpointReceiver{}, nil)
+
+	notAllowed := annotatedPortEndpoint(map[string]string{"io.opentelemetry.discovery.metrics.1234/scraper": "hostmetrics"})
+	notAllowed.ID = "port-2"
+	handler.OnAdd([]observer.Endpoint{
+		annotatedPortEndpoint(map[string]string{
+			"io.opentelemetry.discovery.metrics/port":    "1234",
+			"io.opentelemetry.discovery.metrics/scraper": "redis",
+			"io.opentelemetry.discovery.metrics/config":  "password: secret",
+		}),
+		notAllowed,
+		portEndpoint,
+	})
+
+	runner.AssertExpectations(t)
+	assert.Equal(t, 1, handler.receiversByEndpointID.Size())
+}
+
 func TestOnRemove(t *testing.T) {
 	runner := &mockRunner{}
 	rcvr := &nopWithEndpointReceiver{}
@@ -78,6 +78,12 @@ func (rc *receiverCreator) Start(_ context.Context, host component.Host) error {
 		},
 	}
 
+	for _, ext := range host.GetExtensions() {
+		if reporter, ok := ext.(startFailureReporter); ok {
+			rc.observerHandler.reporters = append(rc.observerHandler.reporters, reporter)
+		}
+	}
+
 	observers := map[config.ComponentID]observer.Observable{}
 
 	// Match all configured observables to the extensions that are running.
@@ -31,6 +31,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/obsreport"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/connctx"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/jaeger"
 )
@@ -180,6 +181,7 @@ func (sr *sapmReceiver) Start(_ context.Context, host component.Host) error {
 	if err != nil {
 		return err
 	}
+	sr.server.ConnContext = connctx.ConnContext
 
 	sr.shutdownWG.Add(1)
 	// run the server on a routine
@@ -19,6 +19,7 @@ import (
 	"compress/gzip"
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -31,6 +32,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer/consumertest"
@@ -38,6 +41,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/connctx"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
 )
@@ -316,6 +320,57 @@ func TestReception(t *testing.T) {
 	}
 }
 
+// authHost provides the server authenticator of the receiver.
+type authHost struct {
+	component.Host
+	authenticator configauth.ServerAuthenticator
+}
+
+func (h *authHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return map[config.ComponentID]component.Extension{config.NewComponentID("cert"): h.authenticator}
+}
+
+func TestClientCertificateAuthentication(t *testing.T) {
+	commonNames := make(chan string, 1)
+	authenticator := configauth.NewServerAuthenticator(configauth.WithAuthenticate(func(ctx context.Context, _ map[string][]string) (context.Context, error) {
+		state, ok := connctx.TLSConnectionState(ctx)
+		if !ok || len(state.VerifiedChains) == 0 {
+			return ctx, errors.New("no verified client certificate")
+		}
+		commonNames <- state.VerifiedChains[0][0].Subject.CommonName
+		return ctx, nil
+	}))
+	config := &Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+			TLSSetting: &configtls.TLSServerSetting{
+				TLSSetting: configtls.TLSSetting{
+					CertFile: "./testdata/server.crt",
+					KeyFile:  "./testdata/server.key",
+				},
+				ClientCAFile: "./testdata/ca.crt",
+			},
+			Auth: &configauth.Authentication{AuthenticatorID: config.NewComponentID("cert")},
+		},
+	}
+
+	sink := new(consumertest.TracesSink)
+	sr, err := newReceiver(componenttest.NewNopReceiverCreateSettings(), config, sink)
+	require.NoError(t, err)
+	require.NoError(t, sr.Start(context.Background(), &authHost{Host: newAssertNoErrorHost(t), authenticator: authenticator}))
+	defer func() {
+		require.NoError(t, sr.Shutdown(context.Background()))
+	}()
+
+	sapm := &splunksapm.PostSpansRequest{Batches: []*model.Batch{grpcFixture(time.Now().UTC())}}
+	resp, err := sendSapm(config.Endpoint, sapm, false, true, "")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "MyCommonName", <-commonNames)
+	assert.Len(t, sink.AllTraces(), 1)
+}
+
 func TestAccessTokenPassthrough(t *testing.T) {
 	tests := []struct {
 		name                   string
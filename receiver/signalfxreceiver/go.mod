@@ -15,7 +15,6 @@ require (
 	go.opentelemetry.io/collector/pdata v0.63.0
 	go.opentelemetry.io/collector/semconv v0.63.0
 	go.uber.org/zap v1.23.0
-
 )
 
 require (
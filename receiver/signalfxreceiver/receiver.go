@@ -36,6 +36,7 @@ import (
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/connctx"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signalfx"
 )
@@ -147,6 +148,7 @@ func (r *sfxReceiver) Start(_ context.Context, host component.Host) error {
 	if err != nil {
 		return err
 	}
+	r.server.ConnContext = connctx.ConnContext
 
 	// TODO: Evaluate what properties should be configurable, for now
 	//		set some hard-coded values.
@@ -38,6 +38,8 @@ import (
 	v3 "skywalking.apache.org/repo/goapi/collect/language/agent/v3"
 	profile "skywalking.apache.org/repo/goapi/collect/language/profile/v3"
 	management "skywalking.apache.org/repo/goapi/collect/management/v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/connctx"
 )
 
 // configuration defines the behavior and the ports that
@@ -155,6 +157,7 @@ func (sr *swReceiver) startCollector(host component.Host) error {
 		if cerr != nil {
 			return cerr
 		}
+		sr.collectorServer.ConnContext = connctx.ConnContext
 
 		sr.goroutines.Add(1)
 		go func() {
@@ -36,6 +36,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/connctx"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
 )
 
@@ -194,6 +195,7 @@ func (r *splunkReceiver) Start(_ context.Context, host component.Host) error {
 	if err != nil {
 		return err
 	}
+	r.server.ConnContext = connctx.ConnContext
 
 	// TODO: Evaluate what properties should be configurable, for now
 	//		set some hard-coded values.
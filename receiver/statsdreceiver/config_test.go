@@ -67,6 +67,27 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "unix"),
+			expected: &Config{
+				ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+				NetAddr: confignet.NetAddr{
+					Endpoint:  "/var/run/statsd.sock",
+					Transport: "unixgram",
+				},
+				AggregationInterval: 60 * time.Second,
+				TimerHistogramMapping: []protocol.TimerHistogramMapping{
+					{
+						StatsdType:   "timing",
+						ObserverType: "gauge",
+					},
+					{
+						StatsdType:   "distribution",
+						ObserverType: "summary",
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -60,7 +60,7 @@ func createDefaultConfig() config.Receiver {
 		AggregationInterval:   defaultAggregationInterval,
 		EnableMetricType:      defaultEnableMetricType,
 		IsMonotonicCounter:    defaultIsMonotonicCounter,
-		TimerHistogramMapping: defaultTimerHistogramMapping,
+		TimerHistogramMapping: append([]protocol.TimerHistogramMapping(nil), defaultTimerHistogramMapping...),
 	}
 }
 
@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DogStatsD events and service checks are not metrics, they are mapped to metrics as follows:
+//   - events are counted in the dogstatsd.events counter, with the title, priority, alert
+//     type and source of the events as attributes.
+//   - service checks are reported as a gauge named after the check, whose value is the
+//     status of the check: 0 (OK), 1 (WARNING), 2 (CRITICAL) or 3 (UNKNOWN).
+//
+// See https://docs.datadoghq.com/developers/dogstatsd/datagram_shell for the formats.
+const (
+	eventPrefix        = "_e{"
+	serviceCheckPrefix = "_sc|"
+
+	// EventsMetricName is the name of the counter DogStatsD events are counted in.
+	EventsMetricName = "dogstatsd.events"
+
+	tagEventTitle      = "event.title"
+	tagEventPriority   = "event.priority"
+	tagEventAlertType  = "event.alert_type"
+	tagEventSourceType = "event.source_type"
+	tagHostName        = "host.name"
+
+	defaultEventPriority  = "normal"
+	defaultEventAlertType = "info"
+)
+
+// parseEventToMetric parses a DogStatsD event of the form
+// _e{<TITLE_LENGTH>,<TEXT_LENGTH>}:<TITLE>|<TEXT>|d:<TIMESTAMP>|h:<HOSTNAME>|k:<AGGREGATION_KEY>|p:<PRIORITY>|s:<SOURCE_TYPE>|t:<ALERT_TYPE>|#<TAGS>|c:<CONTAINER_ID>
+// into a sample of the events counter.
+func parseEventToMetric(line string, enableMetricType bool) (statsDMetric, error) {
+	result := statsDMetric{}
+
+	header, body, ok := strings.Cut(strings.TrimPrefix(line, eventPrefix), "}:")
+	if !ok {
+		return result, fmt.Errorf("invalid event format: %s", line)
+	}
+	titleLenStr, textLenStr, ok := strings.Cut(header, ",")
+	if !ok {
+		return result, fmt.Errorf("invalid event lengths: %s", header)
+	}
+	titleLen, err := strconv.Atoi(titleLenStr)
+	if err != nil || titleLen <= 0 {
+		return result, fmt.Errorf("invalid event title length: %s", titleLenStr)
+	}
+	textLen, err := strconv.Atoi(textLenStr)
+	if err != nil || textLen < 0 {
+		return result, fmt.Errorf("invalid event text length: %s", textLenStr)
+	}
+	// The lengths are in bytes, and the title and text may contain '|'.
+	if len(body) < titleLen+1+textLen || body[titleLen] != '|' {
+		return result, fmt.Errorf("event title and text do not match their lengths: %s", line)
+	}
+	title := body[:titleLen]
+	rest := body[titleLen+1+textLen:]
+
+	kvs := []attribute.KeyValue{attribute.String(tagEventTitle, title)}
+	priority := defaultEventPriority
+	alertType := defaultEventAlertType
+	if rest != "" {
+		if rest[0] != '|' {
+			return result, fmt.Errorf("event title and text do not match their lengths: %s", line)
+		}
+		for _, part := range strings.Split(rest[1:], "|") {
+			switch {
+			case strings.HasPrefix(part, "d:"), strings.HasPrefix(part, "k:"):
+				// The timestamp and aggregation key have no equivalent in the counter.
+			case strings.HasPrefix(part, "h:"):
+				kvs = append(kvs, attribute.String(tagHostName, strings.TrimPrefix(part, "h:")))
+			case strings.HasPrefix(part, "p:"):
+				priority = strings.TrimPrefix(part, "p:")
+			case strings.HasPrefix(part, "s:"):
+				kvs = append(kvs, attribute.String(tagEventSourceType, strings.TrimPrefix(part, "s:")))
+			case strings.HasPrefix(part, "t:"):
+				alertType = strings.TrimPrefix(part, "t:")
+			case strings.HasPrefix(part, "#"):
+				tags, err := parseTags(strings.TrimPrefix(part, "#"))
+				if err != nil {
+					return result, err
+				}
+				kvs = append(kvs, tags...)
+			case strings.HasPrefix(part, "c:"):
+				kvs = append(kvs, attribute.String(tagContainerID, strings.TrimPrefix(part, "c:")))
+			default:
+				return result, fmt.Errorf("unrecognized event part: %s", part)
+			}
+		}
+	}
+	kvs = append(kvs,
+		attribute.String(tagEventPriority, priority),
+		attribute.String(tagEventAlertType, alertType))
+
+	result.description.name = EventsMetricName
+	result.description.metricType = CounterType
+	result.description.attrs = buildAttributeSet(kvs, CounterType, enableMetricType)
+	result.asFloat = 1
+	return result, nil
+}
+
+// parseServiceCheckToMetric parses a DogStatsD service check of the form
+// _sc|<NAME>|<STATUS>|d:<TIMESTAMP>|h:<HOSTNAME>|#<TAGS>|c:<CONTAINER_ID>|m:<MESSAGE>
+// into a gauge sample holding the status of the check.
+func parseServiceCheckToMetric(line string, enableMetricType bool) (statsDMetric, error) {
+	result := statsDMetric{}
+
+	// The message comes last, and may contain '|'.
+	line, _, _ = strings.Cut(line, "|m:")
+	parts := strings.Split(strings.TrimPrefix(line, serviceCheckPrefix), "|")
+	if len(parts) < 2 {
+		return result, fmt.Errorf("invalid service check format: %s", line)
+	}
+	result.description.name = parts[0]
+	if result.description.name == "" {
+		return result, errEmptyMetricName
+	}
+	status, err := strconv.Atoi(parts[1])
+	if err != nil || status < 0 || status > 3 {
+		return result, fmt.Errorf("invalid service check status: %s", parts[1])
+	}
+
+	var kvs []attribute.KeyValue
+	for _, part := range parts[2:] {
+		switch {
+		case strings.HasPrefix(part, "d:"):
+			// The timestamp has no equivalent in the gauge.
+		case strings.HasPrefix(part, "h:"):
+			kvs = append(kvs, attribute.String(tagHostName, strings.TrimPrefix(part, "h:")))
+		case strings.HasPrefix(part, "#"):
+			tags, err := parseTags(strings.TrimPrefix(part, "#"))
+			if err != nil {
+				return result, err
+			}
+			kvs = append(kvs, tags...)
+		case strings.HasPrefix(part, "c:"):
+			kvs = append(kvs, attribute.String(tagContainerID, strings.TrimPrefix(part, "c:")))
+		default:
+			return result, fmt.Errorf("unrecognized service check part: %s", part)
+		}
+	}
+
+	result.description.metricType = GaugeType
+	result.description.attrs = buildAttributeSet(kvs, GaugeType, enableMetricType)
+	result.asFloat = float64(status)
+	return result, nil
+}
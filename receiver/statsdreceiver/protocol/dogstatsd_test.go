@@ -0,0 +1,377 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lightstep/go-expohisto/mapping/logarithm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/metricstestutil"
+)
+
+func Test_ParseMessageToMetric_DogStatsD(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantMetric statsDMetric
+		err        error
+	}{
+		{
+			name:  "distribution with sample rate and tags",
+			input: "test.metric:42.5|d|@0.5|#key:value",
+			wantMetric: testStatsDMetric(
+				"test.metric",
+				42.5,
+				false,
+				"d",
+				0.5,
+				[]string{"key"},
+				[]string{"value"}),
+		},
+		{
+			name:  "set with non numeric value",
+			input: "test.metric:user-1|s|#key:value",
+			wantMetric: statsDMetric{
+				description: testDescription("test.metric", "s", []string{"key"}, []string{"value"}),
+				setValue:    "user-1",
+			},
+		},
+		{
+			name:  "container id",
+			input: "test.metric:42|c|#key:value|c:ci-1234",
+			wantMetric: testStatsDMetric(
+				"test.metric",
+				42,
+				false,
+				"c",
+				0,
+				[]string{"key", "container.id"},
+				[]string{"value", "ci-1234"}),
+		},
+		{
+			name:  "valueless tag",
+			input: "test.metric:42|c|#env:prod,canary",
+			wantMetric: testStatsDMetric(
+				"test.metric",
+				42,
+				false,
+				"c",
+				0,
+				[]string{"env", "canary"},
+				[]string{"prod", ""}),
+		},
+		{
+			name:  "invalid distribution value",
+			input: "test.metric:abc|d",
+			err:   errors.New("parse metric value string: abc"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMessageToMetric(tt.input, false)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantMetric, got)
+			}
+		})
+	}
+}
+
+func Test_ParseEventToMetric(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantAttrs map[string]string
+		err       string
+	}{
+		{
+			name:  "title and text only",
+			input: "_e{5,4}:title|text",
+			wantAttrs: map[string]string{
+				"event.title":      "title",
+				"event.priority":   "normal",
+				"event.alert_type": "info",
+			},
+		},
+		{
+			name:  "all fields",
+			input: "_e{9,5}:deploy|v2|a|b|c|d:1669000000|h:web-1|k:key|p:low|s:jenkins|t:success|#env:prod|c:ci-1234",
+			wantAttrs: map[string]string{
+				"event.title":       "deploy|v2",
+				"event.priority":    "low",
+				"event.alert_type":  "success",
+				"event.source_type": "jenkins",
+				"host.name":         "web-1",
+				"env":               "prod",
+				"container.id":      "ci-1234",
+			},
+		},
+		{
+			name:  "multi-byte title",
+			input: "_e{6,0}:héllo|",
+			wantAttrs: map[string]string{
+				"event.title":      "héllo",
+				"event.priority":   "normal",
+				"event.alert_type": "info",
+			},
+		},
+		{
+			name:  "missing lengths",
+			input: "_e{5}:title|text",
+			err:   "invalid event lengths: 5",
+		},
+		{
+			name:  "invalid title length",
+			input: "_e{a,4}:title|text",
+			err:   "invalid event title length: a",
+		},
+		{
+			name:  "lengths do not match",
+			input: "_e{4,4}:title|text",
+			err:   "event title and text do not match their lengths: _e{4,4}:title|text",
+		},
+		{
+			name:  "text too short",
+			input: "_e{5,10}:title|text",
+			err:   "event title and text do not match their lengths: _e{5,10}:title|text",
+		},
+		{
+			name:  "unrecognized part",
+			input: "_e{5,4}:title|text|x:y",
+			err:   "unrecognized event part: x:y",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseEventToMetric(tt.input, false)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, EventsMetricName, got.description.name)
+			assert.Equal(t, CounterType, got.description.metricType)
+			assert.Equal(t, 1.0, got.asFloat)
+			assert.Equal(t, tt.wantAttrs, attributesToMap(got.description.attrs))
+		})
+	}
+}
+
+func Test_ParseServiceCheckToMetric(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantName   string
+		wantStatus float64
+		wantAttrs  map[string]string
+		err        string
+	}{
+		{
+			name:       "name and status only",
+			input:      "_sc|my.check|0",
+			wantName:   "my.check",
+			wantStatus: 0,
+			wantAttrs:  map[string]string{},
+		},
+		{
+			name:       "all fields",
+			input:      "_sc|my.check|2|d:1669000000|h:web-1|#env:prod,canary|c:ci-1234|m:disk | full",
+			wantName:   "my.check",
+			wantStatus: 2,
+			wantAttrs: map[string]string{
+				"host.name":    "web-1",
+				"env":          "prod",
+				"canary":       "",
+				"container.id": "ci-1234",
+			},
+		},
+		{
+			name:  "missing status",
+			input: "_sc|my.check",
+			err:   "invalid service check format: _sc|my.check",
+		},
+		{
+			name:  "invalid status",
+			input: "_sc|my.check|4",
+			err:   "invalid service check status: 4",
+		},
+		{
+			name:  "empty name",
+			input: "_sc||0",
+			err:   "empty metric name",
+		},
+		{
+			name:  "unrecognized part",
+			input: "_sc|my.check|0|x:y",
+			err:   "unrecognized service check part: x:y",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseServiceCheckToMetric(tt.input, false)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, got.description.name)
+			assert.Equal(t, GaugeType, got.description.metricType)
+			assert.Equal(t, tt.wantStatus, got.asFloat)
+			assert.Equal(t, tt.wantAttrs, attributesToMap(got.description.attrs))
+		})
+	}
+}
+
+func attributesToMap(set attribute.Set) map[string]string {
+	attrs := make(map[string]string)
+	for i := set.Iter(); i.Next(); {
+		attrs[string(i.Attribute().Key)] = i.Attribute().Value.AsString()
+	}
+	return attrs
+}
+
+func TestStatsDParser_AggregateDogStatsD(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	newMetric := func(name string) (pmetric.Metrics, pmetric.Metric) {
+		data := pmetric.NewMetrics()
+		m := data.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName(name)
+		return data, m
+	}
+
+	tests := []struct {
+		name     string
+		input    []string
+		expected pmetric.Metrics
+	}{
+		{
+			name: "set",
+			input: []string{
+				"users:alice|s|#mykey:myvalue",
+				"users:bob|s|#mykey:myvalue",
+				"users:alice|s|#mykey:myvalue",
+			},
+			expected: func() pmetric.Metrics {
+				data, m := newMetric("users")
+				dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+				dp.SetIntValue(2)
+				dp.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(711, 0)))
+				dp.Attributes().PutStr("mykey", "myvalue")
+				return data
+			}(),
+		},
+		{
+			name: "distribution",
+			input: []string{
+				"latency:1|d|#mykey:myvalue",
+				"latency:0|d|@0.5|#mykey:myvalue",
+				"latency:-1|d|#mykey:myvalue",
+			},
+			expected: func() pmetric.Metrics {
+				data, m := newMetric("latency")
+				ep := m.SetEmptyExponentialHistogram()
+				ep.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+				dp := ep.DataPoints().AppendEmpty()
+				dp.Attributes().PutStr("mykey", "myvalue")
+				dp.SetCount(4)
+				dp.SetSum(0)
+				dp.SetMin(-1)
+				dp.SetMax(1)
+				dp.SetZeroCount(2)
+				dp.SetScale(logarithm.MaxScale)
+				dp.Positive().SetOffset(-1)
+				dp.Negative().SetOffset(-1)
+				dp.Positive().BucketCounts().FromRaw([]uint64{1})
+				dp.Negative().BucketCounts().FromRaw([]uint64{1})
+				return data
+			}(),
+		},
+		{
+			name: "events",
+			input: []string{
+				"_e{6,3}:deploy|foo|t:success",
+				"_e{6,3}:deploy|bar|t:success",
+			},
+			expected: func() pmetric.Metrics {
+				data, m := newMetric(EventsMetricName)
+				m.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+				dp := m.Sum().DataPoints().AppendEmpty()
+				dp.SetIntValue(2)
+				dp.SetStartTimestamp(pcommon.NewTimestampFromTime(time.Unix(611, 0)))
+				dp.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(711, 0)))
+				dp.Attributes().PutStr("event.alert_type", "success")
+				dp.Attributes().PutStr("event.priority", "normal")
+				dp.Attributes().PutStr("event.title", "deploy")
+				return data
+			}(),
+		},
+		{
+			name: "service check",
+			input: []string{
+				"_sc|my.check|2|#env:prod|m:down",
+				"_sc|my.check|0|#env:prod",
+			},
+			expected: func() pmetric.Metrics {
+				data, m := newMetric("my.check")
+				dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+				dp.SetDoubleValue(0)
+				dp.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(711, 0)))
+				dp.Attributes().PutStr("env", "prod")
+				return data
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &StatsDParser{}
+			assert.NoError(t, p.Initialize(false, false, nil))
+			p.lastIntervalTime = time.Unix(611, 0)
+			for _, line := range tt.input {
+				assert.NoError(t, p.Aggregate(line))
+			}
+			var nodiffs []*metricstestutil.MetricDiff
+			assert.Equal(t, nodiffs, metricstestutil.DiffMetrics(nodiffs, tt.expected, p.GetMetrics()))
+		})
+	}
+}
+
+func TestStatsDParser_DistributionMapping(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+	p := &StatsDParser{}
+	assert.NoError(t, p.Initialize(false, false, []TimerHistogramMapping{{StatsdType: "distribution", ObserverType: "gauge"}}))
+	assert.NoError(t, p.Aggregate("latency:1|d"))
+	assert.Equal(t, []pmetric.ScopeMetrics{
+		buildGaugeMetric(testStatsDMetric("latency", 1, false, "d", 0, nil, nil), time.Unix(711, 0)),
+	}, p.timersAndDistributions)
+	assert.Empty(t, p.histograms)
+}
@@ -39,17 +39,24 @@ type (
 
 const (
 	tagMetricType = "metric_type"
+	// tagContainerID is the attribute the DogStatsD container ID field is recorded in.
+	tagContainerID = "container.id"
 
 	CounterType   MetricType = "c"
 	GaugeType     MetricType = "g"
 	HistogramType MetricType = "h"
 	TimingType    MetricType = "ms"
-
-	CounterTypeName   TypeName = "counter"
-	GaugeTypeName     TypeName = "gauge"
-	HistogramTypeName TypeName = "histogram"
-	TimingTypeName    TypeName = "timing"
-	TimingAltTypeName TypeName = "timer"
+	// DistributionType and SetType are DogStatsD extensions.
+	DistributionType MetricType = "d"
+	SetType          MetricType = "s"
+
+	CounterTypeName      TypeName = "counter"
+	GaugeTypeName        TypeName = "gauge"
+	HistogramTypeName    TypeName = "histogram"
+	TimingTypeName       TypeName = "timing"
+	TimingAltTypeName    TypeName = "timer"
+	DistributionTypeName TypeName = "distribution"
+	SetTypeName          TypeName = "set"
 
 	GaugeObserver     ObserverType = "gauge"
 	SummaryObserver   ObserverType = "summary"
@@ -78,17 +85,26 @@ var defaultObserverCategory = ObserverCategory{
 	method: DefaultObserverType,
 }
 
+// defaultDistributionObserverCategory aggregates distributions into exponential histograms,
+// as distributions are meant to be aggregated server-side.
+var defaultDistributionObserverCategory = ObserverCategory{
+	method:          HistogramObserver,
+	histogramConfig: expoHistogramConfig(HistogramConfig{}),
+}
+
 // StatsDParser supports the Parse method for parsing StatsD messages with Tags.
 type StatsDParser struct {
 	gauges                 map[statsDMetricDescription]pmetric.ScopeMetrics
 	counters               map[statsDMetricDescription]pmetric.ScopeMetrics
 	summaries              map[statsDMetricDescription]summaryMetric
 	histograms             map[statsDMetricDescription]histogramMetric
+	sets                   map[statsDMetricDescription]map[string]struct{}
 	timersAndDistributions []pmetric.ScopeMetrics
 	enableMetricType       bool
 	isMonotonicCounter     bool
 	timerEvents            ObserverCategory
 	histogramEvents        ObserverCategory
+	distributionEvents     ObserverCategory
 	lastIntervalTime       time.Time
 }
 
@@ -111,9 +127,11 @@ type histogramMetric struct {
 type statsDMetric struct {
 	description statsDMetricDescription
 	asFloat     float64
-	addition    bool
-	unit        string
-	sampleRate  float64
+	// setValue is the raw value of set metrics, which need not be numeric.
+	setValue   string
+	addition   bool
+	unit       string
+	sampleRate float64
 }
 
 type statsDMetricDescription struct {
@@ -132,6 +150,10 @@ func (t MetricType) FullName() TypeName {
 		return TimingTypeName
 	case HistogramType:
 		return HistogramTypeName
+	case DistributionType:
+		return DistributionTypeName
+	case SetType:
+		return SetTypeName
 	}
 	return TypeName(fmt.Sprintf("unknown(%s)", t))
 }
@@ -143,6 +165,7 @@ func (p *StatsDParser) resetState(when time.Time) {
 	p.timersAndDistributions = nil
 	p.summaries = make(map[statsDMetricDescription]summaryMetric)
 	p.histograms = make(map[statsDMetricDescription]histogramMetric)
+	p.sets = make(map[statsDMetricDescription]map[string]struct{})
 }
 
 func (p *StatsDParser) Initialize(enableMetricType bool, isMonotonicCounter bool, sendTimerHistogram []TimerHistogramMapping) error {
@@ -150,6 +173,7 @@ func (p *StatsDParser) Initialize(enableMetricType bool, isMonotonicCounter bool
 
 	p.histogramEvents = defaultObserverCategory
 	p.timerEvents = defaultObserverCategory
+	p.distributionEvents = defaultDistributionObserverCategory
 	p.enableMetricType = enableMetricType
 	p.isMonotonicCounter = isMonotonicCounter
 	// Note: validation occurs in ("../".Config).validate()
@@ -161,6 +185,9 @@ func (p *StatsDParser) Initialize(enableMetricType bool, isMonotonicCounter bool
 		case TimingTypeName, TimingAltTypeName:
 			p.timerEvents.method = eachMap.ObserverType
 			p.timerEvents.histogramConfig = expoHistogramConfig(eachMap.Histogram)
+		case DistributionTypeName:
+			p.distributionEvents.method = eachMap.ObserverType
+			p.distributionEvents.histogramConfig = expoHistogramConfig(eachMap.Histogram)
 		}
 	}
 	return nil
@@ -193,6 +220,10 @@ func (p *StatsDParser) GetMetrics() pmetric.Metrics {
 
 	now := timeNowFunc()
 
+	for desc, values := range p.sets {
+		buildSetMetric(desc, values, now, rm.ScopeMetrics().AppendEmpty())
+	}
+
 	for desc, summaryMetric := range p.summaries {
 		buildSummaryMetric(
 			desc,
@@ -225,13 +256,24 @@ func (p *StatsDParser) observerCategoryFor(t MetricType) ObserverCategory {
 		return p.histogramEvents
 	case TimingType:
 		return p.timerEvents
+	case DistributionType:
+		return p.distributionEvents
 	}
 	return defaultObserverCategory
 }
 
 // Aggregate for each metric line.
 func (p *StatsDParser) Aggregate(line string) error {
-	parsedMetric, err := parseMessageToMetric(line, p.enableMetricType)
+	var parsedMetric statsDMetric
+	var err error
+	switch {
+	case strings.HasPrefix(line, eventPrefix):
+		parsedMetric, err = parseEventToMetric(line, p.enableMetricType)
+	case strings.HasPrefix(line, serviceCheckPrefix):
+		parsedMetric, err = parseServiceCheckToMetric(line, p.enableMetricType)
+	default:
+		parsedMetric, err = parseMessageToMetric(line, p.enableMetricType)
+	}
 	if err != nil {
 		return err
 	}
@@ -260,7 +302,15 @@ func (p *StatsDParser) Aggregate(line string) error {
 			point.SetIntValue(point.IntValue() + parsedMetric.counterValue())
 		}
 
-	case TimingType, HistogramType:
+	case SetType:
+		values, ok := p.sets[parsedMetric.description]
+		if !ok {
+			values = make(map[string]struct{})
+			p.sets[parsedMetric.description] = values
+		}
+		values[parsedMetric.setValue] = struct{}{}
+
+	case TimingType, HistogramType, DistributionType:
 		category := p.observerCategoryFor(parsedMetric.description.metricType)
 		switch category.method {
 		case GaugeObserver:
@@ -325,13 +375,9 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 	if valueStr == "" {
 		return result, errEmptyMetricValue
 	}
-	if strings.HasPrefix(valueStr, "-") || strings.HasPrefix(valueStr, "+") {
-		result.addition = true
-	}
-
 	inType := MetricType(parts[1])
 	switch inType {
-	case CounterType, GaugeType, HistogramType, TimingType:
+	case CounterType, GaugeType, HistogramType, TimingType, DistributionType, SetType:
 		result.description.metricType = inType
 	default:
 		return result, fmt.Errorf("unsupported metric type: %s", inType)
@@ -353,39 +399,59 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 
 			result.sampleRate = f
 		case strings.HasPrefix(part, "#"):
-			tagsStr := strings.TrimPrefix(part, "#")
-
-			tagSets := strings.Split(tagsStr, ",")
-
-			for _, tagSet := range tagSets {
-				tagParts := strings.SplitN(tagSet, ":", 2)
-				if len(tagParts) != 2 {
-					return result, fmt.Errorf("invalid tag format: %s", tagParts)
-				}
-				k := tagParts[0]
-				v := tagParts[1]
-				kvs = append(kvs, attribute.String(k, v))
+			tags, err := parseTags(strings.TrimPrefix(part, "#"))
+			if err != nil {
+				return result, err
 			}
+			kvs = append(kvs, tags...)
+		case strings.HasPrefix(part, "c:"):
+			kvs = append(kvs, attribute.String(tagContainerID, strings.TrimPrefix(part, "c:")))
 		default:
 			return result, fmt.Errorf("unrecognized message part: %s", part)
 		}
 	}
-	var err error
-	result.asFloat, err = strconv.ParseFloat(valueStr, 64)
-	if err != nil {
-		return result, fmt.Errorf("parse metric value string: %s", valueStr)
+
+	if inType == SetType {
+		// Sets count unique occurrences of any value, not only numbers.
+		result.setValue = valueStr
+	} else {
+		if strings.HasPrefix(valueStr, "-") || strings.HasPrefix(valueStr, "+") {
+			result.addition = true
+		}
+		var err error
+		result.asFloat, err = strconv.ParseFloat(valueStr, 64)
+		if err != nil {
+			return result, fmt.Errorf("parse metric value string: %s", valueStr)
+		}
 	}
 
-	// add metric_type dimension for all metrics
-	if enableMetricType {
-		metricType := string(result.description.metricType.FullName())
+	result.description.attrs = buildAttributeSet(kvs, result.description.metricType, enableMetricType)
+	return result, nil
+}
+
+func parseTags(tagsStr string) ([]attribute.KeyValue, error) {
+	tagSets := strings.Split(tagsStr, ",")
 
-		kvs = append(kvs, attribute.String(tagMetricType, metricType))
+	kvs := make([]attribute.KeyValue, 0, len(tagSets))
+	for _, tagSet := range tagSets {
+		// DogStatsD tags may have no value, such as "canary" in "#env:prod,canary"
+		k, v, _ := strings.Cut(tagSet, ":")
+		if k == "" {
+			return nil, fmt.Errorf("invalid tag format: %s", tagSet)
+		}
+		kvs = append(kvs, attribute.String(k, v))
 	}
+	return kvs, nil
+}
 
-	if len(kvs) != 0 {
-		result.description.attrs = attribute.NewSet(kvs...)
+func buildAttributeSet(kvs []attribute.KeyValue, metricType MetricType, enableMetricType bool) attribute.Set {
+	// add metric_type dimension for all metrics
+	if enableMetricType {
+		kvs = append(kvs, attribute.String(tagMetricType, string(metricType.FullName())))
 	}
 
-	return result, nil
+	if len(kvs) == 0 {
+		return attribute.Set{}
+	}
+	return attribute.NewSet(kvs...)
 }
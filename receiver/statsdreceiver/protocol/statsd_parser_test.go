@@ -62,8 +62,8 @@ func Test_ParseMessageToMetric(t *testing.T) {
 		},
 		{
 			name:  "invalid tag format",
-			input: "test.metric:42|c|#key1",
-			err:   errors.New("invalid tag format: [key1]"),
+			input: "test.metric:42|c|#:value1",
+			err:   errors.New("invalid tag format: :value1"),
 		},
 		{
 			name:  "unrecognized message part",
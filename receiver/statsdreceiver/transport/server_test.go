@@ -16,6 +16,7 @@ package transport
 
 import (
 	"net"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"sync"
@@ -45,33 +46,69 @@ func Test_Server_ListenAndServe(t *testing.T) {
 				return client.NewStatsD(client.UDP, host, port)
 			},
 		},
+		{
+			name:          "tcp",
+			buildServerFn: NewTCPServer,
+			buildClientFn: func(host string, port int) (*client.StatsD, error) {
+				return client.NewStatsD(client.TCP, host, port)
+			},
+		},
+		{
+			name:          "unixgram",
+			buildServerFn: NewUnixgramServer,
+			buildClientFn: func(host string, port int) (*client.StatsD, error) {
+				return client.NewStatsD(client.Unixgram, host, port)
+			},
+		},
+		{
+			name:          "unix",
+			buildServerFn: NewUnixServer,
+			buildClientFn: func(host string, port int) (*client.StatsD, error) {
+				return client.NewStatsD(client.Unix, host, port)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			addr := testutil.GetAvailableLocalNetworkAddress(t, "udp")
-
-			// Endpoint should be free.
-			ln0, err := net.ListenPacket("udp", addr)
-			require.NoError(t, err)
-			require.NotNil(t, ln0)
-
-			// Ensure that the endpoint wasn't something like ":0" by checking that a second listener will fail.
-			ln1, err := net.ListenPacket("udp", addr)
-			require.Error(t, err)
-			require.Nil(t, ln1)
-
-			// Unbind the local address so the mock UDP service can use it
-			ln0.Close()
+			var addr, host string
+			var port int
+			switch tt.name {
+			case "unix", "unixgram":
+				if runtime.GOOS == "windows" {
+					t.Skip("unix domain sockets are not supported on windows")
+				}
+				addr = filepath.Join(t.TempDir(), "statsd.sock")
+				host = addr
+			default:
+				addr = testutil.GetAvailableLocalNetworkAddress(t, tt.name)
+
+				if tt.name == "udp" {
+					// Endpoint should be free.
+					ln0, err := net.ListenPacket("udp", addr)
+					require.NoError(t, err)
+					require.NotNil(t, ln0)
+
+					// Ensure that the endpoint wasn't something like ":0" by checking that a second listener will fail.
+					ln1, err := net.ListenPacket("udp", addr)
+					require.Error(t, err)
+					require.Nil(t, ln1)
+
+					// Unbind the local address so the mock UDP service can use it
+					ln0.Close()
+				}
+
+				var portStr string
+				var err error
+				host, portStr, err = net.SplitHostPort(addr)
+				require.NoError(t, err)
+				port, err = strconv.Atoi(portStr)
+				require.NoError(t, err)
+			}
 
 			srv, err := tt.buildServerFn(addr)
 			require.NoError(t, err)
 			require.NotNil(t, srv)
 
-			host, portStr, err := net.SplitHostPort(addr)
-			require.NoError(t, err)
-			port, err := strconv.Atoi(portStr)
-			require.NoError(t, err)
-
 			mc := new(consumertest.MetricsSink)
 			p := &protocol.StatsDParser{}
 			require.NoError(t, err)
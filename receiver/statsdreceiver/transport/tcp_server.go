@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport"
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/consumer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+)
+
+// maxLineLength is the maximum length of a message received over a stream transport.
+const maxLineLength = 64 * 1024
+
+// tcpServer serves a stream oriented transport, in which each connection
+// carries newline separated messages.
+type tcpServer struct {
+	network  string
+	listener net.Listener
+	reporter Reporter
+
+	mu     sync.Mutex
+	conns  map[net.Conn]struct{}
+	closed bool
+	wg     sync.WaitGroup
+}
+
+var _ (Server) = (*tcpServer)(nil)
+
+// NewTCPServer creates a transport.Server using TCP as its transport.
+func NewTCPServer(addr string) (Server, error) {
+	return newStreamServer("tcp", addr)
+}
+
+func newStreamServer(network string, addr string) (Server, error) {
+	listener, err := net.Listen(network, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	t := tcpServer{
+		network:  network,
+		listener: listener,
+		conns:    make(map[net.Conn]struct{}),
+	}
+	return &t, nil
+}
+
+func (t *tcpServer) ListenAndServe(
+	parser protocol.Parser,
+	nextConsumer consumer.Metrics,
+	reporter Reporter,
+	transferChan chan<- string,
+) error {
+	if parser == nil || nextConsumer == nil || reporter == nil {
+		return errNilListenAndServeParameters
+	}
+
+	t.reporter = reporter
+
+	for {
+		conn, err := t.listener.Accept()
+		if err != nil {
+			t.reporter.OnDebugf("%s Transport (%s) - Accept error: %v",
+				strings.ToUpper(t.network),
+				t.listener.Addr(),
+				err)
+			var netErr net.Error
+			if errors.As(err, &netErr) {
+				if netErr.Timeout() {
+					continue
+				}
+			}
+			return err
+		}
+
+		if !t.track(conn) {
+			conn.Close()
+			return net.ErrClosed
+		}
+		go t.handleConn(conn, transferChan)
+	}
+}
+
+// track registers the connection so that it is closed by Close, it returns false if the server is already closed.
+func (t *tcpServer) track(conn net.Conn) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return false
+	}
+	t.conns[conn] = struct{}{}
+	t.wg.Add(1)
+	return true
+}
+
+func (t *tcpServer) handleConn(conn net.Conn, transferChan chan<- string) {
+	defer func() {
+		t.mu.Lock()
+		delete(t.conns, conn)
+		t.mu.Unlock()
+		conn.Close()
+		t.wg.Done()
+	}()
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 4096), maxLineLength)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" {
+			transferChan <- line
+		}
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, net.ErrClosed) {
+		t.reporter.OnDebugf("%s Transport (%s) - Read error: %v",
+			strings.ToUpper(t.network),
+			conn.RemoteAddr(),
+			err)
+	}
+}
+
+// Close stops accepting connections and closes the open ones, waiting for the
+// messages already received to be passed on.
+func (t *tcpServer) Close() error {
+	t.mu.Lock()
+	t.closed = true
+	err := t.listener.Close()
+	for conn := range t.conns {
+		conn.Close()
+	}
+	t.mu.Unlock()
+
+	t.wg.Wait()
+	return err
+}
@@ -26,7 +26,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
+// udpServer serves a packet oriented transport, in which each packet
+// holds one or more newline separated messages.
 type udpServer struct {
+	network    string
 	packetConn net.PacketConn
 	reporter   Reporter
 }
@@ -35,12 +38,17 @@ var _ (Server) = (*udpServer)(nil)
 
 // NewUDPServer creates a transport.Server using UDP as its transport.
 func NewUDPServer(addr string) (Server, error) {
-	packetConn, err := net.ListenPacket("udp", addr)
+	return newPacketServer("udp", addr)
+}
+
+func newPacketServer(network string, addr string) (Server, error) {
+	packetConn, err := net.ListenPacket(network, addr)
 	if err != nil {
 		return nil, err
 	}
 
 	u := udpServer{
+		network:    network,
 		packetConn: packetConn,
 	}
 	return &u, nil
@@ -58,7 +66,7 @@ func (u *udpServer) ListenAndServe(
 
 	u.reporter = reporter
 
-	buf := make([]byte, 65527) // max size for udp packet body (assuming ipv6)
+	buf := make([]byte, 65527) // max size for udp packet body (assuming ipv6), also large enough for unix datagrams
 	for {
 		n, _, err := u.packetConn.ReadFrom(buf)
 		if n > 0 {
@@ -67,7 +75,8 @@ func (u *udpServer) ListenAndServe(
 			u.handlePacket(bufCopy, transferChan)
 		}
 		if err != nil {
-			u.reporter.OnDebugf("UDP Transport (%s) - ReadFrom error: %v",
+			u.reporter.OnDebugf("%s Transport (%s) - ReadFrom error: %v",
+				strings.ToUpper(u.network),
 				u.packetConn.LocalAddr(),
 				err)
 			var netErr net.Error
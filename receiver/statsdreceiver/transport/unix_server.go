@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport"
+
+import (
+	"fmt"
+	"os"
+)
+
+// NewUnixgramServer creates a transport.Server using a datagram unix domain socket as its transport,
+// which is what DogStatsD clients use by default over unix domain sockets.
+func NewUnixgramServer(path string) (Server, error) {
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+	return newPacketServer("unixgram", path)
+}
+
+// NewUnixServer creates a transport.Server using a stream unix domain socket as its transport.
+func NewUnixServer(path string) (Server, error) {
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+	return newStreamServer("unix", path)
+}
+
+// removeStaleSocket removes a socket left over by a previous run at path, which would prevent
+// listening on it. Anything else than a socket is left alone.
+func removeStaleSocket(path string) error {
+	fi, err := os.Lstat(path)
+	if err != nil {
+		return nil
+	}
+	if fi.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("cannot listen on %q: the file exists and is not a socket", path)
+	}
+	return os.Remove(path)
+}
@@ -15,6 +15,9 @@
 package syslogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/syslogreceiver"
 
 import (
+	"sync"
+
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/confmap"
@@ -31,8 +34,15 @@ const (
 	stability = component.StabilityLevelAlpha
 )
 
+var once sync.Once
+
 // NewFactory creates a factory for syslog receiver
 func NewFactory() component.ReceiverFactory {
+	once.Do(func() {
+		// TODO: as with other -contrib factories registering metrics, this is causing the error being ignored
+		_ = view.Register(udp.MetricViews()...)
+	})
+
 	return adapter.NewFactory(ReceiverType{}, stability)
 }
 
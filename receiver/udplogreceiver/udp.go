@@ -15,6 +15,9 @@
 package udplogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/udplogreceiver"
 
 import (
+	"sync"
+
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 
@@ -28,8 +31,15 @@ const (
 	stability = component.StabilityLevelAlpha
 )
 
+var once sync.Once
+
 // NewFactory creates a factory for udp receiver
 func NewFactory() component.ReceiverFactory {
+	once.Do(func() {
+		// TODO: as with other -contrib factories registering metrics, this is causing the error being ignored
+		_ = view.Register(udp.MetricViews()...)
+	})
+
 	return adapter.NewFactory(ReceiverType{}, stability)
 }
 
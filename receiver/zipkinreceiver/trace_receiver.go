@@ -31,6 +31,7 @@ import (
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/connctx"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/zipkin/zipkinv1"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/zipkin/zipkinv2"
 )
@@ -95,6 +96,7 @@ func (zr *zipkinReceiver) Start(_ context.Context, host component.Host) error {
 	if err != nil {
 		return err
 	}
+	zr.server.ConnContext = connctx.ConnContext
 
 	var listener net.Listener
 	listener, err = zr.config.HTTPServerSettings.ToListener()